@@ -0,0 +1,318 @@
+package blockchain
+
+import (
+	"context"
+	"encoding/binary"
+	"sort"
+
+	"github.com/pandoprojects/pando/common"
+	"github.com/pandoprojects/pando/core"
+	"github.com/pandoprojects/pando/crypto"
+	"github.com/pandoprojects/pando/ledger/types"
+	"github.com/pandoprojects/pando/store"
+)
+
+// AccountTxBucketSize is the number of blocks covered by one bucket of the account tx index.
+const AccountTxBucketSize = 512
+
+// accountTxRebuildLogInterval is the number of blocks between two progress logs of the index rebuild.
+const accountTxRebuildLogInterval = 10000
+
+// accountTxBucketsKey constructs the DB key for the list of buckets holding transactions of the given address.
+func accountTxBucketsKey(address common.Address) common.Bytes {
+	return append(common.Bytes("atxb/"), address[:]...)
+}
+
+// accountTxBucketKey constructs the DB key for the transactions of the given address in the given bucket.
+func accountTxBucketKey(address common.Address, bucket uint64) common.Bytes {
+	key := append(common.Bytes("atx/"), address[:]...)
+	key = append(key, '/')
+	var b [8]byte
+	binary.BigEndian.PutUint64(b[:], bucket)
+	return append(key, b[:]...)
+}
+
+// accountTxNextHeightKey is the DB key of the lowest height above which the index is not known
+// to be complete, i.e. all the finalized blocks below it have been indexed.
+var accountTxNextHeightKey = common.Bytes("atxr/next")
+
+// AccountTxEntry locates a finalized transaction which touched an account.
+type AccountTxEntry struct {
+	TxHash      common.Hash
+	BlockHash   common.Hash
+	BlockHeight uint64
+	Index       uint64
+}
+
+// AccountTxBucket is the number of transactions of an account in a bucket.
+type AccountTxBucket struct {
+	Bucket uint64
+	Count  uint64
+}
+
+// AccountTxBuckets lists the non-empty buckets of an account in ascending order.
+type AccountTxBuckets struct {
+	Buckets []AccountTxBucket
+}
+
+// AccountTxs is the content of a bucket, ordered by (BlockHeight, Index).
+type AccountTxs struct {
+	Entries []AccountTxEntry
+}
+
+// SetAccountTxIndexEnabled sets whether finalized transactions are added to the account tx index.
+func (ch *Chain) SetAccountTxIndexEnabled(enabled bool) {
+	ch.accountTxIndexEnabled = enabled
+}
+
+// AccountTxIndexEnabled returns whether the account tx index is maintained.
+func (ch *Chain) AccountTxIndexEnabled() bool {
+	return ch.accountTxIndexEnabled
+}
+
+// AddAccountTxsToIndex adds the transactions of the given finalized block to the index of every
+// account they touched. Adding the same block more than once is harmless.
+func (ch *Chain) AddAccountTxsToIndex(block *core.ExtendedBlock) {
+	ch.accountTxMu.Lock()
+	defer ch.accountTxMu.Unlock()
+
+	blockHash := block.Hash()
+	for idx, rawTx := range block.Txs {
+		tx, err := types.TxFromBytes(rawTx)
+		if err != nil {
+			continue
+		}
+		txHash := crypto.Keccak256Hash(rawTx)
+		entry := AccountTxEntry{
+			TxHash:      txHash,
+			BlockHash:   blockHash,
+			BlockHeight: block.Height,
+			Index:       uint64(idx),
+		}
+		for _, addr := range ch.txAddresses(blockHash, txHash, tx) {
+			ch.addAccountTx(addr, entry)
+		}
+	}
+
+	if ch.accountTxNextHeight() == block.Height {
+		ch.setAccountTxNextHeight(block.Height + 1)
+	}
+}
+
+// txAddresses returns the distinct addresses touched by the transaction: inputs, outputs,
+// stake sources and holders, and the recipients of the smart contract balance changes.
+func (ch *Chain) txAddresses(blockHash common.Hash, txHash common.Hash, tx types.Tx) []common.Address {
+	addrs := []common.Address{}
+	seen := make(map[common.Address]bool)
+	add := func(addr common.Address) {
+		if (addr == common.Address{}) || seen[addr] {
+			return
+		}
+		seen[addr] = true
+		addrs = append(addrs, addr)
+	}
+
+	switch tx := tx.(type) {
+	case *types.CoinbaseTx:
+		add(tx.Proposer.Address)
+		for _, output := range tx.Outputs {
+			add(output.Address)
+		}
+	case *types.SlashTx:
+		add(tx.Proposer.Address)
+		add(tx.SlashedAddress)
+	case *types.SendTx:
+		for _, input := range tx.Inputs {
+			add(input.Address)
+		}
+		for _, output := range tx.Outputs {
+			add(output.Address)
+		}
+	case *types.ReserveFundTx:
+		add(tx.Source.Address)
+	case *types.ReleaseFundTx:
+		add(tx.Source.Address)
+	case *types.ServicePaymentTx:
+		add(tx.Source.Address)
+		add(tx.Target.Address)
+	case *types.SplitRuleTx:
+		add(tx.Initiator.Address)
+		for _, split := range tx.Splits {
+			add(split.Address)
+		}
+	case *types.SmartContractTx:
+		add(tx.From.Address)
+		add(tx.To.Address)
+		if balanceChanges, ok := ch.FindTxBalanceChangesByHash(blockHash, txHash); ok {
+			add(balanceChanges.ContractAddress)
+			for _, change := range balanceChanges.BalanceChanges {
+				add(change.Address)
+			}
+		}
+	case *types.DepositStakeTx:
+		add(tx.Source.Address)
+		add(tx.Holder.Address)
+	case *types.DepositStakeTxV2:
+		add(tx.Source.Address)
+		add(tx.Holder.Address)
+	case *types.WithdrawStakeTx:
+		add(tx.Source.Address)
+		add(tx.Holder.Address)
+	case *types.StakeRewardDistributionTx:
+		add(tx.Holder.Address)
+		add(tx.Beneficiary.Address)
+	case *types.GovernanceProposalTx:
+		add(tx.Proposer.Address)
+	case *types.GovernanceVoteTx:
+		add(tx.Voter.Address)
+	case *types.ValidatorCommissionTx:
+		add(tx.Holder.Address)
+	case *types.ClaimStakeRewardTx:
+		add(tx.Source.Address)
+		add(tx.Holder)
+	}
+	return addrs
+}
+
+func (ch *Chain) addAccountTx(address common.Address, entry AccountTxEntry) {
+	bucket := entry.BlockHeight / AccountTxBucketSize
+
+	txs := &AccountTxs{}
+	bucketKey := accountTxBucketKey(address, bucket)
+	if err := ch.store.Get(bucketKey, txs); err != nil && err != store.ErrKeyNotFound {
+		logger.Panic(err)
+	}
+	pos := sort.Search(len(txs.Entries), func(i int) bool {
+		e := txs.Entries[i]
+		return e.BlockHeight > entry.BlockHeight || (e.BlockHeight == entry.BlockHeight && e.Index >= entry.Index)
+	})
+	if pos < len(txs.Entries) && txs.Entries[pos].BlockHeight == entry.BlockHeight && txs.Entries[pos].Index == entry.Index {
+		return // already indexed
+	}
+	txs.Entries = append(txs.Entries, AccountTxEntry{})
+	copy(txs.Entries[pos+1:], txs.Entries[pos:])
+	txs.Entries[pos] = entry
+	if err := ch.store.Put(bucketKey, txs); err != nil {
+		logger.Panic(err)
+	}
+
+	buckets := ch.getAccountTxBuckets(address)
+	i := sort.Search(len(buckets.Buckets), func(i int) bool { return buckets.Buckets[i].Bucket >= bucket })
+	if i < len(buckets.Buckets) && buckets.Buckets[i].Bucket == bucket {
+		buckets.Buckets[i].Count = uint64(len(txs.Entries))
+	} else {
+		buckets.Buckets = append(buckets.Buckets, AccountTxBucket{})
+		copy(buckets.Buckets[i+1:], buckets.Buckets[i:])
+		buckets.Buckets[i] = AccountTxBucket{Bucket: bucket, Count: uint64(len(txs.Entries))}
+	}
+	if err := ch.store.Put(accountTxBucketsKey(address), buckets); err != nil {
+		logger.Panic(err)
+	}
+}
+
+func (ch *Chain) getAccountTxBuckets(address common.Address) *AccountTxBuckets {
+	buckets := &AccountTxBuckets{}
+	if err := ch.store.Get(accountTxBucketsKey(address), buckets); err != nil && err != store.ErrKeyNotFound {
+		logger.Error(err)
+	}
+	return buckets
+}
+
+// FindAccountTxs returns the total number of indexed transactions of the account, and the
+// transactions from offset skip (newest first) up to limit entries.
+func (ch *Chain) FindAccountTxs(address common.Address, skip, limit uint64) (uint64, []AccountTxEntry) {
+	buckets := ch.getAccountTxBuckets(address)
+	total := uint64(0)
+	for _, b := range buckets.Buckets {
+		total += b.Count
+	}
+
+	entries := []AccountTxEntry{}
+	for i := len(buckets.Buckets) - 1; i >= 0 && uint64(len(entries)) < limit; i-- {
+		b := buckets.Buckets[i]
+		if skip >= b.Count {
+			skip -= b.Count
+			continue
+		}
+		txs := &AccountTxs{}
+		if err := ch.store.Get(accountTxBucketKey(address, b.Bucket), txs); err != nil {
+			logger.Error(err)
+			continue
+		}
+		for j := len(txs.Entries) - 1 - int(skip); j >= 0 && uint64(len(entries)) < limit; j-- {
+			entries = append(entries, txs.Entries[j])
+		}
+		skip = 0
+	}
+	return total, entries
+}
+
+func (ch *Chain) accountTxNextHeight() uint64 {
+	next := uint64(0)
+	if root, err := ch.findBlock(ch.root); err == nil {
+		next = root.Height
+	}
+	if err := ch.store.Get(accountTxNextHeightKey, &next); err != nil && err != store.ErrKeyNotFound {
+		logger.Error(err)
+	}
+	return next
+}
+
+func (ch *Chain) setAccountTxNextHeight(height uint64) {
+	if err := ch.store.Put(accountTxNextHeightKey, height); err != nil {
+		logger.Panic(err)
+	}
+}
+
+// ResetAccountTxIndexRebuild makes the next RebuildAccountTxIndex call start over from the given height.
+func (ch *Chain) ResetAccountTxIndexRebuild(height uint64) {
+	ch.accountTxMu.Lock()
+	defer ch.accountTxMu.Unlock()
+
+	ch.setAccountTxNextHeight(height)
+}
+
+// RebuildAccountTxIndex indexes the blocks which were finalized before the index got enabled, or while
+// the node was running without it. It resumes from the lowest height not known to be indexed (the root
+// block if the index has never been built), and returns once it has passed toHeight and caught up with
+// the blocks finalized in the meantime.
+func (ch *Chain) RebuildAccountTxIndex(ctx context.Context, toHeight uint64) error {
+	ch.accountTxMu.Lock()
+	height := ch.accountTxNextHeight()
+	ch.accountTxMu.Unlock()
+
+	logger.Infof("Rebuilding account tx index from height %v", height)
+	for ; ; height++ {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		default:
+		}
+
+		var finalized *core.ExtendedBlock
+		for _, block := range ch.FindBlocksByHeight(height) {
+			if block.Status.IsFinalized() {
+				finalized = block
+				break
+			}
+		}
+		if finalized == nil && height > toHeight {
+			break
+		}
+		if finalized != nil {
+			ch.AddAccountTxsToIndex(finalized)
+		}
+
+		ch.accountTxMu.Lock()
+		if ch.accountTxNextHeight() == height {
+			ch.setAccountTxNextHeight(height + 1)
+		}
+		ch.accountTxMu.Unlock()
+
+		if height%accountTxRebuildLogInterval == 0 {
+			logger.Infof("Rebuilt account tx index up to height %v", height)
+		}
+	}
+	logger.Infof("Done rebuilding account tx index up to height %v", height-1)
+	return nil
+}
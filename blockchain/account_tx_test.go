@@ -0,0 +1,97 @@
+package blockchain
+
+import (
+	"context"
+	"testing"
+
+	"github.com/pandoprojects/pando/common"
+	"github.com/pandoprojects/pando/core"
+	"github.com/pandoprojects/pando/ledger/types"
+	"github.com/pandoprojects/pando/store/database/backend"
+	"github.com/pandoprojects/pando/store/kvstore"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func buildAccountTxTestChain(require *require.Assertions, indexEnabled bool, numBlocks int,
+	from, to common.Address) (*Chain, []*core.Block) {
+	core.ResetTestBlocks()
+	root := core.CreateTestBlock("a0", "")
+	chain := NewChain(root.ChainID, kvstore.NewKVStore(backend.NewMemDatabase()), root)
+	chain.SetAccountTxIndexEnabled(indexEnabled)
+
+	blocks := []*core.Block{}
+	parent := "a0"
+	for i := 1; i <= numBlocks; i++ {
+		name := "a" + string(rune('0'+i))
+		block := core.CreateTestBlock(name, parent)
+		block.Height = uint64(i * AccountTxBucketSize / 2) // two blocks per bucket
+		tx := &types.SendTx{
+			Inputs:  []types.TxInput{{Address: from, Sequence: uint64(i)}},
+			Outputs: []types.TxOutput{{Address: to}},
+		}
+		raw, err := types.TxToBytes(tx)
+		require.Nil(err)
+		block.Txs = []common.Bytes{raw}
+		block.UpdateHash()
+		_, err = chain.AddBlock(block)
+		require.Nil(err)
+		blocks = append(blocks, block)
+		parent = name
+	}
+	// Finalizing the last block finalizes all of its ancestors
+	require.Nil(chain.FinalizePreviousBlocks(blocks[len(blocks)-1].Hash()))
+	return chain, blocks
+}
+
+func TestAccountTxIndex(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	from := common.HexToAddress("0x1")
+	to := common.HexToAddress("0x2")
+	chain, blocks := buildAccountTxTestChain(require, true, 5, from, to)
+
+	total, entries := chain.FindAccountTxs(from, 0, 2)
+	assert.Equal(uint64(5), total)
+	require.Equal(2, len(entries))
+	assert.Equal(blocks[4].Height, entries[0].BlockHeight)
+	assert.Equal(blocks[3].Height, entries[1].BlockHeight)
+
+	total, entries = chain.FindAccountTxs(to, 4, 2)
+	assert.Equal(uint64(5), total)
+	require.Equal(1, len(entries))
+	assert.Equal(blocks[0].Hash(), entries[0].BlockHash)
+
+	total, entries = chain.FindAccountTxs(common.HexToAddress("0x3"), 0, 10)
+	assert.Equal(uint64(0), total)
+	assert.Equal(0, len(entries))
+
+	// Indexing a block again does not duplicate its transactions
+	eb, err := chain.FindBlock(blocks[2].Hash())
+	require.Nil(err)
+	chain.AddAccountTxsToIndex(eb)
+	total, _ = chain.FindAccountTxs(from, 0, 10)
+	assert.Equal(uint64(5), total)
+}
+
+func TestAccountTxIndexRebuild(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	from := common.HexToAddress("0x1")
+	to := common.HexToAddress("0x2")
+	chain, blocks := buildAccountTxTestChain(require, false, 4, from, to)
+
+	total, _ := chain.FindAccountTxs(from, 0, 10)
+	assert.Equal(uint64(0), total)
+
+	chain.SetAccountTxIndexEnabled(true)
+	require.Nil(chain.RebuildAccountTxIndex(context.Background(), blocks[3].Height))
+
+	total, entries := chain.FindAccountTxs(to, 0, 10)
+	assert.Equal(uint64(4), total)
+	require.Equal(4, len(entries))
+	assert.Equal(blocks[3].Height, entries[0].BlockHeight)
+	assert.Equal(blocks[0].Height, entries[3].BlockHeight)
+}
@@ -25,6 +25,13 @@ type Chain struct {
 	root    common.Hash
 
 	mu *sync.RWMutex
+
+	accountTxIndexEnabled bool
+	accountTxMu           sync.Mutex
+
+	logIndexMu sync.Mutex
+
+	livenessMu sync.Mutex
 }
 
 // NewChain creates a new Chain instance.
@@ -274,6 +281,19 @@ func (ch *Chain) FinalizePreviousBlocks(hash common.Hash) error {
 	ch.mu.Lock()
 	defer ch.mu.Unlock()
 
+	finalized := []*core.ExtendedBlock{}
+	defer func() {
+		// Index the logs and the accounts in ascending height order
+		for i := len(finalized) - 1; i >= 0; i-- {
+			ch.AddLogsToIndex(finalized[i])
+		}
+		if ch.accountTxIndexEnabled {
+			for i := len(finalized) - 1; i >= 0; i-- {
+				ch.AddAccountTxsToIndex(finalized[i])
+			}
+		}
+	}()
+
 	status := core.BlockStatusDirectlyFinalized
 	for !hash.IsEmpty() {
 		block, err := ch.findBlock(hash)
@@ -293,6 +313,7 @@ func (ch *Chain) FinalizePreviousBlocks(hash common.Hash) error {
 		// Force update TX index on block finalization so that the index doesn't point to
 		// duplicate TX in fork.
 		ch.AddTxsToIndex(block, true)
+		finalized = append(finalized, block)
 
 		hash = block.Parent
 	}
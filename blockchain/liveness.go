@@ -0,0 +1,235 @@
+package blockchain
+
+import (
+	"encoding/binary"
+
+	"github.com/pandoprojects/pando/common"
+	"github.com/pandoprojects/pando/store"
+)
+
+// LivenessWindow is the number of most recent finalized blocks over which the uptime of the
+// validators is measured.
+const LivenessWindow uint64 = 1000
+
+// maxRecentMissedHeights is the maximum number of heights kept in the list of the most recent
+// blocks missed by a validator.
+const maxRecentMissedHeights = 32
+
+// maxJailIntents is the maximum number of jail intents kept.
+const maxJailIntents = 100
+
+// blockLivenessKey constructs the DB key for the liveness record of the given height.
+func blockLivenessKey(height uint64) common.Bytes {
+	var b [8]byte
+	binary.BigEndian.PutUint64(b[:], height)
+	return append(common.Bytes("lv/r/"), b[:]...)
+}
+
+// validatorLivenessKey constructs the DB key for the liveness summary of the given validator.
+func validatorLivenessKey(address common.Address) common.Bytes {
+	return append(common.Bytes("lv/v/"), address[:]...)
+}
+
+// livenessNextHeightKey is the DB key of the lowest height which has not been recorded yet.
+var livenessNextHeightKey = common.Bytes("lv/next")
+
+// jailIntentsKey is the DB key of the list of jail intents.
+var jailIntentsKey = common.Bytes("lv/i")
+
+// BlockLiveness records which validators took part in a finalized block.
+type BlockLiveness struct {
+	Height          uint64
+	BlockHash       common.Hash
+	Proposer        common.Address
+	Validators      []common.Address // The validators expected to vote for the block.
+	Voters          []common.Address // The validators whose votes for the block have been seen.
+	MissedProposers []common.Address // The proposers of the epochs skipped between the parent and the block.
+}
+
+// ValidatorLiveness summarizes the participation of a validator over the liveness window.
+type ValidatorLiveness struct {
+	Address            common.Address
+	SignedBlocks       uint64
+	MissedBlocks       uint64
+	ProposedSlots      uint64
+	MissedSlots        uint64
+	LastSignedHeight   uint64
+	LastProposedHeight uint64
+	MissedBlockHeights []uint64 // The most recent heights of the blocks the validator did not vote for.
+}
+
+// Uptime returns the fraction of the blocks of the window the validator voted for.
+func (vl *ValidatorLiveness) Uptime() float64 {
+	total := vl.SignedBlocks + vl.MissedBlocks
+	if total == 0 {
+		return 0
+	}
+	return float64(vl.SignedBlocks) / float64(total)
+}
+
+// JailIntent records that a validator fell below the uptime threshold at a checkpoint. It is
+// kept in the local chain database only, and has no effect on the consensus.
+type JailIntent struct {
+	Address          common.Address
+	Height           uint64 // The height of the checkpoint.
+	UptimeBasisPoint uint64 // The uptime of the validator, in 1/10000.
+}
+
+// AddBlockLiveness adds the liveness record of a finalized block, and drops the record which
+// falls out of the liveness window. Adding the same height more than once is harmless.
+func (ch *Chain) AddBlockLiveness(record *BlockLiveness) {
+	ch.livenessMu.Lock()
+	defer ch.livenessMu.Unlock()
+
+	key := blockLivenessKey(record.Height)
+	if err := ch.store.Get(key, &BlockLiveness{}); err == nil {
+		return // already recorded
+	}
+	if err := ch.store.Put(key, record); err != nil {
+		logger.Panic(err)
+	}
+	ch.applyBlockLiveness(record, true)
+
+	if record.Height >= LivenessWindow {
+		expiredKey := blockLivenessKey(record.Height - LivenessWindow)
+		expired := &BlockLiveness{}
+		if err := ch.store.Get(expiredKey, expired); err == nil {
+			ch.applyBlockLiveness(expired, false)
+			ch.store.Delete(expiredKey)
+		}
+	}
+
+	if ch.livenessNextHeight() <= record.Height {
+		ch.SetLivenessNextHeight(record.Height + 1)
+	}
+}
+
+// applyBlockLiveness adds the record to, or removes it from, the summaries of the validators.
+func (ch *Chain) applyBlockLiveness(record *BlockLiveness, add bool) {
+	voted := make(map[common.Address]bool)
+	for _, voter := range record.Voters {
+		voted[voter] = true
+	}
+	delta := func(count *uint64) {
+		if add {
+			*count++
+		} else if *count > 0 {
+			*count--
+		}
+	}
+
+	update := func(address common.Address, fn func(vl *ValidatorLiveness)) {
+		vl := ch.GetValidatorLiveness(address)
+		fn(vl)
+		if err := ch.store.Put(validatorLivenessKey(address), vl); err != nil {
+			logger.Panic(err)
+		}
+	}
+
+	for _, validator := range record.Validators {
+		update(validator, func(vl *ValidatorLiveness) {
+			if voted[validator] {
+				delta(&vl.SignedBlocks)
+				if add && record.Height > vl.LastSignedHeight {
+					vl.LastSignedHeight = record.Height
+				}
+				return
+			}
+			delta(&vl.MissedBlocks)
+			if add {
+				vl.MissedBlockHeights = append(vl.MissedBlockHeights, record.Height)
+				if len(vl.MissedBlockHeights) > maxRecentMissedHeights {
+					vl.MissedBlockHeights = vl.MissedBlockHeights[len(vl.MissedBlockHeights)-maxRecentMissedHeights:]
+				}
+			} else {
+				kept := []uint64{}
+				for _, height := range vl.MissedBlockHeights {
+					if height > record.Height {
+						kept = append(kept, height)
+					}
+				}
+				vl.MissedBlockHeights = kept
+			}
+		})
+	}
+	if (record.Proposer != common.Address{}) {
+		update(record.Proposer, func(vl *ValidatorLiveness) {
+			delta(&vl.ProposedSlots)
+			if add && record.Height > vl.LastProposedHeight {
+				vl.LastProposedHeight = record.Height
+			}
+		})
+	}
+	for _, proposer := range record.MissedProposers {
+		update(proposer, func(vl *ValidatorLiveness) {
+			delta(&vl.MissedSlots)
+		})
+	}
+}
+
+// GetValidatorLiveness returns the liveness summary of the given validator over the window.
+func (ch *Chain) GetValidatorLiveness(address common.Address) *ValidatorLiveness {
+	vl := &ValidatorLiveness{}
+	if err := ch.store.Get(validatorLivenessKey(address), vl); err != nil && err != store.ErrKeyNotFound {
+		logger.Error(err)
+	}
+	vl.Address = address
+	return vl
+}
+
+// GetBlockLiveness returns the liveness record of the given height, if it is in the window.
+func (ch *Chain) GetBlockLiveness(height uint64) (*BlockLiveness, bool) {
+	record := &BlockLiveness{}
+	if err := ch.store.Get(blockLivenessKey(height), record); err != nil {
+		return nil, false
+	}
+	return record, true
+}
+
+// LivenessNextHeight returns the lowest height above the last recorded one, or zero if no
+// block has been recorded.
+func (ch *Chain) LivenessNextHeight() uint64 {
+	ch.livenessMu.Lock()
+	defer ch.livenessMu.Unlock()
+
+	return ch.livenessNextHeight()
+}
+
+func (ch *Chain) livenessNextHeight() uint64 {
+	next := uint64(0)
+	if err := ch.store.Get(livenessNextHeightKey, &next); err != nil && err != store.ErrKeyNotFound {
+		logger.Error(err)
+	}
+	return next
+}
+
+// SetLivenessNextHeight sets the lowest height which has not been recorded yet.
+func (ch *Chain) SetLivenessNextHeight(height uint64) {
+	if err := ch.store.Put(livenessNextHeightKey, height); err != nil {
+		logger.Panic(err)
+	}
+}
+
+// AddJailIntent records a jail intent. Only the most recent intents are kept.
+func (ch *Chain) AddJailIntent(intent JailIntent) {
+	ch.livenessMu.Lock()
+	defer ch.livenessMu.Unlock()
+
+	intents := ch.GetJailIntents()
+	intents = append(intents, intent)
+	if len(intents) > maxJailIntents {
+		intents = intents[len(intents)-maxJailIntents:]
+	}
+	if err := ch.store.Put(jailIntentsKey, intents); err != nil {
+		logger.Panic(err)
+	}
+}
+
+// GetJailIntents returns the recorded jail intents, oldest first.
+func (ch *Chain) GetJailIntents() []JailIntent {
+	intents := []JailIntent{}
+	if err := ch.store.Get(jailIntentsKey, &intents); err != nil && err != store.ErrKeyNotFound {
+		logger.Error(err)
+	}
+	return intents
+}
@@ -0,0 +1,73 @@
+package blockchain
+
+import (
+	"testing"
+
+	"github.com/pandoprojects/pando/common"
+	"github.com/pandoprojects/pando/core"
+	"github.com/pandoprojects/pando/store/database/backend"
+	"github.com/pandoprojects/pando/store/kvstore"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidatorLiveness(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	core.ResetTestBlocks()
+	root := core.CreateTestBlock("a0", "")
+	chain := NewChain(root.ChainID, kvstore.NewKVStore(backend.NewMemDatabase()), root)
+	v1 := common.HexToAddress("a1")
+	v2 := common.HexToAddress("a2")
+
+	// v1 votes for every block, v2 only for the even ones, and v2 misses a proposal slot at height 3.
+	for height := uint64(1); height <= LivenessWindow+10; height++ {
+		record := &BlockLiveness{
+			Height:     height,
+			Proposer:   v1,
+			Validators: []common.Address{v1, v2},
+			Voters:     []common.Address{v1},
+		}
+		if height%2 == 0 {
+			record.Voters = append(record.Voters, v2)
+		}
+		if height == 3 {
+			record.MissedProposers = []common.Address{v2}
+		}
+		chain.AddBlockLiveness(record)
+	}
+	// Recording the same height again is a no-op.
+	chain.AddBlockLiveness(&BlockLiveness{Height: LivenessWindow + 10, Validators: []common.Address{v1}})
+
+	assert.Equal(LivenessWindow+11, chain.LivenessNextHeight())
+
+	l1 := chain.GetValidatorLiveness(v1)
+	assert.Equal(LivenessWindow, l1.SignedBlocks)
+	assert.Equal(uint64(0), l1.MissedBlocks)
+	assert.Equal(LivenessWindow, l1.ProposedSlots)
+	assert.Equal(LivenessWindow+10, l1.LastSignedHeight)
+	assert.Equal(1.0, l1.Uptime())
+
+	l2 := chain.GetValidatorLiveness(v2)
+	assert.Equal(LivenessWindow/2, l2.SignedBlocks)
+	assert.Equal(LivenessWindow/2, l2.MissedBlocks)
+	assert.Equal(uint64(0), l2.MissedSlots) // the missed slot has left the window
+	assert.Equal(LivenessWindow+10, l2.LastSignedHeight)
+	assert.Equal(0.5, l2.Uptime())
+	require.Equal(maxRecentMissedHeights, len(l2.MissedBlockHeights))
+	assert.Equal(LivenessWindow+9, l2.MissedBlockHeights[len(l2.MissedBlockHeights)-1])
+
+	_, ok := chain.GetBlockLiveness(10)
+	assert.False(ok)
+	record, ok := chain.GetBlockLiveness(11)
+	require.True(ok)
+	assert.Equal(uint64(11), record.Height)
+
+	assert.Equal(0, len(chain.GetJailIntents()))
+	chain.AddJailIntent(JailIntent{Address: v2, Height: 100, UptimeBasisPoint: 5000})
+	intents := chain.GetJailIntents()
+	require.Equal(1, len(intents))
+	assert.Equal(v2, intents[0].Address)
+	assert.Equal(uint64(5000), intents[0].UptimeBasisPoint)
+}
@@ -0,0 +1,420 @@
+package blockchain
+
+import (
+	"context"
+	"encoding/binary"
+	"errors"
+	"fmt"
+
+	"github.com/pandoprojects/pando/common"
+	"github.com/pandoprojects/pando/core"
+	"github.com/pandoprojects/pando/crypto"
+	"github.com/pandoprojects/pando/ledger/types"
+	"github.com/pandoprojects/pando/store"
+)
+
+// BloomBitsSectionSize is the number of blocks covered by one bloom bits section.
+const BloomBitsSectionSize = 4096
+
+// MaxLogQueryRange is the maximum number of blocks a single FindLogs query may cover.
+const MaxLogQueryRange = 100000
+
+// logIndexRebuildLogInterval is the number of blocks between two progress logs of the index rebuild.
+const logIndexRebuildLogInterval = 10000
+
+// logIndexNextHeightKey is the DB key of the lowest height above which the log index is not known
+// to be complete, i.e. all the finalized blocks below it have been indexed.
+var logIndexNextHeightKey = common.Bytes("lbr/next")
+
+// blockBloomKey constructs the DB key for the log bloom of the finalized block at the given height.
+func blockBloomKey(height uint64) common.Bytes {
+	key := make(common.Bytes, 3+8)
+	copy(key, "lb/")
+	binary.BigEndian.PutUint64(key[3:], height)
+	return key
+}
+
+// bloomBitsKey constructs the DB key for the bit vector of the given bloom bit in the given section.
+// Bit i of the vector is set if the block at height section*BloomBitsSectionSize+i has the bloom bit set.
+func bloomBitsKey(bit uint, section uint64) common.Bytes {
+	key := make(common.Bytes, 3+2+1+8)
+	copy(key, "bb/")
+	binary.BigEndian.PutUint16(key[3:], uint16(bit))
+	key[5] = '/'
+	binary.BigEndian.PutUint64(key[6:], section)
+	return key
+}
+
+// BlockBloomEntry records the log bloom of a finalized block.
+type BlockBloomEntry struct {
+	BlockHash common.Hash
+	Bloom     core.Bloom
+}
+
+// LogEntry is a smart contract log together with its position in the chain.
+type LogEntry struct {
+	BlockHash   common.Hash
+	BlockHeight uint64
+	TxHash      common.Hash
+	TxIndex     uint64
+	LogIndex    uint64 // index of the log in the block
+	Log         *types.Log
+}
+
+// LogFilter selects logs by emitting contract and topics. An empty Addresses matches any address.
+// Topics[i] lists the alternatives for the i-th topic, an empty Topics[i] matches any topic.
+type LogFilter struct {
+	Addresses []common.Address
+	Topics    [][]common.Hash
+}
+
+// Matches checks whether the log satisfies the filter.
+func (f *LogFilter) Matches(log *types.Log) bool {
+	if len(f.Addresses) > 0 {
+		matched := false
+		for _, addr := range f.Addresses {
+			if addr == log.Address {
+				matched = true
+				break
+			}
+		}
+		if !matched {
+			return false
+		}
+	}
+	if len(f.Topics) > len(log.Topics) {
+		return false
+	}
+	for i, alternatives := range f.Topics {
+		if len(alternatives) == 0 {
+			continue
+		}
+		matched := false
+		for _, topic := range alternatives {
+			if topic == log.Topics[i] {
+				matched = true
+				break
+			}
+		}
+		if !matched {
+			return false
+		}
+	}
+	return true
+}
+
+// bloomGroups returns for each constrained filter position the bloom bits of its alternatives.
+// A block can only match if, for every group, the bloom has all the bits of one alternative.
+func (f *LogFilter) bloomGroups() [][][3]uint {
+	groups := [][][3]uint{}
+	if len(f.Addresses) > 0 {
+		group := [][3]uint{}
+		for _, addr := range f.Addresses {
+			group = append(group, core.BloomBits(addr.Bytes()))
+		}
+		groups = append(groups, group)
+	}
+	for _, alternatives := range f.Topics {
+		if len(alternatives) == 0 {
+			continue
+		}
+		group := [][3]uint{}
+		for _, topic := range alternatives {
+			group = append(group, core.BloomBits(topic[:]))
+		}
+		groups = append(groups, group)
+	}
+	return groups
+}
+
+// AddLogsToIndex records the log bloom of the given finalized block, and sets the block's
+// bits in the bloom bits sections. Adding the same block more than once is harmless.
+func (ch *Chain) AddLogsToIndex(block *core.ExtendedBlock) {
+	ch.logIndexMu.Lock()
+	defer ch.logIndexMu.Unlock()
+
+	ch.addLogsToIndex(block)
+
+	// The heights between the parent and the block have no block, so the index is complete up to
+	// the block once it is complete up to its parent.
+	next := ch.logIndexNextHeight()
+	if next == block.Height {
+		ch.setLogIndexNextHeight(block.Height + 1)
+	} else if next < block.Height {
+		if parent, err := ch.findBlock(block.Parent); err == nil && parent.Height < next {
+			ch.setLogIndexNextHeight(block.Height + 1)
+		}
+	}
+}
+
+func (ch *Chain) addLogsToIndex(block *core.ExtendedBlock) {
+	blockHash := block.Hash()
+	bloom := core.Bloom{}
+	for _, rawTx := range block.Txs {
+		if !isSmartContractTx(rawTx) {
+			continue
+		}
+		receipt, ok := ch.FindTxReceiptByHash(blockHash, crypto.Keccak256Hash(rawTx))
+		if !ok {
+			continue
+		}
+		bloom.Or(types.LogsBloom(receipt.Logs))
+	}
+
+	err := ch.store.Put(blockBloomKey(block.Height), BlockBloomEntry{
+		BlockHash: blockHash,
+		Bloom:     bloom,
+	})
+	if err != nil {
+		logger.Panic(err)
+	}
+
+	if bloom.IsEmpty() {
+		return
+	}
+	section := block.Height / BloomBitsSectionSize
+	offset := block.Height % BloomBitsSectionSize
+	for bit := uint(0); bit < core.BloomBitLength; bit++ {
+		if !bloom.HasBit(bit) {
+			continue
+		}
+		key := bloomBitsKey(bit, section)
+		vector := ch.getBloomBits(key)
+		vector[offset/8] |= byte(1) << (7 - offset%8)
+		if err := ch.store.Put(key, vector); err != nil {
+			logger.Panic(err)
+		}
+	}
+}
+
+// FindBlockBloom looks up the log bloom of the finalized block at the given height.
+func (ch *Chain) FindBlockBloom(height uint64) (*BlockBloomEntry, bool) {
+	entry := &BlockBloomEntry{}
+	err := ch.store.Get(blockBloomKey(height), entry)
+	if err != nil {
+		if err != store.ErrKeyNotFound {
+			logger.Error(err)
+		}
+		return nil, false
+	}
+	return entry, true
+}
+
+// FindLogs returns the logs of the finalized blocks between fromHeight and toHeight (inclusive)
+// that match the filter. The candidate blocks are selected with the bloom bits sections, so
+// only the receipts of the blocks which may contain matching logs are loaded.
+func (ch *Chain) FindLogs(fromHeight, toHeight uint64, filter *LogFilter) ([]*LogEntry, error) {
+	if fromHeight > toHeight {
+		return nil, errors.New("fromHeight must not be greater than toHeight")
+	}
+	if toHeight-fromHeight >= MaxLogQueryRange {
+		return nil, fmt.Errorf("cannot query logs for more than %v blocks at a time", MaxLogQueryRange)
+	}
+	ch.logIndexMu.Lock()
+	next := ch.logIndexNextHeight()
+	ch.logIndexMu.Unlock()
+	if toHeight >= next {
+		return nil, fmt.Errorf("logs are not indexed from height %v yet", next)
+	}
+
+	groups := filter.bloomGroups()
+	entries := []*LogEntry{}
+	for section := fromHeight / BloomBitsSectionSize; section <= toHeight/BloomBitsSectionSize; section++ {
+		candidates := ch.sectionCandidates(section, groups)
+		first := section * BloomBitsSectionSize
+		for offset := uint64(0); offset < BloomBitsSectionSize; offset++ {
+			height := first + offset
+			if height < fromHeight || height > toHeight {
+				continue
+			}
+			if candidates[offset/8]&(byte(1)<<(7-offset%8)) == 0 {
+				continue
+			}
+			bloomEntry, ok := ch.FindBlockBloom(height)
+			if !ok || !bloomMatches(bloomEntry.Bloom, groups) {
+				continue
+			}
+			entries = append(entries, ch.FindBlockLogs(bloomEntry.BlockHash, filter)...)
+		}
+	}
+	return entries, nil
+}
+
+// FindBlockLogs returns the logs of the given block that match the filter.
+func (ch *Chain) FindBlockLogs(blockHash common.Hash, filter *LogFilter) []*LogEntry {
+	entries := []*LogEntry{}
+	block, err := ch.FindBlock(blockHash)
+	if err != nil {
+		return entries
+	}
+	logIndex := uint64(0)
+	for idx, rawTx := range block.Txs {
+		if !isSmartContractTx(rawTx) {
+			continue
+		}
+		txHash := crypto.Keccak256Hash(rawTx)
+		receipt, ok := ch.FindTxReceiptByHash(blockHash, txHash)
+		if !ok {
+			continue
+		}
+		for _, log := range receipt.Logs {
+			if filter.Matches(log) {
+				entries = append(entries, &LogEntry{
+					BlockHash:   blockHash,
+					BlockHeight: block.Height,
+					TxHash:      txHash,
+					TxIndex:     uint64(idx),
+					LogIndex:    logIndex,
+					Log:         log,
+				})
+			}
+			logIndex++
+		}
+	}
+	return entries
+}
+
+// sectionCandidates returns the bit vector of the blocks in the section whose blooms may match all the groups.
+func (ch *Chain) sectionCandidates(section uint64, groups [][][3]uint) []byte {
+	candidates := make([]byte, BloomBitsSectionSize/8)
+	if len(groups) == 0 {
+		// No constraint, every block having logs is a candidate
+		for i := range candidates {
+			candidates[i] = 0xff
+		}
+		return candidates
+	}
+
+	vectors := make(map[uint][]byte)
+	vectorOf := func(bit uint) []byte {
+		vector, ok := vectors[bit]
+		if !ok {
+			vector = ch.getBloomBits(bloomBitsKey(bit, section))
+			vectors[bit] = vector
+		}
+		return vector
+	}
+
+	for i, group := range groups {
+		groupVector := make([]byte, BloomBitsSectionSize/8)
+		for _, bits := range group {
+			for j := range groupVector {
+				groupVector[j] |= vectorOf(bits[0])[j] & vectorOf(bits[1])[j] & vectorOf(bits[2])[j]
+			}
+		}
+		if i == 0 {
+			candidates = groupVector
+			continue
+		}
+		for j := range candidates {
+			candidates[j] &= groupVector[j]
+		}
+	}
+	return candidates
+}
+
+// bloomMatches checks whether the bloom may contain logs matching all the groups.
+func bloomMatches(bloom core.Bloom, groups [][][3]uint) bool {
+	if bloom.IsEmpty() {
+		return false
+	}
+	for _, group := range groups {
+		matched := false
+		for _, bits := range group {
+			if bloom.HasBit(bits[0]) && bloom.HasBit(bits[1]) && bloom.HasBit(bits[2]) {
+				matched = true
+				break
+			}
+		}
+		if !matched {
+			return false
+		}
+	}
+	return true
+}
+
+func (ch *Chain) getBloomBits(key common.Bytes) []byte {
+	vector := []byte{}
+	err := ch.store.Get(key, &vector)
+	if err != nil && err != store.ErrKeyNotFound {
+		logger.Error(err)
+	}
+	if len(vector) != BloomBitsSectionSize/8 {
+		vector = make([]byte, BloomBitsSectionSize/8)
+	}
+	return vector
+}
+
+func isSmartContractTx(rawTx common.Bytes) bool {
+	tx, err := types.TxFromBytes(rawTx)
+	if err != nil {
+		return false
+	}
+	_, ok := tx.(*types.SmartContractTx)
+	return ok
+}
+
+func (ch *Chain) logIndexNextHeight() uint64 {
+	next := uint64(0)
+	if root, err := ch.findBlock(ch.root); err == nil {
+		next = root.Height + 1
+	}
+	if err := ch.store.Get(logIndexNextHeightKey, &next); err != nil && err != store.ErrKeyNotFound {
+		logger.Error(err)
+	}
+	return next
+}
+
+func (ch *Chain) setLogIndexNextHeight(height uint64) {
+	if err := ch.store.Put(logIndexNextHeightKey, height); err != nil {
+		logger.Panic(err)
+	}
+}
+
+// RebuildLogIndex indexes the logs of the blocks which were finalized before the log index was added,
+// e.g. by an older version of the node. It resumes from the lowest height not known to be indexed (the
+// block above the root block if the index has never been built), and returns once it has passed toHeight
+// and caught up with the blocks finalized in the meantime.
+func (ch *Chain) RebuildLogIndex(ctx context.Context, toHeight uint64) error {
+	ch.logIndexMu.Lock()
+	height := ch.logIndexNextHeight()
+	ch.logIndexMu.Unlock()
+
+	if height > toHeight {
+		return nil
+	}
+	logger.Infof("Rebuilding log index from height %v", height)
+	for ; ; height++ {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		default:
+		}
+
+		var finalized *core.ExtendedBlock
+		for _, block := range ch.FindBlocksByHeight(height) {
+			if block.Status.IsFinalized() {
+				finalized = block
+				break
+			}
+		}
+		if finalized == nil && height > toHeight {
+			break
+		}
+
+		ch.logIndexMu.Lock()
+		if finalized != nil {
+			ch.addLogsToIndex(finalized)
+		}
+		if ch.logIndexNextHeight() == height {
+			ch.setLogIndexNextHeight(height + 1)
+		}
+		ch.logIndexMu.Unlock()
+
+		if height%logIndexRebuildLogInterval == 0 {
+			logger.Infof("Rebuilt log index up to height %v", height)
+		}
+	}
+	logger.Infof("Done rebuilding log index up to height %v", height-1)
+	return nil
+}
@@ -0,0 +1,158 @@
+package blockchain
+
+import (
+	"context"
+	"math/big"
+	"testing"
+
+	"github.com/pandoprojects/pando/common"
+	"github.com/pandoprojects/pando/core"
+	"github.com/pandoprojects/pando/crypto"
+	"github.com/pandoprojects/pando/ledger/types"
+	"github.com/pandoprojects/pando/store/database/backend"
+	"github.com/pandoprojects/pando/store/kvstore"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLogIndex(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	core.ResetTestBlocks()
+	root := core.CreateTestBlock("a0", "")
+	chain := NewChain(root.ChainID, kvstore.NewKVStore(backend.NewMemDatabase()), root)
+
+	token := common.HexToAddress("0x1")
+	other := common.HexToAddress("0x2")
+	transfer := common.HexToHash("0xaa")
+	approval := common.HexToHash("0xbb")
+
+	privKey, _, err := crypto.GenerateKeyPair()
+	require.Nil(err)
+	newTx := func(seq uint64) (types.Tx, common.Bytes) {
+		sig, err := privKey.Sign(common.Bytes("tx"))
+		require.Nil(err)
+		tx := &types.SmartContractTx{
+			From:     types.TxInput{Address: privKey.PublicKey().Address(), Sequence: seq, Signature: sig},
+			To:       types.TxOutput{Address: token},
+			GasLimit: 100000,
+			GasPrice: big.NewInt(1),
+		}
+		raw, err := types.TxToBytes(tx)
+		require.Nil(err)
+		return tx, raw
+	}
+
+	parent := "a0"
+	heights := []uint64{1, BloomBitsSectionSize + 1, BloomBitsSectionSize + 2}
+	logs := [][]*types.Log{
+		{{Address: token, Topics: []common.Hash{transfer}}},
+		{{Address: other, Topics: []common.Hash{transfer}}, {Address: token, Topics: []common.Hash{approval}}},
+		{},
+	}
+	for i, height := range heights {
+		name := string(rune('b' + i))
+		block := core.CreateTestBlock(name, parent)
+		block.Height = height
+		tx, raw := newTx(uint64(i + 1))
+		block.Txs = []common.Bytes{raw}
+		block.UpdateHash()
+		_, err := chain.AddBlock(block)
+		require.Nil(err)
+		chain.AddTxReceipt(block, tx, logs[i], nil, nil, common.Address{}, 21000, nil)
+		require.Nil(chain.FinalizePreviousBlocks(block.Hash()))
+		parent = name
+	}
+
+	entry, ok := chain.FindBlockBloom(heights[0])
+	assert.True(ok)
+	for _, bit := range core.BloomBits(token.Bytes()) {
+		assert.True(entry.Bloom.HasBit(bit))
+	}
+	entry, ok = chain.FindBlockBloom(heights[2])
+	assert.True(ok)
+	assert.True(entry.Bloom.IsEmpty())
+
+	entries, err := chain.FindLogs(0, heights[2], &LogFilter{})
+	assert.Nil(err)
+	assert.Equal(3, len(entries))
+
+	entries, err = chain.FindLogs(0, heights[2], &LogFilter{Addresses: []common.Address{token}})
+	assert.Nil(err)
+	assert.Equal(2, len(entries))
+	assert.Equal(heights[1], entries[1].BlockHeight)
+	assert.Equal(uint64(1), entries[1].LogIndex)
+
+	entries, err = chain.FindLogs(0, heights[2], &LogFilter{Topics: [][]common.Hash{{transfer}}})
+	assert.Nil(err)
+	assert.Equal(2, len(entries))
+
+	entries, err = chain.FindLogs(0, heights[2], &LogFilter{
+		Addresses: []common.Address{token},
+		Topics:    [][]common.Hash{{transfer}},
+	})
+	assert.Nil(err)
+	assert.Equal(1, len(entries))
+	assert.Equal(heights[0], entries[0].BlockHeight)
+
+	entries, err = chain.FindLogs(2, heights[2], &LogFilter{Topics: [][]common.Hash{{approval, common.HexToHash("0xcc")}}})
+	assert.Nil(err)
+	assert.Equal(1, len(entries))
+
+	_, err = chain.FindLogs(2, 1, &LogFilter{})
+	assert.NotNil(err)
+}
+
+func TestLogIndexRebuild(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	core.ResetTestBlocks()
+	root := core.CreateTestBlock("a0", "")
+	chain := NewChain(root.ChainID, kvstore.NewKVStore(backend.NewMemDatabase()), root)
+
+	token := common.HexToAddress("0x1")
+	privKey, _, err := crypto.GenerateKeyPair()
+	require.Nil(err)
+
+	parent := "a0"
+	for i := 1; i <= 3; i++ {
+		name := "a" + string(rune('0'+i))
+		block := core.CreateTestBlock(name, parent)
+		block.Height = uint64(i)
+		sig, err := privKey.Sign(common.Bytes("tx"))
+		require.Nil(err)
+		tx := &types.SmartContractTx{
+			From:     types.TxInput{Address: privKey.PublicKey().Address(), Sequence: uint64(i), Signature: sig},
+			To:       types.TxOutput{Address: token},
+			GasLimit: 100000,
+			GasPrice: big.NewInt(1),
+		}
+		raw, err := types.TxToBytes(tx)
+		require.Nil(err)
+		block.Txs = []common.Bytes{raw}
+		block.UpdateHash()
+		_, err = chain.AddBlock(block)
+		require.Nil(err)
+		chain.AddTxReceipt(block, tx, []*types.Log{{Address: token}}, nil, nil, common.Address{}, 21000, nil)
+		require.Nil(chain.FinalizePreviousBlocks(block.Hash()))
+		parent = name
+	}
+	entries, err := chain.FindLogs(0, 3, &LogFilter{})
+	require.Nil(err)
+	assert.Equal(3, len(entries))
+
+	// The blocks finalized by an older version of the node are not indexed
+	for height := uint64(1); height <= 3; height++ {
+		require.Nil(chain.store.Delete(blockBloomKey(height)))
+	}
+	require.Nil(chain.store.Delete(logIndexNextHeightKey))
+	_, err = chain.FindLogs(0, 3, &LogFilter{})
+	assert.NotNil(err)
+
+	require.Nil(chain.RebuildLogIndex(context.Background(), 3))
+	entries, err = chain.FindLogs(0, 3, &LogFilter{})
+	require.Nil(err)
+	assert.Equal(3, len(entries))
+}
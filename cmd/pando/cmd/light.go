@@ -0,0 +1,127 @@
+package cmd
+
+import (
+	"context"
+	"os"
+	"os/signal"
+	"path"
+	"reflect"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+	"github.com/spf13/viper"
+
+	"github.com/pandoprojects/pando/common"
+	"github.com/pandoprojects/pando/core"
+	dp "github.com/pandoprojects/pando/dispatcher"
+	"github.com/pandoprojects/pando/lightclient"
+	"github.com/pandoprojects/pando/store"
+	"github.com/pandoprojects/pando/store/database/backend"
+	"github.com/pandoprojects/pando/store/kvstore"
+)
+
+// runLightClient runs "pando start --light": it follows the finalized headers from an RPC endpoint
+// or from the p2p peers, and only stores the verified headers.
+func runLightClient() {
+	dbPath := viper.GetString(common.CfgDataPath)
+	if dbPath == "" {
+		dbPath = cfgPath
+	}
+	mainDBPath := path.Join(dbPath, "db", "light", "main")
+	refDBPath := path.Join(dbPath, "db", "light", "ref")
+	db, err := backend.NewLDBDatabase(mainDBPath, refDBPath,
+		viper.GetInt(common.CfgStorageLevelDBCacheSize),
+		viper.GetInt(common.CfgStorageLevelDBHandles))
+	if err != nil {
+		log.Fatalf("Failed to connect to the db. main: %v, ref: %v, err: %v",
+			mainDBPath, refDBPath, err)
+	}
+
+	// trap Ctrl+C and call cancel on the context
+	ctx, cancel := context.WithCancel(context.Background())
+
+	var source lightclient.Source
+	var dispatcher *dp.Dispatcher
+	if endpoint := viper.GetString(common.CfgLightRPCEndpoint); endpoint != "" {
+		source = lightclient.NewRPCSource(endpoint)
+	} else {
+		privKey, err := loadOrCreateKey()
+		if err != nil {
+			log.Fatalf("Failed to load or create key: %v", err)
+		}
+		networkOld, network := newNetworks(privKey, ctx)
+		dispatcher = dp.NewDispatcher(networkOld, network)
+
+		// A light node does not serve other light clients.
+		handler := lightclient.NewHandler(dispatcher, nil)
+		if !reflect.ValueOf(network).IsNil() {
+			network.RegisterMessageHandler(handler)
+		}
+		if !reflect.ValueOf(networkOld).IsNil() {
+			networkOld.RegisterMessageHandler(handler)
+		}
+		if err := dispatcher.Start(ctx); err != nil {
+			log.Fatalf("Failed to start the p2p network: %v", err)
+		}
+		timeout := time.Duration(viper.GetInt(common.CfgLightRequestTimeoutSecs)) * time.Second
+		source = lightclient.NewPeerSource(handler, timeout)
+	}
+
+	c := make(chan os.Signal, 1)
+	signal.Notify(c, os.Interrupt)
+	go func() {
+		<-c
+		signal.Stop(c)
+		cancel()
+	}()
+
+	lc := newLightClient(ctx, source, kvstore.NewKVStore(db))
+	if lc == nil {
+		return
+	}
+	log.WithFields(log.Fields{
+		"root": lc.Root().Hash().Hex(),
+		"head": lc.Head().Height,
+	}).Info("Started light client")
+
+	lc.Start(ctx)
+	lc.Wait()
+	if dispatcher != nil {
+		dispatcher.Stop()
+	}
+	db.Close()
+
+	log.Infof("")
+	log.Infof("Graceful exit.")
+	printExitBanner()
+}
+
+// newLightClient creates the light client from the trusted block of the configuration, the
+// genesis block by default. It retries until the source provides the trusted block, and returns
+// nil if the context is canceled first.
+func newLightClient(ctx context.Context, source lightclient.Source, st store.Store) *lightclient.LightClient {
+	trustedHeight := viper.GetUint64(common.CfgLightTrustedHeight)
+	trustedHash := viper.GetString(common.CfgLightTrustedHash)
+	if trustedHash == "" {
+		trustedHeight = core.GenesisBlockHeight
+		trustedHash = viper.GetString(common.CfgGenesisHash)
+		if trustedHash == "" {
+			trustedHash = core.MainnetGenesisBlockHash
+		}
+	}
+
+	pollInterval := time.Duration(viper.GetInt(common.CfgLightPollIntervalSecs)) * time.Second
+	for {
+		lc, err := lightclient.New(source, st, trustedHeight, common.HexToHash(trustedHash))
+		if err == nil {
+			return lc
+		}
+		log.WithFields(log.Fields{"error": err}).Warn("Failed to start light client, retrying")
+
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-time.After(pollInterval):
+		}
+	}
+}
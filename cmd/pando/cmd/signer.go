@@ -0,0 +1,69 @@
+package cmd
+
+import (
+	"os"
+	"os/signal"
+	"path"
+
+	log "github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+	"github.com/pandoprojects/pando/common"
+	"github.com/pandoprojects/pando/signer"
+)
+
+// signerCmd represents the signer command
+var signerCmd = &cobra.Command{
+	Use:   "signer",
+	Short: "Run a remote signer holding the validator key.",
+	Long: `Run a remote signer holding the validator key, to which the Pando node connects by setting
+signer.remoteAddress. Both ends authenticate with TLS certificates signed by signer.tlsCACert.
+The signer persists the last signed messages to signer.statePath and refuses to double-sign.`,
+	Run: runSigner,
+}
+
+func init() {
+	RootCmd.AddCommand(signerCmd)
+}
+
+func runSigner(cmd *cobra.Command, args []string) {
+	privKey, err := loadOrCreateKey()
+	if err != nil {
+		log.Fatalf("Failed to load or create key: %v", err)
+	}
+
+	statePath := viper.GetString(common.CfgSignerStatePath)
+	if statePath == "" {
+		statePath = path.Join(cfgPath, "signer_state.json")
+	}
+	hwm, err := signer.NewHighWaterMark(statePath)
+	if err != nil {
+		log.Fatalf("Failed to load signer state: %v", err)
+	}
+
+	tlsConfig, err := signer.LoadTLSConfig(
+		viper.GetString(common.CfgSignerTLSCert),
+		viper.GetString(common.CfgSignerTLSKey),
+		viper.GetString(common.CfgSignerTLSCACert),
+		true)
+	if err != nil {
+		log.Fatalf("Failed to load TLS config: %v", err)
+	}
+
+	server := signer.NewServer(signer.NewLocalSignerWithHighWaterMark(privKey, hwm),
+		viper.GetString(common.CfgSignerListenAddress), tlsConfig)
+	if err := server.Start(); err != nil {
+		log.Fatalf("Failed to start signer: %v", err)
+	}
+
+	c := make(chan os.Signal, 1)
+	signal.Notify(c, os.Interrupt)
+	go func() {
+		<-c
+		signal.Stop(c)
+		server.Stop()
+	}()
+
+	server.Wait()
+	log.Infof("Signer stopped.")
+}
@@ -18,12 +18,15 @@ import (
 	"github.com/pandoprojects/pando/cmd/pandocli/cmd/utils"
 	"github.com/pandoprojects/pando/common"
 	"github.com/pandoprojects/pando/common/util"
+	"github.com/pandoprojects/pando/consensus"
 	"github.com/pandoprojects/pando/core"
 	"github.com/pandoprojects/pando/crypto"
+	"github.com/pandoprojects/pando/mempool"
 	"github.com/pandoprojects/pando/node"
 	msg "github.com/pandoprojects/pando/p2p/messenger"
 	msgl "github.com/pandoprojects/pando/p2pl/messenger"
 	"github.com/pandoprojects/pando/rlp"
+	"github.com/pandoprojects/pando/signer"
 	"github.com/pandoprojects/pando/snapshot"
 	"github.com/pandoprojects/pando/store/database/backend"
 	"github.com/pandoprojects/pando/store/rollingdb"
@@ -39,10 +42,18 @@ var startCmd = &cobra.Command{
 }
 
 func init() {
+	startCmd.Flags().Bool("light", false, "Run a light client, which follows the headers without storing the block bodies")
+	viper.BindPFlag(common.CfgLightEnabled, startCmd.Flags().Lookup("light"))
+
 	RootCmd.AddCommand(startCmd)
 }
 
 func runStart(cmd *cobra.Command, args []string) {
+	if viper.GetBool(common.CfgLightEnabled) {
+		runLightClient()
+		return
+	}
+
 	var networkOld *msg.Messenger
 	var network *msgl.Messenger
 	var err error
@@ -52,6 +63,15 @@ func runStart(cmd *cobra.Command, args []string) {
 		log.Fatalf("Failed to load or create key: %v", err)
 	}
 
+	// With a remote signer, the key loaded above only identifies the node in the P2P network.
+	var remoteSigner *signer.RemoteSigner
+	if address := viper.GetString(common.CfgSignerRemoteAddress); address != "" {
+		remoteSigner, err = newRemoteSigner(address)
+		if err != nil {
+			log.Fatalf("Failed to connect to remote signer: %v", err)
+		}
+	}
+
 	// Open database
 	dbPath := viper.GetString(common.CfgDataPath)
 	if dbPath == "" {
@@ -71,6 +91,24 @@ func runStart(cmd *cobra.Command, args []string) {
 			mainDBPath, refDBPath, err)
 	}
 
+	walPath := viper.GetString(common.CfgConsensusWALPath)
+	if walPath == "" {
+		walPath = path.Join(dbPath, "db", "consensus.wal")
+	}
+	wal, err := consensus.OpenWAL(walPath)
+	if err != nil {
+		log.Fatalf("Failed to open the consensus WAL %v: %v", walPath, err)
+	}
+
+	journalPath := viper.GetString(common.CfgMempoolJournalPath)
+	if journalPath == "" {
+		journalPath = path.Join(dbPath, "db", "mempool.journal")
+	}
+	journal, err := mempool.OpenTxJournal(journalPath)
+	if err != nil {
+		log.Fatalf("Failed to open the mempool journal %v: %v", journalPath, err)
+	}
+
 	// load snapshot
 	if len(snapshotPath) == 0 {
 		snapshotPath = path.Join(cfgPath, "snapshot")
@@ -114,26 +152,10 @@ func runStart(cmd *cobra.Command, args []string) {
 
 	viper.Set(common.CfgGenesisChainID, root.ChainID)
 
-	// Parse seeds and filter out empty item.
-	f := func(c rune) bool {
-		return c == ','
-	}
-
 	// trap Ctrl+C and call cancel on the context
 	ctx, cancel := context.WithCancel(context.Background())
 
-	p2pOpt := common.P2POptEnum(viper.GetInt(common.CfgP2POpt))
-	if p2pOpt != common.P2POptOld {
-		port := viper.GetInt(common.CfgP2PLPort)
-		peerSeeds := strings.FieldsFunc(viper.GetString(common.CfgLibP2PSeeds), f)
-		seedPeerOnly := viper.GetBool(common.CfgP2PSeedPeerOnly)
-		network = newMessenger(privKey, peerSeeds, port, seedPeerOnly, ctx)
-	}
-	if p2pOpt != common.P2POptLibp2p {
-		portOld := viper.GetInt(common.CfgP2PPort)
-		peerSeedsOld := strings.FieldsFunc(viper.GetString(common.CfgP2PSeeds), f)
-		networkOld = newMessengerOld(privKey, peerSeedsOld, portOld, ctx)
-	}
+	networkOld, network = newNetworks(privKey, ctx)
 
 	params := &node.Params{
 		ChainID:             root.ChainID,
@@ -146,6 +168,12 @@ func runStart(cmd *cobra.Command, args []string) {
 		SnapshotPath:        snapshotPath,
 		ChainImportDirPath:  chainImportDirPath,
 		ChainCorrectionPath: chainCorrectionPath,
+		ConsensusWAL:        wal,
+		MempoolJournal:      journal,
+	}
+
+	if remoteSigner != nil {
+		params.Signer = remoteSigner
 	}
 
 	n := node.NewNode(params)
@@ -186,6 +214,41 @@ func runStart(cmd *cobra.Command, args []string) {
 	printExitBanner()
 }
 
+// newNetworks creates the p2p networks selected by the p2p.opt setting.
+func newNetworks(privKey *crypto.PrivateKey, ctx context.Context) (networkOld *msg.Messenger, network *msgl.Messenger) {
+	// Parse seeds and filter out empty item.
+	f := func(c rune) bool {
+		return c == ','
+	}
+
+	p2pOpt := common.P2POptEnum(viper.GetInt(common.CfgP2POpt))
+	if p2pOpt != common.P2POptOld {
+		port := viper.GetInt(common.CfgP2PLPort)
+		peerSeeds := strings.FieldsFunc(viper.GetString(common.CfgLibP2PSeeds), f)
+		seedPeerOnly := viper.GetBool(common.CfgP2PSeedPeerOnly)
+		network = newMessenger(privKey, peerSeeds, port, seedPeerOnly, ctx)
+	}
+	if p2pOpt != common.P2POptLibp2p {
+		portOld := viper.GetInt(common.CfgP2PPort)
+		peerSeedsOld := strings.FieldsFunc(viper.GetString(common.CfgP2PSeeds), f)
+		networkOld = newMessengerOld(privKey, peerSeedsOld, portOld, ctx)
+	}
+	return networkOld, network
+}
+
+func newRemoteSigner(address string) (*signer.RemoteSigner, error) {
+	tlsConfig, err := signer.LoadTLSConfig(
+		viper.GetString(common.CfgSignerTLSCert),
+		viper.GetString(common.CfgSignerTLSKey),
+		viper.GetString(common.CfgSignerTLSCACert),
+		false)
+	if err != nil {
+		return nil, err
+	}
+	timeout := time.Duration(viper.GetInt(common.CfgSignerTimeoutSecs)) * time.Second
+	return signer.NewRemoteSigner(address, tlsConfig, timeout)
+}
+
 func loadOrCreateKey() (*crypto.PrivateKey, error) {
 	keyPath := viper.GetString(common.CfgKeyPath)
 	if keyPath == "" {
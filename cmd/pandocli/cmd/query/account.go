@@ -28,9 +28,10 @@ func doAccountCmd(cmd *cobra.Command, args []string) {
 	client := rpcc.NewRPCClient(viper.GetString(utils.CfgRemoteRPCEndpoint))
 
 	res, err := client.Call("pando.GetAccount", rpc.GetAccountArgs{
-		Address: addressFlag,
-		Height:  common.JSONUint64(heightFlag),
-		Preview: previewFlag})
+		Address:   addressFlag,
+		Height:    common.JSONUint64(heightFlag),
+		BlockHash: common.HexToHash(hashFlag),
+		Preview:   previewFlag})
 	if err != nil {
 		utils.Error("Failed to get account details: %v\n", err)
 	}
@@ -47,6 +48,7 @@ func doAccountCmd(cmd *cobra.Command, args []string) {
 func init() {
 	accountCmd.Flags().StringVar(&addressFlag, "address", "", "Address of the account")
 	accountCmd.Flags().Uint64Var(&heightFlag, "height", uint64(0), "height of the block")
+	accountCmd.Flags().StringVar(&hashFlag, "block_hash", "", "hash of the block, alternative to height")
 	accountCmd.Flags().BoolVar(&previewFlag, "preview", false, "Preview account balance from the screened view")
 	accountCmd.MarkFlagRequired("address")
 }
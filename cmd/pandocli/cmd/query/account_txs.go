@@ -0,0 +1,52 @@
+package query
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/pandoprojects/pando/cmd/pandocli/cmd/utils"
+	"github.com/pandoprojects/pando/common"
+	"github.com/pandoprojects/pando/rpc"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+	rpcc "github.com/ybbus/jsonrpc"
+)
+
+// accountTxsCmd represents the account-txs command.
+// Example:
+//		pandocli query account-txs --address=0x2E833968E5bB786Ae419c4d13189fB081Cc43bab --page=0 --page_size=20
+var accountTxsCmd = &cobra.Command{
+	Use:     "account-txs",
+	Short:   "Get the transactions of an account",
+	Long:    `Get the transactions of an account, newest first. Requires the node to enable storage.indexAccountTxs.`,
+	Example: `pandocli query account-txs --address=0x2E833968E5bB786Ae419c4d13189fB081Cc43bab --page=0 --page_size=20`,
+	Run:     doAccountTxsCmd,
+}
+
+func doAccountTxsCmd(cmd *cobra.Command, args []string) {
+	client := rpcc.NewRPCClient(viper.GetString(utils.CfgRemoteRPCEndpoint))
+
+	res, err := client.Call("pando.GetAccountTransactions", rpc.GetAccountTransactionsArgs{
+		Address:  addressFlag,
+		Page:     common.JSONUint64(pageFlag),
+		PageSize: common.JSONUint64(pageSizeFlag)})
+	if err != nil {
+		utils.Error("Failed to get account transactions: %v\n", err)
+	}
+	if res.Error != nil {
+		utils.Error("Failed to get account transactions: %v\n", res.Error)
+	}
+	json, err := json.MarshalIndent(res.Result, "", "    ")
+	if err != nil {
+		utils.Error("Failed to parse server response: %v\n%v\n", err, string(json))
+	}
+	fmt.Println(string(json))
+}
+
+func init() {
+	accountTxsCmd.Flags().StringVar(&addressFlag, "address", "", "Address of the account")
+	accountTxsCmd.Flags().Uint64Var(&pageFlag, "page", uint64(0), "Page number, starting from 0")
+	accountTxsCmd.Flags().Uint64Var(&pageSizeFlag, "page_size", uint64(20), "Number of transactions per page")
+	accountTxsCmd.MarkFlagRequired("address")
+}
@@ -0,0 +1,47 @@
+package query
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/pandoprojects/pando/cmd/pandocli/cmd/utils"
+	"github.com/pandoprojects/pando/rpc"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+	rpcc "github.com/ybbus/jsonrpc"
+)
+
+// delegatorRewardsCmd represents the delegator rewards command.
+// Example:
+//
+//	pandocli query delegator_rewards --address=2E833968E5bB786Ae419c4d13189fB081Cc43bab
+var delegatorRewardsCmd = &cobra.Command{
+	Use:     "delegator_rewards",
+	Short:   "Get the staking rewards of a delegator",
+	Long:    `Get the staking rewards of a delegator which can be claimed, per validator, as of the latest finalized block.`,
+	Example: `pandocli query delegator_rewards --address=2E833968E5bB786Ae419c4d13189fB081Cc43bab`,
+	Run:     doDelegatorRewardsCmd,
+}
+
+func doDelegatorRewardsCmd(cmd *cobra.Command, args []string) {
+	client := rpcc.NewRPCClient(viper.GetString(utils.CfgRemoteRPCEndpoint))
+
+	res, err := client.Call("pando.GetDelegatorRewards", rpc.GetDelegatorRewardsArgs{Address: addressFlag})
+	if err != nil {
+		utils.Error("Failed to get delegator rewards: %v\n", err)
+	}
+	if res.Error != nil {
+		utils.Error("Failed to get delegator rewards: %v\n", res.Error)
+	}
+	json, err := json.MarshalIndent(res.Result, "", "    ")
+	if err != nil {
+		utils.Error("Failed to parse server response: %v\n%v\n", err, string(json))
+	}
+	fmt.Println(string(json))
+}
+
+func init() {
+	delegatorRewardsCmd.Flags().StringVar(&addressFlag, "address", "", "Address of the delegator")
+	delegatorRewardsCmd.MarkFlagRequired("address")
+}
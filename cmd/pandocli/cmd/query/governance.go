@@ -0,0 +1,42 @@
+package query
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/pandoprojects/pando/cmd/pandocli/cmd/utils"
+	"github.com/pandoprojects/pando/rpc"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+	rpcc "github.com/ybbus/jsonrpc"
+)
+
+// governanceCmd represents the governance command.
+// Example:
+//
+//	pandocli query governance
+var governanceCmd = &cobra.Command{
+	Use:     "governance",
+	Short:   "Get the governance parameters",
+	Long:    `Get the governance parameters in effect after the latest finalized block, and the proposals waiting for their activation height.`,
+	Example: `pandocli query governance`,
+	Run:     doGovernanceCmd,
+}
+
+func doGovernanceCmd(cmd *cobra.Command, args []string) {
+	client := rpcc.NewRPCClient(viper.GetString(utils.CfgRemoteRPCEndpoint))
+
+	res, err := client.Call("pando.GetGovernanceParams", rpc.GetGovernanceParamsArgs{})
+	if err != nil {
+		utils.Error("Failed to get governance parameters: %v\n", err)
+	}
+	if res.Error != nil {
+		utils.Error("Failed to get governance parameters: %v\n", res.Error)
+	}
+	json, err := json.MarshalIndent(res.Result, "", "    ")
+	if err != nil {
+		utils.Error("Failed to parse server response: %v\n%v\n", err, string(json))
+	}
+	fmt.Println(string(json))
+}
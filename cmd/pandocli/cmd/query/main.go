@@ -15,6 +15,8 @@ var (
 	endFlag              uint64
 	skipRametronenterpriseFlag     bool
 	includeEthTxHashFlag bool
+	pageFlag             uint64
+	pageSizeFlag         uint64
 )
 
 // QueryCmd represents the query command
@@ -26,6 +28,7 @@ var QueryCmd = &cobra.Command{
 func init() {
 	QueryCmd.AddCommand(statusCmd)
 	QueryCmd.AddCommand(accountCmd)
+	QueryCmd.AddCommand(accountTxsCmd)
 	QueryCmd.AddCommand(metatronCmd)
 	QueryCmd.AddCommand(blockCmd)
 	QueryCmd.AddCommand(txCmd)
@@ -36,6 +39,9 @@ func init() {
 	QueryCmd.AddCommand(rametronenterpriseCmd)
 	QueryCmd.AddCommand(srdrsCmd)
 	QueryCmd.AddCommand(stakeReturnsCmd)
+	QueryCmd.AddCommand(validatorUptimeCmd)
+	QueryCmd.AddCommand(governanceCmd)
+	QueryCmd.AddCommand(delegatorRewardsCmd)
 	QueryCmd.AddCommand(peersCmd)
 	QueryCmd.AddCommand(versionCmd)
 }
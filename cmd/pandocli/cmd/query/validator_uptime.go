@@ -0,0 +1,46 @@
+package query
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/pandoprojects/pando/cmd/pandocli/cmd/utils"
+	"github.com/pandoprojects/pando/rpc"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+	rpcc "github.com/ybbus/jsonrpc"
+)
+
+// validatorUptimeCmd represents the validator-uptime command.
+// Example:
+//		pandocli query validator-uptime --address=0x2E833968E5bB786Ae419c4d13189fB081Cc43bab
+var validatorUptimeCmd = &cobra.Command{
+	Use:     "validator-uptime",
+	Short:   "Get the uptime of the validators",
+	Long:    `Get the votes and proposals missed by the validators over the recent finalized blocks, as seen by the node. All the current validators are listed if no address is specified. The jail intents are advisory and local to the node, they are not enforced on-chain.`,
+	Example: `pandocli query validator-uptime --address=0x2E833968E5bB786Ae419c4d13189fB081Cc43bab`,
+	Run:     doValidatorUptimeCmd,
+}
+
+func doValidatorUptimeCmd(cmd *cobra.Command, args []string) {
+	client := rpcc.NewRPCClient(viper.GetString(utils.CfgRemoteRPCEndpoint))
+
+	res, err := client.Call("pando.GetValidatorLiveness", rpc.GetValidatorLivenessArgs{
+		Address: addressFlag})
+	if err != nil {
+		utils.Error("Failed to get validator uptime: %v\n", err)
+	}
+	if res.Error != nil {
+		utils.Error("Failed to get validator uptime: %v\n", res.Error)
+	}
+	json, err := json.MarshalIndent(res.Result, "", "    ")
+	if err != nil {
+		utils.Error("Failed to parse server response: %v\n%v\n", err, string(json))
+	}
+	fmt.Println(string(json))
+}
+
+func init() {
+	validatorUptimeCmd.Flags().StringVar(&addressFlag, "address", "", "Address of the validator, all the current validators if empty")
+}
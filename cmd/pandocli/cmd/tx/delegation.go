@@ -0,0 +1,130 @@
+package tx
+
+import (
+	"fmt"
+	"math/big"
+
+	"github.com/spf13/cobra"
+
+	"github.com/pandoprojects/pando/cmd/pandocli/cmd/utils"
+	"github.com/pandoprojects/pando/common"
+	"github.com/pandoprojects/pando/ledger/types"
+)
+
+// Flags of the delegation sub commands.
+var (
+	commissionBasisPointFlag uint64
+)
+
+// setCommissionCmd represents the validator commission command
+// Example:
+//
+//	pandocli tx set_commission --chain="privatenet" --from=2E833968E5bB786Ae419c4d13189fB081Cc43bab --commission_basis_point=500 --seq=8
+var setCommissionCmd = &cobra.Command{
+	Use:     "set_commission",
+	Short:   "Set the commission the validator keeps from the staking rewards",
+	Long:    `Set the commission the validator keeps from the staking rewards, in 1/10000 of the rewards. The commission can only be updated once per 14400 blocks.`,
+	Example: `pandocli tx set_commission --chain="privatenet" --from=2E833968E5bB786Ae419c4d13189fB081Cc43bab --commission_basis_point=500 --seq=8`,
+	Run:     doSetCommissionCmd,
+}
+
+func doSetCommissionCmd(cmd *cobra.Command, args []string) {
+	wallet, holderAddress, err := walletUnlockWithPath(cmd, fromFlag, pathFlag, passwordFlag)
+	if err != nil {
+		return
+	}
+	defer wallet.Lock(holderAddress)
+
+	fee, ok := types.ParseCoinAmount(feeFlag)
+	if !ok {
+		utils.Error("Failed to parse fee")
+	}
+
+	commissionTx := &types.ValidatorCommissionTx{
+		Fee: types.Coins{
+			PandoWei: new(big.Int).SetUint64(0),
+			PTXWei:   fee,
+		},
+		Holder: types.TxInput{
+			Address:  holderAddress,
+			Sequence: uint64(seqFlag),
+		},
+		CommissionBasisPoint: commissionBasisPointFlag,
+	}
+
+	sig, err := wallet.Sign(holderAddress, commissionTx.SignBytes(chainIDFlag))
+	if err != nil {
+		utils.Error("Failed to sign transaction: %v\n", err)
+	}
+	commissionTx.SetSignature(holderAddress, sig)
+
+	broadcastTx(commissionTx)
+}
+
+// claimRewardCmd represents the stake reward claim command
+// Example:
+//
+//	pandocli tx claim_reward --chain="privatenet" --from=2E833968E5bB786Ae419c4d13189fB081Cc43bab --holder=70f587259738cB626A1720Af7038B8DcDb6a42a0 --seq=9
+var claimRewardCmd = &cobra.Command{
+	Use:     "claim_reward",
+	Short:   "Claim the staking rewards of the stake delegated to a validator",
+	Long:    `Claim the staking rewards of the stake delegated to a validator. A validator claiming from itself also claims its commission.`,
+	Example: `pandocli tx claim_reward --chain="privatenet" --from=2E833968E5bB786Ae419c4d13189fB081Cc43bab --holder=70f587259738cB626A1720Af7038B8DcDb6a42a0 --seq=9`,
+	Run:     doClaimRewardCmd,
+}
+
+func doClaimRewardCmd(cmd *cobra.Command, args []string) {
+	wallet, sourceAddress, err := walletUnlockWithPath(cmd, fromFlag, pathFlag, passwordFlag)
+	if err != nil {
+		return
+	}
+	defer wallet.Lock(sourceAddress)
+
+	fee, ok := types.ParseCoinAmount(feeFlag)
+	if !ok {
+		utils.Error("Failed to parse fee")
+	}
+
+	claimTx := &types.ClaimStakeRewardTx{
+		Fee: types.Coins{
+			PandoWei: new(big.Int).SetUint64(0),
+			PTXWei:   fee,
+		},
+		Source: types.TxInput{
+			Address:  sourceAddress,
+			Sequence: uint64(seqFlag),
+		},
+		Holder: common.HexToAddress(holderFlag),
+	}
+
+	sig, err := wallet.Sign(sourceAddress, claimTx.SignBytes(chainIDFlag))
+	if err != nil {
+		utils.Error("Failed to sign transaction: %v\n", err)
+	}
+	claimTx.SetSignature(sourceAddress, sig)
+
+	broadcastTx(claimTx)
+}
+
+func init() {
+	for _, cmd := range []*cobra.Command{setCommissionCmd, claimRewardCmd} {
+		cmd.Flags().StringVar(&chainIDFlag, "chain", "", "Chain ID")
+		cmd.Flags().StringVar(&fromFlag, "from", "", "Address of the signer")
+		cmd.Flags().StringVar(&pathFlag, "path", "", "Wallet derivation path")
+		cmd.Flags().StringVar(&feeFlag, "fee", fmt.Sprintf("%dwei", types.MinimumTransactionFeePTXWeiDec2022), "Fee")
+		cmd.Flags().Uint64Var(&seqFlag, "seq", 0, "Sequence number of the transaction")
+		cmd.Flags().StringVar(&walletFlag, "wallet", "soft", "Wallet type (soft|nano)")
+		cmd.Flags().BoolVar(&asyncFlag, "async", false, "block until tx has been included in the blockchain")
+		cmd.Flags().StringVar(&passwordFlag, "password", "", "password to unlock the wallet")
+
+		cmd.MarkFlagRequired("chain")
+		cmd.MarkFlagRequired("from")
+		cmd.MarkFlagRequired("seq")
+	}
+
+	setCommissionCmd.Flags().Uint64Var(&commissionBasisPointFlag, "commission_basis_point", 0, "Commission of the validator, in 1/10000 of the staking rewards")
+	setCommissionCmd.MarkFlagRequired("commission_basis_point")
+
+	claimRewardCmd.Flags().StringVar(&holderFlag, "holder", "", "Validator the stake is delegated to")
+	claimRewardCmd.MarkFlagRequired("holder")
+}
@@ -82,66 +82,19 @@ func doDepositStakeCmd(cmd *cobra.Command, args []string) {
 	// Parse holder flag.
 	var holderAddress common.Address
 	if purposeFlag == core.StakeForValidator {
-		if len(holderFlag) != 40 && len(holderFlag) != 42 {
-			utils.Error("holder must be a valid address")
+		if len(strings.TrimPrefix(holderFlag, "0x")) == 458 {
+			// A summary registers the BLS key of the validator along with the deposit.
+			holderAddress = parseBLSSummary(depositStakeTx, holderFlag, "zyta")
+		} else {
+			if len(holderFlag) != 40 && len(holderFlag) != 42 {
+				utils.Error("holder must be a valid address")
+			}
+			holderAddress = common.HexToAddress(holderFlag)
 		}
-		holderAddress = common.HexToAddress(holderFlag)
 	} else if purposeFlag == core.StakeForGuardian {
-		if strings.HasPrefix(holderFlag, "0x") {
-			holderFlag = holderFlag[2:]
-		}
-		if len(holderFlag) != 458 {
-			utils.Error("Holder must be a valid guardian summary")
-		}
-		guardianKeyBytes, err := hex.DecodeString(holderFlag)
-		if err != nil {
-			utils.Error("Failed to decode guardian address: %v\n", err)
-		}
-		holderAddress = common.BytesToAddress(guardianKeyBytes[:20])
-		blsPubkey, err := bls.PublicKeyFromBytes(guardianKeyBytes[20:68])
-		if err != nil {
-			utils.Error("Failed to decode bls Pubkey: %v\n", err)
-		}
-		blsPop, err := bls.SignatureFromBytes(guardianKeyBytes[68:164])
-		if err != nil {
-			utils.Error("Failed to decode bls POP: %v\n", err)
-		}
-		holderSig, err := crypto.SignatureFromBytes(guardianKeyBytes[164:])
-		if err != nil {
-			utils.Error("Failed to decode signature: %v\n", err)
-		}
-
-		depositStakeTx.BlsPubkey = blsPubkey
-		depositStakeTx.BlsPop = blsPop
-		depositStakeTx.HolderSig = holderSig
+		holderAddress = parseBLSSummary(depositStakeTx, holderFlag, "guardian")
 	} else { // purposeFlag == core.StakeForrametronenterprise
-		if strings.HasPrefix(holderFlag, "0x") {
-			holderFlag = holderFlag[2:]
-		}
-		if len(holderFlag) != 458 {
-			utils.Error("Holder must be a valid rametronenterprise summary")
-		}
-		rametronenterpriseKeyBytes, err := hex.DecodeString(holderFlag)
-		if err != nil {
-			utils.Error("Failed to decode rametronenterprise address: %v\n", err)
-		}
-		holderAddress = common.BytesToAddress(rametronenterpriseKeyBytes[:20])
-		blsPubkey, err := bls.PublicKeyFromBytes(rametronenterpriseKeyBytes[20:68])
-		if err != nil {
-			utils.Error("Failed to decode bls Pubkey: %v\n", err)
-		}
-		blsPop, err := bls.SignatureFromBytes(rametronenterpriseKeyBytes[68:164])
-		if err != nil {
-			utils.Error("Failed to decode bls POP: %v\n", err)
-		}
-		holderSig, err := crypto.SignatureFromBytes(rametronenterpriseKeyBytes[164:])
-		if err != nil {
-			utils.Error("Failed to decode signature: %v\n", err)
-		}
-
-		depositStakeTx.BlsPubkey = blsPubkey
-		depositStakeTx.BlsPop = blsPop
-		depositStakeTx.HolderSig = holderSig
+		holderAddress = parseBLSSummary(depositStakeTx, holderFlag, "rametronenterprise")
 	}
 
 	depositStakeTx.Holder = types.TxOutput{
@@ -177,10 +130,42 @@ func doDepositStakeCmd(cmd *cobra.Command, args []string) {
 	fmt.Printf("Successfully broadcasted transaction.\n")
 }
 
+// parseBLSSummary decodes the holder summary, i.e. the address, BLS key, BLS proof of possession
+// and holder signature of a node, sets the BLS fields of the transaction, and returns the address.
+func parseBLSSummary(depositStakeTx *types.DepositStakeTxV2, holderFlag string, name string) common.Address {
+	if strings.HasPrefix(holderFlag, "0x") {
+		holderFlag = holderFlag[2:]
+	}
+	if len(holderFlag) != 458 {
+		utils.Error("Holder must be a valid %v summary", name)
+	}
+	keyBytes, err := hex.DecodeString(holderFlag)
+	if err != nil {
+		utils.Error("Failed to decode %v address: %v\n", name, err)
+	}
+	blsPubkey, err := bls.PublicKeyFromBytes(keyBytes[20:68])
+	if err != nil {
+		utils.Error("Failed to decode bls Pubkey: %v\n", err)
+	}
+	blsPop, err := bls.SignatureFromBytes(keyBytes[68:164])
+	if err != nil {
+		utils.Error("Failed to decode bls POP: %v\n", err)
+	}
+	holderSig, err := crypto.SignatureFromBytes(keyBytes[164:])
+	if err != nil {
+		utils.Error("Failed to decode signature: %v\n", err)
+	}
+
+	depositStakeTx.BlsPubkey = blsPubkey
+	depositStakeTx.BlsPop = blsPop
+	depositStakeTx.HolderSig = holderSig
+	return common.BytesToAddress(keyBytes[:20])
+}
+
 func init() {
 	depositStakeCmd.Flags().StringVar(&chainIDFlag, "chain", "", "Chain ID")
 	depositStakeCmd.Flags().StringVar(&sourceFlag, "source", "", "Source of the stake")
-	depositStakeCmd.Flags().StringVar(&holderFlag, "holder", "", "Holder of the stake")
+	depositStakeCmd.Flags().StringVar(&holderFlag, "holder", "", "Holder of the stake, or the summary of a zyta to also register its BLS key")
 	depositStakeCmd.Flags().StringVar(&pathFlag, "path", "", "Wallet derivation path")
 	depositStakeCmd.Flags().StringVar(&feeFlag, "fee", fmt.Sprintf("%dwei", types.MinimumTransactionFeePTXWeiDec2022), "Fee")
 	depositStakeCmd.Flags().Uint64Var(&seqFlag, "seq", 0, "Sequence number of the transaction")
@@ -0,0 +1,196 @@
+package tx
+
+import (
+	"encoding/hex"
+	"fmt"
+	"math/big"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+	rpcc "github.com/ybbus/jsonrpc"
+
+	"github.com/pandoprojects/pando/cmd/pandocli/cmd/utils"
+	"github.com/pandoprojects/pando/common"
+	"github.com/pandoprojects/pando/core"
+	"github.com/pandoprojects/pando/ledger/types"
+	"github.com/pandoprojects/pando/rpc"
+)
+
+// Flags of the governance sub commands.
+var (
+	minGasPriceFlag         string
+	maxGasLimitFlag         uint64
+	minFeeFlag              string
+	minValidatorStakeFlag   string
+	minGuardianStakeFlag    string
+	guardianRoundLengthFlag uint64
+	activationHeightFlag    uint64
+	proposalFlag            string
+)
+
+// proposeCmd represents the governance proposal command
+// Example:
+//
+//	pandocli tx propose --chain="privatenet" --from=2E833968E5bB786Ae419c4d13189fB081Cc43bab --min_gas_price=20000000000wei --activation_height=120000 --seq=8
+var proposeCmd = &cobra.Command{
+	Use:     "propose",
+	Short:   "Propose a change of the governance parameters, can only be submitted by a validator",
+	Long:    `Propose a change of the governance parameters from the activation height. Only the specified parameters are changed. The proposal is approved once the validators holding more than 2/3 of the stake voted for it.`,
+	Example: `pandocli tx propose --chain="privatenet" --from=2E833968E5bB786Ae419c4d13189fB081Cc43bab --min_gas_price=20000000000wei --activation_height=120000 --seq=8`,
+	Run:     doProposeCmd,
+}
+
+func doProposeCmd(cmd *cobra.Command, args []string) {
+	wallet, proposerAddress, err := walletUnlockWithPath(cmd, fromFlag, pathFlag, passwordFlag)
+	if err != nil {
+		return
+	}
+	defer wallet.Lock(proposerAddress)
+
+	fee, ok := types.ParseCoinAmount(feeFlag)
+	if !ok {
+		utils.Error("Failed to parse fee")
+	}
+
+	changes := core.GovernanceParams{
+		MinimumGasPrice:             parseOptionalAmount("min_gas_price", minGasPriceFlag),
+		MaximumTxGasLimit:           new(big.Int).SetUint64(maxGasLimitFlag),
+		MinimumTransactionFeePTXWei: parseOptionalAmount("min_fee", minFeeFlag),
+		MinValidatorStakeDeposit:    parseOptionalAmount("min_validator_stake", minValidatorStakeFlag),
+		MinGuardianStakeDeposit:     parseOptionalAmount("min_guardian_stake", minGuardianStakeFlag),
+		GuardianRoundLength:         guardianRoundLengthFlag,
+	}
+	if err := changes.ValidateChanges(); err != nil {
+		utils.Error("Invalid proposal: %v\n", err)
+	}
+
+	proposalTx := &types.GovernanceProposalTx{
+		Fee: types.Coins{
+			PandoWei: new(big.Int).SetUint64(0),
+			PTXWei:   fee,
+		},
+		Proposer: types.TxInput{
+			Address:  proposerAddress,
+			Sequence: uint64(seqFlag),
+		},
+		Changes:          changes,
+		ActivationHeight: activationHeightFlag,
+	}
+
+	sig, err := wallet.Sign(proposerAddress, proposalTx.SignBytes(chainIDFlag))
+	if err != nil {
+		utils.Error("Failed to sign transaction: %v\n", err)
+	}
+	proposalTx.SetSignature(proposerAddress, sig)
+
+	broadcastTx(proposalTx)
+	fmt.Printf("Proposal ID: %v\n", types.TxID(chainIDFlag, proposalTx).Hex())
+}
+
+// voteCmd represents the governance vote command
+// Example:
+//
+//	pandocli tx vote --chain="privatenet" --from=2E833968E5bB786Ae419c4d13189fB081Cc43bab --proposal=0x2a0f6a1b... --seq=9
+var voteCmd = &cobra.Command{
+	Use:     "vote",
+	Short:   "Vote for a governance proposal, can only be submitted by a validator",
+	Example: `pandocli tx vote --chain="privatenet" --from=2E833968E5bB786Ae419c4d13189fB081Cc43bab --proposal=0x2a0f6a1b... --seq=9`,
+	Run:     doVoteCmd,
+}
+
+func doVoteCmd(cmd *cobra.Command, args []string) {
+	wallet, voterAddress, err := walletUnlockWithPath(cmd, fromFlag, pathFlag, passwordFlag)
+	if err != nil {
+		return
+	}
+	defer wallet.Lock(voterAddress)
+
+	fee, ok := types.ParseCoinAmount(feeFlag)
+	if !ok {
+		utils.Error("Failed to parse fee")
+	}
+
+	voteTx := &types.GovernanceVoteTx{
+		Fee: types.Coins{
+			PandoWei: new(big.Int).SetUint64(0),
+			PTXWei:   fee,
+		},
+		Voter: types.TxInput{
+			Address:  voterAddress,
+			Sequence: uint64(seqFlag),
+		},
+		ProposalID: common.HexToHash(proposalFlag),
+	}
+
+	sig, err := wallet.Sign(voterAddress, voteTx.SignBytes(chainIDFlag))
+	if err != nil {
+		utils.Error("Failed to sign transaction: %v\n", err)
+	}
+	voteTx.SetSignature(voterAddress, sig)
+
+	broadcastTx(voteTx)
+}
+
+func parseOptionalAmount(name string, value string) *big.Int {
+	if value == "" {
+		return nil
+	}
+	amount, ok := types.ParseCoinAmount(value)
+	if !ok {
+		utils.Error("Failed to parse %v", name)
+	}
+	return amount
+}
+
+func broadcastTx(tx types.Tx) {
+	raw, err := types.TxToBytes(tx)
+	if err != nil {
+		utils.Error("Failed to encode transaction: %v\n", err)
+	}
+	signedTx := hex.EncodeToString(raw)
+
+	client := rpcc.NewRPCClient(viper.GetString(utils.CfgRemoteRPCEndpoint))
+
+	var res *rpcc.RPCResponse
+	if asyncFlag {
+		res, err = client.Call("pando.BroadcastRawTransactionAsync", rpc.BroadcastRawTransactionArgs{TxBytes: signedTx})
+	} else {
+		res, err = client.Call("pando.BroadcastRawTransaction", rpc.BroadcastRawTransactionArgs{TxBytes: signedTx})
+	}
+	if err != nil {
+		utils.Error("Failed to broadcast transaction: %v\n", err)
+	}
+	if res.Error != nil {
+		utils.Error("Server returned error: %v\n", res.Error)
+	}
+	fmt.Printf("Successfully broadcasted transaction.\n")
+}
+
+func init() {
+	for _, cmd := range []*cobra.Command{proposeCmd, voteCmd} {
+		cmd.Flags().StringVar(&chainIDFlag, "chain", "", "Chain ID")
+		cmd.Flags().StringVar(&fromFlag, "from", "", "Address of the validator")
+		cmd.Flags().StringVar(&pathFlag, "path", "", "Wallet derivation path")
+		cmd.Flags().StringVar(&feeFlag, "fee", fmt.Sprintf("%dwei", types.MinimumTransactionFeePTXWeiDec2022), "Fee")
+		cmd.Flags().Uint64Var(&seqFlag, "seq", 0, "Sequence number of the transaction")
+		cmd.Flags().StringVar(&walletFlag, "wallet", "soft", "Wallet type (soft|nano)")
+		cmd.Flags().BoolVar(&asyncFlag, "async", false, "block until tx has been included in the blockchain")
+		cmd.Flags().StringVar(&passwordFlag, "password", "", "password to unlock the wallet")
+
+		cmd.MarkFlagRequired("chain")
+		cmd.MarkFlagRequired("from")
+		cmd.MarkFlagRequired("seq")
+	}
+
+	proposeCmd.Flags().StringVar(&minGasPriceFlag, "min_gas_price", "", "Minimum gas price of the smart contract transactions, unchanged if empty")
+	proposeCmd.Flags().Uint64Var(&maxGasLimitFlag, "max_gas_limit", 0, "Maximum gas limit of the smart contract transactions, unchanged if zero")
+	proposeCmd.Flags().StringVar(&minFeeFlag, "min_fee", "", "Minimum fee of the regular transactions, unchanged if empty")
+	proposeCmd.Flags().StringVar(&minValidatorStakeFlag, "min_validator_stake", "", "Minimum stake of a validator deposit, unchanged if empty")
+	proposeCmd.Flags().StringVar(&minGuardianStakeFlag, "min_guardian_stake", "", "Minimum stake of a guardian deposit, unchanged if empty")
+	proposeCmd.Flags().Uint64Var(&guardianRoundLengthFlag, "guardian_round_length", 0, "Length of the guardian voting rounds in seconds, unchanged if zero")
+	proposeCmd.Flags().Uint64Var(&activationHeightFlag, "activation_height", 0, "Block height from which the changes take effect")
+	proposeCmd.MarkFlagRequired("activation_height")
+
+	voteCmd.Flags().StringVar(&proposalFlag, "proposal", "", "ID of the governance proposal")
+	voteCmd.MarkFlagRequired("proposal")
+}
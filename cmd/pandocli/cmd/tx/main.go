@@ -54,4 +54,8 @@ func init() {
 	TxCmd.AddCommand(depositStakeCmd)
 	TxCmd.AddCommand(withdrawStakeCmd)
 	TxCmd.AddCommand(stakeRewardDistributionCmd)
+	TxCmd.AddCommand(proposeCmd)
+	TxCmd.AddCommand(voteCmd)
+	TxCmd.AddCommand(setCommissionCmd)
+	TxCmd.AddCommand(claimRewardCmd)
 }
@@ -0,0 +1,226 @@
+package clock
+
+import (
+	"sort"
+	"sync"
+	"time"
+)
+
+// Clock provides the current time and timers. The components driven by timers take a Clock so
+// that a simulation can run them on virtual time.
+type Clock interface {
+	// Now returns the current time.
+	Now() time.Time
+
+	// NewTimer creates a timer which fires once after the given duration.
+	NewTimer(d time.Duration) Timer
+
+	// NewTicker creates a ticker which fires every period.
+	NewTicker(period time.Duration) Ticker
+}
+
+// Timer is a single event timer, see time.Timer.
+type Timer interface {
+	C() <-chan time.Time
+	Stop() bool
+}
+
+// Ticker delivers ticks at intervals, see time.Ticker.
+type Ticker interface {
+	C() <-chan time.Time
+	Stop()
+}
+
+// ---- Real clock ----
+
+// NewRealClock returns a Clock backed by the system time.
+func NewRealClock() Clock {
+	return realClock{}
+}
+
+type realClock struct{}
+
+func (realClock) Now() time.Time {
+	return time.Now()
+}
+
+func (realClock) NewTimer(d time.Duration) Timer {
+	return realTimer{time.NewTimer(d)}
+}
+
+func (realClock) NewTicker(period time.Duration) Ticker {
+	return realTicker{time.NewTicker(period)}
+}
+
+type realTimer struct {
+	*time.Timer
+}
+
+func (t realTimer) C() <-chan time.Time {
+	return t.Timer.C
+}
+
+type realTicker struct {
+	*time.Ticker
+}
+
+func (t realTicker) C() <-chan time.Time {
+	return t.Ticker.C
+}
+
+// ---- Virtual clock ----
+
+// VirtualClock is a Clock whose time only moves when advanced by its owner. The timers fire
+// synchronously while the clock is advanced, in the order of their deadlines.
+type VirtualClock struct {
+	mu     sync.Mutex
+	now    time.Time
+	seq    uint64
+	timers []*virtualTimer
+}
+
+var _ Clock = (*VirtualClock)(nil)
+
+// NewVirtualClock creates a virtual clock starting at the given time.
+func NewVirtualClock(start time.Time) *VirtualClock {
+	return &VirtualClock{now: start}
+}
+
+// Now implements the Clock interface.
+func (c *VirtualClock) Now() time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return c.now
+}
+
+// NewTimer implements the Clock interface.
+func (c *VirtualClock) NewTimer(d time.Duration) Timer {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return c.addTimer(d, 0)
+}
+
+// NewTicker implements the Clock interface.
+func (c *VirtualClock) NewTicker(period time.Duration) Ticker {
+	if period <= 0 {
+		panic("non-positive interval for VirtualClock.NewTicker")
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return virtualTicker{c.addTimer(period, period)}
+}
+
+// NextDeadline returns the earliest deadline of the active timers.
+func (c *VirtualClock) NextDeadline() (time.Time, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if len(c.timers) == 0 {
+		return time.Time{}, false
+	}
+	return c.timers[0].deadline, true
+}
+
+// AdvanceTo moves the clock to the given time, and fires the timers whose deadline has passed.
+// The clock never moves backwards.
+func (c *VirtualClock) AdvanceTo(t time.Time) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	for len(c.timers) > 0 && !c.timers[0].deadline.After(t) {
+		timer := c.timers[0]
+		c.timers = c.timers[1:]
+		if timer.deadline.After(c.now) {
+			c.now = timer.deadline
+		}
+		select {
+		case timer.ch <- c.now:
+		default:
+			// Like time.Ticker, drop the tick if the previous one has not been received.
+		}
+		if timer.period > 0 {
+			timer.deadline = timer.deadline.Add(timer.period)
+			c.insert(timer)
+		} else {
+			timer.active = false
+		}
+	}
+	if t.After(c.now) {
+		c.now = t
+	}
+}
+
+// Advance moves the clock forward by the given duration, see AdvanceTo.
+func (c *VirtualClock) Advance(d time.Duration) {
+	c.AdvanceTo(c.Now().Add(d))
+}
+
+func (c *VirtualClock) addTimer(d time.Duration, period time.Duration) *virtualTimer {
+	c.seq++
+	timer := &virtualTimer{
+		clock:    c,
+		ch:       make(chan time.Time, 1),
+		deadline: c.now.Add(d),
+		period:   period,
+		seq:      c.seq,
+		active:   true,
+	}
+	c.insert(timer)
+	return timer
+}
+
+// insert keeps the timers sorted by deadline, and by creation order for equal deadlines.
+func (c *VirtualClock) insert(timer *virtualTimer) {
+	i := sort.Search(len(c.timers), func(i int) bool {
+		t := c.timers[i]
+		return t.deadline.After(timer.deadline) || (t.deadline.Equal(timer.deadline) && t.seq > timer.seq)
+	})
+	c.timers = append(c.timers, nil)
+	copy(c.timers[i+1:], c.timers[i:])
+	c.timers[i] = timer
+}
+
+func (c *VirtualClock) remove(timer *virtualTimer) bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if !timer.active {
+		return false
+	}
+	timer.active = false
+	for i, t := range c.timers {
+		if t == timer {
+			c.timers = append(c.timers[:i], c.timers[i+1:]...)
+			break
+		}
+	}
+	return true
+}
+
+type virtualTimer struct {
+	clock    *VirtualClock
+	ch       chan time.Time
+	deadline time.Time
+	period   time.Duration
+	seq      uint64
+	active   bool
+}
+
+func (t *virtualTimer) C() <-chan time.Time {
+	return t.ch
+}
+
+func (t *virtualTimer) Stop() bool {
+	return t.clock.remove(t)
+}
+
+type virtualTicker struct {
+	*virtualTimer
+}
+
+func (t virtualTicker) Stop() {
+	t.virtualTimer.Stop()
+}
@@ -0,0 +1,56 @@
+package clock
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func fired(ch <-chan time.Time) bool {
+	select {
+	case <-ch:
+		return true
+	default:
+		return false
+	}
+}
+
+func TestVirtualClock(t *testing.T) {
+	assert := assert.New(t)
+
+	start := time.Unix(1000, 0)
+	c := NewVirtualClock(start)
+	timer := c.NewTimer(2 * time.Second)
+	ticker := c.NewTicker(time.Second)
+	stopped := c.NewTimer(time.Second)
+	assert.True(stopped.Stop())
+	assert.False(stopped.Stop())
+
+	deadline, ok := c.NextDeadline()
+	assert.True(ok)
+	assert.Equal(start.Add(time.Second), deadline)
+
+	c.Advance(500 * time.Millisecond)
+	assert.False(fired(timer.C()))
+	assert.False(fired(ticker.C()))
+
+	c.Advance(500 * time.Millisecond)
+	assert.Equal(start.Add(time.Second), c.Now())
+	assert.False(fired(timer.C()))
+	assert.True(fired(ticker.C()))
+	assert.False(fired(stopped.C()))
+
+	c.Advance(time.Second)
+	assert.True(fired(timer.C()))
+	assert.True(fired(ticker.C()))
+	assert.False(timer.Stop())
+
+	// The ticker keeps firing until it is stopped.
+	ticker.Stop()
+	c.Advance(5 * time.Second)
+	assert.False(fired(ticker.C()))
+	_, ok = c.NextDeadline()
+	assert.False(ok)
+	assert.Equal(start.Add(7*time.Second), c.Now())
+}
@@ -34,6 +34,12 @@ const (
 	CfgConsensusRametronenterpriseVoteQueueSize = "consensus.rametronenterpriseVoteQueueSize"
 	// CfgConsensusPassThroughGuardianVote defines the how guardian vote is handled.
 	CfgConsensusPassThroughGuardianVote = "consensus.passThroughGuardianVote"
+	// CfgConsensusLivenessThreshold defines the minimal uptime of a validator over the liveness window,
+	// below which a local, advisory jail intent is recorded at the next checkpoint. The intents are not
+	// recorded on-chain nor enforced. Zero disables the check.
+	CfgConsensusLivenessThreshold = "consensus.livenessThreshold"
+	// CfgConsensusWALPath defines the path of the consensus write-ahead log, <data.path>/db/consensus.wal by default.
+	CfgConsensusWALPath = "consensus.walPath"
 
 	// CfgStorageRollingEnabled indicates whether rolling is enabled
 	CfgStorageRollingEnabled = "storage.stateRollingEnabled"
@@ -51,6 +57,9 @@ const (
 	CfgStorageLevelDBHandles = "storage.levelDBHandles"
 	// CfgStorageRollingInterval is the block interval that we start new db layer
 	CfgStorageRollingInterval = "storage.rollingInterval"
+	// CfgStorageIndexAccountTxs indicates whether to index the finalized transactions by the accounts they touch.
+	// When enabled on a node that has already synced, the index of the older blocks is rebuilt in the background.
+	CfgStorageIndexAccountTxs = "storage.indexAccountTxs"
 
 	// CfgSyncMessageQueueSize defines the capacity of Sync Manager message queue.
 	CfgSyncMessageQueueSize = "sync.messageQueueSize"
@@ -108,6 +117,36 @@ const (
 	// CfgP2PMaxConnections specifies the number of max connections a node can accept
 	CfgP2PMaxConnections = "p2p.maxConnections"
 
+	// CfgMempoolMaxTxCount sets the maximal number of transactions in the mempool, zero means unlimited.
+	CfgMempoolMaxTxCount = "mempool.maxTxCount"
+	// CfgMempoolMaxTxBytes sets the maximal total size of the transactions in the mempool, zero means unlimited.
+	CfgMempoolMaxTxBytes = "mempool.maxTxBytes"
+	// CfgMempoolPriceBumpPercent sets the minimal gas price increase, in percent, for a transaction to
+	// replace a pending transaction with the same sequence.
+	CfgMempoolPriceBumpPercent = "mempool.priceBumpPercent"
+	// CfgMempoolMaxQueuedTxCount sets the maximal number of transactions waiting for the sequence gaps before them to be filled.
+	CfgMempoolMaxQueuedTxCount = "mempool.maxQueuedTxCount"
+	// CfgMempoolMaxQueuedTxsPerAccount sets the maximal number of queued transactions of an account.
+	CfgMempoolMaxQueuedTxsPerAccount = "mempool.maxQueuedTxsPerAccount"
+	// CfgMempoolQueuedTxLifetime sets how long (in seconds) a transaction can wait for the sequence gap before it to be filled.
+	CfgMempoolQueuedTxLifetime = "mempool.queuedTxLifetime"
+	// CfgMempoolJournalPath sets the path of the journal of the pending transactions, <data.path>/db/mempool.journal by default.
+	CfgMempoolJournalPath = "mempool.journalPath"
+	// CfgMempoolJournalRotateInterval sets the interval (in seconds) to rewrite the journal with the pending transactions.
+	CfgMempoolJournalRotateInterval = "mempool.journalRotateInterval"
+	// CfgMempoolMinGasPrice sets the minimal effective gas price (in wei) of the transactions accepted by the node, empty means the protocol minimum.
+	CfgMempoolMinGasPrice = "mempool.minGasPrice"
+	// CfgMempoolMaxPendingTxsPerSender sets the maximal number of pending transactions of a sender, zero means unlimited.
+	CfgMempoolMaxPendingTxsPerSender = "mempool.maxPendingTxsPerSender"
+	// CfgMempoolPeerTxRatePerSecond sets the rate of the transactions accepted from each peer, zero means unlimited.
+	CfgMempoolPeerTxRatePerSecond = "mempool.peerTxRatePerSecond"
+	// CfgMempoolPeerTxBurst sets the number of transactions a peer can relay in a burst.
+	CfgMempoolPeerTxBurst = "mempool.peerTxBurst"
+	// CfgMempoolContractAllowList sets the comma separated contracts the transactions can call, empty means any contract.
+	CfgMempoolContractAllowList = "mempool.contractAllowList"
+	// CfgMempoolContractDenyList sets the comma separated contracts the transactions cannot call.
+	CfgMempoolContractDenyList = "mempool.contractDenyList"
+
 	// CfgSyncInboundResponseWhitelist filters inbound messages based on peer ID.
 	CfgSyncInboundResponseWhitelist = "sync.inboundResponseWhitelist"
 
@@ -121,6 +160,62 @@ const (
 	CfgRPCMaxConnections = "rpc.maxConnections"
 	// CfgRPCTimeoutSecs set a timeout for RPC.
 	CfgRPCTimeoutSecs = "rpc.timeoutSecs"
+	// CfgRPCEthEnabled sets whether to serve the Ethereum compatible eth_*, net_* and web3_* methods.
+	CfgRPCEthEnabled = "rpc.ethEnabled"
+	// CfgRPCDebugEnabled sets whether to serve the debug_* tracing methods.
+	CfgRPCDebugEnabled = "rpc.debugEnabled"
+	// CfgRPCWsMaxSubscriptions limits the number of subscriptions a websocket connection can hold.
+	CfgRPCWsMaxSubscriptions = "rpc.wsMaxSubscriptions"
+	// CfgRPCWsSubscriptionQueueSize sets the capacity of the per connection notification queue.
+	// Connections that fall behind by more than this many notifications are closed.
+	CfgRPCWsSubscriptionQueueSize = "rpc.wsSubscriptionQueueSize"
+	// CfgRPCGrpcEnabled sets whether to serve the gRPC API alongside JSON-RPC.
+	CfgRPCGrpcEnabled = "rpc.grpcEnabled"
+	// CfgRPCGrpcPort sets the port of the gRPC API.
+	CfgRPCGrpcPort = "rpc.grpcPort"
+	// CfgRPCMaxBatchSize limits the number of calls in a JSON-RPC batch request, zero means unlimited.
+	CfgRPCMaxBatchSize = "rpc.maxBatchSize"
+	// CfgRPCRateLimitEnabled sets whether to rate limit the RPC calls of each client IP.
+	CfgRPCRateLimitEnabled = "rpc.rateLimitEnabled"
+	// CfgRPCRateLimitPerSecond sets the rate at which the call budget of a client IP refills.
+	CfgRPCRateLimitPerSecond = "rpc.rateLimitPerSecond"
+	// CfgRPCRateLimitBurst sets the maximum call budget of a client IP.
+	CfgRPCRateLimitBurst = "rpc.rateLimitBurst"
+	// CfgRPCRateLimitDefaultCost sets the cost of the methods not listed in CfgRPCRateLimitMethodCosts.
+	CfgRPCRateLimitDefaultCost = "rpc.rateLimitDefaultCost"
+	// CfgRPCRateLimitMethodCosts sets the cost of the methods, e.g. "pando.GetStatus:1,pando.GetBlocksByRange:20".
+	CfgRPCRateLimitMethodCosts = "rpc.rateLimitMethodCosts"
+
+	// CfgSignerRemoteAddress sets the address of the remote signer holding the validator keys.
+	// The keys are loaded from the local keystore if empty.
+	CfgSignerRemoteAddress = "signer.remoteAddress"
+	// CfgSignerListenAddress sets the address the signer listens on when run with "pando signer".
+	CfgSignerListenAddress = "signer.listenAddress"
+	// CfgSignerTLSCert sets the path of the TLS certificate identifying this end of the signer connection.
+	CfgSignerTLSCert = "signer.tlsCert"
+	// CfgSignerTLSKey sets the path of the private key of the TLS certificate.
+	CfgSignerTLSKey = "signer.tlsKey"
+	// CfgSignerTLSCACert sets the path of the CA certificate the other end of the signer connection must be signed by.
+	CfgSignerTLSCACert = "signer.tlsCACert"
+	// CfgSignerTimeoutSecs sets the timeout of a signing request to the remote signer.
+	CfgSignerTimeoutSecs = "signer.timeoutSecs"
+	// CfgSignerStatePath sets the path of the file where the signer persists its high-water mark.
+	CfgSignerStatePath = "signer.statePath"
+
+	// CfgLightEnabled sets whether "pando start" runs a light client, which follows the headers
+	// instead of downloading and executing the blocks.
+	CfgLightEnabled = "light.enabled"
+	// CfgLightRPCEndpoint sets the RPC endpoint the light client follows the chain from. The light
+	// client queries its p2p peers if empty.
+	CfgLightRPCEndpoint = "light.rpcEndpoint"
+	// CfgLightTrustedHeight sets the height of the block the light client starts from.
+	CfgLightTrustedHeight = "light.trustedHeight"
+	// CfgLightTrustedHash sets the hash of the block the light client starts from, the genesis block if empty.
+	CfgLightTrustedHash = "light.trustedHash"
+	// CfgLightPollIntervalSecs sets how often the light client checks for new finalized blocks.
+	CfgLightPollIntervalSecs = "light.pollIntervalSecs"
+	// CfgLightRequestTimeoutSecs sets the timeout of a request of the light client to a p2p peer.
+	CfgLightRequestTimeoutSecs = "light.requestTimeoutSecs"
 
 	// CfgLogLevels sets the log level.
 	CfgLogLevels = "log.levels"
@@ -165,6 +260,7 @@ func init() {
 	viper.SetDefault(CfgConsensusMessageQueueSize, 512)
 	viper.SetDefault(CfgConsensusRametronenterpriseVoteQueueSize, 100000)
 	viper.SetDefault(CfgConsensusPassThroughGuardianVote, false)
+	viper.SetDefault(CfgConsensusLivenessThreshold, 0.0)
 
 	viper.SetDefault(CfgSyncMessageQueueSize, 512)
 	viper.SetDefault(CfgSyncDownloadByHash, false)
@@ -178,6 +274,21 @@ func init() {
 	viper.SetDefault(CfgStorageLevelDBCacheSize, 256)
 	viper.SetDefault(CfgStorageLevelDBHandles, 16)
 	viper.SetDefault(CfgStorageRollingInterval, 14400) // approximately 1 days by default
+	viper.SetDefault(CfgStorageIndexAccountTxs, false)
+
+	viper.SetDefault(CfgMempoolMaxTxCount, 25600)
+	viper.SetDefault(CfgMempoolMaxTxBytes, 32*1024*1024)
+	viper.SetDefault(CfgMempoolPriceBumpPercent, 10)
+	viper.SetDefault(CfgMempoolMaxQueuedTxCount, 4096)
+	viper.SetDefault(CfgMempoolMaxQueuedTxsPerAccount, 64)
+	viper.SetDefault(CfgMempoolQueuedTxLifetime, 300)
+	viper.SetDefault(CfgMempoolJournalRotateInterval, 3600)
+	viper.SetDefault(CfgMempoolMinGasPrice, "")
+	viper.SetDefault(CfgMempoolMaxPendingTxsPerSender, 0)
+	viper.SetDefault(CfgMempoolPeerTxRatePerSecond, 0)
+	viper.SetDefault(CfgMempoolPeerTxBurst, 200)
+	viper.SetDefault(CfgMempoolContractAllowList, "")
+	viper.SetDefault(CfgMempoolContractDenyList, "")
 
 	viper.SetDefault(CfgRPCEnabled, false)
 	viper.SetDefault(CfgP2PMessageQueueSize, 512)
@@ -205,6 +316,31 @@ func init() {
 	viper.SetDefault(CfgRPCPort, "16888")
 	viper.SetDefault(CfgRPCMaxConnections, 200)
 	viper.SetDefault(CfgRPCTimeoutSecs, 60)
+	viper.SetDefault(CfgRPCEthEnabled, false)
+	viper.SetDefault(CfgRPCDebugEnabled, false)
+	viper.SetDefault(CfgRPCWsMaxSubscriptions, 32)
+	viper.SetDefault(CfgRPCWsSubscriptionQueueSize, 256)
+	viper.SetDefault(CfgRPCGrpcEnabled, false)
+	viper.SetDefault(CfgRPCGrpcPort, "16890")
+	viper.SetDefault(CfgRPCMaxBatchSize, 100)
+	viper.SetDefault(CfgRPCRateLimitEnabled, false)
+	viper.SetDefault(CfgRPCRateLimitPerSecond, 100)
+	viper.SetDefault(CfgRPCRateLimitBurst, 200)
+	viper.SetDefault(CfgRPCRateLimitDefaultCost, 2)
+	viper.SetDefault(CfgRPCRateLimitMethodCosts, "pando.GetStatus:1,pando.GetVersion:1,pando.GetAccount:1,pando.GetTransaction:1,"+
+		"pando.GetBlocksByRange:20,pando.CallSmartContract:10,pando.EstimateGas:20,pando.GetLogs:20,eth_call:10,eth_estimateGas:20,"+
+		"eth_getLogs:20,debug_traceTransaction:50,debug_traceBlock:100")
+
+	viper.SetDefault(CfgSignerRemoteAddress, "")
+	viper.SetDefault(CfgSignerListenAddress, "127.0.0.1:16891")
+	viper.SetDefault(CfgSignerTimeoutSecs, 5)
+
+	viper.SetDefault(CfgLightEnabled, false)
+	viper.SetDefault(CfgLightRPCEndpoint, "")
+	viper.SetDefault(CfgLightTrustedHeight, 0)
+	viper.SetDefault(CfgLightTrustedHash, "")
+	viper.SetDefault(CfgLightPollIntervalSecs, 5)
+	viper.SetDefault(CfgLightRequestTimeoutSecs, 10)
 
 	viper.SetDefault(CfgLogLevels, "*:debug")
 	viper.SetDefault(CfgLogPrintSelfID, false)
@@ -32,6 +32,20 @@ const HeightSupportpandoprojectsInSmartContract uint64 = 4417900
 // HeightZytaStakeChangedTo10000K specifies the block height to lower the validator stake to 200,000 Pando
 const HeightZytaStakeChangedTo10000K uint64 = 4417900
 
+// HeightEnableEquivocationSlashing specifies the block height to enable slashing the validators that double-sign
+const HeightEnableEquivocationSlashing uint64 = 20000000
+
+// HeightEnableBLSCommitCertificate specifies the minimal block height to enable the validator BLS votes, and the commit
+// certificates with aggregated validator signatures
+const HeightEnableBLSCommitCertificate uint64 = 20000000
+
+// HeightEnableGovernance specifies the minimal block height to enable the on-chain governance of the protocol parameters
+const HeightEnableGovernance uint64 = 20000000
+
+// HeightEnableDelegatedStaking specifies the minimal block height to enable the validator commissions, and the lazy
+// accrual of the validator staking rewards claimed by the delegators
+const HeightEnableDelegatedStaking uint64 = 20000000
+
 
 // CheckpointInterval defines the interval between checkpoints.
 const CheckpointInterval = int64(100)
@@ -409,6 +409,16 @@ const (
 
 	// ChannelIDAggregatedRametronenterpriseVotes indicates the channel for rametronenterprise aggregated vote messages
 	ChannelIDAggregatedRametronenterpriseVotes
+
+	// ChannelIDEquivocationEvidence indicates the channel for the evidences of validator double-signing
+	ChannelIDEquivocationEvidence
+
+	// ChannelIDLightClient indicates the channel for the requests of light clients and their responses
+	ChannelIDLightClient
+
+	// ChannelIDTransactionAnnouncement indicates the channel for the transaction hash announcements and
+	// the requests for the announced transactions
+	ChannelIDTransactionAnnouncement
 )
 
 // P2POptEnum defines the p2p network
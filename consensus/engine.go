@@ -5,22 +5,21 @@ import (
 	"fmt"
 	"math/big"
 	"sort"
-	"strings"
 	"sync"
 	"time"
 
-	"github.com/pandoprojects/pando/crypto/bls"
-
 	log "github.com/sirupsen/logrus"
 	"github.com/spf13/viper"
 	"github.com/pandoprojects/pando/blockchain"
 	"github.com/pandoprojects/pando/common"
+	"github.com/pandoprojects/pando/common/clock"
 	"github.com/pandoprojects/pando/common/result"
 	"github.com/pandoprojects/pando/common/util"
 	"github.com/pandoprojects/pando/core"
 	"github.com/pandoprojects/pando/crypto"
 	"github.com/pandoprojects/pando/dispatcher"
 	"github.com/pandoprojects/pando/rlp"
+	"github.com/pandoprojects/pando/signer"
 	"github.com/pandoprojects/pando/store"
 )
 
@@ -32,7 +31,7 @@ var _ core.ConsensusEngine = (*ConsensusEngine)(nil)
 type ConsensusEngine struct {
 	logger *log.Entry
 
-	privateKey *crypto.PrivateKey
+	signer core.Signer
 
 	chain            *blockchain.Chain
 	dispatcher       *dispatcher.Dispatcher
@@ -43,6 +42,7 @@ type ConsensusEngine struct {
 
 	incoming        chan interface{}
 	finalizedBlocks chan *core.Block
+	validatedBlocks chan *core.Block
 	hasSynced       bool
 
 	// Life cycle
@@ -51,30 +51,39 @@ type ConsensusEngine struct {
 	cancel  context.CancelFunc
 	stopped bool
 
+	clock         clock.Clock
 	mu            *sync.Mutex
-	voteTimer     *time.Timer
-	epochTimer    *time.Timer
-	guardianTimer *time.Ticker
+	voteTimer     clock.Timer
+	epochTimer    clock.Timer
+	guardianTimer clock.Ticker
 
 	voteTimerReady bool
 	blockProcessed bool
 
 	state *State
+	wal   *WAL
 }
 
-// NewConsensusEngine creates a instance of ConsensusEngine.
+// NewConsensusEngine creates a instance of ConsensusEngine which signs with the given key.
 func NewConsensusEngine(privateKey *crypto.PrivateKey, db store.Store, chain *blockchain.Chain, dispatcher *dispatcher.Dispatcher, validatorManager core.ValidatorManager) *ConsensusEngine {
+	return NewConsensusEngineWithSigner(signer.NewLocalSigner(privateKey), db, chain, dispatcher, validatorManager)
+}
+
+// NewConsensusEngineWithSigner creates a instance of ConsensusEngine which signs with the given signer.
+func NewConsensusEngineWithSigner(signer core.Signer, db store.Store, chain *blockchain.Chain, dispatcher *dispatcher.Dispatcher, validatorManager core.ValidatorManager) *ConsensusEngine {
 	e := &ConsensusEngine{
 		chain:      chain,
 		dispatcher: dispatcher,
 
-		privateKey: privateKey,
+		signer: signer,
 
 		incoming:        make(chan interface{}, viper.GetInt(common.CfgConsensusMessageQueueSize)),
 		finalizedBlocks: make(chan *core.Block, viper.GetInt(common.CfgConsensusMessageQueueSize)),
+		validatedBlocks: make(chan *core.Block, viper.GetInt(common.CfgConsensusMessageQueueSize)),
 
 		wg: &sync.WaitGroup{},
 
+		clock: clock.NewRealClock(),
 		mu:    &sync.Mutex{},
 		state: NewState(db, chain),
 
@@ -87,18 +96,24 @@ func NewConsensusEngine(privateKey *crypto.PrivateKey, db store.Store, chain *bl
 	logger = util.GetLoggerForModule("consensus")
 	e.logger = logger
 
-	blsKey, err := bls.GenKey(strings.NewReader(common.Bytes2Hex(privateKey.PublicKey().ToBytes())))
-	if err != nil {
-		e.logger.Panic(err)
-	}
-	e.guardian = NewGuardianEngine(e, blsKey)
-	e.rametronenterprise = NewRametronenterpriseEngine(e, blsKey)
+	e.guardian = NewGuardianEngine(e, signer)
+	e.rametronenterprise = NewRametronenterpriseEngine(e, signer)
 
 	e.logger.WithFields(log.Fields{"state": e.state}).Info("Starting state")
 
 	return e
 }
 
+// SetClock sets the clock of the timers and block timestamps. It must be called before Start.
+func (e *ConsensusEngine) SetClock(clock clock.Clock) {
+	e.clock = clock
+}
+
+// SetWAL sets the write-ahead log of the consensus messages. It must be called before Start.
+func (e *ConsensusEngine) SetWAL(wal *WAL) {
+	e.wal = wal
+}
+
 func (e *ConsensusEngine) SetLedger(ledger core.Ledger) {
 	e.ledger = ledger
 }
@@ -110,12 +125,12 @@ func (e *ConsensusEngine) GetLedger() core.Ledger {
 
 // ID returns the identifier of current node.
 func (e *ConsensusEngine) ID() string {
-	return e.privateKey.PublicKey().Address().Hex()
+	return e.signer.PublicKey().Address().Hex()
 }
 
-// PrivateKey returns the private key
-func (e *ConsensusEngine) PrivateKey() *crypto.PrivateKey {
-	return e.privateKey
+// Signer returns the signer of the validator
+func (e *ConsensusEngine) Signer() core.Signer {
+	return e.signer
 }
 
 // Chain return a pointer to the underlying chain store.
@@ -135,6 +150,85 @@ func (e *ConsensusEngine) GetValidatorManager() core.ValidatorManager {
 
 // Start starts sub components and kick off the main loop.
 func (e *ConsensusEngine) Start(ctx context.Context) {
+	e.init(ctx)
+
+	e.guardian.Start(e.ctx)
+	e.rametronenterprise.Start(e.ctx)
+
+	e.wg.Add(1)
+	go e.mainLoop()
+}
+
+// StartStepping prepares the engine like Start, but starts no goroutine: the caller drives the
+// engine with Step. This lets a simulator run several engines deterministically.
+func (e *ConsensusEngine) StartStepping(ctx context.Context) {
+	e.init(ctx)
+
+	e.enterEpoch()
+	e.propose()
+}
+
+// Step runs one iteration of the main loop without blocking. The queued messages are handled
+// first, then the expired timers. It returns false if there was nothing to do.
+func (e *ConsensusEngine) Step() bool {
+	select {
+	case msg := <-e.incoming:
+		if e.processMessage(msg) {
+			e.enterEpoch()
+			e.propose()
+		}
+		return true
+	default:
+	}
+
+	select {
+	case <-e.voteTimer.C():
+		e.handleVoteTimeout()
+		return true
+	default:
+	}
+
+	select {
+	case <-e.epochTimer.C():
+		e.handleEpochTimeout()
+		e.enterEpoch()
+		e.propose()
+		return true
+	default:
+	}
+
+	select {
+	case <-e.guardianTimer.C():
+		e.handleGuardianTimeout()
+		return true
+	default:
+	}
+
+	select {
+	case vote := <-e.guardian.incoming:
+		e.guardian.processVote(vote)
+		return true
+	default:
+	}
+
+	select {
+	case vote := <-e.rametronenterprise.evIncoming:
+		e.rametronenterprise.processVote(vote)
+		return true
+	default:
+	}
+
+	select {
+	case votes := <-e.rametronenterprise.aevIncoming:
+		e.rametronenterprise.processAggregatedVote(votes)
+		return true
+	default:
+	}
+
+	return false
+}
+
+func (e *ConsensusEngine) init(ctx context.Context) {
 	c, cancel := context.WithCancel(ctx)
 	e.ctx = c
 	e.cancel = cancel
@@ -153,13 +247,10 @@ func (e *ConsensusEngine) Start(ctx context.Context) {
 	e.ledger.ResetState(lastCC.Block)
 
 	e.resetGuardianTimer()
-	e.guardian.Start(e.ctx)
-	e.rametronenterprise.Start(e.ctx)
 
 	e.checkSyncStatus()
 
-	e.wg.Add(1)
-	go e.mainLoop()
+	e.replayWAL()
 }
 
 func (e *ConsensusEngine) autoRewind(lastCC *core.ExtendedBlock) *core.ExtendedBlock {
@@ -273,34 +364,46 @@ func (e *ConsensusEngine) mainLoop() {
 				if endEpoch {
 					break Epoch
 				}
-			case <-e.voteTimer.C:
-				e.voteTimerReady = true
-				if e.blockProcessed {
-					e.vote()
-				}
-			case <-e.epochTimer.C:
-				e.logger.WithFields(log.Fields{"e.epoch": e.GetEpoch()}).Debug("Epoch timeout. Repeating epoch")
-				e.vote()
+			case <-e.voteTimer.C():
+				e.handleVoteTimeout()
+			case <-e.epochTimer.C():
+				e.handleEpochTimeout()
 				break Epoch
-			case <-e.guardianTimer.C:
-				v := e.guardian.GetVoteToBroadcast()
+			case <-e.guardianTimer.C():
+				e.handleGuardianTimeout()
+			}
+		}
+	}
+}
 
-				if v != nil {
-					e.guardian.logger.WithFields(log.Fields{"vote": v}).Debug("Broadcasting guardian vote")
-					e.broadcastGuardianVote(v)
-				}
-				e.guardian.StartNewRound()
+func (e *ConsensusEngine) handleVoteTimeout() {
+	e.voteTimerReady = true
+	if e.blockProcessed {
+		e.vote()
+	}
+}
 
-				rametronenterprisev := e.rametronenterprise.GetVoteToBroadcast()
+func (e *ConsensusEngine) handleEpochTimeout() {
+	e.logger.WithFields(log.Fields{"e.epoch": e.GetEpoch()}).Debug("Epoch timeout. Repeating epoch")
+	e.vote()
+}
 
-				if rametronenterprisev != nil {
-					e.rametronenterprise.logger.WithFields(log.Fields{"vote": rametronenterprisev}).Debug("Broadcasting aggregated rametronenterprise vote")
-					e.broadcastAggregatedRametronenterpriseVotes(rametronenterprisev)
-				}
-				e.rametronenterprise.StartNewRound()
-			}
-		}
+func (e *ConsensusEngine) handleGuardianTimeout() {
+	v := e.guardian.GetVoteToBroadcast()
+
+	if v != nil {
+		e.guardian.logger.WithFields(log.Fields{"vote": v}).Debug("Broadcasting guardian vote")
+		e.broadcastGuardianVote(v)
+	}
+	e.guardian.StartNewRound()
+
+	rametronenterprisev := e.rametronenterprise.GetVoteToBroadcast()
+
+	if rametronenterprisev != nil {
+		e.rametronenterprise.logger.WithFields(log.Fields{"vote": rametronenterprisev}).Debug("Broadcasting aggregated rametronenterprise vote")
+		e.broadcastAggregatedRametronenterpriseVotes(rametronenterprisev)
 	}
+	e.rametronenterprise.StartNewRound()
 }
 
 // enterEpoch is called when engine enters a new epoch.
@@ -311,12 +414,12 @@ func (e *ConsensusEngine) enterEpoch() {
 	if e.epochTimer != nil {
 		e.epochTimer.Stop()
 	}
-	e.epochTimer = time.NewTimer(time.Duration(viper.GetInt(common.CfgConsensusMaxEpochLength)) * time.Second)
+	e.epochTimer = e.clock.NewTimer(time.Duration(viper.GetInt(common.CfgConsensusMaxEpochLength)) * time.Second)
 
 	if e.voteTimer != nil {
 		e.voteTimer.Stop()
 	}
-	e.voteTimer = time.NewTimer(time.Duration(viper.GetInt(common.CfgConsensusMinBlockInterval)) * time.Second)
+	e.voteTimer = e.clock.NewTimer(time.Duration(viper.GetInt(common.CfgConsensusMinBlockInterval)) * time.Second)
 
 	e.voteTimerReady = false
 	e.blockProcessed = false
@@ -336,10 +439,23 @@ func (e *ConsensusEngine) AddMessage(msg interface{}) {
 	e.incoming <- msg
 }
 
+// enqueue adds a message produced by the engine itself to the incoming queue, without blocking
+// the main loop if the queue is full.
+func (e *ConsensusEngine) enqueue(msg interface{}) {
+	select {
+	case e.incoming <- msg:
+	default:
+		go e.AddMessage(msg)
+	}
+}
+
 func (e *ConsensusEngine) processMessage(msg interface{}) (endEpoch bool) {
 	switch m := msg.(type) {
 	case core.Vote:
 		e.logger.WithFields(log.Fields{"vote": m}).Debug("Received vote")
+		if err := e.wal.WriteVote(m); err != nil {
+			e.logger.WithFields(log.Fields{"error": err}).Error("Failed to write vote to WAL")
+		}
 		endEpoch = e.handleVote(m)
 		e.checkCC(m.Block)
 		return endEpoch
@@ -347,6 +463,9 @@ func (e *ConsensusEngine) processMessage(msg interface{}) (endEpoch bool) {
 		e.logger.WithFields(log.Fields{
 			"block": m.BlockHeader,
 		}).Debug("Received block")
+		if err := e.wal.WriteBlock(m); err != nil {
+			e.logger.WithFields(log.Fields{"error": err}).Error("Failed to write block to WAL")
+		}
 		e.handleBlock(m)
 	case *core.AggregatedVotes:
 		// e.logger.WithFields(log.Fields{"guardian vote": m}).Debug("Received guardian vote")
@@ -357,6 +476,9 @@ func (e *ConsensusEngine) processMessage(msg interface{}) (endEpoch bool) {
 	case *core.AggregatedRametronenterpriseVotes:
 		// e.logger.WithFields(log.Fields{"aggregated rametronenterprise vote": m}).Debug("Received agggregated rametronenterprise vote")
 		e.handleAggregatedRametronenterpriseVote(m)
+	case *core.EquivocationEvidence:
+		e.logger.WithFields(log.Fields{"evidence": m}).Debug("Received equivocation evidence")
+		e.handleEquivocationEvidence(m)
 	default:
 		// Should not happen.
 		log.Errorf("Unknown message type: %v", m)
@@ -381,7 +503,7 @@ func (e *ConsensusEngine) checkSyncStatus() error {
 	// current finalized height is at most maxVoteHeight-1
 	currentHeight := uint64(maxVoteHeight - 1)
 
-	e.hasSynced = !isSyncing(e.GetLastFinalizedBlock(), currentHeight)
+	e.hasSynced = !isSyncing(e.GetLastFinalizedBlock(), currentHeight, e.clock.Now())
 
 	return nil
 }
@@ -741,9 +863,15 @@ func (e *ConsensusEngine) handleNormalBlock(eb *core.ExtendedBlock) {
 	}
 	validateBlockTime := time.Since(start1)
 
+	e.detectProposalEquivocation(block)
+
 	for _, vote := range block.HCC.Votes.Votes() {
 		e.handleVote(vote)
 	}
+	if block.HCC.AggregatedVotes != nil {
+		// Aggregated votes cannot be added to the local votes, the certificate proves the commit instead.
+		e.checkCommitCertificate(block.HCC)
+	}
 	if localHCC := e.state.GetHighestCCBlock().Hash(); localHCC != block.HCC.BlockHash {
 		e.logger.WithFields(log.Fields{
 			"localHCC":            localHCC.Hex(),
@@ -790,6 +918,12 @@ func (e *ConsensusEngine) handleNormalBlock(eb *core.ExtendedBlock) {
 
 	e.chain.MarkBlockValid(block.Hash())
 
+	select {
+	case e.validatedBlocks <- block:
+	default:
+		e.logger.Debugf("Failed to notify validated block, height=%v", block.Height)
+	}
+
 	// Skip voting for block older than current best known epoch.
 	// Allow block with one epoch behind since votes are processed first and might advance epoch
 	// before block is processed.
@@ -820,7 +954,7 @@ func (e *ConsensusEngine) handleNormalBlock(eb *core.ExtendedBlock) {
 }
 
 func (e *ConsensusEngine) shouldVote(block common.Hash) bool {
-	return e.shouldVoteByID(e.privateKey.PublicKey().Address(), block)
+	return e.shouldVoteByID(e.signer.PublicKey().Address(), block)
 }
 
 func (e *ConsensusEngine) shouldVoteByID(id common.Address, block common.Hash) bool {
@@ -858,26 +992,32 @@ func (e *ConsensusEngine) vote() {
 		shouldRepeatVote = true
 	}
 
+	var err error
 	if shouldRepeatVote {
-		block, err := e.chain.FindBlock(lastVote.Block)
+		var block *core.ExtendedBlock
+		block, err = e.chain.FindBlock(lastVote.Block)
 		if err != nil {
 			// Should not happen
 			log.Panic(err)
 		}
 		// Recreating vote so that it has updated epoch and signature.
-		vote = e.createVote(block.Block)
+		vote, err = e.createVote(block.Block)
 	} else {
-		vote = e.createVote(tip.Block)
-		e.state.SetLastVote(vote)
+		vote, err = e.createVote(tip.Block)
+		if err == nil {
+			e.state.SetLastVote(vote)
+		}
+	}
+	if err != nil {
+		e.logger.WithFields(log.Fields{"error": err}).Error("Failed to sign vote")
+		return
 	}
 	e.logger.WithFields(log.Fields{
 		"vote": vote,
 	}).Debug("Sending vote")
 	e.broadcastVote(vote)
 
-	go func() {
-		e.AddMessage(vote)
-	}()
+	e.enqueue(vote)
 }
 
 func (e *ConsensusEngine) broadcastVote(vote core.Vote) {
@@ -893,15 +1033,33 @@ func (e *ConsensusEngine) broadcastVote(vote core.Vote) {
 	e.dispatcher.SendData([]string{}, voteMsg)
 }
 
-func (e *ConsensusEngine) createVote(block *core.Block) core.Vote {
+func (e *ConsensusEngine) createVote(block *core.Block) (core.Vote, error) {
 	vote := core.Vote{
 		Block:  block.Hash(),
 		Height: block.Height,
-		ID:     e.privateKey.PublicKey().Address(),
+		ID:     e.signer.PublicKey().Address(),
 		Epoch:  e.GetEpoch(),
 	}
-	vote.Sign(e.privateKey)
-	return vote
+	if err := e.wal.CheckOwnVote(vote); err != nil {
+		return core.Vote{}, err
+	}
+	sig, err := e.signer.SignVote(vote)
+	if err != nil {
+		return core.Vote{}, err
+	}
+	vote.SetSignature(sig)
+	if vote.Height >= common.HeightEnableBLSCommitCertificate {
+		blsSig, err := e.signer.SignVoteBLS(vote)
+		if err != nil {
+			return core.Vote{}, err
+		}
+		vote.BLSSignature = blsSig
+	}
+	// Log the vote before it is broadcast, so that a conflicting vote is refused after a crash.
+	if err := e.wal.WriteOwnVote(vote); err != nil {
+		return core.Vote{}, err
+	}
+	return vote, nil
 }
 
 func (e *ConsensusEngine) validateVote(vote core.Vote) bool {
@@ -920,6 +1078,8 @@ func (e *ConsensusEngine) handleVote(vote core.Vote) (endEpoch bool) {
 		return
 	}
 
+	e.detectVoteEquivocation(vote)
+
 	// Save vote.
 	err := e.state.AddVote(&vote)
 	if err != nil {
@@ -968,43 +1128,141 @@ func (e *ConsensusEngine) handleVote(vote core.Vote) (endEpoch bool) {
 	return
 }
 
+// detectVoteEquivocation reports the voter if it has voted for another block at the same height
+// in the same epoch. The votes for the blocks not received yet are not checked.
+func (e *ConsensusEngine) detectVoteEquivocation(vote core.Vote) {
+	epochVotes, err := e.state.GetEpochVotes()
+	if err != nil {
+		return
+	}
+	for _, v := range epochVotes.Votes() {
+		if v.ID != vote.ID || v.Epoch != vote.Epoch || v.Block == vote.Block {
+			continue
+		}
+		// The height of a vote is not signed, use the heights of the voted blocks instead.
+		blockA, err := e.chain.FindBlock(v.Block)
+		if err != nil {
+			continue
+		}
+		blockB, err := e.chain.FindBlock(vote.Block)
+		if err != nil || blockA.Height != blockB.Height {
+			continue
+		}
+		e.reportEquivocation(core.NewVoteEquivocationEvidence(v, blockA.BlockHeader, vote, blockB.BlockHeader))
+	}
+}
+
+// detectProposalEquivocation reports the proposer of the block if it has proposed another block at
+// the same height in the same epoch.
+func (e *ConsensusEngine) detectProposalEquivocation(block *core.Block) {
+	for _, b := range e.chain.FindBlocksByHeight(block.Height) {
+		if b.Hash() == block.Hash() || b.Proposer != block.Proposer || b.Epoch != block.Epoch {
+			continue
+		}
+		e.reportEquivocation(core.NewProposalEquivocationEvidence(b.BlockHeader, block.BlockHeader))
+	}
+}
+
+// reportEquivocation adds the evidence to the ledger so that the offender is slashed in the next
+// block proposed by this node, and gossips it so that the other proposers can include it as well.
+func (e *ConsensusEngine) reportEquivocation(evidence *core.EquivocationEvidence) {
+	res := e.ledger.AddEquivocationEvidence(evidence)
+	if res.IsError() {
+		e.logger.WithFields(log.Fields{
+			"evidence": evidence,
+			"error":    res.Message,
+		}).Debug("Equivocation evidence not added")
+		return
+	}
+	e.logger.WithFields(log.Fields{
+		"offender": evidence.Offender().Hex(),
+		"epoch":    evidence.Epoch(),
+	}).Warn("Detected equivocation")
+
+	payload, err := rlp.EncodeToBytes(evidence)
+	if err != nil {
+		e.logger.WithFields(log.Fields{"evidence": evidence}).Error("Failed to encode equivocation evidence")
+		return
+	}
+	evidenceMsg := dispatcher.DataResponse{
+		ChannelID: common.ChannelIDEquivocationEvidence,
+		Payload:   payload,
+	}
+	e.dispatcher.SendData([]string{}, evidenceMsg)
+}
+
+// handleEquivocationEvidence adds the evidence received from a peer to the ledger. The sync manager
+// takes care of the gossiping.
+func (e *ConsensusEngine) handleEquivocationEvidence(evidence *core.EquivocationEvidence) {
+	res := e.ledger.AddEquivocationEvidence(evidence)
+	if res.IsError() {
+		e.logger.WithFields(log.Fields{
+			"evidence": evidence,
+			"error":    res.Message,
+		}).Debug("Equivocation evidence not added")
+	}
+}
+
 func (e *ConsensusEngine) checkCC(hash common.Hash) {
-	if hash.IsEmpty() {
+	block, ok := e.findCCCandidate(hash)
+	if !ok {
 		return
 	}
+
+	votes := e.chain.FindVotesByHash(hash).UniqueVoter()
+	validators := e.validatorManager.GetValidatorSet(hash)
+	if validators.HasMajority(votes) {
+		e.processCCBlock(block)
+	}
+}
+
+// checkCommitCertificate processes the block of a commit certificate if the certificate is valid.
+func (e *ConsensusEngine) checkCommitCertificate(cc core.CommitCertificate) {
+	block, ok := e.findCCCandidate(cc.BlockHash)
+	if !ok {
+		return
+	}
+
+	validators := e.validatorManager.GetValidatorSet(cc.BlockHash)
+	if cc.IsValid(validators) {
+		e.processCCBlock(block)
+	}
+}
+
+// findCCCandidate returns the block with the given hash if it may get a CC. Trusted blocks are
+// processed right away.
+func (e *ConsensusEngine) findCCCandidate(hash common.Hash) (*core.ExtendedBlock, bool) {
+	if hash.IsEmpty() {
+		return nil, false
+	}
 	block, err := e.Chain().FindBlock(hash)
 	if err != nil {
 		e.logger.WithFields(log.Fields{"block": hash.Hex()}).Debug("checkCC: Block hash in vote is not found")
-		return
+		return nil, false
 	}
 	// Skip invalid block.
 	if block.Status.IsInvalid() {
-		return
+		return nil, false
 	}
 	// Skip if block is still pending.
 	if block.Status.IsPending() {
-		return
+		return nil, false
 	}
 	// Skip if block already has CC.
 	if block.Status.IsCommitted() || block.Status.IsDirectlyFinalized() || block.Status.IsIndirectlyFinalized() {
-		return
+		return nil, false
 	}
 	// Process hardcoded blocks.
 	if block.Status.IsTrusted() {
 		e.processCCBlock(block)
-		return
+		return nil, false
 	}
 	// Ignore outdated votes.
 	highestCCBlockHeight := e.state.GetHighestCCBlock().Height
 	if block.Height < highestCCBlockHeight {
-		return
-	}
-
-	votes := e.chain.FindVotesByHash(hash).UniqueVoter()
-	validators := e.validatorManager.GetValidatorSet(hash)
-	if validators.HasMajority(votes) {
-		e.processCCBlock(block)
+		return nil, false
 	}
+	return block, true
 }
 
 func (e *ConsensusEngine) GetTipToVote() *core.ExtendedBlock {
@@ -1102,6 +1360,12 @@ func (e *ConsensusEngine) FinalizedBlocks() chan *core.Block {
 	return e.finalizedBlocks
 }
 
+// ValidatedBlocks returns a channel that will be published with blocks once they are validated
+// and added to the chain by the engine, i.e. the new heads before finalization.
+func (e *ConsensusEngine) ValidatedBlocks() chan *core.Block {
+	return e.validatedBlocks
+}
+
 // GetLastFinalizedBlock returns the last finalized block.
 func (e *ConsensusEngine) GetLastFinalizedBlock() *core.ExtendedBlock {
 	return e.state.GetLastFinalizedBlock()
@@ -1157,6 +1421,8 @@ func (e *ConsensusEngine) finalizeBlock(block *core.ExtendedBlock) error {
 	// duplicate TX in fork.
 	e.chain.AddTxsToIndex(block, true)
 
+	e.recordLiveness(block)
+
 	// Guardians and Rametronenterprises to vote for checkpoint blocks.
 	if common.IsCheckPointHeight(block.Height) {
 		e.guardian.StartNewBlock(block.Hash())
@@ -1192,6 +1458,12 @@ func (e *ConsensusEngine) shouldPropose(tip *core.ExtendedBlock, epoch uint64) b
 	return true
 }
 
+// ShouldIncludeValidatorUpdateTxs returns whether a block proposed on top of the tip would include
+// the validator updating transactions, i.e. the stake deposits and withdrawals.
+func (e *ConsensusEngine) ShouldIncludeValidatorUpdateTxs(tip *core.ExtendedBlock) bool {
+	return e.shouldIncludeValidatorUpdateTxs(tip)
+}
+
 func (e *ConsensusEngine) shouldIncludeValidatorUpdateTxs(tip *core.ExtendedBlock) bool {
 	// Check if majority has greater block height.
 	epochVotes, err := e.state.GetEpochVotes()
@@ -1254,11 +1526,12 @@ func (e *ConsensusEngine) createProposal(shouldIncludeValidatorUpdateTxs bool) (
 	block.Epoch = e.GetEpoch()
 	block.Parent = tip.Hash()
 	block.Height = tip.Height + 1
-	block.Proposer = e.privateKey.PublicKey().Address()
-	block.Timestamp = big.NewInt(time.Now().Unix())
+	block.Proposer = e.signer.PublicKey().Address()
+	block.Timestamp = big.NewInt(e.clock.Now().Unix())
 	block.HCC.BlockHash = e.state.GetHighestCCBlock().Hash()
 	hccValidators := e.validatorManager.GetValidatorSet(block.HCC.BlockHash)
-	block.HCC.Votes = e.chain.FindVotesByHash(block.HCC.BlockHash).UniqueVoter().FilterByValidators(hccValidators)
+	hccVotes := e.chain.FindVotesByHash(block.HCC.BlockHash).UniqueVoter().FilterByValidators(hccValidators)
+	block.HCC = core.NewCommitCertificate(block.HCC.BlockHash, hccVotes, hccValidators, block.Height >= common.HeightEnableBLSCommitCertificate)
 
 	// Add guardian votes.
 	if block.Height >= common.HeightEnablePando1 && common.IsCheckPointHeight(block.Height) {
@@ -1280,11 +1553,17 @@ func (e *ConsensusEngine) createProposal(shouldIncludeValidatorUpdateTxs bool) (
 	block.StateHash = newRoot
 
 	// Sign block.
-	sig, err := e.privateKey.Sign(block.SignBytes())
+	if err := e.wal.CheckOwnProposal(block.BlockHeader); err != nil {
+		return core.Proposal{}, err
+	}
+	sig, err := e.signer.SignProposal(block.BlockHeader)
 	if err != nil {
-		e.logger.WithFields(log.Fields{"error": err}).Panic("Failed to sign vote")
+		return core.Proposal{}, fmt.Errorf("Failed to sign block proposal: %v", err)
 	}
 	block.SetSignature(sig)
+	if err := e.wal.WriteOwnProposal(block.BlockHeader); err != nil {
+		return core.Proposal{}, fmt.Errorf("Failed to write block proposal to WAL: %v", err)
+	}
 
 	proposal := core.Proposal{
 		Block:      block,
@@ -1348,9 +1627,7 @@ func (e *ConsensusEngine) propose() {
 	}
 	e.dispatcher.SendData([]string{}, proposalMsg)
 
-	go func() {
-		e.AddMessage(proposal.Block)
-	}()
+	e.enqueue(proposal.Block)
 }
 
 func (e *ConsensusEngine) pruneState(currentBlockHeight uint64) {
@@ -1383,14 +1660,33 @@ func (e *ConsensusEngine) resetGuardianTimer() {
 	if e.guardianTimer != nil {
 		e.guardianTimer.Stop()
 	}
-	e.guardianTimer = time.NewTicker(time.Duration(viper.GetInt(common.CfgGuardianRoundLength)) * time.Second)
+	e.guardianTimer = e.clock.NewTicker(time.Duration(e.guardianRoundLength()) * time.Second)
+}
+
+// guardianRoundLength returns the length in seconds of the guardian voting rounds, as set by the
+// governance parameters of the last finalized block, or by the local configuration.
+func (e *ConsensusEngine) guardianRoundLength() uint64 {
+	roundLength := uint64(viper.GetInt(common.CfgGuardianRoundLength))
+	lfb := e.GetLastFinalizedBlock()
+	if e.ledger == nil || lfb == nil {
+		return roundLength
+	}
+	params, err := e.ledger.GetGovernanceParams(lfb.Hash())
+	if err != nil {
+		e.logger.WithFields(log.Fields{"error": err}).Warn("Failed to retrieve the governance parameters")
+		return roundLength
+	}
+	if params != nil && params.GuardianRoundLength != 0 {
+		roundLength = params.GuardianRoundLength
+	}
+	return roundLength
 }
 
-func isSyncing(lastestFinalizedBlock *core.ExtendedBlock, currentHeight uint64) bool {
+func isSyncing(lastestFinalizedBlock *core.ExtendedBlock, currentHeight uint64, now time.Time) bool {
 	if lastestFinalizedBlock == nil {
 		return true
 	}
-	currentTime := big.NewInt(time.Now().Unix())
+	currentTime := big.NewInt(now.Unix())
 	maxDiff := new(big.Int).SetUint64(30) // thirty seconds, about 5 blocks
 	threshold := new(big.Int).Sub(currentTime, maxDiff)
 	isSyncing := lastestFinalizedBlock.Timestamp.Cmp(threshold) < 0
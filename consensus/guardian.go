@@ -9,7 +9,6 @@ import (
 	"github.com/pandoprojects/pando/common"
 	"github.com/pandoprojects/pando/common/util"
 	"github.com/pandoprojects/pando/core"
-	"github.com/pandoprojects/pando/crypto/bls"
 )
 
 const (
@@ -20,8 +19,8 @@ const (
 type GuardianEngine struct {
 	logger *log.Entry
 
-	engine  *ConsensusEngine
-	privKey *bls.SecretKey
+	engine *ConsensusEngine
+	signer core.Signer
 
 	// State for current voting
 	block       common.Hash
@@ -36,11 +35,11 @@ type GuardianEngine struct {
 	mu       *sync.Mutex
 }
 
-func NewGuardianEngine(c *ConsensusEngine, privateKey *bls.SecretKey) *GuardianEngine {
+func NewGuardianEngine(c *ConsensusEngine, signer core.Signer) *GuardianEngine {
 	return &GuardianEngine{
-		logger:  util.GetLoggerForModule("guardian"),
-		engine:  c,
-		privKey: privateKey,
+		logger: util.GetLoggerForModule("guardian"),
+		engine: c,
+		signer: signer,
 
 		incoming: make(chan *core.AggregatedVotes, viper.GetInt(common.CfgConsensusMessageQueueSize)),
 		mu:       &sync.Mutex{},
@@ -67,7 +66,7 @@ func (g *GuardianEngine) StartNewBlock(block common.Hash) {
 	}
 	g.gcp = gcp
 	g.gcpHash = gcp.Hash()
-	g.signerIndex = gcp.WithStake().Index(g.privKey.PublicKey())
+	g.signerIndex = gcp.WithStake().Index(g.signer.BLSPublicKey())
 
 	g.logger.WithFields(log.Fields{
 		"block":       block.Hex(),
@@ -77,7 +76,15 @@ func (g *GuardianEngine) StartNewBlock(block common.Hash) {
 
 	if g.isGuardian() {
 		g.nextVote = core.NewAggregateVotes(block, gcp)
-		g.nextVote.Sign(g.privKey, g.signerIndex)
+		if err := g.signVote(block); err != nil {
+			g.logger.WithFields(log.Fields{
+				"block": block.Hex(),
+				"error": err,
+			}).Error("Failed to sign guardian vote")
+			g.nextVote = nil
+			g.currVote = nil
+			return
+		}
 		g.currVote = g.nextVote.Copy()
 	} else {
 		g.nextVote = nil
@@ -86,6 +93,20 @@ func (g *GuardianEngine) StartNewBlock(block common.Hash) {
 
 }
 
+// signVote adds the signature of the guardian to the next vote.
+func (g *GuardianEngine) signVote(block common.Hash) error {
+	eb, err := g.engine.chain.FindBlock(block)
+	if err != nil {
+		return err
+	}
+	sig, err := g.signer.SignGuardianVote(eb.Height, g.nextVote)
+	if err != nil {
+		return err
+	}
+	g.nextVote.AddSignature(sig, g.signerIndex)
+	return nil
+}
+
 func (g *GuardianEngine) StartNewRound() {
 	g.mu.Lock()
 	defer g.mu.Unlock()
@@ -0,0 +1,122 @@
+package consensus
+
+import (
+	log "github.com/sirupsen/logrus"
+	"github.com/spf13/viper"
+
+	"github.com/pandoprojects/pando/blockchain"
+	"github.com/pandoprojects/pando/common"
+	"github.com/pandoprojects/pando/core"
+)
+
+const (
+	// maxLivenessBackfill is the maximum number of finalized blocks recorded at once, e.g. when
+	// several blocks are finalized together or after the node has caught up.
+	maxLivenessBackfill = 100
+
+	// maxMissedSlotsPerBlock is the maximum number of skipped proposal slots charged for a block.
+	maxMissedSlotsPerBlock = 16
+)
+
+// recordLiveness records the votes and the proposals of the validators for the given finalized
+// block and the finalized ancestors which have not been recorded yet. The votes are the ones seen
+// by this node, so the records are only kept once the node has caught up with the network.
+func (e *ConsensusEngine) recordLiveness(block *core.ExtendedBlock) {
+	if !e.hasSynced {
+		return
+	}
+
+	next := e.chain.LivenessNextHeight()
+	blocks := []*core.ExtendedBlock{}
+	for b := block; b != nil && b.Height >= next && len(blocks) < maxLivenessBackfill; {
+		blocks = append(blocks, b)
+		if b.Height == 0 {
+			break
+		}
+		parent, err := e.chain.FindBlock(b.Parent)
+		if err != nil {
+			break
+		}
+		b = parent
+	}
+
+	for i := len(blocks) - 1; i >= 0; i-- {
+		e.chain.AddBlockLiveness(e.blockLiveness(blocks[i]))
+	}
+
+	if common.IsCheckPointHeight(block.Height) {
+		e.checkLivenessThreshold(block)
+	}
+}
+
+// blockLiveness builds the liveness record of a finalized block.
+func (e *ConsensusEngine) blockLiveness(block *core.ExtendedBlock) *blockchain.BlockLiveness {
+	record := &blockchain.BlockLiveness{
+		Height:    block.Height,
+		BlockHash: block.Hash(),
+		Proposer:  block.Proposer,
+	}
+
+	validators := e.validatorManager.GetValidatorSet(block.Hash())
+	votes := e.chain.FindVotesByHash(block.Hash())
+	voted := make(map[common.Address]bool)
+	for _, vote := range votes.Votes() {
+		voted[vote.ID] = true
+	}
+	for _, v := range validators.Validators() {
+		record.Validators = append(record.Validators, v.Address)
+		if voted[v.Address] {
+			record.Voters = append(record.Voters, v.Address)
+		}
+	}
+
+	// The epochs skipped between the parent and the block are slots in which the expected
+	// proposer did not get a block finalized.
+	parent, err := e.chain.FindBlock(block.Parent)
+	if err != nil || block.Epoch <= parent.Epoch+1 {
+		return record
+	}
+	first := parent.Epoch + 1
+	if block.Epoch-first > maxMissedSlotsPerBlock {
+		first = block.Epoch - maxMissedSlotsPerBlock
+	}
+	for epoch := first; epoch < block.Epoch; epoch++ {
+		proposer := e.validatorManager.GetNextProposer(parent.Hash(), epoch)
+		record.MissedProposers = append(record.MissedProposers, proposer.Address)
+	}
+	return record
+}
+
+// checkLivenessThreshold records a jail intent for each current validator whose uptime over a
+// full liveness window is below the configured threshold. The intents are advisory: they are
+// kept locally and reported through the RPC, but not enforced by the protocol. The uptime is
+// computed from the votes received by this node, which differ from node to node, so it cannot
+// back an on-chain jailing or slashing.
+func (e *ConsensusEngine) checkLivenessThreshold(block *core.ExtendedBlock) {
+	threshold := viper.GetFloat64(common.CfgConsensusLivenessThreshold)
+	if threshold <= 0 {
+		return
+	}
+
+	for _, v := range e.validatorManager.GetValidatorSet(block.Hash()).Validators() {
+		liveness := e.chain.GetValidatorLiveness(v.Address)
+		if liveness.SignedBlocks+liveness.MissedBlocks < blockchain.LivenessWindow {
+			continue
+		}
+		uptime := liveness.Uptime()
+		if uptime >= threshold {
+			continue
+		}
+		e.chain.AddJailIntent(blockchain.JailIntent{
+			Address:          v.Address,
+			Height:           block.Height,
+			UptimeBasisPoint: uint64(uptime * 10000),
+		})
+		e.logger.WithFields(log.Fields{
+			"validator": v.Address.Hex(),
+			"uptime":    uptime,
+			"threshold": threshold,
+			"height":    block.Height,
+		}).Warn("Validator uptime below threshold, recorded an advisory jail intent")
+	}
+}
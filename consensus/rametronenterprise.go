@@ -10,7 +10,6 @@ import (
 	"github.com/pandoprojects/pando/common"
 	"github.com/pandoprojects/pando/common/util"
 	"github.com/pandoprojects/pando/core"
-	"github.com/pandoprojects/pando/crypto/bls"
 )
 
 const (
@@ -22,8 +21,8 @@ const (
 type RametronenterpriseEngine struct {
 	logger *log.Entry
 
-	engine  *ConsensusEngine
-	privKey *bls.SecretKey
+	engine *ConsensusEngine
+	signer core.Signer
 
 	voteBookkeeper *RametronenterpriseVoteBookkeeper
 
@@ -40,11 +39,11 @@ type RametronenterpriseEngine struct {
 	mu          *sync.Mutex
 }
 
-func NewRametronenterpriseEngine(c *ConsensusEngine, privateKey *bls.SecretKey) *RametronenterpriseEngine {
+func NewRametronenterpriseEngine(c *ConsensusEngine, signer core.Signer) *RametronenterpriseEngine {
 	return &RametronenterpriseEngine{
-		logger:  util.GetLoggerForModule("rametronenterprise"),
-		engine:  c,
-		privKey: privateKey,
+		logger: util.GetLoggerForModule("rametronenterprise"),
+		engine: c,
+		signer: signer,
 
 		voteBookkeeper: CreateRametronenterpriseVoteBookkeeper(DefaultMaxNumVotesCached),
 
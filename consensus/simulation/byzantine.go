@@ -0,0 +1,111 @@
+package simulation
+
+import (
+	"math/big"
+
+	"github.com/pandoprojects/pando/common"
+	"github.com/pandoprojects/pando/core"
+	"github.com/pandoprojects/pando/dispatcher"
+	"github.com/pandoprojects/pando/rlp"
+)
+
+// Byzantine describes how a faulty validator deviates from the protocol.
+type Byzantine struct {
+	// Withhold drops the proposals and votes of the validator, so that it never takes part in
+	// consensus while still following the chain.
+	Withhold bool
+
+	// Equivocate makes the validator send a conflicting twin of each of its proposals to the peers
+	// with an odd index, and vote for the twin instead of the original block towards them.
+	Equivocate bool
+}
+
+// misbehave applies the misbehaviour of the sender to the envelope. It returns the envelope to
+// deliver instead, or nil if the envelope is withheld. Only the messages signed by the sender are
+// altered, the messages it relays are left untouched.
+func (s *Simulation) misbehave(env *envelope) *envelope {
+	sender := s.nodes[env.from]
+	if sender.IsHonest() {
+		return env
+	}
+	resp, ok := env.content.(dispatcher.DataResponse)
+	if !ok {
+		return env
+	}
+	address := sender.PrivateKey.PublicKey().Address()
+
+	switch resp.ChannelID {
+	case common.ChannelIDProposal:
+		proposal := core.Proposal{}
+		if err := rlp.DecodeBytes(resp.Payload, &proposal); err != nil || proposal.Block == nil || proposal.Block.Proposer != address {
+			return env
+		}
+		if sender.Byzantine.Withhold {
+			return nil
+		}
+		if sender.Byzantine.Equivocate && receivesTwins(env.to) {
+			proposal.Block = s.twinBlock(sender, proposal.Block)
+			return s.withContent(env, proposal)
+		}
+	case common.ChannelIDVote:
+		vote := core.Vote{}
+		if err := rlp.DecodeBytes(resp.Payload, &vote); err != nil || vote.ID != address {
+			return env
+		}
+		if sender.Byzantine.Withhold {
+			return nil
+		}
+		if twin, ok := s.twins[vote.Block]; ok && sender.Byzantine.Equivocate && receivesTwins(env.to) {
+			vote.Block = twin.Hash()
+			vote.Sign(sender.PrivateKey)
+			return s.withContent(env, vote)
+		}
+	}
+	return env
+}
+
+// receivesTwins returns whether an equivocating validator sends its twin messages to the given node.
+func receivesTwins(index int) bool {
+	return index%2 == 1
+}
+
+// twinBlock returns a block which conflicts with the given one, i.e. at the same height and epoch
+// and signed by the same proposer. The same twin is returned for every recipient.
+func (s *Simulation) twinBlock(sender *Node, block *core.Block) *core.Block {
+	if twin, ok := s.twins[block.Hash()]; ok {
+		return twin
+	}
+	raw, err := rlp.EncodeToBytes(block)
+	if err != nil {
+		panic(err)
+	}
+	twin := &core.Block{}
+	if err := rlp.DecodeBytes(raw, twin); err != nil {
+		panic(err)
+	}
+	twin.Timestamp = new(big.Int).Add(block.Timestamp, big.NewInt(1))
+	sig, err := sender.PrivateKey.Sign(twin.SignBytes())
+	if err != nil {
+		panic(err)
+	}
+	twin.SetSignature(sig)
+	twin.UpdateHash()
+
+	s.twins[block.Hash()] = twin
+	return twin
+}
+
+// withContent returns a copy of the envelope carrying the given message on the same channel.
+func (s *Simulation) withContent(env *envelope, msg interface{}) *envelope {
+	payload, err := rlp.EncodeToBytes(msg)
+	if err != nil {
+		panic(err)
+	}
+	altered := *env
+	altered.content = dispatcher.DataResponse{
+		ChannelID: env.channelID,
+		Payload:   payload,
+	}
+	altered.raw = s.encode(&altered)
+	return &altered
+}
@@ -0,0 +1,167 @@
+package simulation
+
+import (
+	"bytes"
+	"context"
+	"sort"
+	"time"
+
+	"github.com/pandoprojects/pando/common"
+	"github.com/pandoprojects/pando/p2p"
+	p2ptypes "github.com/pandoprojects/pando/p2p/types"
+)
+
+// envelope is a message in flight between two nodes.
+type envelope struct {
+	from      int
+	to        int
+	channelID common.ChannelIDEnum
+	content   interface{}
+	raw       common.Bytes
+}
+
+// event is the delivery of an envelope at a point of the virtual time.
+type event struct {
+	at       time.Time
+	seq      uint64
+	envelope *envelope
+}
+
+// eventHeap orders the events by delivery time, and by scheduling order for equal times.
+type eventHeap []*event
+
+func (h eventHeap) Len() int { return len(h) }
+
+func (h eventHeap) Less(i, j int) bool {
+	if !h[i].at.Equal(h[j].at) {
+		return h[i].at.Before(h[j].at)
+	}
+	return h[i].seq < h[j].seq
+}
+
+func (h eventHeap) Swap(i, j int) { h[i], h[j] = h[j], h[i] }
+
+func (h *eventHeap) Push(x interface{}) {
+	*h = append(*h, x.(*event))
+}
+
+func (h *eventHeap) Pop() interface{} {
+	old := *h
+	n := len(old)
+	x := old[n-1]
+	*h = old[0 : n-1]
+	return x
+}
+
+// sortEnvelopes sorts the envelopes sent during a round of the simulation. The nodes iterate over
+// maps when sending messages, so their order is not reproducible otherwise.
+func sortEnvelopes(envelopes []*envelope) {
+	sort.SliceStable(envelopes, func(i, j int) bool {
+		a, b := envelopes[i], envelopes[j]
+		if a.from != b.from {
+			return a.from < b.from
+		}
+		if a.to != b.to {
+			return a.to < b.to
+		}
+		if a.channelID != b.channelID {
+			return a.channelID < b.channelID
+		}
+		return bytes.Compare(a.raw, b.raw) < 0
+	})
+}
+
+// endpoint implements the p2p.Network interface for a node of the simulation. The simulated
+// network is fully connected, and the messages are queued in the simulation instead of being sent
+// right away.
+type endpoint struct {
+	sim      *Simulation
+	index    int
+	id       string
+	peers    []string
+	handlers map[common.ChannelIDEnum]p2p.MessageHandler
+}
+
+var _ p2p.Network = (*endpoint)(nil)
+
+// Start implements the p2p.Network interface.
+func (ep *endpoint) Start(ctx context.Context) error {
+	return nil
+}
+
+// Wait implements the p2p.Network interface.
+func (ep *endpoint) Wait() {
+}
+
+// Stop implements the p2p.Network interface.
+func (ep *endpoint) Stop() {
+}
+
+// Broadcast implements the p2p.Network interface.
+func (ep *endpoint) Broadcast(message p2ptypes.Message, skipRametronenterprise bool) chan bool {
+	for _, peerID := range ep.peers {
+		ep.Send(peerID, message)
+	}
+	return ep.sent()
+}
+
+// BroadcastToNeighbors implements the p2p.Network interface. All the peers are neighbors in the
+// simulated network.
+func (ep *endpoint) BroadcastToNeighbors(message p2ptypes.Message, maxNumPeersToBroadcast int, skipRametronenterprise bool) chan bool {
+	return ep.Broadcast(message, skipRametronenterprise)
+}
+
+// Send implements the p2p.Network interface.
+func (ep *endpoint) Send(peerID string, message p2ptypes.Message) bool {
+	to, ok := ep.sim.nodeIndex[peerID]
+	if !ok {
+		return false
+	}
+	ep.sim.outbox = append(ep.sim.outbox, &envelope{
+		from:      ep.index,
+		to:        to,
+		channelID: message.ChannelID,
+		content:   message.Content,
+	})
+	return true
+}
+
+// Peers implements the p2p.Network interface.
+func (ep *endpoint) Peers(skipRametronenterprise bool) []string {
+	peers := make([]string, len(ep.peers))
+	copy(peers, ep.peers)
+	return peers
+}
+
+// PeerURLs implements the p2p.Network interface.
+func (ep *endpoint) PeerURLs(skipRametronenterprise bool) []string {
+	return ep.Peers(skipRametronenterprise)
+}
+
+// PeerExists implements the p2p.Network interface.
+func (ep *endpoint) PeerExists(peerID string) bool {
+	for _, id := range ep.peers {
+		if id == peerID {
+			return true
+		}
+	}
+	return false
+}
+
+// RegisterMessageHandler implements the p2p.Network interface.
+func (ep *endpoint) RegisterMessageHandler(messageHandler p2p.MessageHandler) {
+	for _, channelID := range messageHandler.GetChannelIDs() {
+		ep.handlers[channelID] = messageHandler
+	}
+}
+
+// ID implements the p2p.Network interface.
+func (ep *endpoint) ID() string {
+	return ep.id
+}
+
+func (ep *endpoint) sent() chan bool {
+	success := make(chan bool, 1)
+	success <- true
+	return success
+}
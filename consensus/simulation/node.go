@@ -0,0 +1,178 @@
+package simulation
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+
+	"github.com/pandoprojects/pando/blockchain"
+	"github.com/pandoprojects/pando/common"
+	"github.com/pandoprojects/pando/common/clock"
+	"github.com/pandoprojects/pando/consensus"
+	"github.com/pandoprojects/pando/core"
+	"github.com/pandoprojects/pando/crypto"
+	dp "github.com/pandoprojects/pando/dispatcher"
+	ld "github.com/pandoprojects/pando/ledger"
+	"github.com/pandoprojects/pando/ledger/state"
+	"github.com/pandoprojects/pando/ledger/types"
+	mp "github.com/pandoprojects/pando/mempool"
+	"github.com/pandoprojects/pando/netsync"
+	"github.com/pandoprojects/pando/p2p"
+	msgl "github.com/pandoprojects/pando/p2pl/messenger"
+	"github.com/pandoprojects/pando/store/database"
+	"github.com/pandoprojects/pando/store/database/backend"
+	"github.com/pandoprojects/pando/store/kvstore"
+)
+
+// Node is a validator of the simulation, running the same components as a full node.
+type Node struct {
+	Index      int
+	ID         string
+	PrivateKey *crypto.PrivateKey
+	Byzantine  Byzantine
+
+	Chain       *blockchain.Chain
+	Consensus   *consensus.ConsensusEngine
+	SyncManager *netsync.SyncManager
+	Dispatcher  *dp.Dispatcher
+	Ledger      *ld.Ledger
+
+	endpoint  *endpoint
+	finalized []*core.Block
+}
+
+// IsHonest returns whether the node follows the protocol.
+func (n *Node) IsHonest() bool {
+	return !n.Byzantine.Withhold && !n.Byzantine.Equivocate
+}
+
+// FinalizedBlocks returns the blocks published by the consensus engine of the node, in the order
+// of finalization.
+func (n *Node) FinalizedBlocks() []*core.Block {
+	return n.finalized
+}
+
+// step runs the sync manager or the consensus engine of the node once, and returns false if they
+// were both idle.
+func (n *Node) step() bool {
+	return n.SyncManager.Step() || n.Consensus.Step()
+}
+
+// collectFinalized drains the finalized blocks published by the consensus engine.
+func (n *Node) collectFinalized() []*core.Block {
+	blocks := []*core.Block{}
+	for {
+		select {
+		case block := <-n.Consensus.FinalizedBlocks():
+			blocks = append(blocks, block)
+		default:
+			n.finalized = append(n.finalized, blocks...)
+			return blocks
+		}
+	}
+}
+
+// validatorKey derives the key of a validator from the seed of the simulation.
+func validatorKey(seed int64, index int) *crypto.PrivateKey {
+	privKey, _, err := crypto.TEST_GenerateKeyPairWithSeed(fmt.Sprintf("simulation-%d-validator-%d", seed, index))
+	if err != nil {
+		panic(fmt.Sprintf("Failed to generate validator key: %v", err))
+	}
+	return privKey
+}
+
+// writeGenesis saves the genesis state, in which every validator has the same stake, and returns
+// the genesis block.
+func writeGenesis(chainID string, db database.Database, keys []*crypto.PrivateKey) *core.Block {
+	stake := new(big.Int).Set(core.MinValidatorStakeDeposit)
+	balance := new(big.Int).Mul(new(big.Int).SetUint64(10), core.MinValidatorStakeDeposit)
+
+	vcp := &core.ValidatorCandidatePool{}
+	sv := state.NewStoreView(0, common.Hash{}, db)
+	for _, key := range keys {
+		address := key.PublicKey().Address()
+		if err := vcp.DepositStake(address, address, stake, 0); err != nil {
+			panic(fmt.Sprintf("Failed to deposit stake: %v", err))
+		}
+		sv.SetAccount(address, &types.Account{
+			Address: address,
+			Balance: types.Coins{
+				PandoWei: new(big.Int).Set(balance),
+				PTXWei:   new(big.Int).Set(balance),
+			},
+		})
+	}
+	sv.UpdateValidatorCandidatePool(vcp)
+
+	root := core.NewBlock()
+	root.ChainID = chainID
+	root.StateHash = sv.Save()
+	return root
+}
+
+// newNode assembles the components of a validator like node.NewNode, on an in-memory database and
+// the virtual clock of the simulation.
+func newNode(sim *Simulation, index int, keys []*crypto.PrivateKey, byzantine Byzantine, clock clock.Clock) *Node {
+	privKey := keys[index]
+	id := privKey.PublicKey().Address().Hex()
+
+	peers := []string{}
+	for i, key := range keys {
+		if i != index {
+			peers = append(peers, key.PublicKey().Address().Hex())
+		}
+	}
+	ep := &endpoint{
+		sim:      sim,
+		index:    index,
+		id:       id,
+		peers:    peers,
+		handlers: make(map[common.ChannelIDEnum]p2p.MessageHandler),
+	}
+
+	db := backend.NewMemDatabase()
+	root := writeGenesis(chainID, db, keys)
+	store := kvstore.NewKVStore(db)
+	chain := blockchain.NewChain(chainID, store, root)
+
+	var network *msgl.Messenger
+	validatorManager := consensus.NewRotatingValidatorManager()
+	dispatcher := dp.NewDispatcher(ep, network)
+	dispatcher.SetSynchronousSend(true)
+	engine := consensus.NewConsensusEngine(privKey, store, chain, dispatcher, validatorManager)
+	engine.SetClock(clock)
+
+	syncMgr := netsync.NewSyncManager(chain, engine, ep, network, dispatcher, engine, nil /* no metrics reporting */)
+	syncMgr.SetClock(clock)
+	syncMgr.SetRandSeed(sim.config.Seed + int64(index))
+	mempool := mp.CreateMempool(dispatcher, engine)
+	ledger := ld.NewLedger(chainID, db, noopTagger{}, chain, engine, validatorManager, mempool)
+	ledger.SetEquivocationSlashingHeight(1)
+
+	validatorManager.SetConsensusEngine(engine)
+	engine.SetLedger(ledger)
+	mempool.SetLedger(ledger)
+
+	return &Node{
+		Index:       index,
+		ID:          id,
+		PrivateKey:  privKey,
+		Byzantine:   byzantine,
+		Chain:       chain,
+		Consensus:   engine,
+		SyncManager: syncMgr,
+		Dispatcher:  dispatcher,
+		Ledger:      ledger,
+		endpoint:    ep,
+	}
+}
+
+// noopTagger ignores the state roots, the in-memory database of a node is never rolled.
+type noopTagger struct{}
+
+func (noopTagger) Tag(height uint64, root common.Hash) {}
+
+func (n *Node) start(ctx context.Context) {
+	n.Consensus.StartStepping(ctx)
+	n.SyncManager.StartStepping(ctx)
+}
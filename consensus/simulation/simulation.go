@@ -0,0 +1,408 @@
+package simulation
+
+import (
+	"container/heap"
+	"context"
+	"crypto/sha256"
+	"encoding/binary"
+	"fmt"
+	"hash"
+	"math/rand"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+	"github.com/spf13/viper"
+
+	"github.com/pandoprojects/pando/common"
+	"github.com/pandoprojects/pando/common/clock"
+	"github.com/pandoprojects/pando/core"
+	"github.com/pandoprojects/pando/crypto"
+)
+
+const chainID = "simulation"
+
+// queueSize is the capacity of the message queues of the nodes. The nodes are stepped by a single
+// goroutine, so the queues must never fill up.
+const queueSize = 8192
+
+// Partition splits the validators into groups which cannot reach each other between Start and End,
+// measured from the beginning of the simulation. The validators not listed in any group form
+// another group.
+type Partition struct {
+	Start  time.Duration
+	End    time.Duration
+	Groups [][]int
+}
+
+// Config configures a simulation. The same configuration, and in particular the same seed, always
+// produces the same execution.
+type Config struct {
+	Seed          int64
+	NumValidators int
+
+	// Every message is delayed by a random duration between MinDelay and MaxDelay.
+	MinDelay time.Duration
+	MaxDelay time.Duration
+
+	// DropRate is the probability that a message is lost.
+	DropRate float64
+
+	// ReorderRate is the probability that a message is delayed by up to ReorderDelay more, so that
+	// it is overtaken by the messages sent after it.
+	ReorderRate  float64
+	ReorderDelay time.Duration
+
+	// HealAt is the time after which no message is dropped or reordered any more. The faults last
+	// for the whole simulation if it is zero.
+	HealAt time.Duration
+
+	Partitions []Partition
+
+	// Byzantine maps the indexes of the faulty validators to their behaviour.
+	Byzantine map[int]Byzantine
+}
+
+// Simulation runs several validators in a single goroutine, on a virtual clock and a simulated
+// network which injects faults. Each validator runs the consensus engine, the ledger and the sync
+// manager of a full node.
+type Simulation struct {
+	config Config
+	clock  *clock.VirtualClock
+	start  time.Time
+	rand   *rand.Rand
+	ctx    context.Context
+	cancel context.CancelFunc
+
+	nodes     []*Node
+	nodeIndex map[string]int
+
+	outbox []*envelope
+	events eventHeap
+	seq    uint64
+	twins  map[common.Hash]*core.Block
+
+	delivered int
+	dropped   int
+	trace     hash.Hash
+}
+
+// New creates a simulation. It overrides the message queue sizes in the global configuration.
+func New(config Config) (*Simulation, error) {
+	if config.NumValidators < 1 {
+		return nil, fmt.Errorf("Invalid number of validators: %v", config.NumValidators)
+	}
+	if config.MinDelay < 0 || config.MaxDelay < config.MinDelay {
+		return nil, fmt.Errorf("Invalid message delay: [%v, %v]", config.MinDelay, config.MaxDelay)
+	}
+	for index := range config.Byzantine {
+		if index < 0 || index >= config.NumValidators {
+			return nil, fmt.Errorf("Invalid byzantine validator: %v", index)
+		}
+	}
+
+	viper.Set(common.CfgConsensusMessageQueueSize, queueSize)
+	viper.Set(common.CfgSyncMessageQueueSize, queueSize)
+
+	start := time.Unix(1600000000, 0)
+	ctx, cancel := context.WithCancel(context.Background())
+	sim := &Simulation{
+		config:    config,
+		clock:     clock.NewVirtualClock(start),
+		start:     start,
+		rand:      rand.New(rand.NewSource(config.Seed)),
+		ctx:       ctx,
+		cancel:    cancel,
+		nodeIndex: make(map[string]int),
+		twins:     make(map[common.Hash]*core.Block),
+		trace:     sha256.New(),
+	}
+
+	keys := make([]*crypto.PrivateKey, config.NumValidators)
+	for i := range keys {
+		keys[i] = validatorKey(config.Seed, i)
+		sim.nodeIndex[keys[i].PublicKey().Address().Hex()] = i
+	}
+	for i := range keys {
+		sim.nodes = append(sim.nodes, newNode(sim, i, keys, config.Byzantine[i], sim.clock))
+	}
+	for _, node := range sim.nodes {
+		node.start(ctx)
+	}
+
+	log.WithFields(log.Fields{
+		"seed":          config.Seed,
+		"numValidators": config.NumValidators,
+	}).Info("Started consensus simulation")
+
+	return sim, nil
+}
+
+// Stop stops the nodes of the simulation.
+func (s *Simulation) Stop() {
+	s.cancel()
+}
+
+// Nodes returns the validators of the simulation.
+func (s *Simulation) Nodes() []*Node {
+	return s.nodes
+}
+
+// Elapsed returns the virtual time elapsed since the beginning of the simulation.
+func (s *Simulation) Elapsed() time.Duration {
+	return s.clock.Now().Sub(s.start)
+}
+
+// Stats returns the number of messages delivered and dropped so far.
+func (s *Simulation) Stats() (delivered int, dropped int) {
+	return s.delivered, s.dropped
+}
+
+// Run runs the simulation for the given duration of virtual time.
+func (s *Simulation) Run(d time.Duration) {
+	s.RunUntil(func() bool { return false }, d)
+}
+
+// RunUntil runs the simulation until the condition holds, or until the given duration of virtual
+// time has passed. It returns whether the condition holds.
+func (s *Simulation) RunUntil(cond func() bool, d time.Duration) bool {
+	end := s.clock.Now().Add(d)
+	for {
+		s.settle()
+		if cond() {
+			return true
+		}
+
+		next, ok := s.clock.NextDeadline()
+		if len(s.events) > 0 && (!ok || s.events[0].at.Before(next)) {
+			next, ok = s.events[0].at, true
+		}
+		if !ok || next.After(end) {
+			s.clock.AdvanceTo(end)
+			s.settle()
+			return cond()
+		}
+
+		s.clock.AdvanceTo(next)
+		for len(s.events) > 0 && !s.events[0].at.After(next) {
+			s.deliver(heap.Pop(&s.events).(*event).envelope)
+		}
+	}
+}
+
+// RunUntilFinalized runs the simulation until every honest validator has finalized a block at the
+// given height, or until the given duration of virtual time has passed.
+func (s *Simulation) RunUntilFinalized(height uint64, d time.Duration) bool {
+	return s.RunUntil(func() bool {
+		return s.CheckLiveness(height) == nil
+	}, d)
+}
+
+// settle steps the nodes in turn until they are all idle at the current time.
+func (s *Simulation) settle() {
+	for {
+		progressed := false
+		for _, node := range s.nodes {
+			for node.step() {
+				progressed = true
+			}
+		}
+		s.flush()
+		for _, node := range s.nodes {
+			for _, block := range node.collectFinalized() {
+				s.record(node.Index, node.Index, block.Hash().Bytes())
+			}
+		}
+		if !progressed {
+			return
+		}
+	}
+}
+
+// flush schedules the messages sent by the nodes since the last flush.
+func (s *Simulation) flush() {
+	outbox := s.outbox
+	s.outbox = nil
+	for _, env := range outbox {
+		env.raw = s.encode(env)
+	}
+	sortEnvelopes(outbox)
+
+	for _, env := range outbox {
+		if env = s.misbehave(env); env == nil || env.raw == nil {
+			s.dropped++
+			continue
+		}
+		s.schedule(env)
+	}
+}
+
+// schedule injects the network faults, and queues the envelope for delivery.
+func (s *Simulation) schedule(env *envelope) {
+	elapsed := s.Elapsed()
+	if s.partitioned(env.from, env.to, elapsed) {
+		s.dropped++
+		return
+	}
+
+	faulty := s.config.HealAt == 0 || elapsed < s.config.HealAt
+	if faulty && s.config.DropRate > 0 && s.rand.Float64() < s.config.DropRate {
+		s.dropped++
+		return
+	}
+	delay := s.config.MinDelay
+	if spread := s.config.MaxDelay - s.config.MinDelay; spread > 0 {
+		delay += time.Duration(s.rand.Int63n(int64(spread) + 1))
+	}
+	if faulty && s.config.ReorderRate > 0 && s.rand.Float64() < s.config.ReorderRate && s.config.ReorderDelay > 0 {
+		delay += time.Duration(s.rand.Int63n(int64(s.config.ReorderDelay) + 1))
+	}
+
+	s.seq++
+	heap.Push(&s.events, &event{
+		at:       s.clock.Now().Add(delay),
+		seq:      s.seq,
+		envelope: env,
+	})
+}
+
+// partitioned returns whether the two validators are separated by a partition at the given time.
+func (s *Simulation) partitioned(from, to int, elapsed time.Duration) bool {
+	for _, p := range s.config.Partitions {
+		if elapsed < p.Start || elapsed >= p.End {
+			continue
+		}
+		if partitionGroup(p, from) != partitionGroup(p, to) {
+			return true
+		}
+	}
+	return false
+}
+
+func partitionGroup(p Partition, index int) int {
+	for i, group := range p.Groups {
+		for _, member := range group {
+			if member == index {
+				return i
+			}
+		}
+	}
+	return -1
+}
+
+// encode serializes the message like the p2p layer of the sender, so that the receiver parses it
+// from the wire format. It returns nil if the sender has no handler for the channel.
+func (s *Simulation) encode(env *envelope) common.Bytes {
+	handler, ok := s.nodes[env.from].endpoint.handlers[env.channelID]
+	if !ok {
+		return nil
+	}
+	raw, err := handler.EncodeMessage(env.content)
+	if err != nil {
+		log.WithFields(log.Fields{"error": err, "channel": env.channelID}).Warn("Failed to encode simulated message")
+		return nil
+	}
+	return raw
+}
+
+// deliver passes the envelope to the message handler of the receiver.
+func (s *Simulation) deliver(env *envelope) {
+	receiver := s.nodes[env.to]
+	handler, ok := receiver.endpoint.handlers[env.channelID]
+	if !ok {
+		s.dropped++
+		return
+	}
+	message, err := handler.ParseMessage(s.nodes[env.from].ID, env.channelID, env.raw)
+	if err != nil {
+		log.WithFields(log.Fields{"error": err, "channel": env.channelID}).Warn("Failed to parse simulated message")
+		s.dropped++
+		return
+	}
+	s.delivered++
+	s.record(env.from, env.to, env.raw)
+	handler.HandleMessage(message)
+}
+
+// record adds an event to the trace of the simulation.
+func (s *Simulation) record(from, to int, data []byte) {
+	var header [24]byte
+	binary.BigEndian.PutUint64(header[0:8], uint64(s.clock.Now().UnixNano()))
+	binary.BigEndian.PutUint64(header[8:16], uint64(from))
+	binary.BigEndian.PutUint64(header[16:24], uint64(to))
+	s.trace.Write(header[:])
+	s.trace.Write(data)
+}
+
+// TraceDigest returns a digest of the messages delivered and the blocks finalized so far. Two
+// simulations with the same configuration have the same digest.
+func (s *Simulation) TraceDigest() common.Hash {
+	return common.BytesToHash(s.trace.Sum(nil))
+}
+
+// ---- Invariants ----
+
+// CheckSafety verifies that the honest validators have not finalized conflicting blocks, i.e.
+// different blocks at the same height.
+func (s *Simulation) CheckSafety() error {
+	finalized := make(map[uint64]common.Hash)
+	finalizedBy := make(map[uint64]int)
+	for _, node := range s.nodes {
+		if !node.IsHonest() {
+			continue
+		}
+		block := node.Consensus.GetLastFinalizedBlock()
+		for {
+			if hash, ok := finalized[block.Height]; ok && hash != block.Hash() {
+				return fmt.Errorf("Conflicting blocks finalized at height %v: %v by validator %v, %v by validator %v",
+					block.Height, hash.Hex(), finalizedBy[block.Height], block.Hash().Hex(), node.Index)
+			}
+			finalized[block.Height] = block.Hash()
+			finalizedBy[block.Height] = node.Index
+			if block.Parent.IsEmpty() {
+				break
+			}
+			parent, err := node.Chain.FindBlock(block.Parent)
+			if err != nil {
+				return fmt.Errorf("Validator %v lost the parent of finalized block %v: %v", node.Index, block.Hash().Hex(), err)
+			}
+			if !parent.Status.IsFinalized() {
+				return fmt.Errorf("Validator %v has not finalized %v, the parent of finalized block %v",
+					node.Index, parent.Hash().Hex(), block.Hash().Hex())
+			}
+			block = parent
+		}
+
+		// The blocks published by the engine must extend each other.
+		for i := 1; i < len(node.finalized); i++ {
+			if node.finalized[i].Height <= node.finalized[i-1].Height {
+				return fmt.Errorf("Validator %v finalized height %v after height %v",
+					node.Index, node.finalized[i].Height, node.finalized[i-1].Height)
+			}
+		}
+	}
+	for _, node := range s.nodes {
+		if !node.IsHonest() {
+			continue
+		}
+		for _, block := range node.finalized {
+			if hash := finalized[block.Height]; hash != block.Hash() {
+				return fmt.Errorf("Validator %v published finalized block %v which conflicts with %v at height %v",
+					node.Index, block.Hash().Hex(), hash.Hex(), block.Height)
+			}
+		}
+	}
+	return nil
+}
+
+// CheckLiveness verifies that every honest validator has finalized a block at the given height.
+func (s *Simulation) CheckLiveness(height uint64) error {
+	for _, node := range s.nodes {
+		if !node.IsHonest() {
+			continue
+		}
+		if lfb := node.Consensus.GetLastFinalizedBlock(); lfb.Height < height {
+			return fmt.Errorf("Validator %v has only finalized height %v, expected %v", node.Index, lfb.Height, height)
+		}
+	}
+	return nil
+}
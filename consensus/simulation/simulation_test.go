@@ -0,0 +1,156 @@
+package simulation
+
+import (
+	"testing"
+	"time"
+
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/pandoprojects/pando/common"
+	"github.com/pandoprojects/pando/common/util"
+	"github.com/pandoprojects/pando/core"
+)
+
+func newTestSimulation(require *require.Assertions, config Config) *Simulation {
+	viper.Set(common.CfgLogLevels, "*:error")
+	util.InitLog()
+
+	sim, err := New(config)
+	require.Nil(err)
+	return sim
+}
+
+func newTestConfig(seed int64) Config {
+	return Config{
+		Seed:          seed,
+		NumValidators: 4,
+		MinDelay:      10 * time.Millisecond,
+		MaxDelay:      500 * time.Millisecond,
+	}
+}
+
+func TestSimulationNetworkFaults(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	config := newTestConfig(1)
+	config.DropRate = 0.2
+	config.ReorderRate = 0.2
+	config.ReorderDelay = 3 * time.Second
+	config.HealAt = 3 * time.Minute
+	// Neither side of the partition has a majority.
+	config.Partitions = []Partition{{Start: 30 * time.Second, End: 90 * time.Second, Groups: [][]int{{0, 1}, {2, 3}}}}
+
+	sim := newTestSimulation(require, config)
+	defer sim.Stop()
+
+	sim.Run(time.Minute)
+	require.Nil(sim.CheckSafety())
+	stalled := sim.Nodes()[0].Consensus.GetLastFinalizedBlock().Height
+	sim.Run(20 * time.Second)
+	assert.Equal(stalled, sim.Nodes()[0].Consensus.GetLastFinalizedBlock().Height)
+
+	assert.True(sim.RunUntilFinalized(stalled+10, 10*time.Minute))
+	require.Nil(sim.CheckSafety())
+	delivered, dropped := sim.Stats()
+	assert.True(delivered > 0)
+	assert.True(dropped > 0)
+}
+
+func TestSimulationWithholding(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	config := newTestConfig(2)
+	config.Byzantine = map[int]Byzantine{0: {Withhold: true}}
+	sim := newTestSimulation(require, config)
+	defer sim.Stop()
+
+	assert.True(sim.RunUntilFinalized(10, 10*time.Minute))
+	require.Nil(sim.CheckSafety())
+}
+
+func TestSimulationEquivocation(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	config := newTestConfig(3)
+	config.MaxDelay = 200 * time.Millisecond
+	config.Byzantine = map[int]Byzantine{1: {Equivocate: true}}
+	sim := newTestSimulation(require, config)
+	defer sim.Stop()
+
+	assert.True(sim.RunUntilFinalized(15, 10*time.Minute))
+	require.Nil(sim.CheckSafety())
+	require.True(len(sim.twins) > 0)
+
+	// The honest validators have slashed the equivocating one.
+	offender := sim.Nodes()[1].PrivateKey.PublicKey().Address()
+	for _, node := range sim.Nodes() {
+		if !node.IsHonest() {
+			continue
+		}
+		vcp, err := node.Ledger.GetFinalizedValidatorCandidatePool(node.Consensus.GetLastFinalizedBlock().Hash(), false)
+		require.Nil(err)
+		assert.True(vcp.FindStakeDelegate(offender).TotalStake().Cmp(core.MinValidatorStakeDeposit) < 0)
+	}
+}
+
+func TestSimulationReproducible(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	run := func(seed int64) (common.Hash, []*core.Block) {
+		config := newTestConfig(seed)
+		config.DropRate = 0.1
+		config.ReorderRate = 0.1
+		config.ReorderDelay = 2 * time.Second
+		config.Byzantine = map[int]Byzantine{3: {Equivocate: true}}
+		sim := newTestSimulation(require, config)
+		defer sim.Stop()
+
+		sim.Run(2 * time.Minute)
+		require.Nil(sim.CheckSafety())
+		return sim.TraceDigest(), sim.Nodes()[0].FinalizedBlocks()
+	}
+
+	digest, blocks := run(4)
+	require.True(len(blocks) > 0)
+	for i := 0; i < 2; i++ {
+		digestAgain, blocksAgain := run(4)
+		assert.Equal(digest, digestAgain)
+		require.Equal(len(blocks), len(blocksAgain))
+		for j := range blocks {
+			assert.Equal(blocks[j].Hash(), blocksAgain[j].Hash())
+		}
+	}
+
+	digestOther, _ := run(5)
+	assert.NotEqual(digest, digestOther)
+}
+
+func TestSimulationSeeds(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	for seed := int64(10); seed < 15; seed++ {
+		config := newTestConfig(seed)
+		config.NumValidators = 7
+		config.DropRate = 0.1
+		config.ReorderRate = 0.2
+		config.ReorderDelay = 2 * time.Second
+		config.HealAt = 2 * time.Minute
+		config.Partitions = []Partition{{Start: 20 * time.Second, End: 50 * time.Second, Groups: [][]int{{0, 1, 2, 3, 4}}}}
+		config.Byzantine = map[int]Byzantine{
+			int(seed) % 7:   {Equivocate: true},
+			int(seed+3) % 7: {Withhold: true},
+		}
+		sim := newTestSimulation(require, config)
+
+		assert.True(sim.RunUntilFinalized(10, 10*time.Minute), "seed %v", seed)
+		assert.Nil(sim.CheckSafety(), "seed %v", seed)
+		sim.Stop()
+	}
+}
@@ -9,7 +9,7 @@ import (
 	"github.com/pandoprojects/pando/core"
 )
 
-const MaxValidatorCount int = 31
+const MaxValidatorCount int = core.MaxValidatorCount
 
 //
 // -------------------------------- FixedValidatorManager ----------------------------------
@@ -140,21 +140,7 @@ func (m *RotatingValidatorManager) GetNextValidatorSet(blockHash common.Hash) *c
 //
 
 func SelectTopStakeHoldersAsValidators(vcp *core.ValidatorCandidatePool) *core.ValidatorSet {
-	maxNumValidators := MaxValidatorCount
-	topStakeHolders := vcp.GetTopStakeHolders(maxNumValidators)
-
-	valSet := core.NewValidatorSet()
-	for _, stakeHolder := range topStakeHolders {
-		valAddr := stakeHolder.Holder.Hex()
-		valStake := stakeHolder.TotalStake()
-		if valStake.Cmp(core.Zero) == 0 {
-			continue
-		}
-		validator := core.NewValidator(valAddr, valStake)
-		valSet.AddValidator(validator)
-	}
-
-	return valSet
+	return core.SelectTopStakeHoldersAsValidators(vcp)
 }
 
 func selectTopStakeHoldersAsValidatorsForBlock(consensus core.ConsensusEngine, blockHash common.Hash, isNext bool) *core.ValidatorSet {
@@ -0,0 +1,537 @@
+package consensus
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"hash/crc32"
+	"io"
+	"os"
+	"path/filepath"
+	"sync"
+
+	log "github.com/sirupsen/logrus"
+
+	"github.com/pandoprojects/pando/common"
+	"github.com/pandoprojects/pando/core"
+	"github.com/pandoprojects/pando/rlp"
+)
+
+// WAL entry types.
+const (
+	walEntryBlock       byte = iota + 1 // Hash of a received block
+	walEntryVote                        // Received vote
+	walEntryOwnVote                     // Vote signed by this node
+	walEntryOwnProposal                 // Header of a block proposed by this node
+)
+
+const (
+	walRecordHeaderSize = 8
+	maxWALRecordSize    = 1 << 20
+	maxWALSize          = 64 * 1024 * 1024
+	walSyncBatchSize    = 64 // number of the received blocks and votes buffered before they are synced
+)
+
+type walEntry struct {
+	Type    byte
+	Payload common.Bytes
+}
+
+// WAL is a write-ahead log of the consensus inputs (received blocks and votes) and outputs
+// (votes and proposals signed by this node). The own votes and proposals are fsynced before the
+// call returns, so an own vote logged before being broadcast is never lost in a crash. They are
+// indexed to refuse signing a conflicting message after a restart. The received blocks and votes
+// are buffered and synced in batches, to keep the disk flushes off the processing of the gossip.
+// Losing the last of them in a crash is harmless, they are only replayed as a hint.
+//
+// Each record is a 4-byte big-endian length and a 4-byte CRC32 checksum followed by the RLP
+// encoded entry. A torn record at the end of the log is discarded when the log is opened. A
+// nil WAL logs nothing.
+type WAL struct {
+	mu   sync.Mutex
+	path string
+	file *os.File
+	size int64
+
+	writer   *bufio.Writer
+	unsynced int // number of the records written since the last sync
+
+	ownVoteEpoch    uint64
+	ownVotes        map[uint64]core.Vote // Own votes in ownVoteEpoch by height
+	lastOwnProposal *core.BlockHeader
+}
+
+// OpenWAL opens the WAL at the given path, creating it if it does not exist.
+func OpenWAL(path string) (*WAL, error) {
+	if err := os.MkdirAll(filepath.Dir(path), 0700); err != nil {
+		return nil, err
+	}
+	file, err := os.OpenFile(path, os.O_RDWR|os.O_CREATE, 0600)
+	if err != nil {
+		return nil, err
+	}
+	w := &WAL{
+		path:     path,
+		file:     file,
+		ownVotes: make(map[uint64]core.Vote),
+	}
+
+	size, err := w.scan(w.index)
+	if err != nil {
+		file.Close()
+		return nil, err
+	}
+	info, err := file.Stat()
+	if err != nil {
+		file.Close()
+		return nil, err
+	}
+	if info.Size() > size {
+		logger.WithFields(log.Fields{
+			"path":      path,
+			"size":      info.Size(),
+			"validSize": size,
+		}).Warn("Truncating torn records at the end of the consensus WAL")
+		if err := file.Truncate(size); err != nil {
+			file.Close()
+			return nil, err
+		}
+	}
+	if _, err := file.Seek(size, io.SeekStart); err != nil {
+		file.Close()
+		return nil, err
+	}
+	w.size = size
+	w.writer = bufio.NewWriter(file)
+	return w, nil
+}
+
+// Close closes the WAL file.
+func (w *WAL) Close() error {
+	if w == nil {
+		return nil
+	}
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	if err := w.sync(); err != nil {
+		w.file.Close()
+		return err
+	}
+	return w.file.Close()
+}
+
+// WriteBlock logs a received block.
+func (w *WAL) WriteBlock(block *core.Block) error {
+	if w == nil {
+		return nil
+	}
+	return w.write(walEntryBlock, block.Hash())
+}
+
+// WriteVote logs a received vote.
+func (w *WAL) WriteVote(vote core.Vote) error {
+	if w == nil {
+		return nil
+	}
+	return w.write(walEntryVote, vote)
+}
+
+// CheckOwnVote returns an error if the vote conflicts with a vote signed by this node: a vote
+// in an earlier epoch, or for another block at the same height in the same epoch.
+func (w *WAL) CheckOwnVote(vote core.Vote) error {
+	if w == nil {
+		return nil
+	}
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	if vote.Epoch < w.ownVoteEpoch {
+		return fmt.Errorf("Vote epoch %v is earlier than the logged vote epoch %v", vote.Epoch, w.ownVoteEpoch)
+	}
+	if vote.Epoch > w.ownVoteEpoch {
+		return nil
+	}
+	if logged, ok := w.ownVotes[vote.Height]; ok && logged.Block != vote.Block {
+		return fmt.Errorf("Conflicting vote at height %v in epoch %v, logged block: %v, new block: %v",
+			vote.Height, vote.Epoch, logged.Block.Hex(), vote.Block.Hex())
+	}
+	return nil
+}
+
+// WriteOwnVote logs a vote signed by this node. It must be called before the vote is broadcast.
+func (w *WAL) WriteOwnVote(vote core.Vote) error {
+	if w == nil {
+		return nil
+	}
+	if err := w.CheckOwnVote(vote); err != nil {
+		return err
+	}
+	return w.write(walEntryOwnVote, vote)
+}
+
+// CheckOwnProposal returns an error if the header conflicts with a block proposed by this node:
+// a block in an earlier epoch, or another block in the same epoch.
+func (w *WAL) CheckOwnProposal(header *core.BlockHeader) error {
+	if w == nil {
+		return nil
+	}
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	last := w.lastOwnProposal
+	if last == nil || header.Epoch > last.Epoch {
+		return nil
+	}
+	if header.Epoch < last.Epoch {
+		return fmt.Errorf("Proposal epoch %v is earlier than the logged proposal epoch %v", header.Epoch, last.Epoch)
+	}
+	// The header may not be signed yet, so the headers are compared without their signatures. The
+	// hash of an unsigned header is not computed, since it would be cached.
+	if !bytes.Equal(header.SignBytes(), last.SignBytes()) {
+		return fmt.Errorf("Conflicting proposal in epoch %v, logged block: %v", header.Epoch, last.Hash().Hex())
+	}
+	return nil
+}
+
+// WriteOwnProposal logs the header of a block proposed by this node. It must be called before
+// the proposal is broadcast.
+func (w *WAL) WriteOwnProposal(header *core.BlockHeader) error {
+	if w == nil {
+		return nil
+	}
+	if err := w.CheckOwnProposal(header); err != nil {
+		return err
+	}
+	return w.write(walEntryOwnProposal, header)
+}
+
+// Replay calls the handler with the logged entries in order. The message is a common.Hash for
+// walEntryBlock, a core.Vote for walEntryVote and walEntryOwnVote, and a *core.BlockHeader for
+// walEntryOwnProposal. The handler must not call the WAL.
+func (w *WAL) Replay(handler func(entryType byte, msg interface{})) error {
+	if w == nil {
+		return nil
+	}
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	if err := w.writer.Flush(); err != nil {
+		return err
+	}
+	_, err := w.scan(handler)
+	return err
+}
+
+// index updates the index of own votes and proposals with a logged entry.
+func (w *WAL) index(entryType byte, msg interface{}) {
+	switch entryType {
+	case walEntryOwnVote:
+		vote := msg.(core.Vote)
+		if vote.Epoch < w.ownVoteEpoch {
+			return
+		}
+		if vote.Epoch > w.ownVoteEpoch {
+			w.ownVoteEpoch = vote.Epoch
+			w.ownVotes = make(map[uint64]core.Vote)
+		}
+		w.ownVotes[vote.Height] = vote
+	case walEntryOwnProposal:
+		header := msg.(*core.BlockHeader)
+		if w.lastOwnProposal == nil || header.Epoch >= w.lastOwnProposal.Epoch {
+			w.lastOwnProposal = header
+		}
+	}
+}
+
+func (w *WAL) write(entryType byte, msg interface{}) error {
+	payload, err := rlp.EncodeToBytes(msg)
+	if err != nil {
+		return err
+	}
+	record, err := encodeWALRecord(walEntry{Type: entryType, Payload: payload})
+	if err != nil {
+		return err
+	}
+
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	if w.size+int64(len(record)) > maxWALSize {
+		if err := w.rotate(); err != nil {
+			return err
+		}
+	}
+	if _, err := w.writer.Write(record); err != nil {
+		return err
+	}
+	w.size += int64(len(record))
+	w.unsynced++
+
+	// The own messages must be on disk before they are broadcast. Syncing them also syncs the
+	// received messages written before.
+	if entryType == walEntryOwnVote || entryType == walEntryOwnProposal || w.unsynced >= walSyncBatchSize {
+		if err := w.sync(); err != nil {
+			return err
+		}
+	}
+
+	switch entryType {
+	case walEntryOwnVote:
+		w.index(entryType, msg)
+	case walEntryOwnProposal:
+		header := *msg.(*core.BlockHeader)
+		w.index(entryType, &header)
+	}
+	return nil
+}
+
+// sync writes the buffered records to the file and flushes it to the disk.
+func (w *WAL) sync() error {
+	if w.unsynced == 0 {
+		return nil
+	}
+	if err := w.writer.Flush(); err != nil {
+		return err
+	}
+	if err := w.file.Sync(); err != nil {
+		return err
+	}
+	w.unsynced = 0
+	return nil
+}
+
+// rotate replaces the log with one holding only the indexed own votes and proposal, which are
+// all that is needed to refuse conflicting messages. The buffered received messages are dropped.
+func (w *WAL) rotate() error {
+	tmpPath := w.path + ".tmp"
+	tmp, err := os.OpenFile(tmpPath, os.O_RDWR|os.O_CREATE|os.O_TRUNC, 0600)
+	if err != nil {
+		return err
+	}
+
+	size := int64(0)
+	writeEntry := func(entryType byte, msg interface{}) error {
+		payload, err := rlp.EncodeToBytes(msg)
+		if err != nil {
+			return err
+		}
+		record, err := encodeWALRecord(walEntry{Type: entryType, Payload: payload})
+		if err != nil {
+			return err
+		}
+		if _, err := tmp.Write(record); err != nil {
+			return err
+		}
+		size += int64(len(record))
+		return nil
+	}
+	for _, vote := range w.ownVotes {
+		if err = writeEntry(walEntryOwnVote, vote); err != nil {
+			break
+		}
+	}
+	if err == nil && w.lastOwnProposal != nil {
+		err = writeEntry(walEntryOwnProposal, w.lastOwnProposal)
+	}
+	if err == nil {
+		err = tmp.Sync()
+	}
+	if err != nil {
+		tmp.Close()
+		os.Remove(tmpPath)
+		return err
+	}
+
+	if err := os.Rename(tmpPath, w.path); err != nil {
+		tmp.Close()
+		return err
+	}
+	if dir, err := os.Open(filepath.Dir(w.path)); err == nil {
+		dir.Sync()
+		dir.Close()
+	}
+	w.file.Close()
+	w.file = tmp
+	w.size = size
+	w.writer.Reset(tmp)
+	w.unsynced = 0
+
+	logger.WithFields(log.Fields{"path": w.path, "size": size}).Info("Rotated consensus WAL")
+	return nil
+}
+
+// scan reads the log from the beginning and calls the handler with the decoded entries. It
+// returns the size of the valid prefix of the log.
+func (w *WAL) scan(handler func(entryType byte, msg interface{})) (int64, error) {
+	if _, err := w.file.Seek(0, io.SeekStart); err != nil {
+		return 0, err
+	}
+	defer w.file.Seek(0, io.SeekEnd)
+
+	reader := bufio.NewReader(w.file)
+	size := int64(0)
+	for {
+		entry, n, err := decodeWALRecord(reader)
+		if err != nil {
+			// End of the log, or a torn record left by a crash.
+			return size, nil
+		}
+		msg, err := decodeWALEntry(entry)
+		if err != nil {
+			return size, fmt.Errorf("Failed to decode consensus WAL entry at offset %v: %v", size, err)
+		}
+		handler(entry.Type, msg)
+		size += n
+	}
+}
+
+func decodeWALEntry(entry *walEntry) (interface{}, error) {
+	switch entry.Type {
+	case walEntryBlock:
+		hash := common.Hash{}
+		err := rlp.DecodeBytes(entry.Payload, &hash)
+		return hash, err
+	case walEntryVote, walEntryOwnVote:
+		vote := core.Vote{}
+		err := rlp.DecodeBytes(entry.Payload, &vote)
+		return vote, err
+	case walEntryOwnProposal:
+		header := &core.BlockHeader{}
+		err := rlp.DecodeBytes(entry.Payload, header)
+		return header, err
+	default:
+		return nil, fmt.Errorf("Unknown entry type: %v", entry.Type)
+	}
+}
+
+func encodeWALRecord(entry walEntry) ([]byte, error) {
+	raw, err := rlp.EncodeToBytes(entry)
+	if err != nil {
+		return nil, err
+	}
+	record := make([]byte, walRecordHeaderSize+len(raw))
+	binary.BigEndian.PutUint32(record[0:4], uint32(len(raw)))
+	binary.BigEndian.PutUint32(record[4:8], crc32.ChecksumIEEE(raw))
+	copy(record[walRecordHeaderSize:], raw)
+	return record, nil
+}
+
+func decodeWALRecord(reader io.Reader) (*walEntry, int64, error) {
+	header := make([]byte, walRecordHeaderSize)
+	if _, err := io.ReadFull(reader, header); err != nil {
+		return nil, 0, err
+	}
+	length := binary.BigEndian.Uint32(header[0:4])
+	if length > maxWALRecordSize {
+		return nil, 0, errors.New("WAL record too large")
+	}
+	raw := make([]byte, length)
+	if _, err := io.ReadFull(reader, raw); err != nil {
+		return nil, 0, err
+	}
+	if crc32.ChecksumIEEE(raw) != binary.BigEndian.Uint32(header[4:8]) {
+		return nil, 0, errors.New("WAL record checksum mismatch")
+	}
+	entry := &walEntry{}
+	if err := rlp.DecodeBytes(raw, entry); err != nil {
+		return nil, 0, err
+	}
+	return entry, int64(walRecordHeaderSize + len(raw)), nil
+}
+
+// replayWAL restores the last own vote and proposal from the WAL if the consensus state is behind
+// them, e.g. after a crash between signing a vote and committing the state, and re-queues the
+// received blocks and votes above the last finalized height, which may not have been processed.
+func (e *ConsensusEngine) replayWAL() {
+	if e.wal == nil {
+		return
+	}
+
+	var ownVote *core.Vote
+	var ownProposal *core.BlockHeader
+	maxEpoch := uint64(0)
+	blocks := []common.Hash{}
+	votes := []core.Vote{}
+	err := e.wal.Replay(func(entryType byte, msg interface{}) {
+		switch entryType {
+		case walEntryBlock:
+			blocks = append(blocks, msg.(common.Hash))
+		case walEntryVote:
+			votes = append(votes, msg.(core.Vote))
+		case walEntryOwnVote:
+			vote := msg.(core.Vote)
+			if ownVote == nil || vote.Height >= ownVote.Height {
+				ownVote = &vote
+			}
+			if vote.Epoch > maxEpoch {
+				maxEpoch = vote.Epoch
+			}
+			votes = append(votes, vote)
+		case walEntryOwnProposal:
+			header := msg.(*core.BlockHeader)
+			if ownProposal == nil || header.Epoch >= ownProposal.Epoch {
+				ownProposal = header
+			}
+			if header.Epoch > maxEpoch {
+				maxEpoch = header.Epoch
+			}
+		}
+	})
+	if err != nil {
+		e.logger.WithFields(log.Fields{"error": err}).Error("Failed to replay consensus WAL")
+		return
+	}
+
+	if maxEpoch > e.GetEpoch() {
+		e.logger.WithFields(log.Fields{
+			"state.Epoch": e.GetEpoch(),
+			"wal.Epoch":   maxEpoch,
+		}).Info("Restoring epoch from WAL")
+		e.state.SetEpoch(maxEpoch)
+	}
+	if ownVote != nil && ownVote.Height > e.state.GetLastVote().Height {
+		if _, err := e.chain.FindBlock(ownVote.Block); err == nil {
+			e.logger.WithFields(log.Fields{"vote": *ownVote}).Info("Restoring last vote from WAL")
+			e.state.SetLastVote(*ownVote)
+		}
+	}
+	if lastProposal := e.state.GetLastProposal(); ownProposal != nil &&
+		(lastProposal.Block == nil || lastProposal.Block.Epoch < ownProposal.Epoch) {
+		if block, err := e.chain.FindBlock(ownProposal.Hash()); err == nil {
+			e.logger.WithFields(log.Fields{"block": ownProposal}).Info("Restoring last proposal from WAL")
+			e.state.SetLastProposal(core.Proposal{
+				Block:      block.Block,
+				ProposerID: common.HexToAddress(e.ID()),
+			})
+		}
+	}
+
+	lfh := e.state.GetLastFinalizedBlock().Height
+	messages := []interface{}{}
+	for _, hash := range blocks {
+		block, err := e.chain.FindBlock(hash)
+		if err != nil || block.Height <= lfh || !block.Status.IsPending() {
+			continue
+		}
+		messages = append(messages, block.Block)
+	}
+	for _, vote := range votes {
+		if vote.Height > lfh {
+			messages = append(messages, vote)
+		}
+	}
+	e.logger.WithFields(log.Fields{"messages": len(messages)}).Info("Replaying consensus WAL")
+
+	go func() {
+		for _, msg := range messages {
+			select {
+			case e.incoming <- msg:
+			case <-e.ctx.Done():
+				return
+			}
+		}
+	}()
+}
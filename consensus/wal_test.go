@@ -0,0 +1,145 @@
+package consensus
+
+import (
+	"io/ioutil"
+	"os"
+	"path"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/pandoprojects/pando/common"
+	"github.com/pandoprojects/pando/core"
+	"github.com/pandoprojects/pando/crypto"
+)
+
+func newTestWALVote(block string, height, epoch uint64) core.Vote {
+	return core.Vote{
+		Block:  common.HexToHash(block),
+		Height: height,
+		Epoch:  epoch,
+		ID:     common.HexToAddress("a1"),
+	}
+}
+
+func TestWALOwnVotes(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	dir, err := ioutil.TempDir("", "wal")
+	require.Nil(err)
+	defer os.RemoveAll(dir)
+	walPath := path.Join(dir, "consensus.wal")
+
+	wal, err := OpenWAL(walPath)
+	require.Nil(err)
+	require.Nil(wal.WriteOwnVote(newTestWALVote("b1", 10, 5)))
+	require.Nil(wal.WriteVote(newTestWALVote("b2", 10, 5)))
+	proposal := &core.BlockHeader{Height: 11, Epoch: 5, StateHash: common.HexToHash("c1")}
+	proposer, _, _ := crypto.GenerateKeyPair()
+	proposal.Signature, _ = proposer.Sign(proposal.SignBytes())
+	require.Nil(wal.WriteOwnProposal(proposal))
+	require.Nil(wal.Close())
+
+	// The own messages are still refused after reopening the log.
+	wal, err = OpenWAL(walPath)
+	require.Nil(err)
+	defer wal.Close()
+
+	assert.Nil(wal.CheckOwnVote(newTestWALVote("b1", 10, 5)))
+	assert.NotNil(wal.CheckOwnVote(newTestWALVote("b2", 10, 5)))
+	assert.NotNil(wal.CheckOwnVote(newTestWALVote("b3", 11, 4)))
+	assert.Nil(wal.CheckOwnVote(newTestWALVote("b3", 11, 5)))
+	assert.Nil(wal.CheckOwnVote(newTestWALVote("b2", 10, 6)))
+
+	// The header is checked before it is signed.
+	assert.Nil(wal.CheckOwnProposal(&core.BlockHeader{Height: 11, Epoch: 5, StateHash: common.HexToHash("c1")}))
+	assert.NotNil(wal.CheckOwnProposal(&core.BlockHeader{Height: 11, Epoch: 5, StateHash: common.HexToHash("c2")}))
+	assert.NotNil(wal.CheckOwnProposal(&core.BlockHeader{Height: 10, Epoch: 4, StateHash: common.HexToHash("c3")}))
+	assert.Nil(wal.CheckOwnProposal(&core.BlockHeader{Height: 12, Epoch: 6, StateHash: common.HexToHash("c4")}))
+
+	// A vote in a later epoch resets the votes of the previous epoch.
+	require.Nil(wal.WriteOwnVote(newTestWALVote("b2", 10, 6)))
+	assert.NotNil(wal.CheckOwnVote(newTestWALVote("b1", 10, 5)))
+	assert.NotNil(wal.WriteOwnVote(newTestWALVote("b1", 10, 6)))
+}
+
+func TestWALReplay(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	dir, err := ioutil.TempDir("", "wal")
+	require.Nil(err)
+	defer os.RemoveAll(dir)
+	walPath := path.Join(dir, "consensus.wal")
+
+	wal, err := OpenWAL(walPath)
+	require.Nil(err)
+	block := core.NewBlock()
+	block.Height = 10
+	block.Epoch = 5
+	require.Nil(wal.WriteBlock(block))
+	require.Nil(wal.WriteVote(newTestWALVote("b1", 10, 5)))
+	require.Nil(wal.WriteOwnVote(newTestWALVote("b1", 10, 5)))
+	require.Nil(wal.Close())
+
+	// A record torn by a crash is discarded.
+	file, err := os.OpenFile(walPath, os.O_WRONLY|os.O_APPEND, 0600)
+	require.Nil(err)
+	_, err = file.Write([]byte{0, 0, 0, 100, 1, 2, 3})
+	require.Nil(err)
+	require.Nil(file.Close())
+
+	wal, err = OpenWAL(walPath)
+	require.Nil(err)
+	defer wal.Close()
+	require.Nil(wal.WriteVote(newTestWALVote("b2", 11, 6)))
+
+	entryTypes := []byte{}
+	msgs := []interface{}{}
+	require.Nil(wal.Replay(func(entryType byte, msg interface{}) {
+		entryTypes = append(entryTypes, entryType)
+		msgs = append(msgs, msg)
+	}))
+	assert.Equal([]byte{walEntryBlock, walEntryVote, walEntryOwnVote, walEntryVote}, entryTypes)
+	assert.Equal(block.Hash(), msgs[0])
+	assert.Equal(common.HexToHash("b1"), msgs[1].(core.Vote).Block)
+	assert.Equal(uint64(10), msgs[2].(core.Vote).Height)
+	assert.Equal(common.HexToHash("b2"), msgs[3].(core.Vote).Block)
+}
+
+func TestWALSyncBatches(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	dir, err := ioutil.TempDir("", "wal")
+	require.Nil(err)
+	defer os.RemoveAll(dir)
+	walPath := path.Join(dir, "consensus.wal")
+
+	wal, err := OpenWAL(walPath)
+	require.Nil(err)
+	defer wal.Close()
+	fileSize := func() int64 {
+		info, err := os.Stat(walPath)
+		require.Nil(err)
+		return info.Size()
+	}
+
+	// The received votes are synced once a batch is full.
+	for i := 0; i < walSyncBatchSize-1; i++ {
+		require.Nil(wal.WriteVote(newTestWALVote("b1", 10, 5)))
+	}
+	assert.Equal(walSyncBatchSize-1, wal.unsynced)
+	require.Nil(wal.WriteVote(newTestWALVote("b1", 10, 5)))
+	assert.Equal(0, wal.unsynced)
+	assert.Equal(wal.size, fileSize())
+
+	// An own vote is synced right away, together with the received votes written before.
+	require.Nil(wal.WriteVote(newTestWALVote("b2", 11, 5)))
+	assert.Equal(1, wal.unsynced)
+	require.Nil(wal.WriteOwnVote(newTestWALVote("b2", 11, 5)))
+	assert.Equal(0, wal.unsynced)
+	assert.Equal(wal.size, fileSize())
+}
@@ -369,6 +369,9 @@ func (h *BlockHeader) Validate(chainID string) result.Result {
 	if h.HCC.BlockHash.IsEmpty() {
 		return result.Error("HCC is empty")
 	}
+	if h.HCC.AggregatedVotes != nil && h.Height < common.HeightEnableBLSCommitCertificate {
+		return result.Error("HCC cannot have aggregated votes before the BLS commit certificate fork")
+	}
 	if h.Timestamp == nil {
 		return result.Error("Timestamp is missing")
 	}
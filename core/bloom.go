@@ -91,26 +91,40 @@ func (b *Bloom) UnmarshalText(input []byte) error {
 	return hexutil.UnmarshalFixedText("Bloom", input, b[:])
 }
 
-// func CreateBloom(receipts Receipts) Bloom {
-// 	bin := new(big.Int)
-// 	for _, receipt := range receipts {
-// 		bin.Or(bin, LogsBloom(receipt.Logs))
-// 	}
-
-// 	return BytesToBloom(bin.Bytes())
-// }
-
-// func LogsBloom(logs []*Log) *big.Int {
-// 	bin := new(big.Int)
-// 	for _, log := range logs {
-// 		bin.Or(bin, bloom9(log.Address.Bytes()))
-// 		for _, b := range log.Topics {
-// 			bin.Or(bin, bloom9(b[:]))
-// 		}
-// 	}
-
-// 	return bin
-// }
+// AddBytes adds the bloom bits of d to the filter.
+func (b *Bloom) AddBytes(d []byte) {
+	for _, bit := range BloomBits(d) {
+		b[BloomByteLength-1-bit/8] |= byte(1) << (bit % 8)
+	}
+}
+
+// Or merges the bits of other into the filter.
+func (b *Bloom) Or(other Bloom) {
+	for i := range b {
+		b[i] |= other[i]
+	}
+}
+
+// HasBit returns whether the given bit (as returned by BloomBits) is set.
+func (b Bloom) HasBit(bit uint) bool {
+	return b[BloomByteLength-1-bit/8]&(byte(1)<<(bit%8)) != 0
+}
+
+// IsEmpty returns whether no bit of the filter is set.
+func (b Bloom) IsEmpty() bool {
+	return b == Bloom{}
+}
+
+// BloomBits returns the indexes of the three bits set by d in a bloom filter,
+// the same bits as bloom9 sets.
+func BloomBits(d []byte) [3]uint {
+	h := crypto.Keccak256(d)
+	var bits [3]uint
+	for i := 0; i < 3; i++ {
+		bits[i] = (uint(h[2*i+1]) + (uint(h[2*i]) << 8)) & (BloomBitLength - 1)
+	}
+	return bits
+}
 
 func bloom9(b []byte) *big.Int {
 	b = crypto.Keccak256(b)
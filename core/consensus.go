@@ -2,13 +2,12 @@ package core
 
 import (
 	"github.com/pandoprojects/pando/common"
-	"github.com/pandoprojects/pando/crypto"
 )
 
 // ConsensusEngine is the interface of a consensus engine.
 type ConsensusEngine interface {
 	ID() string
-	PrivateKey() *crypto.PrivateKey
+	Signer() Signer
 	GetTip(includePendingBlockingLeaf bool) *ExtendedBlock
 	GetEpoch() uint64
 	GetLedger() Ledger
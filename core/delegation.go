@@ -0,0 +1,119 @@
+package core
+
+import (
+	"fmt"
+	"math/big"
+
+	"github.com/pandoprojects/pando/common"
+)
+
+const (
+	// MaxValidatorCommissionBasisPoint is the maximal commission of a validator, in 1/10000 of its rewards.
+	MaxValidatorCommissionBasisPoint uint64 = 10000
+
+	// ValidatorCommissionUpdateInterval is the minimal number of blocks between two commission updates of a
+	// validator, which gives the delegators time to react to the changes.
+	ValidatorCommissionUpdateInterval uint64 = 14400
+)
+
+// DelegationRewardRatioPrecision scales the cumulative reward per unit of stake of the validator reward
+// pools, to limit the rounding errors.
+var DelegationRewardRatioPrecision = new(big.Int).Exp(big.NewInt(10), big.NewInt(18), nil)
+
+//
+// ------- ValidatorRewardPool ------- //
+//
+
+// ValidatorRewardPool tracks the staking rewards of a validator and its delegators. Instead of paying
+// every stake source at each checkpoint, the pool keeps the cumulative reward per unit of stake, from
+// which the rewards of a delegator are derived when it settles, i.e. when its stake changes or it
+// claims its rewards (F1 fee distribution).
+type ValidatorRewardPool struct {
+	Holder                 common.Address
+	CommissionBasisPoint   uint64   // the fraction of the rewards kept by the validator, in 1/10000
+	CommissionUpdateHeight uint64   // the block height of the last commission update
+	Commission             *big.Int // the commission accrued but not claimed yet, in PTXWei
+	RewardRatio            *big.Int // the cumulative reward per unit of stake, scaled by DelegationRewardRatioPrecision
+}
+
+// NewValidatorRewardPool creates a new reward pool for the validator, with no commission.
+func NewValidatorRewardPool(holder common.Address) *ValidatorRewardPool {
+	return &ValidatorRewardPool{
+		Holder:      holder,
+		Commission:  big.NewInt(0),
+		RewardRatio: big.NewInt(0),
+	}
+}
+
+// Accrue deducts the commission of the validator from the reward, and distributes the rest over the
+// total stake of the validator. The reward goes to the validator if it has no stake left.
+func (p *ValidatorRewardPool) Accrue(reward *big.Int, totalStake *big.Int) {
+	if totalStake.Sign() <= 0 {
+		p.Commission = new(big.Int).Add(p.Commission, reward)
+		return
+	}
+
+	commission := new(big.Int).Mul(reward, new(big.Int).SetUint64(p.CommissionBasisPoint))
+	commission.Div(commission, big.NewInt(10000))
+	p.Commission = new(big.Int).Add(p.Commission, commission)
+
+	delegated := new(big.Int).Sub(reward, commission)
+	delta := new(big.Int).Mul(delegated, DelegationRewardRatioPrecision)
+	delta.Div(delta, totalStake)
+	p.RewardRatio = new(big.Int).Add(p.RewardRatio, delta)
+}
+
+func (p *ValidatorRewardPool) String() string {
+	return fmt.Sprintf("{Holder: %v, CommissionBasisPoint: %v, CommissionUpdateHeight: %v, Commission: %v, RewardRatio: %v}",
+		p.Holder, p.CommissionBasisPoint, p.CommissionUpdateHeight, p.Commission, p.RewardRatio)
+}
+
+//
+// ------- DelegatorReward ------- //
+//
+
+// DelegatorReward tracks the staking rewards of a stake source delegated to a validator.
+type DelegatorReward struct {
+	Source      common.Address
+	Holder      common.Address
+	RewardRatio *big.Int // the reward ratio of the validator reward pool at the last settlement
+	Unclaimed   *big.Int // the rewards settled but not claimed yet, in PTXWei
+}
+
+// NewDelegatorReward creates a new record for the delegator, which starts accruing from the
+// current reward ratio of the pool.
+func NewDelegatorReward(source common.Address, holder common.Address) *DelegatorReward {
+	return &DelegatorReward{
+		Source:      source,
+		Holder:      holder,
+		RewardRatio: big.NewInt(0),
+		Unclaimed:   big.NewInt(0),
+	}
+}
+
+// Pending returns the unclaimed rewards of the delegator, including the rewards accrued by the stake
+// since the last settlement.
+func (d *DelegatorReward) Pending(pool *ValidatorRewardPool, stake *big.Int) *big.Int {
+	pending := new(big.Int).Set(d.Unclaimed)
+	if pool == nil || stake.Sign() <= 0 {
+		return pending
+	}
+	accrued := new(big.Int).Sub(pool.RewardRatio, d.RewardRatio)
+	accrued.Mul(accrued, stake)
+	accrued.Div(accrued, DelegationRewardRatioPrecision)
+	return pending.Add(pending, accrued)
+}
+
+// Settle adds the rewards accrued by the stake since the last settlement to the unclaimed rewards.
+// It needs to be called before any change of the stake.
+func (d *DelegatorReward) Settle(pool *ValidatorRewardPool, stake *big.Int) {
+	d.Unclaimed = d.Pending(pool, stake)
+	if pool != nil {
+		d.RewardRatio = new(big.Int).Set(pool.RewardRatio)
+	}
+}
+
+func (d *DelegatorReward) String() string {
+	return fmt.Sprintf("{Source: %v, Holder: %v, RewardRatio: %v, Unclaimed: %v}",
+		d.Source, d.Holder, d.RewardRatio, d.Unclaimed)
+}
@@ -0,0 +1,57 @@
+package core
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/pandoprojects/pando/common"
+)
+
+func TestValidatorRewardPoolAccrue(t *testing.T) {
+	assert := assert.New(t)
+
+	holder := common.HexToAddress("0x1")
+	pool := NewValidatorRewardPool(holder)
+	pool.CommissionBasisPoint = 1000 // 10%
+
+	pool.Accrue(big.NewInt(1000), big.NewInt(300))
+	assert.Equal("100", pool.Commission.String())
+	// 900 * 1e18 / 300
+	assert.Equal(new(big.Int).Mul(big.NewInt(3), DelegationRewardRatioPrecision).String(), pool.RewardRatio.String())
+
+	// Without any stake, the whole reward goes to the validator
+	pool.Accrue(big.NewInt(50), big.NewInt(0))
+	assert.Equal("150", pool.Commission.String())
+	assert.Equal(new(big.Int).Mul(big.NewInt(3), DelegationRewardRatioPrecision).String(), pool.RewardRatio.String())
+}
+
+func TestDelegatorRewardSettle(t *testing.T) {
+	assert := assert.New(t)
+
+	source := common.HexToAddress("0x1")
+	holder := common.HexToAddress("0x2")
+	pool := NewValidatorRewardPool(holder)
+	reward := NewDelegatorReward(source, holder)
+
+	assert.Equal("0", reward.Pending(nil, big.NewInt(100)).String())
+
+	// The delegator holds 100 of the 400 stake
+	pool.Accrue(big.NewInt(1000), big.NewInt(400))
+	assert.Equal("250", reward.Pending(pool, big.NewInt(100)).String())
+
+	// The stake is doubled after the settlement
+	reward.Settle(pool, big.NewInt(100))
+	assert.Equal("250", reward.Unclaimed.String())
+	assert.Equal(pool.RewardRatio.String(), reward.RewardRatio.String())
+	assert.Equal("250", reward.Pending(pool, big.NewInt(200)).String())
+
+	pool.Accrue(big.NewInt(1000), big.NewInt(500))
+	assert.Equal("650", reward.Pending(pool, big.NewInt(200)).String())
+
+	// Nothing accrues without stake
+	reward.Settle(pool, big.NewInt(200))
+	pool.Accrue(big.NewInt(1000), big.NewInt(300))
+	assert.Equal("650", reward.Pending(pool, big.NewInt(0)).String())
+}
@@ -0,0 +1,160 @@
+package core
+
+import (
+	"bytes"
+	"fmt"
+
+	"github.com/pandoprojects/pando/common"
+	"github.com/pandoprojects/pando/common/result"
+	"github.com/pandoprojects/pando/crypto"
+	"github.com/pandoprojects/pando/rlp"
+)
+
+// EquivocationSlashBasisPoint is the fraction of the stakes of a validator, in 1/10000, which
+// is slashed when the validator is caught double-signing.
+const EquivocationSlashBasisPoint uint64 = 1000
+
+// MaxEquivocationEvidenceAge is the maximal number of blocks between the conflicting messages and
+// the block including their evidence. It is well below the stake return locking period, so the stakes
+// withdrawn after the offence can still be slashed, and below the number of blocks whose states are
+// retained by the pruning, so the validator set of the offence can still be computed.
+const MaxEquivocationEvidenceAge uint64 = 1000
+
+// EquivocationEvidence proves that a validator signed two conflicting messages in the same epoch.
+// It holds either two votes of the validator for different blocks at the same height, together
+// with the headers of the voted blocks, or two different blocks proposed by the validator. The
+// evidence is self-authenticating since it carries the signatures of the offender.
+type EquivocationEvidence struct {
+	Votes   []Vote         // The two conflicting votes, empty for conflicting proposals.
+	Headers []*BlockHeader // The headers of the voted blocks, or the two conflicting proposals.
+}
+
+// NewVoteEquivocationEvidence creates the evidence of two conflicting votes. The votes are ordered
+// by block hash, so that all the nodes detecting the same conflict create the same evidence.
+func NewVoteEquivocationEvidence(voteA Vote, headerA *BlockHeader, voteB Vote, headerB *BlockHeader) *EquivocationEvidence {
+	if bytes.Compare(voteA.Block[:], voteB.Block[:]) > 0 {
+		voteA, voteB = voteB, voteA
+		headerA, headerB = headerB, headerA
+	}
+	return &EquivocationEvidence{
+		Votes:   []Vote{voteA, voteB},
+		Headers: []*BlockHeader{headerA, headerB},
+	}
+}
+
+// NewProposalEquivocationEvidence creates the evidence of two conflicting block proposals.
+func NewProposalEquivocationEvidence(headerA *BlockHeader, headerB *BlockHeader) *EquivocationEvidence {
+	hashA, hashB := headerA.Hash(), headerB.Hash()
+	if bytes.Compare(hashA[:], hashB[:]) > 0 {
+		headerA, headerB = headerB, headerA
+	}
+	return &EquivocationEvidence{
+		Headers: []*BlockHeader{headerA, headerB},
+	}
+}
+
+// IsVoteEquivocation returns whether the evidence is about conflicting votes.
+func (e *EquivocationEvidence) IsVoteEquivocation() bool {
+	return len(e.Votes) != 0
+}
+
+// Offender returns the address of the validator that double-signed.
+func (e *EquivocationEvidence) Offender() common.Address {
+	if e.IsVoteEquivocation() {
+		return e.Votes[0].ID
+	}
+	if len(e.Headers) == 0 || e.Headers[0] == nil {
+		return common.Address{}
+	}
+	return e.Headers[0].Proposer
+}
+
+// Epoch returns the epoch in which the conflicting messages were signed.
+func (e *EquivocationEvidence) Epoch() uint64 {
+	if e.IsVoteEquivocation() {
+		return e.Votes[0].Epoch
+	}
+	if len(e.Headers) == 0 || e.Headers[0] == nil {
+		return 0
+	}
+	return e.Headers[0].Epoch
+}
+
+// Height returns the height of the blocks the conflicting messages were signed for.
+func (e *EquivocationEvidence) Height() uint64 {
+	if len(e.Headers) == 0 || e.Headers[0] == nil {
+		return 0
+	}
+	return e.Headers[0].Height
+}
+
+// Validate checks that the evidence proves a double-signing on the given chain.
+func (e *EquivocationEvidence) Validate(chainID string) result.Result {
+	if len(e.Headers) != 2 || e.Headers[0] == nil || e.Headers[1] == nil {
+		return result.Error("Evidence should contain two block headers")
+	}
+	headerA, headerB := e.Headers[0], e.Headers[1]
+	if headerA.ChainID != chainID || headerB.ChainID != chainID {
+		return result.Error("ChainID mismatch")
+	}
+
+	switch len(e.Votes) {
+	case 0:
+		if res := headerA.Validate(chainID); res.IsError() {
+			return res
+		}
+		if res := headerB.Validate(chainID); res.IsError() {
+			return res
+		}
+		if headerA.Proposer != headerB.Proposer {
+			return result.Error("Blocks are proposed by different proposers")
+		}
+		if headerA.Epoch != headerB.Epoch {
+			return result.Error("Blocks are proposed in different epochs")
+		}
+		if bytes.Equal(headerA.SignBytes(), headerB.SignBytes()) {
+			return result.Error("Blocks are not conflicting")
+		}
+	case 2:
+		voteA, voteB := e.Votes[0], e.Votes[1]
+		if res := voteA.Validate(); res.IsError() {
+			return res
+		}
+		if res := voteB.Validate(); res.IsError() {
+			return res
+		}
+		if voteA.ID != voteB.ID {
+			return result.Error("Votes are signed by different voters")
+		}
+		if voteA.Epoch != voteB.Epoch {
+			return result.Error("Votes are signed in different epochs")
+		}
+		if voteA.Block == voteB.Block {
+			return result.Error("Votes are not conflicting")
+		}
+		// The height of a vote is not signed, so it is taken from the header of the voted block.
+		if voteA.Block != headerA.Hash() || voteB.Block != headerB.Hash() {
+			return result.Error("Headers do not match the voted blocks")
+		}
+		if headerA.Height != headerB.Height {
+			return result.Error("Voted blocks are at different heights")
+		}
+	default:
+		return result.Error("Evidence should contain either zero or two votes")
+	}
+	return result.OK
+}
+
+// Hash calculates the hash of the evidence.
+func (e *EquivocationEvidence) Hash() common.Hash {
+	raw, _ := rlp.EncodeToBytes(e)
+	return crypto.Keccak256Hash(raw)
+}
+
+func (e *EquivocationEvidence) String() string {
+	kind := "proposal"
+	if e.IsVoteEquivocation() {
+		kind = "vote"
+	}
+	return fmt.Sprintf("EquivocationEvidence{kind: %v, offender: %v, epoch: %v}", kind, e.Offender().Hex(), e.Epoch())
+}
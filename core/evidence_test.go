@@ -0,0 +1,118 @@
+package core
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/pandoprojects/pando/common"
+	"github.com/pandoprojects/pando/crypto"
+	"github.com/pandoprojects/pando/rlp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func createEvidenceTestHeader(proposer *crypto.PrivateKey, height uint64, epoch uint64, name string) *BlockHeader {
+	header := &BlockHeader{
+		ChainID:   "privatenet",
+		Epoch:     epoch,
+		Height:    height,
+		Parent:    common.HexToHash("a0"),
+		HCC:       CommitCertificate{BlockHash: common.HexToHash("a0")},
+		StateHash: common.HexToHash(name),
+		Timestamp: big.NewInt(1),
+		Proposer:  proposer.PublicKey().Address(),
+	}
+	header.Signature, _ = proposer.Sign(header.SignBytes())
+	return header
+}
+
+func createEvidenceTestVote(voter *crypto.PrivateKey, header *BlockHeader, epoch uint64) Vote {
+	vote := Vote{
+		Block:  header.Hash(),
+		Height: header.Height,
+		Epoch:  epoch,
+		ID:     voter.PublicKey().Address(),
+	}
+	vote.Sign(voter)
+	return vote
+}
+
+func TestVoteEquivocationEvidence(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	proposer, _, _ := crypto.GenerateKeyPair()
+	voter, _, _ := crypto.GenerateKeyPair()
+
+	headerA := createEvidenceTestHeader(proposer, 5, 3, "b1")
+	headerB := createEvidenceTestHeader(proposer, 5, 4, "b2")
+	voteA := createEvidenceTestVote(voter, headerA, 7)
+	voteB := createEvidenceTestVote(voter, headerB, 7)
+
+	evidence := NewVoteEquivocationEvidence(voteA, headerA, voteB, headerB)
+	assert.True(evidence.Validate("privatenet").IsOK())
+	assert.True(evidence.Validate("mainnet").IsError())
+	assert.True(evidence.IsVoteEquivocation())
+	assert.Equal(voter.PublicKey().Address(), evidence.Offender())
+	assert.Equal(uint64(7), evidence.Epoch())
+
+	// The same conflict yields the same evidence whatever the order of detection.
+	assert.Equal(evidence.Hash(), NewVoteEquivocationEvidence(voteB, headerB, voteA, headerA).Hash())
+
+	raw, err := rlp.EncodeToBytes(evidence)
+	require.Nil(err)
+	decoded := &EquivocationEvidence{}
+	require.Nil(rlp.DecodeBytes(raw, decoded))
+	assert.True(decoded.Validate("privatenet").IsOK())
+	assert.Equal(evidence.Hash(), decoded.Hash())
+
+	// Votes in different epochs do not conflict.
+	voteC := createEvidenceTestVote(voter, headerB, 8)
+	assert.True(NewVoteEquivocationEvidence(voteA, headerA, voteC, headerB).Validate("privatenet").IsError())
+
+	// Votes for blocks at different heights do not conflict, even if the unsigned vote heights are forged.
+	headerD := createEvidenceTestHeader(proposer, 6, 4, "b3")
+	voteD := createEvidenceTestVote(voter, headerD, 7)
+	voteD.Height = 5
+	assert.True(NewVoteEquivocationEvidence(voteA, headerA, voteD, headerD).Validate("privatenet").IsError())
+
+	// The headers must match the voted blocks.
+	assert.True(NewVoteEquivocationEvidence(voteA, headerA, voteB, headerA).Validate("privatenet").IsError())
+
+	// Votes of different voters do not conflict.
+	other, _, _ := crypto.GenerateKeyPair()
+	voteE := createEvidenceTestVote(other, headerB, 7)
+	assert.True(NewVoteEquivocationEvidence(voteA, headerA, voteE, headerB).Validate("privatenet").IsError())
+}
+
+func TestProposalEquivocationEvidence(t *testing.T) {
+	assert := assert.New(t)
+
+	proposer, _, _ := crypto.GenerateKeyPair()
+
+	headerA := createEvidenceTestHeader(proposer, 5, 3, "b1")
+	headerB := createEvidenceTestHeader(proposer, 5, 3, "b2")
+
+	evidence := NewProposalEquivocationEvidence(headerA, headerB)
+	assert.True(evidence.Validate("privatenet").IsOK())
+	assert.False(evidence.IsVoteEquivocation())
+	assert.Equal(proposer.PublicKey().Address(), evidence.Offender())
+	assert.Equal(uint64(3), evidence.Epoch())
+
+	// Repeating the same proposal is not an equivocation.
+	assert.True(NewProposalEquivocationEvidence(headerA, headerA).Validate("privatenet").IsError())
+
+	// Proposals in different epochs do not conflict.
+	headerC := createEvidenceTestHeader(proposer, 5, 4, "b3")
+	assert.True(NewProposalEquivocationEvidence(headerA, headerC).Validate("privatenet").IsError())
+
+	// Proposals of different proposers do not conflict.
+	other, _, _ := crypto.GenerateKeyPair()
+	headerD := createEvidenceTestHeader(other, 5, 3, "b4")
+	assert.True(NewProposalEquivocationEvidence(headerA, headerD).Validate("privatenet").IsError())
+
+	// The signatures of the proposals are verified.
+	headerE := createEvidenceTestHeader(proposer, 5, 3, "b5")
+	headerE.StateHash = common.HexToHash("b6")
+	assert.True(NewProposalEquivocationEvidence(headerA, headerE).Validate("privatenet").IsError())
+}
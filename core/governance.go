@@ -0,0 +1,152 @@
+package core
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/pandoprojects/pando/common"
+)
+
+const (
+	// GovernanceMinActivationDelay is the minimal number of blocks between a governance proposal and
+	// the activation of its parameters, which gives the node operators time to review it.
+	GovernanceMinActivationDelay uint64 = 3600
+
+	// GovernanceMaxActivationDelay is the maximal number of blocks between a governance proposal and
+	// the activation of its parameters.
+	GovernanceMaxActivationDelay uint64 = 30 * 14400
+
+	// MaxNumPendingGovernanceProposals is the maximal number of governance proposals waiting for
+	// their activation height, to avoid spamming.
+	MaxNumPendingGovernanceProposals = 16
+)
+
+// GovernanceParams are the protocol parameters the validators can change on-chain with governance
+// proposals, without a coordinated binary release.
+type GovernanceParams struct {
+	MinimumGasPrice             *big.Int `json:"minimum_gas_price"`               // for smart contract transactions
+	MaximumTxGasLimit           *big.Int `json:"maximum_tx_gas_limit"`            // for smart contract transactions
+	MinimumTransactionFeePTXWei *big.Int `json:"minimum_transaction_fee_ptx_wei"` // for regular transactions
+	MinValidatorStakeDeposit    *big.Int `json:"min_validator_stake_deposit"`
+	MinGuardianStakeDeposit     *big.Int `json:"min_guardian_stake_deposit"`
+	GuardianRoundLength         uint64   `json:"guardian_round_length"` // in seconds, zero for the local configuration
+}
+
+// Copy returns a deep copy of the parameters.
+func (p *GovernanceParams) Copy() *GovernanceParams {
+	return &GovernanceParams{
+		MinimumGasPrice:             copyBigInt(p.MinimumGasPrice),
+		MaximumTxGasLimit:           copyBigInt(p.MaximumTxGasLimit),
+		MinimumTransactionFeePTXWei: copyBigInt(p.MinimumTransactionFeePTXWei),
+		MinValidatorStakeDeposit:    copyBigInt(p.MinValidatorStakeDeposit),
+		MinGuardianStakeDeposit:     copyBigInt(p.MinGuardianStakeDeposit),
+		GuardianRoundLength:         p.GuardianRoundLength,
+	}
+}
+
+// Merge returns a copy of the parameters, with the fields that are set in the changes replaced.
+// The unset, i.e. nil or zero, fields of the changes leave the parameters unchanged.
+func (p *GovernanceParams) Merge(changes *GovernanceParams) *GovernanceParams {
+	merged := p.Copy()
+	if isSet(changes.MinimumGasPrice) {
+		merged.MinimumGasPrice = copyBigInt(changes.MinimumGasPrice)
+	}
+	if isSet(changes.MaximumTxGasLimit) {
+		merged.MaximumTxGasLimit = copyBigInt(changes.MaximumTxGasLimit)
+	}
+	if isSet(changes.MinimumTransactionFeePTXWei) {
+		merged.MinimumTransactionFeePTXWei = copyBigInt(changes.MinimumTransactionFeePTXWei)
+	}
+	if isSet(changes.MinValidatorStakeDeposit) {
+		merged.MinValidatorStakeDeposit = copyBigInt(changes.MinValidatorStakeDeposit)
+	}
+	if isSet(changes.MinGuardianStakeDeposit) {
+		merged.MinGuardianStakeDeposit = copyBigInt(changes.MinGuardianStakeDeposit)
+	}
+	if changes.GuardianRoundLength != 0 {
+		merged.GuardianRoundLength = changes.GuardianRoundLength
+	}
+	return merged
+}
+
+// ValidateChanges checks the parameter changes of a governance proposal.
+func (p *GovernanceParams) ValidateChanges() error {
+	fields := []*big.Int{
+		p.MinimumGasPrice,
+		p.MaximumTxGasLimit,
+		p.MinimumTransactionFeePTXWei,
+		p.MinValidatorStakeDeposit,
+		p.MinGuardianStakeDeposit,
+	}
+	hasChange := p.GuardianRoundLength != 0
+	for _, field := range fields {
+		if field != nil && field.Sign() < 0 {
+			return fmt.Errorf("Negative governance parameter: %v", field)
+		}
+		hasChange = hasChange || isSet(field)
+	}
+	if !hasChange {
+		return errors.New("No governance parameter changed")
+	}
+	return nil
+}
+
+func (p *GovernanceParams) String() string {
+	return fmt.Sprintf("GovernanceParams{MinimumGasPrice: %v, MaximumTxGasLimit: %v, MinimumTransactionFeePTXWei: %v, MinValidatorStakeDeposit: %v, MinGuardianStakeDeposit: %v, GuardianRoundLength: %v}",
+		p.MinimumGasPrice, p.MaximumTxGasLimit, p.MinimumTransactionFeePTXWei,
+		p.MinValidatorStakeDeposit, p.MinGuardianStakeDeposit, p.GuardianRoundLength)
+}
+
+func isSet(value *big.Int) bool {
+	return value != nil && value.Sign() != 0
+}
+
+func copyBigInt(value *big.Int) *big.Int {
+	if value == nil {
+		return nil
+	}
+	return new(big.Int).Set(value)
+}
+
+// GovernanceProposal is a change of the governance parameters proposed by a validator. It is
+// approved once the validators holding more than 2/3 of the stake voted for it, and its changes
+// take effect from the activation height. A proposal that is not approved before its activation
+// height expires.
+type GovernanceProposal struct {
+	ID               common.Hash      `json:"id"` // the hash of the proposal transaction
+	Proposer         common.Address   `json:"proposer"`
+	Changes          GovernanceParams `json:"changes"`
+	ActivationHeight uint64           `json:"activation_height"`
+	Voters           []common.Address `json:"voters"`
+	Approved         bool             `json:"approved"`
+}
+
+// HasVoted returns whether the validator has voted for the proposal.
+func (p *GovernanceProposal) HasVoted(voter common.Address) bool {
+	for _, v := range p.Voters {
+		if v == voter {
+			return true
+		}
+	}
+	return false
+}
+
+// HasSupermajority returns whether the voters hold more than 2/3 of the stake of the validator set.
+func (p *GovernanceProposal) HasSupermajority(validatorSet *ValidatorSet) bool {
+	votedStake := new(big.Int)
+	for _, voter := range p.Voters {
+		validator, err := validatorSet.GetValidator(voter)
+		if err == nil {
+			votedStake.Add(votedStake, validator.Stake)
+		}
+	}
+	lhs := new(big.Int).Mul(votedStake, big.NewInt(3))
+	rhs := new(big.Int).Mul(validatorSet.TotalStake(), big.NewInt(2))
+	return lhs.Cmp(rhs) > 0
+}
+
+func (p *GovernanceProposal) String() string {
+	return fmt.Sprintf("GovernanceProposal{ID: %v, Proposer: %v, Changes: %v, ActivationHeight: %v, Voters: %v, Approved: %v}",
+		p.ID.Hex(), p.Proposer.Hex(), p.Changes.String(), p.ActivationHeight, len(p.Voters), p.Approved)
+}
@@ -0,0 +1,82 @@
+package core
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/pandoprojects/pando/common"
+)
+
+func TestGovernanceParamsMerge(t *testing.T) {
+	assert := assert.New(t)
+
+	params := &GovernanceParams{
+		MinimumGasPrice:             big.NewInt(4000),
+		MaximumTxGasLimit:           big.NewInt(20000000),
+		MinimumTransactionFeePTXWei: big.NewInt(1000),
+		MinValidatorStakeDeposit:    big.NewInt(200),
+		MinGuardianStakeDeposit:     big.NewInt(100),
+		GuardianRoundLength:         30,
+	}
+
+	changes := &GovernanceParams{
+		MinimumGasPrice:     big.NewInt(8000),
+		MaximumTxGasLimit:   big.NewInt(0),
+		GuardianRoundLength: 60,
+	}
+	merged := params.Merge(changes)
+	assert.Equal(int64(8000), merged.MinimumGasPrice.Int64())
+	assert.Equal(int64(20000000), merged.MaximumTxGasLimit.Int64()) // zero leaves it unchanged
+	assert.Equal(int64(1000), merged.MinimumTransactionFeePTXWei.Int64())
+	assert.Equal(int64(200), merged.MinValidatorStakeDeposit.Int64())
+	assert.Equal(int64(100), merged.MinGuardianStakeDeposit.Int64())
+	assert.Equal(uint64(60), merged.GuardianRoundLength)
+
+	// The merge does not alias the original parameters or the changes.
+	changes.MinimumGasPrice.SetInt64(1)
+	merged.MaximumTxGasLimit.SetInt64(1)
+	assert.Equal(int64(4000), params.MinimumGasPrice.Int64())
+	assert.Equal(int64(8000), merged.MinimumGasPrice.Int64())
+	assert.Equal(int64(20000000), params.MaximumTxGasLimit.Int64())
+}
+
+func TestGovernanceParamsValidateChanges(t *testing.T) {
+	assert := assert.New(t)
+
+	assert.NotNil((&GovernanceParams{}).ValidateChanges())
+	assert.NotNil((&GovernanceParams{MinimumGasPrice: big.NewInt(0)}).ValidateChanges())
+	assert.NotNil((&GovernanceParams{MinimumGasPrice: big.NewInt(1), MinGuardianStakeDeposit: big.NewInt(-1)}).ValidateChanges())
+	assert.Nil((&GovernanceParams{MinValidatorStakeDeposit: big.NewInt(1)}).ValidateChanges())
+	assert.Nil((&GovernanceParams{GuardianRoundLength: 20}).ValidateChanges())
+}
+
+func TestGovernanceProposalSupermajority(t *testing.T) {
+	assert := assert.New(t)
+
+	va1Addr := common.HexToAddress("0x111")
+	va2Addr := common.HexToAddress("0x222")
+	va3Addr := common.HexToAddress("0x333")
+	va4Addr := common.HexToAddress("0x444")
+
+	vs := NewValidatorSet()
+	vs.AddValidator(NewValidator(va1Addr.Hex(), big.NewInt(100000001)))
+	vs.AddValidator(NewValidator(va2Addr.Hex(), big.NewInt(100000000)))
+	vs.AddValidator(NewValidator(va3Addr.Hex(), big.NewInt(50000000)))
+	vs.AddValidator(NewValidator(va4Addr.Hex(), big.NewInt(50000000)))
+
+	proposal := &GovernanceProposal{Proposer: va1Addr, Voters: []common.Address{va1Addr}}
+	assert.True(proposal.HasVoted(va1Addr))
+	assert.False(proposal.HasVoted(va2Addr))
+	assert.False(proposal.HasSupermajority(vs)) // about 1/3
+
+	// The votes of non-validators do not count.
+	proposal.Voters = []common.Address{va2Addr, va3Addr, va4Addr, common.HexToAddress("0x555")}
+	assert.False(proposal.HasSupermajority(vs)) // slightly less than 2/3
+
+	proposal.Voters = []common.Address{va1Addr, va2Addr}
+	assert.True(proposal.HasSupermajority(vs)) // slightly above 2/3
+
+	assert.False(proposal.HasSupermajority(NewValidatorSet()))
+}
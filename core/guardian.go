@@ -49,15 +49,26 @@ func (a *AggregatedVotes) signBytes() common.Bytes {
 	return b
 }
 
+// SignBytes returns the bytes to be signed by the guardians.
+func (a *AggregatedVotes) SignBytes() common.Bytes {
+	return a.signBytes()
+}
+
 // Sign adds signer's signature. Returns false if signer has already signed.
 func (a *AggregatedVotes) Sign(key *bls.SecretKey, signerIdx int) bool {
+	return a.AddSignature(key.Sign(a.signBytes()), signerIdx)
+}
+
+// AddSignature adds a signature of the signer over SignBytes(). Returns false if signer has
+// already signed.
+func (a *AggregatedVotes) AddSignature(sig *bls.Signature, signerIdx int) bool {
 	if a.Multiplies[signerIdx] > 0 {
 		// Already signed, do nothing.
 		return false
 	}
 
 	a.Multiplies[signerIdx] = 1
-	a.Signature.Aggregate(key.Sign(a.signBytes()))
+	a.Signature.Aggregate(sig)
 	return true
 }
 
@@ -302,6 +313,12 @@ func (gcp *GuardianCandidatePool) DepositStake(source common.Address, holder com
 	if blockHeight >= common.HeightLowerMetaStakeThresholdTo10000 {
 		minGuardianStake = MinGuardianStakeDeposit
 	}
+	return gcp.DepositStakeWithMinimum(source, holder, amount, pubkey, minGuardianStake)
+}
+
+// DepositStakeWithMinimum deposits the stake to the guardian, which must be at least the given minimum,
+// e.g. the threshold set by the governance parameters.
+func (gcp *GuardianCandidatePool) DepositStakeWithMinimum(source common.Address, holder common.Address, amount *big.Int, pubkey *bls.PublicKey, minGuardianStake *big.Int) (err error) {
 	if amount.Cmp(minGuardianStake) < 0 {
 		return fmt.Errorf("Insufficient stake: %v", amount)
 	}
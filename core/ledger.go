@@ -31,6 +31,10 @@ type Ledger interface {
 	GetCurrentBlock() *Block
 	ScreenTxUnsafe(rawTx common.Bytes) result.Result
 	ScreenTx(rawTx common.Bytes) (priority *TxInfo, res result.Result)
+	ScreenReplacementTx(rawTx common.Bytes, replacedRawTx common.Bytes) (priority *TxInfo, res result.Result)
+	ScreenFutureTx(rawTx common.Bytes) (priority *TxInfo, res result.Result)
+	GetTxInfo(rawTx common.Bytes) (priority *TxInfo, res result.Result)
+	ResetScreenedAccount(address common.Address)
 	ProposeBlockTxs(block *Block, shouldIncludeValidatorUpdateTxs bool) (stateRootHash common.Hash, blockRawTxs []common.Bytes, res result.Result)
 	ApplyBlockTxs(block *Block) result.Result
 	ApplyBlockTxsForChainCorrection(block *Block) (common.Hash, result.Result)
@@ -41,4 +45,6 @@ type Ledger interface {
 	GetGuardianCandidatePool(blockHash common.Hash) (*GuardianCandidatePool, error)
 	GetRametronenterprisePoolOfLastCheckpoint(blockHash common.Hash) (RametronenterprisePool, error)
 	PruneState(endHeight uint64) error
+	AddEquivocationEvidence(evidence *EquivocationEvidence) result.Result
+	GetGovernanceParams(blockHash common.Hash) (*GovernanceParams, error)
 }
@@ -0,0 +1,38 @@
+package core
+
+import (
+	"github.com/pandoprojects/pando/common"
+	"github.com/pandoprojects/pando/crypto"
+	"github.com/pandoprojects/pando/crypto/bls"
+)
+
+// Signer holds the keys of a validator and signs the consensus messages on its behalf. The keys
+// may be kept in the node process, or in a separate signer process. Implementations refuse to
+// sign messages which could make the validator double-sign.
+type Signer interface {
+	// PublicKey returns the public key of the validator.
+	PublicKey() *crypto.PublicKey
+
+	// BLSPublicKey returns the BLS public key of the validator, used for the guardian votes.
+	BLSPublicKey() *bls.PublicKey
+
+	// BLSPop returns the BLS proof of possession, and the signature of the proof by the validator key.
+	BLSPop() (*bls.Signature, *crypto.Signature, error)
+
+	// SignVote signs a vote of the validator.
+	SignVote(vote Vote) (*crypto.Signature, error)
+
+	// SignVoteBLS signs a vote of the validator with its BLS key, so that the vote can be
+	// aggregated in commit certificates.
+	SignVoteBLS(vote Vote) (*bls.Signature, error)
+
+	// SignProposal signs a block proposed by the validator.
+	SignProposal(header *BlockHeader) (*crypto.Signature, error)
+
+	// SignGuardianVote signs the guardian vote for the checkpoint block at the given height.
+	SignGuardianVote(height uint64, vote *AggregatedVotes) (*bls.Signature, error)
+
+	// SignTx signs a transaction the validator adds to the blocks it proposes, e.g. the coinbase
+	// transaction. The transaction is given in its serialized form.
+	SignTx(chainID string, rawTx common.Bytes) (*crypto.Signature, error)
+}
@@ -6,6 +6,7 @@ import (
 	"math/big"
 
 	"github.com/pandoprojects/pando/common"
+	"github.com/pandoprojects/pando/crypto/bls"
 )
 
 const (
@@ -99,6 +100,10 @@ func (s *Stake) UnmarshalJSON(data []byte) error {
 type StakeHolder struct {
 	Holder common.Address
 	Stakes []*Stake
+
+	// BlsPubkeys holds the BLS public key registered by a validator, if any. It is a tail list
+	// with at most one key, so that the holders without a key are encoded as before.
+	BlsPubkeys []*bls.PublicKey `rlp:"tail" json:"-"`
 }
 
 func NewStakeHolder(holder common.Address, stakes []*Stake) *StakeHolder {
@@ -118,6 +123,16 @@ func (sh *StakeHolder) TotalStake() *big.Int {
 	return totalAmount
 }
 
+// SourceStake returns the stake of the source delegated to the holder, excluding the withdrawn stake.
+func (sh *StakeHolder) SourceStake(source common.Address) *big.Int {
+	for _, stake := range sh.Stakes {
+		if stake.Source == source && !stake.Withdrawn {
+			return new(big.Int).Set(stake.Amount)
+		}
+	}
+	return big.NewInt(0)
+}
+
 func (sh *StakeHolder) depositStake(source common.Address, amount *big.Int) error {
 	if amount.Cmp(Zero) < 0 {
 		return fmt.Errorf("Invalid stake: %v", amount)
@@ -173,6 +188,27 @@ func (sh *StakeHolder) returnStake(source common.Address, currentHeight uint64)
 	return nil, fmt.Errorf("Cannot return, no matched stake source address found: %v", source)
 }
 
+// slashStake slashes the given fraction, in 1/10000, of each stake of the holder, including the
+// withdrawn stakes which have not been returned yet. It returns the total slashed amount.
+func (sh *StakeHolder) slashStake(basisPoint uint64) *big.Int {
+	totalSlashed := new(big.Int)
+	for _, stake := range sh.Stakes {
+		slashed := new(big.Int).Mul(stake.Amount, new(big.Int).SetUint64(basisPoint))
+		slashed.Div(slashed, big.NewInt(10000))
+		stake.Amount = new(big.Int).Sub(stake.Amount, slashed)
+		totalSlashed.Add(totalSlashed, slashed)
+	}
+	return totalSlashed
+}
+
+// BlsPubkey returns the BLS public key registered by the validator, or nil if it has none.
+func (sh *StakeHolder) BlsPubkey() *bls.PublicKey {
+	if len(sh.BlsPubkeys) == 0 {
+		return nil
+	}
+	return sh.BlsPubkeys[0]
+}
+
 func (sh *StakeHolder) String() string {
 	return fmt.Sprintf("{holder: %v, stakes :%v}", sh.Holder, sh.Stakes)
 }
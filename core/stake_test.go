@@ -5,7 +5,10 @@ import (
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 	"github.com/pandoprojects/pando/common"
+	"github.com/pandoprojects/pando/crypto/bls"
+	"github.com/pandoprojects/pando/rlp"
 )
 
 func TestStakeBasics(t *testing.T) {
@@ -146,3 +149,38 @@ func TestStakeReturn(t *testing.T) {
 	assert.Nil(returnedStake) // sourceAddr3 never deposited any stake, so cannot return
 	assert.NotNil(err)
 }
+
+func TestStakeHolderBlsPubkeyEncoding(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	type legacyStakeHolder struct {
+		Holder common.Address
+		Stakes []*Stake
+	}
+
+	holder := common.HexToAddress("0x111")
+	vcp := &ValidatorCandidatePool{}
+	require.Nil(vcp.DepositStake(holder, holder, MinValidatorStakeDeposit, 1))
+	assert.Nil(vcp.FindStakeDelegate(holder).BlsPubkey())
+
+	// Holders without BLS key are encoded as before.
+	sh := vcp.FindStakeDelegate(holder)
+	raw, err := rlp.EncodeToBytes(sh)
+	require.Nil(err)
+	legacy, err := rlp.EncodeToBytes(legacyStakeHolder{sh.Holder, sh.Stakes})
+	require.Nil(err)
+	assert.Equal(legacy, raw)
+
+	blsKey, err := bls.RandKey()
+	require.Nil(err)
+	require.Nil(vcp.SetBlsPubkey(holder, blsKey.PublicKey()))
+	assert.NotNil(vcp.SetBlsPubkey(common.HexToAddress("0x222"), blsKey.PublicKey()))
+
+	raw, err = rlp.EncodeToBytes(vcp)
+	require.Nil(err)
+	decoded := &ValidatorCandidatePool{}
+	require.Nil(rlp.DecodeBytes(raw, decoded))
+	require.NotNil(decoded.FindStakeDelegate(holder).BlsPubkey())
+	assert.True(blsKey.PublicKey().Equals(decoded.FindStakeDelegate(holder).BlsPubkey()))
+}
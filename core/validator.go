@@ -11,10 +11,14 @@ import (
 	log "github.com/sirupsen/logrus"
 
 	"github.com/pandoprojects/pando/common"
+	"github.com/pandoprojects/pando/crypto/bls"
 )
 
 var logger *log.Entry = log.WithFields(log.Fields{"prefix": "core"})
 
+// MaxValidatorCount is the maximal number of validators selected from the validator candidate pool
+const MaxValidatorCount int = 31
+
 var (
 	// ErrValidatorNotFound for ID is not found in validator set.
 	ErrValidatorNotFound = errors.New("ValidatorNotFound")
@@ -22,14 +26,15 @@ var (
 
 // Validator contains the public information of a validator.
 type Validator struct {
-	Address common.Address
-	Stake   *big.Int
+	Address   common.Address
+	Stake     *big.Int
+	BlsPubkey *bls.PublicKey `json:"-"` // Verifies the aggregated votes of the validator, nil if it has no BLS key.
 }
 
 // NewValidator creates a new validator instance.
 func NewValidator(addressStr string, stake *big.Int) Validator {
 	address := common.HexToAddress(addressStr)
-	return Validator{address, stake}
+	return Validator{Address: address, Stake: stake}
 }
 
 // ID returns the ID of the validator, which is the string representation of its address.
@@ -45,6 +50,12 @@ func (v Validator) Equals(x Validator) bool {
 	if v.Stake.Cmp(x.Stake) != 0 {
 		return false
 	}
+	if (v.BlsPubkey == nil) != (x.BlsPubkey == nil) {
+		return false
+	}
+	if v.BlsPubkey != nil && !v.BlsPubkey.Equals(x.BlsPubkey) {
+		return false
+	}
 	return true
 }
 
@@ -120,6 +131,16 @@ func (s *ValidatorSet) GetValidator(id common.Address) (Validator, error) {
 	return Validator{}, ErrValidatorNotFound
 }
 
+// Index returns the index of a validator in the set. Returns -1 if not found.
+func (s *ValidatorSet) Index(id common.Address) int {
+	for i, v := range s.validators {
+		if v.ID() == id {
+			return i
+		}
+	}
+	return -1
+}
+
 // AddValidator adds a validator to the validator set.
 func (s *ValidatorSet) AddValidator(validator Validator) {
 	s.validators = append(s.validators, validator)
@@ -137,9 +158,18 @@ func (s *ValidatorSet) TotalStake() *big.Int {
 
 // HasMajorityVotes checks whether a vote set has reach majority.
 func (s *ValidatorSet) HasMajorityVotes(votes []Vote) bool {
+	voters := make([]common.Address, len(votes))
+	for i, vote := range votes {
+		voters[i] = vote.ID
+	}
+	return s.HasMajorityVoters(voters)
+}
+
+// HasMajorityVoters checks whether the given voters hold a majority of the stake.
+func (s *ValidatorSet) HasMajorityVoters(voters []common.Address) bool {
 	votedStake := new(big.Int).SetUint64(0)
-	for _, vote := range votes {
-		validator, err := s.GetValidator(vote.ID)
+	for _, voter := range voters {
+		validator, err := s.GetValidator(voter)
 		if err == nil {
 			votedStake = new(big.Int).Add(votedStake, validator.Stake)
 		}
@@ -185,6 +215,23 @@ type ValidatorCandidatePool struct {
 	SortedCandidates []*StakeHolder
 }
 
+// Copy returns a deep copy of the pool, which can be modified without affecting the original.
+func (vcp *ValidatorCandidatePool) Copy() *ValidatorCandidatePool {
+	copied := &ValidatorCandidatePool{SortedCandidates: []*StakeHolder{}}
+	for _, candidate := range vcp.SortedCandidates {
+		stakes := []*Stake{}
+		for _, stake := range candidate.Stakes {
+			stakeCopy := *stake
+			stakeCopy.Amount = new(big.Int).Set(stake.Amount)
+			stakes = append(stakes, &stakeCopy)
+		}
+		holder := NewStakeHolder(candidate.Holder, stakes)
+		holder.BlsPubkeys = append([]*bls.PublicKey{}, candidate.BlsPubkeys...)
+		copied.SortedCandidates = append(copied.SortedCandidates, holder)
+	}
+	return copied
+}
+
 func (vcp *ValidatorCandidatePool) FindStakeDelegate(delegateAddr common.Address) *StakeHolder {
 	for _, candidate := range vcp.SortedCandidates {
 		if candidate.Holder == delegateAddr {
@@ -202,11 +249,37 @@ func (vcp *ValidatorCandidatePool) GetTopStakeHolders(maxNumStakeHolders int) []
 	return vcp.SortedCandidates[:n]
 }
 
+// SelectTopStakeHoldersAsValidators returns the validator set made of the top stake holders of the
+// candidate pool, weighted by their stakes. The stake holders without stake are skipped.
+func SelectTopStakeHoldersAsValidators(vcp *ValidatorCandidatePool) *ValidatorSet {
+	topStakeHolders := vcp.GetTopStakeHolders(MaxValidatorCount)
+
+	valSet := NewValidatorSet()
+	for _, stakeHolder := range topStakeHolders {
+		valAddr := stakeHolder.Holder.Hex()
+		valStake := stakeHolder.TotalStake()
+		if valStake.Cmp(Zero) == 0 {
+			continue
+		}
+		validator := NewValidator(valAddr, valStake)
+		validator.BlsPubkey = stakeHolder.BlsPubkey()
+		valSet.AddValidator(validator)
+	}
+
+	return valSet
+}
+
 func (vcp *ValidatorCandidatePool) DepositStake(source common.Address, holder common.Address, amount *big.Int, blockHeight uint64) (err error) {
 	minValidatorStake := MinValidatorStakeDeposit
 	if blockHeight >= common.HeightZytaStakeChangedTo10000K {
 		minValidatorStake = MinValidatorStakeDeposit
 	}
+	return vcp.DepositStakeWithMinimum(source, holder, amount, minValidatorStake)
+}
+
+// DepositStakeWithMinimum deposits the stake to the holder, which must be at least the given minimum,
+// e.g. the threshold set by the governance parameters.
+func (vcp *ValidatorCandidatePool) DepositStakeWithMinimum(source common.Address, holder common.Address, amount *big.Int, minValidatorStake *big.Int) (err error) {
 	if amount.Cmp(minValidatorStake) < 0 {
 		return fmt.Errorf("insufficient stake: %v", amount)
 	}
@@ -233,6 +306,16 @@ func (vcp *ValidatorCandidatePool) DepositStake(source common.Address, holder co
 	return nil
 }
 
+// SetBlsPubkey registers the BLS public key of a validator, which replaces its previous key if any.
+func (vcp *ValidatorCandidatePool) SetBlsPubkey(holder common.Address, pubkey *bls.PublicKey) error {
+	candidate := vcp.FindStakeDelegate(holder)
+	if candidate == nil {
+		return fmt.Errorf("No matched stake holder address found: %v", holder)
+	}
+	candidate.BlsPubkeys = []*bls.PublicKey{pubkey}
+	return nil
+}
+
 func (vcp *ValidatorCandidatePool) WithdrawStake(source common.Address, holder common.Address, currentHeight uint64) error {
 	matchedHolderFound := false
 	for _, candidate := range vcp.SortedCandidates {
@@ -287,6 +370,23 @@ func (vcp *ValidatorCandidatePool) ReturnStakes(currentHeight uint64) []*Stake {
 	return returnedStakes
 }
 
+// SlashStake slashes the given fraction, in 1/10000, of the stakes of the holder, and returns
+// the slashed amount. The slashed stake is burned.
+func (vcp *ValidatorCandidatePool) SlashStake(holder common.Address, basisPoint uint64) (*big.Int, error) {
+	if basisPoint > 10000 {
+		return nil, fmt.Errorf("Invalid slash basis point: %v", basisPoint)
+	}
+	candidate := vcp.FindStakeDelegate(holder)
+	if candidate == nil {
+		return nil, fmt.Errorf("No matched stake holder address found: %v", holder)
+	}
+	slashed := candidate.slashStake(basisPoint)
+
+	vcp.sortCandidates()
+
+	return slashed, nil
+}
+
 func (vcp *ValidatorCandidatePool) sortCandidates() {
 	sort.Slice(vcp.SortedCandidates[:], func(i, j int) bool { // descending order in (totalStake, holderAddress)
 		stakeCmp := vcp.SortedCandidates[i].TotalStake().Cmp(vcp.SortedCandidates[j].TotalStake())
@@ -358,6 +358,40 @@ func TestValidatorSetUniqueSortedOrder(t *testing.T) {
 	assert.Equal(vcpJson3, vcpJson4)
 }
 
+func TestValidatorCandidatePoolSlashStake(t *testing.T) {
+	assert := assert.New(t)
+
+	sourceAddr1 := common.HexToAddress("0x111")
+	sourceAddr2 := common.HexToAddress("0x222")
+	holderAddr1 := common.HexToAddress("0xf01")
+	holderAddr2 := common.HexToAddress("0xf02")
+
+	vcp := &ValidatorCandidatePool{}
+	assert.Nil(vcp.DepositStake(sourceAddr1, holderAddr1, new(big.Int).Mul(big.NewInt(10), MinValidatorStakeDeposit), 1))
+	assert.Nil(vcp.DepositStake(sourceAddr2, holderAddr1, new(big.Int).Mul(big.NewInt(20), MinValidatorStakeDeposit), 1))
+	assert.Nil(vcp.DepositStake(sourceAddr1, holderAddr2, new(big.Int).Mul(big.NewInt(25), MinValidatorStakeDeposit), 1))
+	assert.Equal(holderAddr1, vcp.SortedCandidates[0].Holder)
+
+	// The withdrawn stakes which have not been returned yet are slashed as well.
+	assert.Nil(vcp.WithdrawStake(sourceAddr2, holderAddr1, 10))
+
+	slashed, err := vcp.SlashStake(holderAddr1, 1000)
+	assert.Nil(err)
+	assert.Equal(new(big.Int).Mul(big.NewInt(3), MinValidatorStakeDeposit), slashed)
+
+	holder1 := vcp.FindStakeDelegate(holderAddr1)
+	assert.Equal(new(big.Int).Mul(big.NewInt(9), MinValidatorStakeDeposit), holder1.Stakes[0].Amount)
+	assert.Equal(new(big.Int).Mul(big.NewInt(18), MinValidatorStakeDeposit), holder1.Stakes[1].Amount)
+
+	// The candidates are sorted again after the slashing.
+	assert.Equal(holderAddr2, vcp.SortedCandidates[0].Holder)
+
+	_, err = vcp.SlashStake(common.HexToAddress("0xf03"), 1000)
+	assert.NotNil(err)
+	_, err = vcp.SlashStake(holderAddr1, 10001)
+	assert.NotNil(err)
+}
+
 // ------------------------- Utilities -------------------------
 
 func checkAndPrintAllSortedCandidates(t *testing.T, assert *assert.Assertions, vcp *ValidatorCandidatePool) {
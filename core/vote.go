@@ -11,6 +11,7 @@ import (
 	"github.com/pandoprojects/pando/common"
 	"github.com/pandoprojects/pando/common/result"
 	"github.com/pandoprojects/pando/crypto"
+	"github.com/pandoprojects/pando/crypto/bls"
 	"github.com/pandoprojects/pando/rlp"
 )
 
@@ -25,10 +26,84 @@ func (p Proposal) String() string {
 	return fmt.Sprintf("Proposal{block: %v, proposer: %v, votes: %v}", p.Block, p.ProposerID, p.Votes)
 }
 
-// CommitCertificate represents a commit made a majority of validators.
+// CommitCertificate represents a commit made a majority of validators. After the BLS commit
+// certificate fork, the votes of the validators with a BLS key are aggregated in AggregatedVotes,
+// and Votes only holds the votes of the others.
 type CommitCertificate struct {
-	Votes     *VoteSet `rlp:"nil"`
-	BlockHash common.Hash
+	Votes           *VoteSet `rlp:"nil"`
+	BlockHash       common.Hash
+	AggregatedVotes *AggregatedValidatorVotes `json:",omitempty"`
+}
+
+// commitCertificateRLP is the RLP encoding of a commit certificate. The aggregated votes are a
+// tail list, so that the certificates without them are encoded as before the fork.
+type commitCertificateRLP struct {
+	Votes           *VoteSet `rlp:"nil"`
+	BlockHash       common.Hash
+	AggregatedVotes []*AggregatedValidatorVotes `rlp:"tail"`
+}
+
+// NewCommitCertificate creates the commit certificate of a block from the votes for it. If
+// aggregate is true, the BLS signatures of the validators are aggregated, and only the votes
+// without a valid BLS signature are kept individually.
+func NewCommitCertificate(block common.Hash, votes *VoteSet, validators *ValidatorSet, aggregate bool) CommitCertificate {
+	cc := CommitCertificate{
+		BlockHash: block,
+		Votes:     votes,
+	}
+	if !aggregate {
+		return cc
+	}
+
+	cc.Votes = NewVoteSet()
+	aggregated := NewAggregatedValidatorVotes(block, validators)
+	for _, vote := range votes.Votes() {
+		if !aggregated.AddVote(vote, validators) {
+			cc.Votes.AddVote(vote)
+		}
+	}
+	if aggregated.Size() > 0 {
+		cc.AggregatedVotes = aggregated
+	}
+	return cc
+}
+
+var _ rlp.Encoder = CommitCertificate{}
+
+// EncodeRLP implements RLP Encoder interface.
+func (cc CommitCertificate) EncodeRLP(w io.Writer) error {
+	enc := commitCertificateRLP{
+		Votes:     cc.Votes,
+		BlockHash: cc.BlockHash,
+	}
+	if cc.AggregatedVotes != nil {
+		enc.AggregatedVotes = []*AggregatedValidatorVotes{cc.AggregatedVotes}
+	}
+	return rlp.Encode(w, enc)
+}
+
+var _ rlp.Decoder = (*CommitCertificate)(nil)
+
+// DecodeRLP implements RLP Decoder interface.
+func (cc *CommitCertificate) DecodeRLP(stream *rlp.Stream) error {
+	dec := commitCertificateRLP{}
+	if err := stream.Decode(&dec); err != nil {
+		return err
+	}
+	if len(dec.AggregatedVotes) > 1 {
+		return fmt.Errorf("Commit certificate has %v aggregated votes", len(dec.AggregatedVotes))
+	}
+	// Without the votes aggregated, the vote set may be empty, which is decoded as nil.
+	cc.Votes = dec.Votes
+	if cc.Votes == nil {
+		cc.Votes = NewVoteSet()
+	}
+	cc.BlockHash = dec.BlockHash
+	cc.AggregatedVotes = nil
+	if len(dec.AggregatedVotes) == 1 {
+		cc.AggregatedVotes = dec.AggregatedVotes[0]
+	}
+	return nil
 }
 
 // Copy creates a copy of this commit certificate.
@@ -39,43 +114,245 @@ func (cc CommitCertificate) Copy() CommitCertificate {
 	if cc.Votes != nil {
 		ret.Votes = cc.Votes.Copy()
 	}
+	if cc.AggregatedVotes != nil {
+		ret.AggregatedVotes = cc.AggregatedVotes.Copy()
+	}
 	return ret
 }
 
 func (cc CommitCertificate) String() string {
-	return fmt.Sprintf("CC{BlockHash: %v, Votes: %v}", cc.BlockHash.Hex(), cc.Votes)
+	return fmt.Sprintf("CC{BlockHash: %v, Votes: %v, AggregatedVotes: %v}", cc.BlockHash.Hex(), cc.Votes, cc.AggregatedVotes)
 }
 
 // IsValid checks if a CommitCertificate is valid.
 func (cc CommitCertificate) IsValid(validators *ValidatorSet) bool {
-	if cc.Votes == nil || cc.Votes.IsEmpty() {
+	voters := []common.Address{}
+	signers := make(map[common.Address]bool)
+	if cc.AggregatedVotes != nil {
+		if cc.AggregatedVotes.Block != cc.BlockHash {
+			return false
+		}
+		if cc.AggregatedVotes.Validate(validators).IsError() {
+			return false
+		}
+		for _, signer := range cc.AggregatedVotes.SignedValidators(validators) {
+			voters = append(voters, signer.ID())
+			signers[signer.ID()] = true
+		}
+	}
+
+	if cc.Votes != nil {
+		filtered := cc.Votes.UniqueVoter()
+		if filtered.Size() != cc.Votes.Size() {
+			return false
+		}
+		for _, vote := range filtered.Votes() {
+			if vote.Block != cc.BlockHash {
+				return false
+			}
+			if signers[vote.ID] {
+				return false
+			}
+			if vote.Validate().IsError() {
+				return false
+			}
+			voters = append(voters, vote.ID)
+		}
+	}
+
+	if len(voters) == 0 || len(voters) > validators.Size() {
+		return false
+	}
+	return validators.HasMajorityVoters(voters)
+}
+
+// AggregatedValidatorVotes represents the votes of validators on a block, with their BLS
+// signatures aggregated.
+type AggregatedValidatorVotes struct {
+	Block     common.Hash    // Hash of the block.
+	Signers   common.Bytes   // Bitmap of the signers, in the order of the validator set.
+	Signature *bls.Signature // Aggregated signature.
+}
+
+// NewAggregatedValidatorVotes creates an aggregation without signer for the given block.
+func NewAggregatedValidatorVotes(block common.Hash, validators *ValidatorSet) *AggregatedValidatorVotes {
+	return &AggregatedValidatorVotes{
+		Block:     block,
+		Signers:   make(common.Bytes, (validators.Size()+7)/8),
+		Signature: bls.NewAggregateSignature(),
+	}
+}
+
+func (a *AggregatedValidatorVotes) String() string {
+	if a == nil {
+		return "nil"
+	}
+	return fmt.Sprintf("AggregatedValidatorVotes{Block: %s, Signers: %v}", a.Block.Hex(), a.Signers)
+}
+
+// SignBytes returns the bytes signed by the validators.
+func (a *AggregatedValidatorVotes) SignBytes() common.Bytes {
+	tmp := &AggregatedValidatorVotes{
+		Block: a.Block,
+	}
+	b, _ := rlp.EncodeToBytes(tmp)
+	return b
+}
+
+// HasSigned returns whether the validator at the given index of the validator set has signed.
+func (a *AggregatedValidatorVotes) HasSigned(idx int) bool {
+	if idx < 0 || idx/8 >= len(a.Signers) {
 		return false
 	}
-	filtered := cc.Votes.UniqueVoter()
-	if filtered.Size() != cc.Votes.Size() {
+	return a.Signers[idx/8]&(1<<uint(idx%8)) != 0
+}
+
+// AddVote adds the BLS signature of a vote. Returns false if the vote has no valid BLS signature,
+// or if the voter has already signed.
+func (a *AggregatedValidatorVotes) AddVote(vote Vote, validators *ValidatorSet) bool {
+	if vote.Block != a.Block || vote.BLSSignature.IsEmpty() {
 		return false
 	}
-	if filtered.Size() > validators.Size() {
+	idx := validators.Index(vote.ID)
+	if idx < 0 || a.HasSigned(idx) {
 		return false
 	}
-	for _, vote := range filtered.Votes() {
-		if vote.Block != cc.BlockHash {
-			return false
+	pubkey := validators.Validators()[idx].BlsPubkey
+	if pubkey.IsEmpty() || !vote.BLSSignature.Verify(vote.BLSSignBytes(), pubkey) {
+		return false
+	}
+
+	a.Signers[idx/8] |= 1 << uint(idx%8)
+	a.Signature.Aggregate(vote.BLSSignature)
+	return true
+}
+
+// Size returns the number of signers.
+func (a *AggregatedValidatorVotes) Size() int {
+	ret := 0
+	for _, b := range a.Signers {
+		for ; b != 0; b &= b - 1 {
+			ret++
 		}
-		if vote.Validate().IsError() {
-			return false
+	}
+	return ret
+}
+
+// SignedValidators returns the validators which have signed.
+func (a *AggregatedValidatorVotes) SignedValidators(validators *ValidatorSet) []Validator {
+	ret := []Validator{}
+	for i, v := range validators.Validators() {
+		if a.HasSigned(i) {
+			ret = append(ret, v)
+		}
+	}
+	return ret
+}
+
+// Validate verifies the aggregated signature against the keys of the signers.
+func (a *AggregatedValidatorVotes) Validate(validators *ValidatorSet) result.Result {
+	if len(a.Signers) != (validators.Size()+7)/8 {
+		return result.Error("signer bitmap size %d does not match validator set size %d", len(a.Signers), validators.Size())
+	}
+	for i := validators.Size(); i < 8*len(a.Signers); i++ {
+		if a.HasSigned(i) {
+			return result.Error("signer %d is out of the validator set", i)
 		}
 	}
-	return validators.HasMajority(filtered)
+	if a.Signature.IsEmpty() {
+		return result.Error("signature cannot be nil")
+	}
+	signers := a.SignedValidators(validators)
+	if len(signers) == 0 {
+		return result.Error("no signer")
+	}
+	aggPubkey := bls.NewAggregatePubkey()
+	for _, signer := range signers {
+		if signer.BlsPubkey.IsEmpty() {
+			return result.Error("signer %s has no BLS key", signer.ID().Hex())
+		}
+		aggPubkey.Aggregate(signer.BlsPubkey)
+	}
+	if !a.Signature.Verify(a.SignBytes(), aggPubkey) {
+		return result.Error("signature verification failed")
+	}
+	return result.OK
+}
+
+// Copy clones the aggregated votes.
+func (a *AggregatedValidatorVotes) Copy() *AggregatedValidatorVotes {
+	clone := &AggregatedValidatorVotes{
+		Block: a.Block,
+	}
+	if a.Signers != nil {
+		clone.Signers = make(common.Bytes, len(a.Signers))
+		copy(clone.Signers, a.Signers)
+	}
+	if a.Signature != nil {
+		clone.Signature = a.Signature.Copy()
+	}
+	return clone
 }
 
 // Vote represents a vote on a block by a validaor.
 type Vote struct {
-	Block     common.Hash    // Hash of the tip as seen by the voter.
-	Height    uint64         // Height of the tip
-	Epoch     uint64         // Voter's current epoch. It doesn't need to equal the epoch in the block above.
-	ID        common.Address // Voter's address.
-	Signature *crypto.Signature
+	Block        common.Hash    // Hash of the tip as seen by the voter.
+	Height       uint64         // Height of the tip
+	Epoch        uint64         // Voter's current epoch. It doesn't need to equal the epoch in the block above.
+	ID           common.Address // Voter's address.
+	Signature    *crypto.Signature
+	BLSSignature *bls.Signature `json:",omitempty"` // Signature of BLSSignBytes(), set after the BLS commit certificate fork.
+}
+
+// voteRLP is the RLP encoding of a vote. The BLS signature is a tail list, so that the votes
+// without it are encoded as before the fork.
+type voteRLP struct {
+	Block        common.Hash
+	Height       uint64
+	Epoch        uint64
+	ID           common.Address
+	Signature    *crypto.Signature
+	BLSSignature []*bls.Signature `rlp:"tail"`
+}
+
+var _ rlp.Encoder = Vote{}
+
+// EncodeRLP implements RLP Encoder interface.
+func (v Vote) EncodeRLP(w io.Writer) error {
+	enc := voteRLP{
+		Block:     v.Block,
+		Height:    v.Height,
+		Epoch:     v.Epoch,
+		ID:        v.ID,
+		Signature: v.Signature,
+	}
+	if v.BLSSignature != nil {
+		enc.BLSSignature = []*bls.Signature{v.BLSSignature}
+	}
+	return rlp.Encode(w, enc)
+}
+
+var _ rlp.Decoder = (*Vote)(nil)
+
+// DecodeRLP implements RLP Decoder interface.
+func (v *Vote) DecodeRLP(stream *rlp.Stream) error {
+	dec := voteRLP{}
+	if err := stream.Decode(&dec); err != nil {
+		return err
+	}
+	if len(dec.BLSSignature) > 1 {
+		return fmt.Errorf("Vote has %v BLS signatures", len(dec.BLSSignature))
+	}
+	v.Block = dec.Block
+	v.Height = dec.Height
+	v.Epoch = dec.Epoch
+	v.ID = dec.ID
+	v.Signature = dec.Signature
+	v.BLSSignature = nil
+	if len(dec.BLSSignature) == 1 {
+		v.BLSSignature = dec.BLSSignature[0]
+	}
+	return nil
 }
 
 func (v Vote) String() string {
@@ -93,6 +370,12 @@ func (v Vote) SignBytes() common.Bytes {
 	return raw
 }
 
+// BLSSignBytes returns raw bytes to be signed with the BLS key of the voter. Unlike SignBytes(),
+// they only cover the block, so that the votes of all the validators can be aggregated.
+func (v Vote) BLSSignBytes() common.Bytes {
+	return (&AggregatedValidatorVotes{Block: v.Block}).SignBytes()
+}
+
 // Sign signs the vote using given private key.
 func (v *Vote) Sign(priv *crypto.PrivateKey) {
 	sig, err := priv.Sign(v.SignBytes())
@@ -269,10 +552,11 @@ func (s *VoteSet) UniqueVoterAndBlock() *VoteSet {
 	return ret
 }
 
-// UniqueVoter consolidate vote set by removing votes from the same voter in older epoches.
+// UniqueVoter consolidate vote set by removing votes from the same voter in older epoches. Among
+// the votes of a voter in the same epoch, the first one in the order of Votes() is kept.
 func (s *VoteSet) UniqueVoter() *VoteSet {
 	latestVotes := make(map[string]Vote)
-	for _, vote := range s.votes {
+	for _, vote := range s.Votes() {
 		key := fmt.Sprintf("%s", vote.ID)
 		if prev, ok := latestVotes[key]; ok && prev.Epoch >= vote.Epoch {
 			continue
@@ -297,9 +581,18 @@ func (s *VoteSet) FilterByValidators(validators *ValidatorSet) *VoteSet {
 	return ret
 }
 
-// VoteByID implements sort.Interface for []Vote based on Voter's ID.
+// VoteByID implements sort.Interface for []Vote based on Voter's ID. The votes of the same voter
+// are ordered by block and epoch, so that vote sets are always encoded the same way.
 type VoteByID []Vote
 
-func (a VoteByID) Len() int           { return len(a) }
-func (a VoteByID) Swap(i, j int)      { a[i], a[j] = a[j], a[i] }
-func (a VoteByID) Less(i, j int) bool { return bytes.Compare(a[i].ID.Bytes(), a[j].ID.Bytes()) < 0 }
+func (a VoteByID) Len() int      { return len(a) }
+func (a VoteByID) Swap(i, j int) { a[i], a[j] = a[j], a[i] }
+func (a VoteByID) Less(i, j int) bool {
+	if c := bytes.Compare(a[i].ID.Bytes(), a[j].ID.Bytes()); c != 0 {
+		return c < 0
+	}
+	if c := bytes.Compare(a[i].Block.Bytes(), a[j].Block.Bytes()); c != 0 {
+		return c < 0
+	}
+	return a[i].Epoch < a[j].Epoch
+}
@@ -3,11 +3,14 @@ package core
 import (
 	"bytes"
 	"math/big"
+	"sort"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 	"github.com/pandoprojects/pando/common"
 	"github.com/pandoprojects/pando/crypto"
+	"github.com/pandoprojects/pando/crypto/bls"
 	"github.com/pandoprojects/pando/rlp"
 )
 
@@ -259,3 +262,203 @@ func TestCommitCertificate(t *testing.T) {
 	cc = CommitCertificate{Votes: invalidVoteSet, BlockHash: blockHash}
 	assert.False(cc.IsValid(vs))
 }
+
+// legacyVote is the encoding of the votes before the BLS commit certificate fork.
+type legacyVote struct {
+	Block     common.Hash
+	Height    uint64
+	Epoch     uint64
+	ID        common.Address
+	Signature *crypto.Signature
+}
+
+func TestVoteBLSEncoding(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	privKey, _, _ := crypto.GenerateKeyPair()
+	vote := Vote{
+		Block:  CreateTestBlock("", "").Hash(),
+		Height: 10,
+		Epoch:  1,
+		ID:     privKey.PublicKey().Address(),
+	}
+	vote.Sign(privKey)
+
+	// Votes without BLS signature are encoded as before the fork.
+	b, err := rlp.EncodeToBytes(vote)
+	require.Nil(err)
+	legacy, err := rlp.EncodeToBytes(legacyVote{vote.Block, vote.Height, vote.Epoch, vote.ID, vote.Signature})
+	require.Nil(err)
+	assert.Equal(legacy, b)
+	decoded := Vote{}
+	require.Nil(rlp.DecodeBytes(legacy, &decoded))
+	assert.Nil(decoded.BLSSignature)
+
+	blsKey, err := bls.RandKey()
+	require.Nil(err)
+	vote.BLSSignature = blsKey.Sign(vote.BLSSignBytes())
+	b, err = rlp.EncodeToBytes(vote)
+	require.Nil(err)
+	decoded = Vote{}
+	require.Nil(rlp.DecodeBytes(b, &decoded))
+	assert.Equal(vote.Hash(), decoded.Hash())
+	assert.True(decoded.Validate().IsOK())
+	assert.True(decoded.BLSSignature.Verify(decoded.BLSSignBytes(), blsKey.PublicKey()))
+
+	// The BLS signature does not depend on the epoch, so that votes in different epochs can be
+	// aggregated.
+	vote.Epoch = 2
+	assert.NotEqual(decoded.SignBytes(), vote.SignBytes())
+	assert.Equal(decoded.BLSSignBytes(), vote.BLSSignBytes())
+}
+
+type testBLSValidator struct {
+	privKey *crypto.PrivateKey
+	blsKey  *bls.SecretKey
+}
+
+// createBLSTestValidators creates validators with the given stakes, in the order of the validator
+// set. Only the validators with hasBLSKey set have a BLS key.
+func createBLSTestValidators(require *require.Assertions, stakes []int64, hasBLSKey []bool) (*ValidatorSet, []testBLSValidator) {
+	ret := []testBLSValidator{}
+	for range stakes {
+		privKey, _, err := crypto.GenerateKeyPair()
+		require.Nil(err)
+		blsKey, err := bls.RandKey()
+		require.Nil(err)
+		ret = append(ret, testBLSValidator{privKey, blsKey})
+	}
+	sort.Slice(ret, func(i, j int) bool {
+		return bytes.Compare(ret[i].privKey.PublicKey().Address().Bytes(), ret[j].privKey.PublicKey().Address().Bytes()) < 0
+	})
+
+	vs := NewValidatorSet()
+	for i, v := range ret {
+		validator := NewValidator(v.privKey.PublicKey().Address().Hex(), big.NewInt(stakes[i]))
+		if hasBLSKey[i] {
+			validator.BlsPubkey = v.blsKey.PublicKey()
+		}
+		vs.AddValidator(validator)
+	}
+	return vs, ret
+}
+
+func createBLSTestVote(v testBLSValidator, block common.Hash, epoch uint64) Vote {
+	vote := Vote{
+		Block:  block,
+		Height: common.HeightEnableBLSCommitCertificate,
+		Epoch:  epoch,
+		ID:     v.privKey.PublicKey().Address(),
+	}
+	vote.Sign(v.privKey)
+	vote.BLSSignature = v.blsKey.Sign(vote.BLSSignBytes())
+	return vote
+}
+
+func TestAggregatedCommitCertificate(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	vs, validators := createBLSTestValidators(require, []int64{100, 100, 100, 100}, []bool{true, true, true, false})
+	block := common.HexToHash("a1")
+
+	votes := NewVoteSet()
+	for i, v := range validators {
+		votes.AddVote(createBLSTestVote(v, block, uint64(i)))
+	}
+
+	// Without aggregation, the certificate holds the votes.
+	cc := NewCommitCertificate(block, votes, vs, false)
+	assert.Nil(cc.AggregatedVotes)
+	assert.Equal(4, cc.Votes.Size())
+	assert.True(cc.IsValid(vs))
+
+	// The votes of the validators with a BLS key are aggregated, the others are kept.
+	cc = NewCommitCertificate(block, votes, vs, true)
+	require.NotNil(cc.AggregatedVotes)
+	assert.Equal(3, cc.AggregatedVotes.Size())
+	assert.Equal(1, cc.Votes.Size())
+	assert.Equal(validators[3].privKey.PublicKey().Address(), cc.Votes.Votes()[0].ID)
+	assert.True(cc.IsValid(vs))
+
+	raw, err := rlp.EncodeToBytes(cc)
+	require.Nil(err)
+	decoded := CommitCertificate{}
+	require.Nil(rlp.DecodeBytes(raw, &decoded))
+	assert.True(decoded.IsValid(vs))
+	assert.Equal(cc.AggregatedVotes.Signers, decoded.AggregatedVotes.Signers)
+
+	// The certificate is smaller than the votes.
+	rawVotes, err := rlp.EncodeToBytes(CommitCertificate{Votes: votes, BlockHash: block})
+	require.Nil(err)
+	assert.True(len(raw) < len(rawVotes))
+
+	// Votes with an invalid BLS signature are not aggregated.
+	votes = NewVoteSet()
+	for i, v := range validators {
+		vote := createBLSTestVote(v, block, 0)
+		if i == 0 {
+			vote.BLSSignature = v.blsKey.Sign(common.Bytes("other"))
+		}
+		votes.AddVote(vote)
+	}
+	cc = NewCommitCertificate(block, votes, vs, true)
+	assert.Equal(2, cc.AggregatedVotes.Size())
+	assert.Equal(2, cc.Votes.Size())
+	assert.True(cc.IsValid(vs))
+
+	// Reject aggregated votes for another block.
+	other := cc.Copy()
+	other.AggregatedVotes.Block = common.HexToHash("a2")
+	assert.False(other.IsValid(vs))
+
+	// Reject signers which are not in the aggregated signature.
+	other = cc.Copy()
+	other.AggregatedVotes.Signers[0] |= 1
+	assert.False(other.IsValid(vs))
+
+	// Reject signers out of the validator set.
+	other = cc.Copy()
+	other.AggregatedVotes.Signers[0] |= 1 << 5
+	assert.False(other.IsValid(vs))
+
+	// Reject voters both aggregated and individual.
+	other = cc.Copy()
+	other.Votes.AddVote(createBLSTestVote(validators[1], block, 0))
+	assert.False(other.IsValid(vs))
+
+	// Reject certificates without majority.
+	votes = NewVoteSet()
+	votes.AddVote(createBLSTestVote(validators[0], block, 0))
+	cc = NewCommitCertificate(block, votes, vs, true)
+	assert.Equal(1, cc.AggregatedVotes.Size())
+	assert.False(cc.IsValid(vs))
+}
+
+func TestCommitCertificateLegacyEncoding(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	type legacyCommitCertificate struct {
+		Votes     *VoteSet `rlp:"nil"`
+		BlockHash common.Hash
+	}
+
+	vs, validators := createBLSTestValidators(require, []int64{100, 100}, []bool{false, false})
+	block := common.HexToHash("a1")
+	votes := NewVoteSet()
+	for _, v := range validators {
+		vote := createBLSTestVote(v, block, 0)
+		vote.BLSSignature = nil
+		votes.AddVote(vote)
+	}
+
+	cc := NewCommitCertificate(block, votes, vs, true)
+	assert.Nil(cc.AggregatedVotes)
+	raw, err := rlp.EncodeToBytes(cc)
+	require.Nil(err)
+	legacy, err := rlp.EncodeToBytes(legacyCommitCertificate{Votes: votes, BlockHash: block})
+	require.Nil(err)
+	assert.Equal(legacy, raw)
+}
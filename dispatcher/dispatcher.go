@@ -24,6 +24,8 @@ type Dispatcher struct {
 	p2pnet  p2p.Network
 	p2plnet p2pl.Network
 
+	synchronousSend bool
+
 	// Life cycle
 	wg      *sync.WaitGroup
 	quit    chan struct{}
@@ -41,6 +43,13 @@ func NewDispatcher(p2pnet p2p.Network, p2plnet p2pl.Network) *Dispatcher {
 	}
 }
 
+// SetSynchronousSend makes the dispatcher send the messages to peers from the calling goroutine
+// instead of a new goroutine per peer, so that a simulated network receives them in a
+// deterministic order.
+func (dp *Dispatcher) SetSynchronousSend(synchronous bool) {
+	dp.synchronousSend = synchronous
+}
+
 // Start is called when the dispatcher starts
 func (dp *Dispatcher) Start(ctx context.Context) error {
 	c, cancel := context.WithCancel(ctx)
@@ -191,18 +200,23 @@ func (dp *Dispatcher) send(peerIDs []string, channelID common.ChannelIDEnum, con
 		Content:   content,
 	}
 
-	for _, peerID := range peerIDs {
-		go func(peerID string) {
-			if !reflect.ValueOf(dp.p2pnet).IsNil() {
-				ok := dp.p2pnet.Send(peerID, messageOld)
-				if !ok {
-					logger.Debugf("Failed to send message to [%v]: %v, %v", peerID, channelID, content)
-				}
-			}
-			if !reflect.ValueOf(dp.p2plnet).IsNil() {
-				dp.p2plnet.Send(peerID, message)
+	sendToPeer := func(peerID string) {
+		if !reflect.ValueOf(dp.p2pnet).IsNil() {
+			ok := dp.p2pnet.Send(peerID, messageOld)
+			if !ok {
+				logger.Debugf("Failed to send message to [%v]: %v, %v", peerID, channelID, content)
 			}
-		}(peerID)
+		}
+		if !reflect.ValueOf(dp.p2plnet).IsNil() {
+			dp.p2plnet.Send(peerID, message)
+		}
+	}
+	for _, peerID := range peerIDs {
+		if dp.synchronousSend {
+			sendToPeer(peerID)
+		} else {
+			go sendToPeer(peerID)
+		}
 	}
 }
 
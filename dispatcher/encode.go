@@ -0,0 +1,69 @@
+package dispatcher
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+
+	"github.com/pandoprojects/pando/common"
+	"github.com/pandoprojects/pando/rlp"
+)
+
+// EncodeMessage encodes the inventory and data messages into raw bytes, prefixed with their message ID
+func EncodeMessage(message interface{}) (common.Bytes, error) {
+	var buf bytes.Buffer
+	var msgID common.MessageIDEnum
+	switch message.(type) {
+	case InventoryRequest:
+		msgID = common.MessageIDInvRequest
+	case InventoryResponse:
+		msgID = common.MessageIDInvResponse
+	case DataRequest:
+		msgID = common.MessageIDDataRequest
+	case DataResponse:
+		msgID = common.MessageIDDataResponse
+	default:
+		return nil, errors.New("Unsupported message type")
+	}
+	err := rlp.Encode(&buf, msgID)
+	if err != nil {
+		return nil, err
+	}
+	err = rlp.Encode(&buf, message)
+	if err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// DecodeMessage decodes the raw message encoded by EncodeMessage
+
+func DecodeMessage(raw common.Bytes) (interface{}, error) {
+	if len(raw) <= 1 {
+		return nil, fmt.Errorf("Invalid message size")
+	}
+	var msgID common.MessageIDEnum
+	err := rlp.DecodeBytes(raw[:1], &msgID)
+	if err != nil {
+		return nil, err
+	}
+	if msgID == common.MessageIDInvRequest {
+		data := InventoryRequest{}
+		err = rlp.DecodeBytes(raw[1:], &data)
+		return data, err
+	} else if msgID == common.MessageIDInvResponse {
+		data := InventoryResponse{}
+		err = rlp.DecodeBytes(raw[1:], &data)
+		return data, err
+	} else if msgID == common.MessageIDDataRequest {
+		data := DataRequest{}
+		err = rlp.DecodeBytes(raw[1:], &data)
+		return data, err
+	} else if msgID == common.MessageIDDataResponse {
+		data := DataResponse{}
+		err = rlp.DecodeBytes(raw[1:], &data)
+		return data, err
+	} else {
+		return nil, fmt.Errorf("Unknown message ID: %v", msgID)
+	}
+}
@@ -0,0 +1,113 @@
+package ledger
+
+import (
+	"encoding/hex"
+	"sync"
+
+	"github.com/pandoprojects/pando/common"
+	"github.com/pandoprojects/pando/common/result"
+	"github.com/pandoprojects/pando/core"
+	st "github.com/pandoprojects/pando/ledger/state"
+	"github.com/pandoprojects/pando/ledger/types"
+)
+
+// maxPendingEvidences is the maximum number of equivocation evidences waiting to be included
+// in a block.
+const maxPendingEvidences = 64
+
+// evidencePool keeps the equivocation evidences detected or received by the node until the
+// corresponding offences have been slashed.
+type evidencePool struct {
+	mu        sync.Mutex
+	evidences []*core.EquivocationEvidence
+}
+
+func newEvidencePool() *evidencePool {
+	return &evidencePool{}
+}
+
+// add adds the evidence unless an evidence for the same offence is pending already.
+func (ep *evidencePool) add(evidence *core.EquivocationEvidence) bool {
+	ep.mu.Lock()
+	defer ep.mu.Unlock()
+
+	if len(ep.evidences) >= maxPendingEvidences {
+		return false
+	}
+	for _, e := range ep.evidences {
+		if e.Offender() == evidence.Offender() && e.Epoch() == evidence.Epoch() {
+			return false
+		}
+	}
+	ep.evidences = append(ep.evidences, evidence)
+	return true
+}
+
+// filter keeps only the evidences for which keep returns true, and returns them.
+func (ep *evidencePool) filter(keep func(evidence *core.EquivocationEvidence) bool) []*core.EquivocationEvidence {
+	ep.mu.Lock()
+	defer ep.mu.Unlock()
+
+	kept := []*core.EquivocationEvidence{}
+	for _, e := range ep.evidences {
+		if keep(e) {
+			kept = append(kept, e)
+		}
+	}
+	ep.evidences = kept
+	return append([]*core.EquivocationEvidence{}, kept...)
+}
+
+// AddEquivocationEvidence adds an evidence of double-signing, to be included in the blocks
+// proposed by the node.
+func (ledger *Ledger) AddEquivocationEvidence(evidence *core.EquivocationEvidence) result.Result {
+	res := evidence.Validate(ledger.state.GetChainID())
+	if res.IsError() {
+		return res
+	}
+	if !ledger.evidencePool.add(evidence) {
+		return result.Error("Evidence is already pending or the evidence pool is full")
+	}
+	logger.Infof("Added equivocation evidence: %v", evidence)
+	return result.OK
+}
+
+// addEquivocationEvidenceTxs adds the transactions reporting the pending equivocation evidences.
+// The evidences of the offences that have been slashed already, or that are too old, are dropped.
+func (ledger *Ledger) addEquivocationEvidenceTxs(view *st.StoreView, proposer *core.Validator, rawTxs *[]common.Bytes) {
+	if view.Height()+1 < ledger.executor.EquivocationSlashingHeight() {
+		return
+	}
+
+	evidences := ledger.evidencePool.filter(func(evidence *core.EquivocationEvidence) bool {
+		if evidence.Height()+core.MaxEquivocationEvidenceAge < view.Height()+1 {
+			return false
+		}
+		return view.GetEquivocationSlashHeight(evidence.Offender(), evidence.Epoch()) == 0
+	})
+
+	proposerAddress := proposer.Address
+	for _, evidence := range evidences {
+		evidenceTx := &types.EquivocationEvidenceTx{
+			Proposer: types.TxInput{
+				Address: proposerAddress,
+			},
+			Evidence: *evidence,
+		}
+
+		signature, err := ledger.signTransaction(evidenceTx)
+		if err != nil {
+			logger.Errorf("Failed to add equivocation evidence transaction: %v", err)
+			continue
+		}
+		evidenceTx.SetSignature(proposerAddress, signature)
+		evidenceTxBytes, err := types.TxToBytes(evidenceTx)
+		if err != nil {
+			logger.Errorf("Failed to add equivocation evidence transaction: %v", err)
+			continue
+		}
+
+		*rawTxs = append(*rawTxs, evidenceTxBytes)
+		logger.Debugf("Adding equivocation evidence transction: tx: %v, bytes: %v", evidenceTx, hex.EncodeToString(evidenceTxBytes))
+	}
+}
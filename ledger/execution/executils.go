@@ -254,12 +254,12 @@ func adjustByOutputs(view *state.StoreView, accounts map[string]*types.Account,
 	}
 }
 
-func sanityCheckForGasPrice(gasPrice *big.Int, blockHeight uint64) bool {
+func sanityCheckForGasPrice(view *state.StoreView, gasPrice *big.Int) bool {
 	if gasPrice == nil {
 		return false
 	}
 
-	minimumGasPrice := types.GetMinimumGasPrice(blockHeight)
+	minimumGasPrice := view.GetGovernanceParams().MinimumGasPrice
 	if gasPrice.Cmp(minimumGasPrice) < 0 {
 		return false
 	}
@@ -267,17 +267,17 @@ func sanityCheckForGasPrice(gasPrice *big.Int, blockHeight uint64) bool {
 	return true
 }
 
-func sanityCheckForFee(fee types.Coins, blockHeight uint64) (minimumFee *big.Int, success bool) {
+func sanityCheckForFee(view *state.StoreView, fee types.Coins) (minimumFee *big.Int, success bool) {
 	fee = fee.NoNil()
-	minimumFee = types.GetMinimumTransactionFeePTXWei(blockHeight)
+	minimumFee = view.GetGovernanceParams().MinimumTransactionFeePTXWei
 	success = (fee.PandoWei.Cmp(types.Zero) == 0 && fee.PTXWei.Cmp(minimumFee) >= 0)
 
 	return minimumFee, success
 }
 
-func sanityCheckForSendTxFee(fee types.Coins, numAccountsAffected uint64, blockHeight uint64) (minimumFee *big.Int, success bool) {
+func sanityCheckForSendTxFee(view *state.StoreView, fee types.Coins, numAccountsAffected uint64, blockHeight uint64) (minimumFee *big.Int, success bool) {
 	fee = fee.NoNil()
-	minimumFee = types.GetSendTxMinimumTransactionFeePTXWei(numAccountsAffected, blockHeight)
+	minimumFee = types.GetSendTxMinimumTransactionFee(view.GetGovernanceParams(), numAccountsAffected, blockHeight)
 	success = (fee.PandoWei.Cmp(types.Zero) == 0 && fee.PTXWei.Cmp(minimumFee) >= 0)
 
 	return minimumFee, success
@@ -298,9 +298,45 @@ func getBlockHeight(ledgerState *state.LedgerState) uint64 {
 }
 
 func getRegularTxGas(ledgerState *state.LedgerState) uint64 {
-	blockHeight := getBlockHeight(ledgerState)
-	if blockHeight < common.HeightJune2022FeeAdjustment {
-		return types.GasRegularTx
+	return types.GetRegularTxGas(getBlockHeight(ledgerState))
+}
+
+// getSenderDebit returns the coins the transaction takes at most from the balance of its sender, i.e.
+// the account whose sequence it consumes. For a smart contract transaction, it is the full gas limit
+// charged on top of the transferred value.
+func getSenderDebit(transaction types.Tx) types.Coins {
+	switch tx := transaction.(type) {
+	case *types.SendTx:
+		if len(tx.Inputs) == 0 {
+			return types.NewCoins(0, 0)
+		}
+		return tx.Inputs[0].Coins.NoNil() // includes the fee
+	case *types.SmartContractTx:
+		gasFee := new(big.Int).Mul(tx.GasPrice, new(big.Int).SetUint64(tx.GasLimit))
+		return tx.From.Coins.NoNil().Plus(types.Coins{PandoWei: big.NewInt(0), PTXWei: gasFee})
+	case *types.ReserveFundTx:
+		return tx.Fee.NoNil().Plus(tx.Collateral.NoNil()).Plus(tx.Source.Coins.NoNil())
+	case *types.DepositStakeTx:
+		return tx.Fee.NoNil().Plus(tx.Source.Coins.NoNil())
+	case *types.ReleaseFundTx:
+		return tx.Fee.NoNil()
+	case *types.ServicePaymentTx:
+		return tx.Fee.NoNil()
+	case *types.SplitRuleTx:
+		return tx.Fee.NoNil()
+	case *types.WithdrawStakeTx:
+		return tx.Fee.NoNil()
+	case *types.StakeRewardDistributionTx:
+		return tx.Fee.NoNil()
+	case *types.GovernanceProposalTx:
+		return tx.Fee.NoNil()
+	case *types.GovernanceVoteTx:
+		return tx.Fee.NoNil()
+	case *types.ValidatorCommissionTx:
+		return tx.Fee.NoNil()
+	case *types.ClaimStakeRewardTx:
+		return tx.Fee.NoNil()
+	default:
+		return types.NewCoins(0, 0)
 	}
-	return types.GasRegularTxJune2021
 }
@@ -9,6 +9,7 @@ import (
 	"github.com/pandoprojects/pando/core"
 	st "github.com/pandoprojects/pando/ledger/state"
 	"github.com/pandoprojects/pando/ledger/types"
+	"github.com/pandoprojects/pando/ledger/vm"
 	"github.com/pandoprojects/pando/store/database"
 )
 
@@ -45,8 +46,15 @@ type Executor struct {
 	depositStakeTxExec            *DepositStakeExecutor
 	withdrawStakeTxExec           *WithdrawStakeExecutor
 	stakeRewardDistributionTxExec *StakeRewardDistributionTxExecutor
+	equivocationEvidenceTxExec    *EquivocationEvidenceTxExecutor
+	governanceProposalTxExec      *GovernanceProposalTxExecutor
+	governanceVoteTxExec          *GovernanceVoteTxExecutor
+	validatorCommissionTxExec     *ValidatorCommissionTxExecutor
+	claimStakeRewardTxExec        *ClaimStakeRewardTxExecutor
 
 	skipSanityCheck bool
+
+	equivocationSlashingHeight uint64
 }
 
 // NewExecutor creates a new instance of Executor
@@ -68,7 +76,14 @@ func NewExecutor(db database.Database, chain *blockchain.Chain, state *st.Ledger
 		depositStakeTxExec:            NewDepositStakeExecutor(state),
 		withdrawStakeTxExec:           NewWithdrawStakeExecutor(state),
 		stakeRewardDistributionTxExec: NewStakeRewardDistributionTxExecutor(state),
+		equivocationEvidenceTxExec:    NewEquivocationEvidenceTxExecutor(chain, state, consensus, valMgr),
+		governanceProposalTxExec:      NewGovernanceProposalTxExecutor(state),
+		governanceVoteTxExec:          NewGovernanceVoteTxExecutor(state),
+		validatorCommissionTxExec:     NewValidatorCommissionTxExecutor(state),
+		claimStakeRewardTxExec:        NewClaimStakeRewardTxExecutor(state),
 		skipSanityCheck:               false,
+
+		equivocationSlashingHeight: common.HeightEnableEquivocationSlashing,
 	}
 
 	return executor
@@ -80,6 +95,26 @@ func (exec *Executor) SetSkipSanityCheck(skip bool) {
 	exec.skipSanityCheck = skip
 }
 
+// SetEquivocationSlashingHeight overrides the height enabling the equivocation slashing, e.g. in
+// the simulations. All the nodes of a network must use the same height.
+func (exec *Executor) SetEquivocationSlashingHeight(height uint64) {
+	exec.equivocationSlashingHeight = height
+}
+
+// EquivocationSlashingHeight returns the height enabling the equivocation slashing.
+func (exec *Executor) EquivocationSlashingHeight() uint64 {
+	return exec.equivocationSlashingHeight
+}
+
+// SetTracer attaches the tracer to the EVM of the smart contract transactions processed
+// afterwards. A nil tracer disables tracing.
+func (exec *Executor) SetTracer(tracer vm.Tracer) {
+	exec.smartContractTxExec.vmConfig = vm.Config{
+		Debug:  tracer != nil,
+		Tracer: tracer,
+	}
+}
+
 // ExecuteTx executes the given transaction
 func (exec *Executor) ExecuteTx(tx types.Tx) (common.Hash, result.Result) {
 	return exec.processTx(tx, core.DeliveredView)
@@ -95,6 +130,62 @@ func (exec *Executor) ScreenTx(tx types.Tx) (common.Hash, result.Result) {
 	return exec.processTx(tx, core.ScreenedView)
 }
 
+// ScreenReplacementTx checks the transaction replacing a pending transaction with the same sequence in the
+// mempool. The screened view already reflects the replaced transaction, so the transaction is checked against
+// a copy of the screened view with the sequence of the sender rewound, and the fee and the amount of the
+// replaced transaction refunded.
+func (exec *Executor) ScreenReplacementTx(tx types.Tx, replacedTx types.Tx) result.Result {
+	txInfo, res := exec.GetTxInfo(tx)
+	if res.IsError() {
+		return res
+	}
+	replacedTxInfo, res := exec.GetTxInfo(replacedTx)
+	if res.IsError() {
+		return res
+	}
+	if txInfo.Address != replacedTxInfo.Address || txInfo.Sequence != replacedTxInfo.Sequence {
+		return result.Error("Transaction does not have the sender and the sequence of the replaced transaction").
+			WithErrorCode(result.CodeInvalidSequence)
+	}
+	return exec.screenTxOutOfSequence(tx, false, getSenderDebit(replacedTx))
+}
+
+// ScreenFutureTx checks the transaction whose sequence is ahead of the next sequence of the sender, which
+// the mempool queues until the sequence gap is filled. The transaction is checked against a copy of the
+// screened view with the sequence of the sender fast-forwarded.
+func (exec *Executor) ScreenFutureTx(tx types.Tx) result.Result {
+	return exec.screenTxOutOfSequence(tx, true, types.NewCoins(0, 0))
+}
+
+func (exec *Executor) screenTxOutOfSequence(tx types.Tx, future bool, refund types.Coins) result.Result {
+	txInfo, res := exec.GetTxInfo(tx)
+	if res.IsError() {
+		return res
+	}
+
+	view, err := exec.state.Screened().Copy()
+	if err != nil {
+		return result.Error("Failed to copy the screened view: %v", err)
+	}
+	account := view.GetAccount(txInfo.Address)
+	if account == nil {
+		return result.Error("Account %v does not exist", txInfo.Address.Hex()).WithErrorCode(result.CodeInvalidSequence)
+	}
+	if future && txInfo.Sequence <= account.Sequence+1 {
+		return result.Error("Sequence %v is not ahead of the next sequence %v", txInfo.Sequence, account.Sequence+1).
+			WithErrorCode(result.CodeInvalidSequence)
+	}
+	if !future && (txInfo.Sequence == 0 || txInfo.Sequence > account.Sequence) {
+		return result.Error("No pending transaction with sequence %v to replace", txInfo.Sequence).
+			WithErrorCode(result.CodeInvalidSequence)
+	}
+	account.Sequence = txInfo.Sequence - 1
+	account.Balance = account.Balance.Plus(refund)
+	view.SetAccount(txInfo.Address, account)
+
+	return exec.sanityCheck(exec.state.GetChainID(), view, core.ScreenedView, tx)
+}
+
 // GetTxInfo extracts tx information used by mempool to sort Txs.
 func (exec *Executor) GetTxInfo(tx types.Tx) (*core.TxInfo, result.Result) {
 	txExecutor := exec.getTxExecutor(tx)
@@ -181,6 +272,18 @@ func (exec *Executor) isTxTypeSupported(view *st.StoreView, tx types.Tx) bool {
 		if blockHeight < common.HeightEnablePando2 {
 			return false
 		}
+	case *types.EquivocationEvidenceTx:
+		if blockHeight < exec.equivocationSlashingHeight {
+			return false
+		}
+	case *types.GovernanceProposalTx, *types.GovernanceVoteTx:
+		if blockHeight < common.HeightEnableGovernance {
+			return false
+		}
+	case *types.ValidatorCommissionTx, *types.ClaimStakeRewardTx:
+		if blockHeight < common.HeightEnableDelegatedStaking {
+			return false
+		}
 	default:
 		return true
 	}
@@ -215,6 +318,16 @@ func (exec *Executor) getTxExecutor(tx types.Tx) TxExecutor {
 		txExecutor = exec.depositStakeTxExec
 	case *types.StakeRewardDistributionTx:
 		txExecutor = exec.stakeRewardDistributionTxExec
+	case *types.EquivocationEvidenceTx:
+		txExecutor = exec.equivocationEvidenceTxExec
+	case *types.GovernanceProposalTx:
+		txExecutor = exec.governanceProposalTxExec
+	case *types.GovernanceVoteTx:
+		txExecutor = exec.governanceVoteTxExec
+	case *types.ValidatorCommissionTx:
+		txExecutor = exec.validatorCommissionTxExec
+	case *types.ClaimStakeRewardTx:
+		txExecutor = exec.claimStakeRewardTxExec
 	default:
 		txExecutor = nil
 	}
@@ -18,6 +18,7 @@ import (
 	st "github.com/pandoprojects/pando/ledger/state"
 
 	"github.com/pandoprojects/pando/ledger/types"
+	"github.com/pandoprojects/pando/signer"
 	"github.com/pandoprojects/pando/store/database/backend"
 )
 
@@ -29,6 +30,7 @@ type TestConsensusEngine struct {
 
 func (tce *TestConsensusEngine) ID() string                        { return tce.privKey.PublicKey().Address().Hex() }
 func (tce *TestConsensusEngine) PrivateKey() *crypto.PrivateKey    { return tce.privKey }
+func (tce *TestConsensusEngine) Signer() core.Signer               { return signer.NewLocalSigner(tce.privKey) }
 func (tce *TestConsensusEngine) GetTip(bool) *core.ExtendedBlock   { return nil }
 func (tce *TestConsensusEngine) GetEpoch() uint64                  { return 100 }
 func (tce *TestConsensusEngine) AddMessage(msg interface{})        {}
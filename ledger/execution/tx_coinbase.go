@@ -178,12 +178,34 @@ func (exec *CoinbaseTxExecutor) process(chainID string, view *st.StoreView, view
 		}
 	}
 
+	blockHeight := view.Height() + 1 // the view points to the parent of the current block
+	if blockHeight >= common.HeightEnableDelegatedStaking && common.IsCheckPointHeight(blockHeight) {
+		exec.accrueValidatorRewards(view)
+	}
+
 	view.SetCoinbaseTransactionProcessed(true)
 
 	txHash := types.TxID(chainID, tx)
 	return txHash, result.OK
 }
 
+// accrueValidatorRewards adds the checkpoint rewards of the validators to their reward pools.
+func (exec *CoinbaseTxExecutor) accrueValidatorRewards(view *st.StoreView) {
+	ledger := exec.consensus.GetLedger()
+	currentBlock := ledger.GetCurrentBlock()
+	validatorSet := getValidatorSet(ledger, exec.valMgr)
+
+	var guardianVotes *core.AggregatedVotes
+	var guardianPool *core.GuardianCandidatePool
+	if currentBlock.GuardianVotes != nil && currentBlock.Height >= common.HeightEnablePando1 {
+		guardianVotes = currentBlock.GuardianVotes
+		guardianPool, _ = RetrievePools(ledger, exec.chain, exec.db, currentBlock.Height, guardianVotes, currentBlock.RametronenterpriseVotes)
+	}
+
+	validatorRewards := CalculateValidatorRewards(view, validatorSet, guardianVotes, guardianPool)
+	accrueValidatorRewards(view, validatorSet, validatorRewards)
+}
+
 func RetrievePools(ledger core.Ledger, chain *blockchain.Chain, db database.Database, blockHeight uint64, guardianVotes *core.AggregatedVotes,
 	rametronenterpriseVotes *core.AggregatedRametronenterpriseVotes) (guardianPool *core.GuardianCandidatePool, rametronenterprisePool core.RametronenterprisePool) {
 	guardianPool = nil
@@ -256,6 +278,53 @@ func CalculateReward(ledger core.Ledger, view *st.StoreView, validatorSet *core.
 	return accountReward
 }
 
+// CalculateValidatorRewards calculates the checkpoint rewards of the validators after the delegated staking
+// is enabled. Each validator gets the share of the block reward proportional to its stake, which accrues to
+// its reward pool instead of being paid by the coinbase transaction.
+func CalculateValidatorRewards(view *st.StoreView, validatorSet *core.ValidatorSet,
+	guardianVotes *core.AggregatedVotes, guardianPool *core.GuardianCandidatePool) map[common.Address]*big.Int {
+	validatorRewards := map[common.Address]*big.Int{}
+	blockHeight := view.Height() + 1 // view points to the parent block
+	if blockHeight < common.HeightEnableDelegatedStaking || !common.IsCheckPointHeight(blockHeight) {
+		return validatorRewards
+	}
+
+	// the guardians rewarded at the checkpoint share the block reward with the validators
+	totalStake := validatorSet.TotalStake()
+	if blockHeight >= common.HeightEnablePando1 && guardianVotes != nil && guardianPool != nil {
+		totalStake.Add(totalStake, getRewardedGuardianStake(guardianVotes, guardianPool))
+	}
+	if totalStake.Cmp(big.NewInt(0)) == 0 {
+		return validatorRewards
+	}
+
+	totalReward := big.NewInt(1).Mul(ptxRewardPerBlock, big.NewInt(common.CheckpointInterval))
+	for _, v := range validatorSet.Validators() {
+		reward := new(big.Int).Mul(totalReward, v.Stake)
+		validatorRewards[v.Address] = reward.Div(reward, totalStake)
+	}
+	return validatorRewards
+}
+
+// getRewardedGuardianStake returns the total stake of the guardians rewarded for voting at the checkpoint,
+// the same way as grantValidatorAndGuardianReward() selects them.
+func getRewardedGuardianStake(guardianVotes *core.AggregatedVotes, guardianPool *core.GuardianCandidatePool) *big.Int {
+	totalStake := big.NewInt(0)
+	guardianPool = guardianPool.WithStake()
+	for i, g := range guardianPool.SortedGuardians {
+		if guardianVotes.Multiplies[i] == 0 {
+			continue
+		}
+		for _, stake := range g.Stakes {
+			if stake.Withdrawn || stake.Amount.Cmp(minGaurdianReward) < 0 {
+				continue
+			}
+			totalStake.Add(totalStake, stake.Amount)
+		}
+	}
+	return totalStake
+}
+
 func grantValidatorsWithZeroReward(validatorSet *core.ValidatorSet, accountReward *map[string]types.Coins) {
 	// Initial Mainnet release should not reward the validators until the guardians ready to deploy
 	zeroReward := types.Coins{}.NoNil()
@@ -268,6 +337,10 @@ func grantValidatorReward(ledger core.Ledger, view *st.StoreView, validatorSet *
 	if !common.IsCheckPointHeight(blockHeight) {
 		return
 	}
+	if blockHeight >= common.HeightEnableDelegatedStaking {
+		// the validator rewards accrue to the validator reward pools instead, see CalculateValidatorRewards()
+		return
+	}
 
 	totalStake := validatorSet.TotalStake()
 
@@ -350,34 +423,39 @@ func grantValidatorAndGuardianReward(ledger core.Ledger, view *st.StoreView, val
 	effectiveStakes := [][]*core.Stake{}          // For compatiblity with old sampling algorithm, stakes from the same staker are grouped together
 	stakeGroupMap := make(map[common.Address]int) // stake source address -> index of the group in the effectiveStakes slice
 
-	// TODO - Need to confirm: should we get the VCP from the current view? What if there is a stake deposit/withdraw?
-	vcp := view.GetValidatorCandidatePool()
-	for _, v := range validatorSet.Validators() {
-		validatorAddr := v.Address
-		stakeDelegate := vcp.FindStakeDelegate(validatorAddr)
-		if stakeDelegate == nil { // should not happen
-			panic(fmt.Sprintf("Failed to find stake delegate in the VCP: %v", hex.EncodeToString(validatorAddr[:])))
-		}
-
-		stakes := stakeDelegate.Stakes
-		for _, stake := range stakes {
-			if stake.Withdrawn {
-				continue
+	// After the delegated staking is enabled, the validator rewards accrue to the validator reward pools
+	// instead, see CalculateValidatorRewards()
+	delegated := blockHeight >= common.HeightEnableDelegatedStaking
+	if !delegated {
+		// TODO - Need to confirm: should we get the VCP from the current view? What if there is a stake deposit/withdraw?
+		vcp := view.GetValidatorCandidatePool()
+		for _, v := range validatorSet.Validators() {
+			validatorAddr := v.Address
+			stakeDelegate := vcp.FindStakeDelegate(validatorAddr)
+			if stakeDelegate == nil { // should not happen
+				panic(fmt.Sprintf("Failed to find stake delegate in the VCP: %v", hex.EncodeToString(validatorAddr[:])))
 			}
-			stakeSource := stake.Source
-			stakeAmount := stake.Amount
-			if stakeAmount.Cmp(minValidatorReward) < 0 {
-				continue
-			}
-			logger.Infof("grantValidatorReward :: if case :: staker val %v and stake amount %v ", stakeSource, stakeAmount)
 
-			if _, exists := stakeGroupMap[stake.Source]; !exists {
-				stakeGroupMap[stake.Source] = len(effectiveStakes)
-				effectiveStakes = append(effectiveStakes, []*core.Stake{})
+			stakes := stakeDelegate.Stakes
+			for _, stake := range stakes {
+				if stake.Withdrawn {
+					continue
+				}
+				stakeSource := stake.Source
+				stakeAmount := stake.Amount
+				if stakeAmount.Cmp(minValidatorReward) < 0 {
+					continue
+				}
+				logger.Infof("grantValidatorReward :: if case :: staker val %v and stake amount %v ", stakeSource, stakeAmount)
+
+				if _, exists := stakeGroupMap[stake.Source]; !exists {
+					stakeGroupMap[stake.Source] = len(effectiveStakes)
+					effectiveStakes = append(effectiveStakes, []*core.Stake{})
+				}
+				stake.Holder = stakeDelegate.Holder
+				idx := stakeGroupMap[stake.Source]
+				effectiveStakes[idx] = append(effectiveStakes[idx], stake)
 			}
-			stake.Holder = stakeDelegate.Holder
-			idx := stakeGroupMap[stake.Source]
-			effectiveStakes[idx] = append(effectiveStakes[idx], stake)
 		}
 	}
 
@@ -409,6 +487,16 @@ func grantValidatorAndGuardianReward(ledger core.Ledger, view *st.StoreView, val
 	}
 
 	totalReward := big.NewInt(1).Mul(ptxRewardPerBlock, big.NewInt(common.CheckpointInterval))
+	if delegated {
+		// the guardians share the reward left after the validator rewards
+		guardianStake := new(big.Int).Sub(totalStake, validatorSet.TotalStake())
+		if guardianStake.Cmp(big.NewInt(0)) == 0 {
+			return
+		}
+		totalReward = totalReward.Mul(totalReward, guardianStake)
+		totalReward = totalReward.Div(totalReward, totalStake)
+		totalStake = guardianStake
+	}
 
 	var srdsr *st.StakeRewardDistributionRuleSet
 	if blockHeight >= common.HeightEnablePando2 {
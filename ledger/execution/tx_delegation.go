@@ -0,0 +1,323 @@
+package execution
+
+import (
+	"fmt"
+	"math/big"
+
+	"github.com/pandoprojects/pando/common"
+	"github.com/pandoprojects/pando/common/result"
+	"github.com/pandoprojects/pando/core"
+	st "github.com/pandoprojects/pando/ledger/state"
+	"github.com/pandoprojects/pando/ledger/types"
+)
+
+var _ TxExecutor = (*ValidatorCommissionTxExecutor)(nil)
+var _ TxExecutor = (*ClaimStakeRewardTxExecutor)(nil)
+
+// ------------------------------- ValidatorCommission Transaction -----------------------------------
+
+// ValidatorCommissionTxExecutor implements the TxExecutor interface
+type ValidatorCommissionTxExecutor struct {
+	state *st.LedgerState
+}
+
+// NewValidatorCommissionTxExecutor creates a new instance of ValidatorCommissionTxExecutor
+func NewValidatorCommissionTxExecutor(state *st.LedgerState) *ValidatorCommissionTxExecutor {
+	return &ValidatorCommissionTxExecutor{
+		state: state,
+	}
+}
+
+func (exec *ValidatorCommissionTxExecutor) sanityCheck(chainID string, view *st.StoreView, viewSel core.ViewSelector, transaction types.Tx) result.Result {
+	blockHeight := view.Height() + 1 // the view points to the parent of the current block
+	tx := transaction.(*types.ValidatorCommissionTx)
+
+	res := tx.Holder.ValidateBasic()
+	if res.IsError() {
+		return res
+	}
+
+	holderAccount, res := getInput(view, tx.Holder)
+	if res.IsError() {
+		return res
+	}
+
+	signBytes := tx.SignBytes(chainID)
+	res = validateInputAdvanced(holderAccount, signBytes, tx.Holder, blockHeight)
+	if res.IsError() {
+		logger.Debugf(fmt.Sprintf("validateInputAdvanced failed on %v: %v", tx.Holder.Address.Hex(), res))
+		return res
+	}
+
+	if minTxFee, success := sanityCheckForFee(view, tx.Fee); !success {
+		return result.Error("Insufficient fee. Transaction fee needs to be at least %v PTXWei",
+			minTxFee).WithErrorCode(result.CodeInvalidFee)
+	}
+
+	vcp := view.GetValidatorCandidatePool()
+	if vcp == nil || vcp.FindStakeDelegate(tx.Holder.Address) == nil {
+		return result.Error("%v is not a validator candidate", tx.Holder.Address.Hex())
+	}
+
+	if tx.CommissionBasisPoint > core.MaxValidatorCommissionBasisPoint {
+		return result.Error("Commission needs to be at most %v basis points", core.MaxValidatorCommissionBasisPoint)
+	}
+
+	pool := view.GetValidatorRewardPool(tx.Holder.Address)
+	if pool != nil && pool.CommissionUpdateHeight != 0 &&
+		blockHeight < pool.CommissionUpdateHeight+core.ValidatorCommissionUpdateInterval {
+		return result.Error("Commission was updated at height %v, it can be updated again from height %v",
+			pool.CommissionUpdateHeight, pool.CommissionUpdateHeight+core.ValidatorCommissionUpdateInterval)
+	}
+
+	minimalBalance := tx.Fee
+	if !holderAccount.Balance.IsGTE(minimalBalance) {
+		return result.Error("ValidatorCommission: Holder balance is %v, but required minimal balance is %v",
+			holderAccount.Balance, minimalBalance)
+	}
+
+	return result.OK
+}
+
+func (exec *ValidatorCommissionTxExecutor) process(chainID string, view *st.StoreView, viewSel core.ViewSelector, transaction types.Tx) (common.Hash, result.Result) {
+	blockHeight := view.Height() + 1 // the view points to the parent of the current block
+	tx := transaction.(*types.ValidatorCommissionTx)
+
+	holderAccount, res := getInput(view, tx.Holder)
+	if res.IsError() {
+		return common.Hash{}, res
+	}
+
+	if !chargeFee(holderAccount, tx.Fee) {
+		return common.Hash{}, result.Error("Failed to charge transaction fee")
+	}
+
+	pool := view.GetValidatorRewardPool(tx.Holder.Address)
+	if pool == nil {
+		pool = core.NewValidatorRewardPool(tx.Holder.Address)
+	}
+	pool.CommissionBasisPoint = tx.CommissionBasisPoint
+	pool.CommissionUpdateHeight = blockHeight
+	view.SetValidatorRewardPool(pool)
+
+	holderAccount.Sequence++
+	view.SetAccount(tx.Holder.Address, holderAccount)
+
+	txHash := types.TxID(chainID, tx)
+	return txHash, result.OK
+}
+
+func (exec *ValidatorCommissionTxExecutor) getTxInfo(transaction types.Tx) *core.TxInfo {
+	tx := transaction.(*types.ValidatorCommissionTx)
+	return &core.TxInfo{
+		Address:           tx.Holder.Address,
+		Sequence:          tx.Holder.Sequence,
+		EffectiveGasPrice: exec.calculateEffectiveGasPrice(transaction),
+	}
+}
+
+func (exec *ValidatorCommissionTxExecutor) calculateEffectiveGasPrice(transaction types.Tx) *big.Int {
+	tx := transaction.(*types.ValidatorCommissionTx)
+	fee := tx.Fee
+	gas := new(big.Int).SetUint64(getRegularTxGas(exec.state))
+	effectiveGasPrice := new(big.Int).Div(fee.PTXWei, gas)
+	return effectiveGasPrice
+}
+
+// ------------------------------- ClaimStakeReward Transaction -----------------------------------
+
+// ClaimStakeRewardTxExecutor implements the TxExecutor interface
+type ClaimStakeRewardTxExecutor struct {
+	state *st.LedgerState
+}
+
+// NewClaimStakeRewardTxExecutor creates a new instance of ClaimStakeRewardTxExecutor
+func NewClaimStakeRewardTxExecutor(state *st.LedgerState) *ClaimStakeRewardTxExecutor {
+	return &ClaimStakeRewardTxExecutor{
+		state: state,
+	}
+}
+
+func (exec *ClaimStakeRewardTxExecutor) sanityCheck(chainID string, view *st.StoreView, viewSel core.ViewSelector, transaction types.Tx) result.Result {
+	blockHeight := view.Height() + 1 // the view points to the parent of the current block
+	tx := transaction.(*types.ClaimStakeRewardTx)
+
+	res := tx.Source.ValidateBasic()
+	if res.IsError() {
+		return res
+	}
+
+	sourceAccount, res := getInput(view, tx.Source)
+	if res.IsError() {
+		return res
+	}
+
+	signBytes := tx.SignBytes(chainID)
+	res = validateInputAdvanced(sourceAccount, signBytes, tx.Source, blockHeight)
+	if res.IsError() {
+		logger.Debugf(fmt.Sprintf("validateInputAdvanced failed on %v: %v", tx.Source.Address.Hex(), res))
+		return res
+	}
+
+	if minTxFee, success := sanityCheckForFee(view, tx.Fee); !success {
+		return result.Error("Insufficient fee. Transaction fee needs to be at least %v PTXWei",
+			minTxFee).WithErrorCode(result.CodeInvalidFee)
+	}
+
+	reward, commission := GetPendingStakeReward(view, tx.Source.Address, tx.Holder)
+	if reward.Sign() == 0 && commission.Sign() == 0 {
+		return result.Error("No stake reward of %v delegated to %v to claim", tx.Source.Address.Hex(), tx.Holder.Hex())
+	}
+
+	minimalBalance := tx.Fee
+	if !sourceAccount.Balance.IsGTE(minimalBalance) {
+		return result.Error("ClaimStakeReward: Source balance is %v, but required minimal balance is %v",
+			sourceAccount.Balance, minimalBalance)
+	}
+
+	return result.OK
+}
+
+func (exec *ClaimStakeRewardTxExecutor) process(chainID string, view *st.StoreView, viewSel core.ViewSelector, transaction types.Tx) (common.Hash, result.Result) {
+	tx := transaction.(*types.ClaimStakeRewardTx)
+
+	sourceAccount, res := getInput(view, tx.Source)
+	if res.IsError() {
+		return common.Hash{}, res
+	}
+
+	if !chargeFee(sourceAccount, tx.Fee) {
+		return common.Hash{}, result.Error("Failed to charge transaction fee")
+	}
+
+	claimed := big.NewInt(0)
+	vcp := view.GetValidatorCandidatePool()
+	if reward := settleDelegatorReward(view, vcp, tx.Source.Address, tx.Holder); reward != nil {
+		claimed.Add(claimed, reward.Unclaimed)
+		if getDelegatedStake(vcp, tx.Source.Address, tx.Holder).Sign() == 0 {
+			view.DeleteDelegatorReward(tx.Source.Address, tx.Holder) // nothing left to accrue
+		} else {
+			reward.Unclaimed = big.NewInt(0)
+			view.SetDelegatorReward(reward)
+		}
+	}
+	if tx.Source.Address == tx.Holder {
+		if pool := view.GetValidatorRewardPool(tx.Holder); pool != nil {
+			claimed.Add(claimed, pool.Commission)
+			pool.Commission = big.NewInt(0)
+			view.SetValidatorRewardPool(pool)
+		}
+	}
+
+	sourceAccount.Balance = sourceAccount.Balance.Plus(types.Coins{
+		PandoWei: big.NewInt(0),
+		PTXWei:   claimed,
+	})
+	sourceAccount.Sequence++
+	view.SetAccount(tx.Source.Address, sourceAccount)
+
+	logger.Infof("Stake reward claimed, source: %v, holder: %v, amount: %v", tx.Source.Address.Hex(), tx.Holder.Hex(), claimed)
+
+	txHash := types.TxID(chainID, tx)
+	return txHash, result.OK
+}
+
+func (exec *ClaimStakeRewardTxExecutor) getTxInfo(transaction types.Tx) *core.TxInfo {
+	tx := transaction.(*types.ClaimStakeRewardTx)
+	return &core.TxInfo{
+		Address:           tx.Source.Address,
+		Sequence:          tx.Source.Sequence,
+		EffectiveGasPrice: exec.calculateEffectiveGasPrice(transaction),
+	}
+}
+
+func (exec *ClaimStakeRewardTxExecutor) calculateEffectiveGasPrice(transaction types.Tx) *big.Int {
+	tx := transaction.(*types.ClaimStakeRewardTx)
+	fee := tx.Fee
+	gas := new(big.Int).SetUint64(getRegularTxGas(exec.state))
+	effectiveGasPrice := new(big.Int).Div(fee.PTXWei, gas)
+	return effectiveGasPrice
+}
+
+// ------------------------------- Reward accrual -----------------------------------
+
+// GetPendingStakeReward returns the rewards of the stake of the source delegated to the holder which
+// can be claimed, and the unclaimed commission if the source is the holder itself.
+func GetPendingStakeReward(view *st.StoreView, source common.Address, holder common.Address) (reward *big.Int, commission *big.Int) {
+	pool := view.GetValidatorRewardPool(holder)
+	delegatorReward := view.GetDelegatorReward(source, holder)
+	if delegatorReward == nil {
+		delegatorReward = core.NewDelegatorReward(source, holder)
+	}
+
+	reward = delegatorReward.Pending(pool, getDelegatedStake(view.GetValidatorCandidatePool(), source, holder))
+	commission = big.NewInt(0)
+	if source == holder && pool != nil {
+		commission = new(big.Int).Set(pool.Commission)
+	}
+	return reward, commission
+}
+
+// accrueValidatorRewards adds the rewards of the validators to their reward pools, from which their
+// delegators claim later.
+func accrueValidatorRewards(view *st.StoreView, validatorSet *core.ValidatorSet, rewards map[common.Address]*big.Int) {
+	vcp := view.GetValidatorCandidatePool()
+	for _, v := range validatorSet.Validators() {
+		reward, ok := rewards[v.Address]
+		if !ok || reward.Sign() == 0 {
+			continue
+		}
+		pool := view.GetValidatorRewardPool(v.Address)
+		if pool == nil {
+			pool = core.NewValidatorRewardPool(v.Address)
+		}
+		totalStake := big.NewInt(0)
+		if vcp != nil {
+			if candidate := vcp.FindStakeDelegate(v.Address); candidate != nil {
+				totalStake = candidate.TotalStake()
+			}
+		}
+		pool.Accrue(reward, totalStake)
+		view.SetValidatorRewardPool(pool)
+		logger.Debugf("Validator reward accrued, holder: %v, reward: %v, pool: %v", v.Address.Hex(), reward, pool)
+	}
+}
+
+// settleDelegatorReward settles the rewards of the stake of the source delegated to the holder. It
+// needs to be called before any change of the stake. It returns nil if the source has nothing to
+// settle, i.e. the holder has never been rewarded.
+func settleDelegatorReward(view *st.StoreView, vcp *core.ValidatorCandidatePool, source common.Address, holder common.Address) *core.DelegatorReward {
+	pool := view.GetValidatorRewardPool(holder)
+	reward := view.GetDelegatorReward(source, holder)
+	if reward == nil {
+		if pool == nil {
+			return nil
+		}
+		reward = core.NewDelegatorReward(source, holder)
+	}
+	reward.Settle(pool, getDelegatedStake(vcp, source, holder))
+	view.SetDelegatorReward(reward)
+	return reward
+}
+
+// settleAllDelegatorRewards settles the rewards of all the stakes delegated to the holder.
+func settleAllDelegatorRewards(view *st.StoreView, vcp *core.ValidatorCandidatePool, holder common.Address) {
+	candidate := vcp.FindStakeDelegate(holder)
+	if candidate == nil {
+		return
+	}
+	for _, stake := range candidate.Stakes {
+		settleDelegatorReward(view, vcp, stake.Source, holder)
+	}
+}
+
+func getDelegatedStake(vcp *core.ValidatorCandidatePool, source common.Address, holder common.Address) *big.Int {
+	if vcp == nil {
+		return big.NewInt(0)
+	}
+	candidate := vcp.FindStakeDelegate(holder)
+	if candidate == nil {
+		return big.NewInt(0)
+	}
+	return candidate.SourceStake(source)
+}
@@ -54,7 +54,7 @@ func (exec *DepositStakeExecutor) sanityCheck(chainID string, view *st.StoreView
 		return res
 	}
 
-	if minTxFee, success := sanityCheckForFee(tx.Fee, blockHeight); !success {
+	if minTxFee, success := sanityCheckForFee(view, tx.Fee); !success {
 		return result.Error("Insufficient fee. Transaction fee needs to be at least %v PTXWei",
 			minTxFee).WithErrorCode(result.CodeInvalidFee)
 	}
@@ -82,10 +82,7 @@ func (exec *DepositStakeExecutor) sanityCheck(chainID string, view *st.StoreView
 
 	// Minimum stake deposit requirement to avoid spamming
 	if tx.Purpose == core.StakeForValidator {
-		minValidatorStake := core.MinValidatorStakeDeposit
-		if blockHeight >= common.HeightZytaStakeChangedTo10000K {
-			minValidatorStake = core.MinValidatorStakeDeposit
-		}
+		minValidatorStake := view.GetGovernanceParams().MinValidatorStakeDeposit
 		if stake.PTXWei.Cmp(minValidatorStake) < 0 {
 			return result.Error("Insufficient amount of stake, at least %v PTXWei is required for each validator deposit", minValidatorStake).
 				WithErrorCode(result.CodeInsufficientStake)
@@ -93,10 +90,7 @@ func (exec *DepositStakeExecutor) sanityCheck(chainID string, view *st.StoreView
 	}
 
 	if tx.Purpose == core.StakeForGuardian {
-		minGuardianStake := core.MinGuardianStakeDeposit
-		if blockHeight >= common.HeightLowerMetaStakeThresholdTo10000 {
-			minGuardianStake = core.MinGuardianStakeDeposit
-		}
+		minGuardianStake := view.GetGovernanceParams().MinGuardianStakeDeposit
 		if stake.PTXWei.Cmp(minGuardianStake) < 0 {
 			return result.Error("Insufficient amount of stake, at least %v PTXWei is required for each guardian deposit", minGuardianStake).
 				WithErrorCode(result.CodeInsufficientStake)
@@ -225,10 +219,30 @@ func (exec *DepositStakeExecutor) process(chainID string, view *st.StoreView, vi
 		sourceAccount.Balance = sourceAccount.Balance.Minus(stake)
 		stakeAmount := stake.PTXWei
 		vcp := view.GetValidatorCandidatePool()
-		err := vcp.DepositStake(sourceAddress, holderAddress, stakeAmount, blockHeight)
+
+		// The validators register their BLS key with a deposit after the BLS commit certificate fork.
+		registerBLSKey := tx.BlsPubkey != nil && blockHeight >= common.HeightEnableBLSCommitCertificate
+		if registerBLSKey {
+			checkBLSRes := exec.checkBLSSummary(tx)
+			if checkBLSRes.IsError() {
+				return common.Hash{}, checkBLSRes
+			}
+		}
+
+		if blockHeight >= common.HeightEnableDelegatedStaking {
+			settleDelegatorReward(view, vcp, sourceAddress, holderAddress)
+		}
+
+		minValidatorStake := view.GetGovernanceParams().MinValidatorStakeDeposit
+		err := vcp.DepositStakeWithMinimum(sourceAddress, holderAddress, stakeAmount, minValidatorStake)
 		if err != nil {
 			return common.Hash{}, result.Error("Failed to deposit stake, err: %v", err)
 		}
+		if registerBLSKey {
+			if err := vcp.SetBlsPubkey(holderAddress, tx.BlsPubkey); err != nil {
+				return common.Hash{}, result.Error("Failed to register BLS key, err: %v", err)
+			}
+		}
 		view.UpdateValidatorCandidatePool(vcp)
 	} else if tx.Purpose == core.StakeForGuardian {
 		sourceAccount.Balance = sourceAccount.Balance.Minus(stake)
@@ -242,7 +256,8 @@ func (exec *DepositStakeExecutor) process(chainID string, view *st.StoreView, vi
 			}
 		}
 
-		err := gcp.DepositStake(sourceAddress, holderAddress, stakeAmount, tx.BlsPubkey, blockHeight)
+		minGuardianStake := view.GetGovernanceParams().MinGuardianStakeDeposit
+		err := gcp.DepositStakeWithMinimum(sourceAddress, holderAddress, stakeAmount, tx.BlsPubkey, minGuardianStake)
 		if err != nil {
 			return common.Hash{}, result.Error("Failed to deposit stake, err: %v", err)
 		}
@@ -0,0 +1,170 @@
+package execution
+
+import (
+	"math/big"
+
+	"github.com/pandoprojects/pando/blockchain"
+	"github.com/pandoprojects/pando/common"
+	"github.com/pandoprojects/pando/common/result"
+	"github.com/pandoprojects/pando/core"
+	st "github.com/pandoprojects/pando/ledger/state"
+	"github.com/pandoprojects/pando/ledger/types"
+)
+
+var _ TxExecutor = (*EquivocationEvidenceTxExecutor)(nil)
+
+// ------------------------------- EquivocationEvidence Transaction -----------------------------------
+
+// EquivocationEvidenceTxExecutor implements the TxExecutor interface
+type EquivocationEvidenceTxExecutor struct {
+	chain     *blockchain.Chain
+	state     *st.LedgerState
+	consensus core.ConsensusEngine
+	valMgr    core.ValidatorManager
+}
+
+// NewEquivocationEvidenceTxExecutor creates a new instance of EquivocationEvidenceTxExecutor
+func NewEquivocationEvidenceTxExecutor(chain *blockchain.Chain, state *st.LedgerState, consensus core.ConsensusEngine, valMgr core.ValidatorManager) *EquivocationEvidenceTxExecutor {
+	return &EquivocationEvidenceTxExecutor{
+		chain:     chain,
+		state:     state,
+		consensus: consensus,
+		valMgr:    valMgr,
+	}
+}
+
+func (exec *EquivocationEvidenceTxExecutor) sanityCheck(chainID string, view *st.StoreView, viewSel core.ViewSelector, transaction types.Tx) result.Result {
+	tx := transaction.(*types.EquivocationEvidenceTx)
+
+	validatorSet := getValidatorSet(exec.consensus.GetLedger(), exec.valMgr)
+	validatorAddresses := getValidatorAddresses(validatorSet)
+
+	// Validate proposer, basic
+	res := tx.Proposer.ValidateBasic()
+	if res.IsError() {
+		return res
+	}
+
+	// verify the proposer is one of the validators
+	res = isAValidator(tx.Proposer.Address, validatorAddresses)
+	if res.IsError() {
+		return res
+	}
+
+	proposerAccount, res := getOrMakeInput(view, tx.Proposer)
+	if res.IsError() {
+		return res
+	}
+
+	// verify the proposer's signature
+	signBytes := tx.SignBytes(chainID)
+	if !tx.Proposer.Signature.Verify(signBytes, proposerAccount.Address) {
+		return result.Error("SignBytes: %X", signBytes)
+	}
+
+	evidence := &tx.Evidence
+	res = evidence.Validate(chainID)
+	if res.IsError() {
+		return result.Error("Invalid equivocation evidence: %v", res.Message)
+	}
+
+	// The evidence is checked against the ancestors of the current block only, which all the
+	// nodes applying the block have.
+	parent, err := exec.chain.FindBlock(exec.consensus.GetLedger().GetCurrentBlock().Parent)
+	if err != nil {
+		return result.Error("Failed to find the parent block: %v", err)
+	}
+	if evidence.Height() > parent.Height || evidence.Epoch() > parent.Epoch {
+		return result.Error("Equivocation evidence from the future, evidence height: %v, epoch: %v, parent height: %v, epoch: %v",
+			evidence.Height(), evidence.Epoch(), parent.Height, parent.Epoch)
+	}
+	if evidence.Height()+core.MaxEquivocationEvidenceAge <= parent.Height {
+		return result.Error("Equivocation evidence is too old, evidence height: %v, parent height: %v",
+			evidence.Height(), parent.Height)
+	}
+
+	ancestor := exec.findAncestor(parent, evidence.Height())
+	if ancestor == nil {
+		return result.Error("Failed to find the ancestor block at height %v", evidence.Height())
+	}
+	offender := evidence.Offender()
+	if _, err := exec.valMgr.GetValidatorSet(ancestor.Hash()).GetValidator(offender); err != nil {
+		return result.Error("Validator %v was not a validator at height %v", offender.Hex(), evidence.Height())
+	}
+
+	if slashHeight := view.GetEquivocationSlashHeight(offender, evidence.Epoch()); slashHeight != 0 {
+		return result.Error("Validator %v has already been slashed at height %v for epoch %v",
+			offender.Hex(), slashHeight, evidence.Epoch())
+	}
+
+	vcp := view.GetValidatorCandidatePool()
+	if vcp == nil || vcp.FindStakeDelegate(offender) == nil {
+		return result.Error("Validator %v has no stake to slash", offender.Hex())
+	}
+
+	return result.OK
+}
+
+// findAncestor returns the ancestor of the block at the given height, or nil if it cannot be found.
+func (exec *EquivocationEvidenceTxExecutor) findAncestor(block *core.ExtendedBlock, height uint64) *core.ExtendedBlock {
+	for block.Height > height {
+		parent, err := exec.chain.FindBlock(block.Parent)
+		if err != nil {
+			return nil
+		}
+		block = parent
+	}
+	if block.Height != height {
+		return nil
+	}
+	return block
+}
+
+func (exec *EquivocationEvidenceTxExecutor) process(chainID string, view *st.StoreView, viewSel core.ViewSelector, transaction types.Tx) (common.Hash, result.Result) {
+	tx := transaction.(*types.EquivocationEvidenceTx)
+	blockHeight := view.Height() + 1 // the view points to the parent of the current block
+
+	evidence := &tx.Evidence
+	offender := evidence.Offender()
+
+	vcp := view.GetValidatorCandidatePool()
+	if vcp == nil {
+		return common.Hash{}, result.Error("Validator candidate pool does not exist")
+	}
+	if blockHeight >= common.HeightEnableDelegatedStaking {
+		settleAllDelegatorRewards(view, vcp, offender)
+	}
+	slashedAmount, err := vcp.SlashStake(offender, core.EquivocationSlashBasisPoint)
+	if err != nil {
+		return common.Hash{}, result.Error("Failed to slash stake, err: %v", err)
+	}
+	view.UpdateValidatorCandidatePool(vcp)
+	view.SetEquivocationSlashHeight(offender, evidence.Epoch(), blockHeight)
+
+	// The slashing changes the stakes of the validators, similar to the stake withdrawals.
+	hl := view.GetStakeTransactionHeightList()
+	if hl == nil {
+		hl = &types.HeightList{}
+	}
+	hl.Append(blockHeight)
+	view.UpdateStakeTransactionHeightList(hl)
+
+	logger.Infof("Slashed validator %v for equivocation in epoch %v, slashed amount: %v",
+		offender.Hex(), evidence.Epoch(), slashedAmount)
+
+	txHash := types.TxID(chainID, tx)
+	return txHash, result.OK
+}
+
+func (exec *EquivocationEvidenceTxExecutor) getTxInfo(transaction types.Tx) *core.TxInfo {
+	tx := transaction.(*types.EquivocationEvidenceTx)
+	return &core.TxInfo{
+		Address:           tx.Proposer.Address,
+		Sequence:          tx.Proposer.Sequence,
+		EffectiveGasPrice: exec.calculateEffectiveGasPrice(transaction),
+	}
+}
+
+func (exec *EquivocationEvidenceTxExecutor) calculateEffectiveGasPrice(transaction types.Tx) *big.Int {
+	return new(big.Int).SetUint64(0)
+}
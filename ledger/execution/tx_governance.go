@@ -0,0 +1,262 @@
+package execution
+
+import (
+	"fmt"
+	"math/big"
+
+	"github.com/pandoprojects/pando/common"
+	"github.com/pandoprojects/pando/common/result"
+	"github.com/pandoprojects/pando/core"
+	st "github.com/pandoprojects/pando/ledger/state"
+	"github.com/pandoprojects/pando/ledger/types"
+)
+
+var _ TxExecutor = (*GovernanceProposalTxExecutor)(nil)
+var _ TxExecutor = (*GovernanceVoteTxExecutor)(nil)
+
+// ------------------------------- GovernanceProposal Transaction -----------------------------------
+
+// GovernanceProposalTxExecutor implements the TxExecutor interface
+type GovernanceProposalTxExecutor struct {
+	state *st.LedgerState
+}
+
+// NewGovernanceProposalTxExecutor creates a new instance of GovernanceProposalTxExecutor
+func NewGovernanceProposalTxExecutor(state *st.LedgerState) *GovernanceProposalTxExecutor {
+	return &GovernanceProposalTxExecutor{
+		state: state,
+	}
+}
+
+func (exec *GovernanceProposalTxExecutor) sanityCheck(chainID string, view *st.StoreView, viewSel core.ViewSelector, transaction types.Tx) result.Result {
+	blockHeight := view.Height() + 1 // the view points to the parent of the current block
+	tx := transaction.(*types.GovernanceProposalTx)
+
+	res := tx.Proposer.ValidateBasic()
+	if res.IsError() {
+		return res
+	}
+
+	proposerAccount, res := getInput(view, tx.Proposer)
+	if res.IsError() {
+		return res
+	}
+
+	signBytes := tx.SignBytes(chainID)
+	res = validateInputAdvanced(proposerAccount, signBytes, tx.Proposer, blockHeight)
+	if res.IsError() {
+		logger.Debugf(fmt.Sprintf("validateInputAdvanced failed on %v: %v", tx.Proposer.Address.Hex(), res))
+		return res
+	}
+
+	if minTxFee, success := sanityCheckForFee(view, tx.Fee); !success {
+		return result.Error("Insufficient fee. Transaction fee needs to be at least %v PTXWei",
+			minTxFee).WithErrorCode(result.CodeInvalidFee)
+	}
+
+	res = isAValidator(tx.Proposer.Address, getValidatorAddresses(getGovernanceValidatorSet(view)))
+	if res.IsError() {
+		return result.Error("Only the validators can submit governance proposals")
+	}
+
+	if err := tx.Changes.ValidateChanges(); err != nil {
+		return result.Error("Invalid governance proposal: %v", err)
+	}
+
+	if tx.ActivationHeight < blockHeight+core.GovernanceMinActivationDelay ||
+		tx.ActivationHeight > blockHeight+core.GovernanceMaxActivationDelay {
+		return result.Error("Activation height needs to be between %v and %v",
+			blockHeight+core.GovernanceMinActivationDelay, blockHeight+core.GovernanceMaxActivationDelay)
+	}
+
+	if len(view.GetPendingGovernanceProposals()) >= core.MaxNumPendingGovernanceProposals {
+		return result.Error("Too many pending governance proposals, at most %v are allowed",
+			core.MaxNumPendingGovernanceProposals)
+	}
+
+	minimalBalance := tx.Fee
+	if !proposerAccount.Balance.IsGTE(minimalBalance) {
+		return result.Error("GovernanceProposal: Proposer balance is %v, but required minimal balance is %v",
+			proposerAccount.Balance, minimalBalance)
+	}
+
+	return result.OK
+}
+
+func (exec *GovernanceProposalTxExecutor) process(chainID string, view *st.StoreView, viewSel core.ViewSelector, transaction types.Tx) (common.Hash, result.Result) {
+	tx := transaction.(*types.GovernanceProposalTx)
+
+	proposerAccount, res := getInput(view, tx.Proposer)
+	if res.IsError() {
+		return common.Hash{}, res
+	}
+
+	if !chargeFee(proposerAccount, tx.Fee) {
+		return common.Hash{}, result.Error("Failed to charge transaction fee")
+	}
+
+	txHash := types.TxID(chainID, tx)
+	proposal := &core.GovernanceProposal{
+		ID:               txHash,
+		Proposer:         tx.Proposer.Address,
+		Changes:          tx.Changes,
+		ActivationHeight: tx.ActivationHeight,
+		Voters:           []common.Address{tx.Proposer.Address},
+	}
+	proposal.Approved = proposal.HasSupermajority(getGovernanceValidatorSet(view))
+	view.SetGovernanceProposal(proposal)
+	view.SetPendingGovernanceProposals(append(view.GetPendingGovernanceProposals(), proposal.ID))
+
+	proposerAccount.Sequence++
+	view.SetAccount(tx.Proposer.Address, proposerAccount)
+
+	logger.Infof("Governance proposal submitted: %v", proposal)
+
+	return txHash, result.OK
+}
+
+func (exec *GovernanceProposalTxExecutor) getTxInfo(transaction types.Tx) *core.TxInfo {
+	tx := transaction.(*types.GovernanceProposalTx)
+	return &core.TxInfo{
+		Address:           tx.Proposer.Address,
+		Sequence:          tx.Proposer.Sequence,
+		EffectiveGasPrice: exec.calculateEffectiveGasPrice(transaction),
+	}
+}
+
+func (exec *GovernanceProposalTxExecutor) calculateEffectiveGasPrice(transaction types.Tx) *big.Int {
+	tx := transaction.(*types.GovernanceProposalTx)
+	fee := tx.Fee
+	gas := new(big.Int).SetUint64(getRegularTxGas(exec.state))
+	effectiveGasPrice := new(big.Int).Div(fee.PTXWei, gas)
+	return effectiveGasPrice
+}
+
+// ------------------------------- GovernanceVote Transaction -----------------------------------
+
+// GovernanceVoteTxExecutor implements the TxExecutor interface
+type GovernanceVoteTxExecutor struct {
+	state *st.LedgerState
+}
+
+// NewGovernanceVoteTxExecutor creates a new instance of GovernanceVoteTxExecutor
+func NewGovernanceVoteTxExecutor(state *st.LedgerState) *GovernanceVoteTxExecutor {
+	return &GovernanceVoteTxExecutor{
+		state: state,
+	}
+}
+
+func (exec *GovernanceVoteTxExecutor) sanityCheck(chainID string, view *st.StoreView, viewSel core.ViewSelector, transaction types.Tx) result.Result {
+	blockHeight := view.Height() + 1 // the view points to the parent of the current block
+	tx := transaction.(*types.GovernanceVoteTx)
+
+	res := tx.Voter.ValidateBasic()
+	if res.IsError() {
+		return res
+	}
+
+	voterAccount, res := getInput(view, tx.Voter)
+	if res.IsError() {
+		return res
+	}
+
+	signBytes := tx.SignBytes(chainID)
+	res = validateInputAdvanced(voterAccount, signBytes, tx.Voter, blockHeight)
+	if res.IsError() {
+		logger.Debugf(fmt.Sprintf("validateInputAdvanced failed on %v: %v", tx.Voter.Address.Hex(), res))
+		return res
+	}
+
+	if minTxFee, success := sanityCheckForFee(view, tx.Fee); !success {
+		return result.Error("Insufficient fee. Transaction fee needs to be at least %v PTXWei",
+			minTxFee).WithErrorCode(result.CodeInvalidFee)
+	}
+
+	res = isAValidator(tx.Voter.Address, getValidatorAddresses(getGovernanceValidatorSet(view)))
+	if res.IsError() {
+		return result.Error("Only the validators can vote for governance proposals")
+	}
+
+	proposal := view.GetGovernanceProposal(tx.ProposalID)
+	if proposal == nil {
+		return result.Error("Governance proposal %v not found", tx.ProposalID.Hex())
+	}
+	if proposal.Approved {
+		return result.Error("Governance proposal %v has already been approved", tx.ProposalID.Hex())
+	}
+	if blockHeight >= proposal.ActivationHeight {
+		return result.Error("Governance proposal %v has expired at height %v", tx.ProposalID.Hex(), proposal.ActivationHeight)
+	}
+	if proposal.HasVoted(tx.Voter.Address) {
+		return result.Error("Validator %v has already voted for governance proposal %v",
+			tx.Voter.Address.Hex(), tx.ProposalID.Hex())
+	}
+
+	minimalBalance := tx.Fee
+	if !voterAccount.Balance.IsGTE(minimalBalance) {
+		return result.Error("GovernanceVote: Voter balance is %v, but required minimal balance is %v",
+			voterAccount.Balance, minimalBalance)
+	}
+
+	return result.OK
+}
+
+func (exec *GovernanceVoteTxExecutor) process(chainID string, view *st.StoreView, viewSel core.ViewSelector, transaction types.Tx) (common.Hash, result.Result) {
+	tx := transaction.(*types.GovernanceVoteTx)
+
+	voterAccount, res := getInput(view, tx.Voter)
+	if res.IsError() {
+		return common.Hash{}, res
+	}
+
+	proposal := view.GetGovernanceProposal(tx.ProposalID)
+	if proposal == nil {
+		return common.Hash{}, result.Error("Governance proposal %v not found", tx.ProposalID.Hex())
+	}
+
+	if !chargeFee(voterAccount, tx.Fee) {
+		return common.Hash{}, result.Error("Failed to charge transaction fee")
+	}
+
+	if !proposal.HasVoted(tx.Voter.Address) {
+		proposal.Voters = append(proposal.Voters, tx.Voter.Address)
+	}
+	if !proposal.Approved && proposal.HasSupermajority(getGovernanceValidatorSet(view)) {
+		proposal.Approved = true
+		logger.Infof("Governance proposal approved: %v", proposal)
+	}
+	view.SetGovernanceProposal(proposal)
+
+	voterAccount.Sequence++
+	view.SetAccount(tx.Voter.Address, voterAccount)
+
+	txHash := types.TxID(chainID, tx)
+	return txHash, result.OK
+}
+
+func (exec *GovernanceVoteTxExecutor) getTxInfo(transaction types.Tx) *core.TxInfo {
+	tx := transaction.(*types.GovernanceVoteTx)
+	return &core.TxInfo{
+		Address:           tx.Voter.Address,
+		Sequence:          tx.Voter.Sequence,
+		EffectiveGasPrice: exec.calculateEffectiveGasPrice(transaction),
+	}
+}
+
+func (exec *GovernanceVoteTxExecutor) calculateEffectiveGasPrice(transaction types.Tx) *big.Int {
+	tx := transaction.(*types.GovernanceVoteTx)
+	fee := tx.Fee
+	gas := new(big.Int).SetUint64(getRegularTxGas(exec.state))
+	effectiveGasPrice := new(big.Int).Div(fee.PTXWei, gas)
+	return effectiveGasPrice
+}
+
+// getGovernanceValidatorSet returns the validators that vote for the governance proposals, i.e. the
+// top stake holders of the validator candidate pool of the view, weighted by their stakes.
+func getGovernanceValidatorSet(view *st.StoreView) *core.ValidatorSet {
+	vcp := view.GetValidatorCandidatePool()
+	if vcp == nil {
+		return core.NewValidatorSet()
+	}
+	return core.SelectTopStakeHoldersAsValidators(vcp)
+}
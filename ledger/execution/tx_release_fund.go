@@ -51,7 +51,7 @@ func (exec *ReleaseFundTxExecutor) sanityCheck(chainID string, view *st.StoreVie
 		return res
 	}
 
-	if minTxFee, success := sanityCheckForFee(tx.Fee, blockHeight); !success {
+	if minTxFee, success := sanityCheckForFee(view, tx.Fee); !success {
 		return result.Error("Insufficient fee. Transaction fee needs to be at least %v PTXWei",
 			minTxFee).WithErrorCode(result.CodeInvalidFee)
 	}
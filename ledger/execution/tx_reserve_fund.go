@@ -63,7 +63,7 @@ func (exec *ReserveFundTxExecutor) sanityCheck(chainID string, view *st.StoreVie
 			WithErrorCode(result.CodeInvalidFundToReserve)
 	}
 
-	if minTxFee, success := sanityCheckForFee(tx.Fee, blockHeight); !success {
+	if minTxFee, success := sanityCheckForFee(view, tx.Fee); !success {
 		return result.Error("Insufficient fee. Transaction fee needs to be at least %v PTXWei",
 			minTxFee).WithErrorCode(result.CodeInvalidFee)
 	}
@@ -79,7 +79,7 @@ func (exec *SendTxExecutor) sanityCheck(chainID string, view *st.StoreView, view
 		return res
 	}
 
-	if minTxFee, success := sanityCheckForSendTxFee(tx.Fee, numAccountsAffected, blockHeight); !success {
+	if minTxFee, success := sanityCheckForSendTxFee(view, tx.Fee, numAccountsAffected, blockHeight); !success {
 		return result.Error("Insufficient fee. Transaction fee needs to be at least %v PTXWei",
 			minTxFee).WithErrorCode(result.CodeInvalidFee)
 	}
@@ -77,8 +77,7 @@ func (exec *ServicePaymentTxExecutor) sanityCheck(chainID string, view *st.Store
 		return result.Error(errMsg)
 	}
 
-	blockHeight := view.Height() + 1 // the view points to the parent of the current block
-	if minTxFee, success := sanityCheckForFee(tx.Fee, blockHeight); !success {
+	if minTxFee, success := sanityCheckForFee(view, tx.Fee); !success {
 		return result.Error("Insufficient fee. Transaction fee needs to be at least %v PTXWei",
 			minTxFee).WithErrorCode(result.CodeInvalidFee)
 	}
@@ -21,9 +21,10 @@ var _ TxExecutor = (*SmartContractTxExecutor)(nil)
 
 // SmartContractTxExecutor implements the TxExecutor interface
 type SmartContractTxExecutor struct {
-	state  *st.LedgerState
-	chain  *blockchain.Chain
-	ledger core.Ledger
+	state    *st.LedgerState
+	chain    *blockchain.Chain
+	ledger   core.Ledger
+	vmConfig vm.Config
 }
 
 // NewSmartContractTxExecutor creates a new instance of SmartContractTxExecutor
@@ -99,13 +100,14 @@ func (exec *SmartContractTxExecutor) sanityCheck(chainID string, view *st.StoreV
 			WithErrorCode(result.CodeInvalidValueToTransfer)
 	}
 
-	if !sanityCheckForGasPrice(tx.GasPrice, blockHeight) {
-		minimumGasPrice := types.GetMinimumGasPrice(blockHeight)
+	params := view.GetGovernanceParams()
+	if !sanityCheckForGasPrice(view, tx.GasPrice) {
+		minimumGasPrice := params.MinimumGasPrice
 		return result.Error("Insufficient gas price. Gas price needs to be at least %v PTXWei", minimumGasPrice).
 			WithErrorCode(result.CodeInvalidGasPrice)
 	}
 
-	maxGasLimit := types.GetMaxGasLimit(blockHeight)
+	maxGasLimit := params.MaximumTxGasLimit
 	if new(big.Int).SetUint64(tx.GasLimit).Cmp(maxGasLimit) > 0 {
 		return result.Error("Invalid gas limit. Gas limit needs to be at most %v", maxGasLimit).
 			WithErrorCode(result.CodeInvalidGasLimit)
@@ -153,7 +155,7 @@ func (exec *SmartContractTxExecutor) process(chainID string, view *st.StoreView,
 	// Note: for contract deployment, vm.Execute() might transfer coins from the fromAccount to the
 	//       deployed smart contract. Thus, we should call vm.Execute() before calling getInput().
 	//       Otherwise, the fromAccount returned by getInput() will have incorrect balance.
-	evmRet, contractAddr, gasUsed, evmErr := vm.Execute(exec.state.ParentBlock(), tx, view)
+	evmRet, contractAddr, gasUsed, evmErr := vm.ExecuteWithConfig(exec.state.ParentBlock(), tx, view, exec.vmConfig)
 
 	fromAddress := tx.From.Address
 	fromAccount, success := getInput(view, tx.From)
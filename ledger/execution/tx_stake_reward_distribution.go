@@ -99,7 +99,7 @@ func (exec *StakeRewardDistributionTxExecutor) sanityCheck(chainID string, view
 	// 	return result.Error("Invalid purpose: %v", tx.Purpose)
 	// }
 
-	if minTxFee, success := sanityCheckForFee(tx.Fee, blockHeight); !success {
+	if minTxFee, success := sanityCheckForFee(view, tx.Fee); !success {
 		return result.Error("Insufficient fee. Transaction fee needs to be at least %v PTXWei",
 			minTxFee).WithErrorCode(result.CodeInvalidFee)
 	}
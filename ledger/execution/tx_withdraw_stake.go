@@ -49,7 +49,7 @@ func (exec *WithdrawStakeExecutor) sanityCheck(chainID string, view *st.StoreVie
 		return res
 	}
 
-	if minTxFee, success := sanityCheckForFee(tx.Fee, blockHeight); !success {
+	if minTxFee, success := sanityCheckForFee(view, tx.Fee); !success {
 		return result.Error("Insufficient fee. Transaction fee needs to be at least %v PTXWei",
 			minTxFee).WithErrorCode(result.CodeInvalidFee)
 	}
@@ -90,6 +90,9 @@ func (exec *WithdrawStakeExecutor) process(chainID string, view *st.StoreView, v
 	if tx.Purpose == core.StakeForValidator {
 		vcp := view.GetValidatorCandidatePool()
 		currentHeight := exec.state.Height()
+		if view.Height()+1 >= common.HeightEnableDelegatedStaking { // the view points to the parent of the current block
+			settleDelegatorReward(view, vcp, sourceAddress, holderAddress)
+		}
 		err := vcp.WithdrawStake(sourceAddress, holderAddress, currentHeight)
 		if err != nil {
 			return common.Hash{}, result.Error("Failed to withdraw stake, err: %v", err)
@@ -0,0 +1,61 @@
+package ledger
+
+import (
+	"github.com/pandoprojects/pando/common"
+	"github.com/pandoprojects/pando/core"
+	st "github.com/pandoprojects/pando/ledger/state"
+	"github.com/pandoprojects/pando/store/kvstore"
+)
+
+// GetGovernanceParams returns the governance parameters in effect for the child blocks of the
+// given block.
+func (ledger *Ledger) GetGovernanceParams(blockHash common.Hash) (*core.GovernanceParams, error) {
+	db := ledger.state.DB()
+	store := kvstore.NewKVStore(db)
+	block, err := findBlock(store, blockHash)
+	if err != nil {
+		return nil, err
+	}
+	storeView := st.NewStoreView(block.Height, block.StateHash, db)
+	return storeView.GetGovernanceParams(), nil
+}
+
+// handleGovernanceProposals activates the changes of the approved governance proposals at their
+// activation height, and drops the proposals that expired without being approved. Since the view
+// holds the parameters for the next block, the changes are applied at the end of the block before
+// the activation height.
+func (ledger *Ledger) handleGovernanceProposals(view *st.StoreView) {
+	proposalIDs := view.GetPendingGovernanceProposals()
+	if len(proposalIDs) == 0 {
+		return
+	}
+
+	nextBlockHeight := view.Height() + 2 // the view points to the parent of the current block
+	params := view.GetGovernanceParams()
+	paramsChanged := false
+	pending := []common.Hash{}
+	for _, proposalID := range proposalIDs {
+		proposal := view.GetGovernanceProposal(proposalID)
+		if proposal == nil {
+			logger.Panicf("Failed to retrieve pending governance proposal %v", proposalID.Hex())
+		}
+		if proposal.ActivationHeight > nextBlockHeight {
+			pending = append(pending, proposalID)
+			continue
+		}
+		if !proposal.Approved {
+			logger.Infof("Governance proposal expired: %v", proposal)
+			continue
+		}
+		params = params.Merge(&proposal.Changes)
+		paramsChanged = true
+		logger.Infof("Governance proposal activated: %v, params: %v", proposal, params)
+	}
+
+	if paramsChanged {
+		view.SetGovernanceParams(params)
+	}
+	if len(pending) != len(proposalIDs) {
+		view.SetPendingGovernanceProposals(pending)
+	}
+}
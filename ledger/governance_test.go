@@ -0,0 +1,66 @@
+package ledger
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/pandoprojects/pando/common"
+	"github.com/pandoprojects/pando/core"
+	st "github.com/pandoprojects/pando/ledger/state"
+	"github.com/pandoprojects/pando/ledger/types"
+	"github.com/pandoprojects/pando/store/database/backend"
+)
+
+func TestHandleGovernanceProposals(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	ledger := &Ledger{}
+	view := st.NewStoreView(99, common.Hash{}, backend.NewMemDatabase())
+	defaults := types.GetDefaultGovernanceParams(100)
+	assert.Equal(defaults.String(), view.GetGovernanceParams().String())
+
+	approved := &core.GovernanceProposal{
+		ID:               common.BytesToHash([]byte("approved")),
+		Changes:          core.GovernanceParams{MinimumGasPrice: big.NewInt(12345)},
+		ActivationHeight: 101,
+		Approved:         true,
+	}
+	expired := &core.GovernanceProposal{
+		ID:               common.BytesToHash([]byte("expired")),
+		Changes:          core.GovernanceParams{GuardianRoundLength: 60},
+		ActivationHeight: 101,
+	}
+	future := &core.GovernanceProposal{
+		ID:               common.BytesToHash([]byte("future")),
+		Changes:          core.GovernanceParams{MaximumTxGasLimit: big.NewInt(1)},
+		ActivationHeight: 102,
+		Approved:         true,
+	}
+	for _, proposal := range []*core.GovernanceProposal{approved, expired, future} {
+		view.SetGovernanceProposal(proposal)
+	}
+	view.SetPendingGovernanceProposals([]common.Hash{approved.ID, expired.ID, future.ID})
+
+	// At the end of block 100, the proposals activated at block 101 are resolved.
+	ledger.handleGovernanceProposals(view)
+	params := view.GetGovernanceParams()
+	assert.Equal(int64(12345), params.MinimumGasPrice.Int64())
+	assert.Equal(uint64(0), params.GuardianRoundLength)
+	assert.Equal(defaults.MaximumTxGasLimit.String(), params.MaximumTxGasLimit.String())
+	assert.Equal([]common.Hash{future.ID}, view.GetPendingGovernanceProposals())
+
+	view.IncrementHeight()
+	ledger.handleGovernanceProposals(view)
+	params = view.GetGovernanceParams()
+	assert.Equal(int64(12345), params.MinimumGasPrice.Int64())
+	assert.Equal(int64(1), params.MaximumTxGasLimit.Int64())
+	assert.Equal(0, len(view.GetPendingGovernanceProposals()))
+
+	// The resolved proposals remain queryable.
+	require.NotNil(view.GetGovernanceProposal(expired.ID))
+	assert.False(view.GetGovernanceProposal(expired.ID).Approved)
+}
@@ -44,6 +44,8 @@ type Ledger struct {
 	mu       *sync.RWMutex // Lock for accessing ledger state.
 	state    *st.LedgerState
 	executor *exec.Executor
+
+	evidencePool *evidencePool
 }
 
 // NewLedger creates an instance of Ledger
@@ -57,6 +59,8 @@ func NewLedger(chainID string, db database.Database, tagger st.Tagger, chain *bl
 		mempool:   mempool,
 		mu:        &sync.RWMutex{},
 		state:     state,
+
+		evidencePool: newEvidencePool(),
 	}
 	executor := exec.NewExecutor(db, chain, state, consensus, valMgr, ledger)
 	ledger.SetExecutor(executor)
@@ -68,6 +72,12 @@ func (ledger *Ledger) SetExecutor(executor *exec.Executor) {
 	ledger.executor = executor
 }
 
+// SetEquivocationSlashingHeight overrides the height enabling the equivocation slashing, e.g. in
+// the simulations. All the nodes of a network must use the same height.
+func (ledger *Ledger) SetEquivocationSlashingHeight(height uint64) {
+	ledger.executor.SetEquivocationSlashingHeight(height)
+}
+
 // State returns the state of the ledger
 func (ledger *Ledger) State() *st.LedgerState {
 	return ledger.state
@@ -251,6 +261,81 @@ func (ledger *Ledger) ScreenTx(rawTx common.Bytes) (txInfo *core.TxInfo, res res
 	return txInfo, res
 }
 
+// ScreenReplacementTx screens the given transaction, which replaces the pending transaction with the
+// same sequence in the mempool. Unlike ScreenTx, it leaves the screened view untouched.
+func (ledger *Ledger) ScreenReplacementTx(rawTx common.Bytes, replacedRawTx common.Bytes) (txInfo *core.TxInfo, res result.Result) {
+	replacedTx, err := types.TxFromBytes(replacedRawTx)
+	if err != nil {
+		return nil, result.Error("Error decoding the replaced tx: %v", err)
+	}
+	return ledger.screenTxOutOfSequence(rawTx, func(tx types.Tx) result.Result {
+		return ledger.executor.ScreenReplacementTx(tx, replacedTx)
+	})
+}
+
+// ScreenFutureTx screens the given transaction, whose sequence is ahead of the next sequence of the
+// sender. Unlike ScreenTx, it leaves the screened view untouched.
+func (ledger *Ledger) ScreenFutureTx(rawTx common.Bytes) (txInfo *core.TxInfo, res result.Result) {
+	return ledger.screenTxOutOfSequence(rawTx, ledger.executor.ScreenFutureTx)
+}
+
+func (ledger *Ledger) screenTxOutOfSequence(rawTx common.Bytes, screenTx func(tx types.Tx) result.Result) (txInfo *core.TxInfo, res result.Result) {
+	var tx types.Tx
+	tx, err := types.TxFromBytes(rawTx)
+	if err != nil {
+		return nil, result.Error("Error decoding tx: %v", err)
+	}
+
+	if ledger.shouldSkipCheckTx(tx) {
+		return nil, result.Error("Unauthorized transaction, should skip").
+			WithErrorCode(result.CodeUnauthorizedTx)
+	}
+
+	// Copying the screened view commits its trie, hence the write lock
+	ledger.mu.Lock()
+	defer ledger.mu.Unlock()
+
+	res = screenTx(tx)
+	if res.IsError() {
+		return nil, res
+	}
+
+	txInfo, res = ledger.executor.GetTxInfo(tx)
+	if res.IsError() {
+		return nil, res
+	}
+
+	return txInfo, res
+}
+
+// GetTxInfo returns the information the mempool sorts the given transaction by, without screening it.
+func (ledger *Ledger) GetTxInfo(rawTx common.Bytes) (txInfo *core.TxInfo, res result.Result) {
+	var tx types.Tx
+	tx, err := types.TxFromBytes(rawTx)
+	if err != nil {
+		return nil, result.Error("Error decoding tx: %v", err)
+	}
+
+	ledger.mu.RLock()
+	defer ledger.mu.RUnlock()
+
+	return ledger.executor.GetTxInfo(tx)
+}
+
+// ResetScreenedAccount resets the account in the screened view to its state in the delivered view,
+// which rolls back the transactions of the account screened since the last block. The mempool calls
+// it when evicting the pending transactions of the account.
+func (ledger *Ledger) ResetScreenedAccount(address common.Address) {
+	ledger.mu.Lock()
+	defer ledger.mu.Unlock()
+
+	account := ledger.state.Delivered().GetAccount(address)
+	if account == nil {
+		return
+	}
+	ledger.state.Screened().SetAccount(address, account)
+}
+
 // ProposeBlockTxs collects and executes a list of transactions, which will be used to assemble the next blockl
 // It also clears these transactions from the mempool.
 func (ledger *Ledger) ProposeBlockTxs(block *core.Block, shouldIncludeValidatorUpdateTxs bool) (stateRootHash common.Hash, blockRawTxs []common.Bytes, res result.Result) {
@@ -303,6 +388,9 @@ func (ledger *Ledger) ProposeBlockTxs(block *core.Block, shouldIncludeValidatorU
 			if _, ok := tx.(*types.WithdrawStakeTx); ok {
 				continue
 			}
+			if _, ok := tx.(*types.EquivocationEvidenceTx); ok {
+				continue
+			}
 		}
 
 		_, res := ledger.executor.CheckTx(tx)
@@ -372,6 +460,8 @@ func (ledger *Ledger) ApplyBlockTxs(block *core.Block) result.Result {
 			hasValidatorUpdate = true
 		} else if wtx, ok := tx.(*types.WithdrawStakeTx); ok && wtx.Purpose == core.StakeForValidator {
 			hasValidatorUpdate = true
+		} else if _, ok := tx.(*types.EquivocationEvidenceTx); ok {
+			hasValidatorUpdate = true
 		}
 		_, res := ledger.executor.ExecuteTx(tx)
 		if res.IsError() {
@@ -453,6 +543,8 @@ func (ledger *Ledger) ApplyBlockTxsForChainCorrection(block *core.Block) (common
 			hasValidatorUpdate = true
 		} else if wtx, ok := tx.(*types.WithdrawStakeTx); ok && wtx.Purpose == core.StakeForValidator {
 			hasValidatorUpdate = true
+		} else if _, ok := tx.(*types.EquivocationEvidenceTx); ok {
+			hasValidatorUpdate = true
 		}
 		_, res := ledger.executor.ExecuteTx(tx)
 		if res.IsError() {
@@ -640,6 +732,8 @@ func (ledger *Ledger) shouldSkipCheckTx(tx types.Tx) bool {
 		return true
 	case *types.SlashTx:
 		return true
+	case *types.EquivocationEvidenceTx:
+		return true
 	default:
 		return false
 	}
@@ -655,6 +749,9 @@ func (ledger *Ledger) handleDelayedStateUpdates(view *st.StoreView) {
 	if blockHeight >= common.HeightEnablePando2 {
 		ledger.handleRametronenterpriseStakeReturns(view)
 	}
+	if blockHeight >= common.HeightEnableGovernance {
+		ledger.handleGovernanceProposals(view)
+	}
 }
 
 func (ledger *Ledger) handleValidatorStakeReturn(view *st.StoreView) {
@@ -771,6 +868,7 @@ func (ledger *Ledger) addSpecialTransactions(block *core.Block, view *st.StoreVi
 	validatorSet := ledger.valMgr.GetNextValidatorSet(parentBlkHash)
 
 	ledger.addCoinbaseTx(view, &proposer, validatorSet, rawTxs)
+	ledger.addEquivocationEvidenceTxs(view, &proposer, rawTxs)
 	//ledger.addSlashTxs(view, &proposer, &validators, rawTxs)
 }
 
@@ -864,8 +962,11 @@ func (ledger *Ledger) addSlashTxs(view *st.StoreView, proposer *core.Validator,
 // signTransaction signs the given transaction
 func (ledger *Ledger) signTransaction(tx types.Tx) (*crypto.Signature, error) {
 	chainID := ledger.state.GetChainID()
-	signBytes := tx.SignBytes(chainID)
-	signature, err := ledger.consensus.PrivateKey().Sign(signBytes)
+	rawTx, err := types.TxToBytes(tx)
+	if err != nil {
+		return nil, err
+	}
+	signature, err := ledger.consensus.Signer().SignTx(chainID, rawTx)
 	if err != nil {
 		return nil, err
 	}
@@ -98,3 +98,41 @@ func RametronenterpriseStakeReturnsKey(height uint64) common.Bytes {
 func RametronenterprisesTotalActiveStakeKey() common.Bytes {
 	return common.Bytes("ls/rametronenterprisetas")
 }
+
+// EquivocationSlashKey returns the state key recording that the validator has been slashed
+// for double-signing in the given epoch
+func EquivocationSlashKey(offender common.Address, epoch uint64) common.Bytes {
+	key := append(common.Bytes("ls/eqs/"), offender[:]...)
+	return append(key, common.Bytes("/"+strconv.FormatUint(epoch, 10))...)
+}
+
+// GovernanceParamsKey returns the state key of the governance parameters in effect
+func GovernanceParamsKey() common.Bytes {
+	return common.Bytes("ls/gov/params")
+}
+
+// GovernanceProposalKey returns the state key of the governance proposal with the given ID
+func GovernanceProposalKey(proposalID common.Hash) common.Bytes {
+	return append(common.Bytes("ls/gov/p/"), proposalID[:]...)
+}
+
+// PendingGovernanceProposalsKey returns the state key of the IDs of the governance proposals
+// waiting for their activation height
+func PendingGovernanceProposalsKey() common.Bytes {
+	return common.Bytes("ls/gov/pending")
+}
+
+// ValidatorRewardPoolKey returns the state key of the reward pool of the validator
+func ValidatorRewardPoolKey(holder common.Address) common.Bytes {
+	return append(common.Bytes("ls/vrp/"), holder[:]...)
+}
+
+// DelegatorRewardKeyPrefix returns the prefix of the state keys of the rewards of the stake source
+func DelegatorRewardKeyPrefix(source common.Address) common.Bytes {
+	return append(append(common.Bytes("ls/dr/"), source[:]...), '/')
+}
+
+// DelegatorRewardKey returns the state key of the rewards of the stake source delegated to the holder
+func DelegatorRewardKey(source common.Address, holder common.Address) common.Bytes {
+	return append(DelegatorRewardKeyPrefix(source), holder[:]...)
+}
@@ -0,0 +1,115 @@
+package state
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+
+	"github.com/pandoprojects/pando/common"
+	"github.com/pandoprojects/pando/crypto"
+	"github.com/pandoprojects/pando/ledger/types"
+	"github.com/pandoprojects/pando/rlp"
+	"github.com/pandoprojects/pando/store/trie"
+)
+
+// emptyTrieRoot is the root hash of a trie without any entry.
+var emptyTrieRoot = common.HexToHash("56e81f171bcc55a6ff8345e692c0f86e5b48e01b996cadc001622fb5e363b421")
+
+// StateProof is a merkle proof of a key in the state trie or in the storage trie of an account.
+// It holds the RLP encoded trie nodes on the path from the root to the key. The proof of a key
+// absent from the trie ends with the node which proves its absence.
+type StateProof [][]byte
+
+// Put implements the database.Putter interface, so a StateProof can be filled by the trie.
+func (p *StateProof) Put(key []byte, value []byte) error {
+	*p = append(*p, common.CopyBytes(value))
+	return nil
+}
+
+// Get implements the trie.DatabaseReader interface. It looks up the node by its hash.
+func (p StateProof) Get(key []byte) ([]byte, error) {
+	for _, node := range p {
+		if bytes.Equal(crypto.Keccak256(node), key) {
+			return node, nil
+		}
+	}
+	return nil, fmt.Errorf("proof node %x not found", key)
+}
+
+// Has implements the trie.DatabaseReader interface.
+func (p StateProof) Has(key []byte) (bool, error) {
+	_, err := p.Get(key)
+	return err == nil, nil
+}
+
+// ProveAccount returns the merkle proof of the account with the given address against the state root.
+func (sv *StoreView) ProveAccount(addr common.Address) (StateProof, error) {
+	proof := StateProof{}
+	if err := sv.store.Prove(AccountKey(addr), &proof); err != nil {
+		return nil, err
+	}
+	return proof, nil
+}
+
+// ProveStorage returns the merkle proof of the storage slot of the given account against the
+// storage root of the account. The proof is empty if the account has no storage.
+func (sv *StoreView) ProveStorage(addr common.Address, key common.Hash) (StateProof, error) {
+	proof := StateProof{}
+	account := sv.GetAccount(addr)
+	if account == nil || isEmptyTrieRoot(account.Root) {
+		return proof, nil
+	}
+	storage := sv.getAccountStorage(account)
+	if storage == nil {
+		return nil, fmt.Errorf("storage of account %v is not available", addr.Hex())
+	}
+	if err := storage.Prove(key[:], &proof); err != nil {
+		return nil, err
+	}
+	return proof, nil
+}
+
+// VerifyAccountProof checks the merkle proof of the account with the given address against the
+// state root, and returns the proven account. It returns nil without an error if the proof shows
+// that the account does not exist.
+func VerifyAccountProof(stateRoot common.Hash, addr common.Address, proof StateProof) (*types.Account, error) {
+	value, _, err := trie.VerifyProof(stateRoot, AccountKey(addr), proof)
+	if err != nil {
+		return nil, err
+	}
+	if len(value) == 0 {
+		return nil, nil
+	}
+	account := &types.Account{}
+	if err := types.FromBytes(value, account); err != nil {
+		return nil, fmt.Errorf("invalid account in proof: %v", err)
+	}
+	return account, nil
+}
+
+// VerifyStorageProof checks the merkle proof of the storage slot against the storage root of an
+// account, as found in an account verified with VerifyAccountProof, and returns the proven value.
+func VerifyStorageProof(storageRoot common.Hash, key common.Hash, proof StateProof) (common.Hash, error) {
+	if isEmptyTrieRoot(storageRoot) {
+		if len(proof) != 0 {
+			return common.Hash{}, errors.New("unexpected proof nodes for an empty storage")
+		}
+		return common.Hash{}, nil
+	}
+	enc, _, err := trie.VerifyProof(storageRoot, key[:], proof)
+	if err != nil {
+		return common.Hash{}, err
+	}
+	if len(enc) == 0 {
+		return common.Hash{}, nil
+	}
+	_, content, _, err := rlp.Split(enc)
+	if err != nil {
+		return common.Hash{}, fmt.Errorf("invalid storage value in proof: %v", err)
+	}
+	return common.BytesToHash(content), nil
+}
+
+func isEmptyTrieRoot(root common.Hash) bool {
+	return root == common.Hash{} || root == emptyTrieRoot
+}
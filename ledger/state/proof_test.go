@@ -0,0 +1,75 @@
+package state
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/pandoprojects/pando/common"
+	"github.com/pandoprojects/pando/ledger/types"
+	"github.com/pandoprojects/pando/store/database/backend"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStateProof(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	db := backend.NewMemDatabase()
+	sv := NewStoreView(uint64(1), common.Hash{}, db)
+
+	addr := common.HexToAddress("0x1")
+	contract := common.HexToAddress("0x2")
+	slot := common.HexToHash("0x10")
+	sv.SetAccount(addr, &types.Account{
+		Address: addr,
+		Balance: types.Coins{PandoWei: big.NewInt(100), PTXWei: big.NewInt(5)},
+	})
+	sv.SetState(contract, slot, common.HexToHash("0xabcd"))
+	stateRoot := sv.Save()
+
+	sv = NewStoreView(uint64(1), stateRoot, db)
+
+	// Existing account
+	proof, err := sv.ProveAccount(addr)
+	require.Nil(err)
+	account, err := VerifyAccountProof(stateRoot, addr, proof)
+	require.Nil(err)
+	require.NotNil(account)
+	assert.Equal(int64(100), account.Balance.PandoWei.Int64())
+
+	// Tampered balance does not verify
+	_, err = VerifyAccountProof(common.HexToHash("0x1234"), addr, proof)
+	assert.NotNil(err)
+
+	// Absent account
+	missing := common.HexToAddress("0x3")
+	proof, err = sv.ProveAccount(missing)
+	require.Nil(err)
+	account, err = VerifyAccountProof(stateRoot, missing, proof)
+	assert.Nil(err)
+	assert.Nil(account)
+
+	// Storage slots
+	proof, err = sv.ProveAccount(contract)
+	require.Nil(err)
+	account, err = VerifyAccountProof(stateRoot, contract, proof)
+	require.Nil(err)
+	require.NotNil(account)
+
+	storageProof, err := sv.ProveStorage(contract, slot)
+	require.Nil(err)
+	value, err := VerifyStorageProof(account.Root, slot, storageProof)
+	assert.Nil(err)
+	assert.Equal(common.HexToHash("0xabcd"), value)
+
+	emptySlot := common.HexToHash("0x11")
+	storageProof, err = sv.ProveStorage(contract, emptySlot)
+	require.Nil(err)
+	value, err = VerifyStorageProof(account.Root, emptySlot, storageProof)
+	assert.Nil(err)
+	assert.Equal(common.Hash{}, value)
+
+	_, err = VerifyStorageProof(account.Root, slot, StateProof{})
+	assert.NotNil(err)
+}
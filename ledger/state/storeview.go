@@ -371,6 +371,189 @@ func (sv *StoreView) UpdateStakeTransactionHeightList(hl *types.HeightList) {
 	sv.Set(StakeTransactionHeightListKey(), hlBytes)
 }
 
+// GetEquivocationSlashHeight returns the height of the block in which the validator was slashed
+// for double-signing in the given epoch, or zero if it has not been slashed.
+func (sv *StoreView) GetEquivocationSlashHeight(offender common.Address, epoch uint64) uint64 {
+	data := sv.Get(EquivocationSlashKey(offender, epoch))
+	if data == nil || len(data) == 0 {
+		return 0
+	}
+	var height uint64
+	err := types.FromBytes(data, &height)
+	if err != nil {
+		log.Panicf("Error reading equivocation slash height %X, error: %v",
+			data, err.Error())
+	}
+	return height
+}
+
+// SetEquivocationSlashHeight records that the validator was slashed for double-signing in the
+// given epoch, so that it cannot be slashed twice for the same offence.
+func (sv *StoreView) SetEquivocationSlashHeight(offender common.Address, epoch uint64, height uint64) {
+	heightBytes, err := types.ToBytes(height)
+	if err != nil {
+		log.Panicf("Error writing equivocation slash height %v, error: %v",
+			height, err.Error())
+	}
+	sv.Set(EquivocationSlashKey(offender, epoch), heightBytes)
+}
+
+// GetGovernanceParams returns the governance parameters in effect for the next block, i.e. the
+// block on top of the view. They are the parameters hardcoded in the protocol until the validators
+// change them.
+func (sv *StoreView) GetGovernanceParams() *core.GovernanceParams {
+	data := sv.Get(GovernanceParamsKey())
+	if data == nil || len(data) == 0 {
+		return types.GetDefaultGovernanceParams(sv.Height() + 1)
+	}
+	params := &core.GovernanceParams{}
+	err := types.FromBytes(data, params)
+	if err != nil {
+		log.Panicf("Error reading governance parameters %X, error: %v",
+			data, err.Error())
+	}
+	return params
+}
+
+// SetGovernanceParams sets the governance parameters in effect.
+func (sv *StoreView) SetGovernanceParams(params *core.GovernanceParams) {
+	paramsBytes, err := types.ToBytes(params)
+	if err != nil {
+		log.Panicf("Error writing governance parameters %v, error: %v",
+			params, err.Error())
+	}
+	sv.Set(GovernanceParamsKey(), paramsBytes)
+}
+
+// GetGovernanceProposal returns the governance proposal with the given ID, or nil if not found.
+func (sv *StoreView) GetGovernanceProposal(proposalID common.Hash) *core.GovernanceProposal {
+	data := sv.Get(GovernanceProposalKey(proposalID))
+	if data == nil || len(data) == 0 {
+		return nil
+	}
+	proposal := &core.GovernanceProposal{}
+	err := types.FromBytes(data, proposal)
+	if err != nil {
+		log.Panicf("Error reading governance proposal %X, error: %v",
+			data, err.Error())
+	}
+	return proposal
+}
+
+// SetGovernanceProposal saves the governance proposal.
+func (sv *StoreView) SetGovernanceProposal(proposal *core.GovernanceProposal) {
+	proposalBytes, err := types.ToBytes(proposal)
+	if err != nil {
+		log.Panicf("Error writing governance proposal %v, error: %v",
+			proposal, err.Error())
+	}
+	sv.Set(GovernanceProposalKey(proposal.ID), proposalBytes)
+}
+
+// GetPendingGovernanceProposals returns the IDs of the governance proposals waiting for their
+// activation height, in the order they were proposed.
+func (sv *StoreView) GetPendingGovernanceProposals() []common.Hash {
+	data := sv.Get(PendingGovernanceProposalsKey())
+	if data == nil || len(data) == 0 {
+		return []common.Hash{}
+	}
+	proposalIDs := []common.Hash{}
+	err := types.FromBytes(data, &proposalIDs)
+	if err != nil {
+		log.Panicf("Error reading pending governance proposals %X, error: %v",
+			data, err.Error())
+	}
+	return proposalIDs
+}
+
+// SetPendingGovernanceProposals sets the IDs of the governance proposals waiting for their
+// activation height.
+func (sv *StoreView) SetPendingGovernanceProposals(proposalIDs []common.Hash) {
+	if len(proposalIDs) == 0 {
+		sv.Delete(PendingGovernanceProposalsKey())
+		return
+	}
+	idsBytes, err := types.ToBytes(proposalIDs)
+	if err != nil {
+		log.Panicf("Error writing pending governance proposals %v, error: %v",
+			proposalIDs, err.Error())
+	}
+	sv.Set(PendingGovernanceProposalsKey(), idsBytes)
+}
+
+// GetValidatorRewardPool returns the reward pool of the validator, or nil if it has never been rewarded
+// nor set its commission.
+func (sv *StoreView) GetValidatorRewardPool(holder common.Address) *core.ValidatorRewardPool {
+	data := sv.Get(ValidatorRewardPoolKey(holder))
+	if data == nil || len(data) == 0 {
+		return nil
+	}
+	pool := &core.ValidatorRewardPool{}
+	err := types.FromBytes(data, pool)
+	if err != nil {
+		log.Panicf("Error reading validator reward pool %X, error: %v",
+			data, err.Error())
+	}
+	return pool
+}
+
+// SetValidatorRewardPool saves the reward pool of the validator.
+func (sv *StoreView) SetValidatorRewardPool(pool *core.ValidatorRewardPool) {
+	poolBytes, err := types.ToBytes(pool)
+	if err != nil {
+		log.Panicf("Error writing validator reward pool %v, error: %v",
+			pool, err.Error())
+	}
+	sv.Set(ValidatorRewardPoolKey(pool.Holder), poolBytes)
+}
+
+// GetDelegatorReward returns the rewards of the stake source delegated to the holder, or nil if the
+// source has not settled its rewards since the delegated staking is enabled.
+func (sv *StoreView) GetDelegatorReward(source common.Address, holder common.Address) *core.DelegatorReward {
+	data := sv.Get(DelegatorRewardKey(source, holder))
+	if data == nil || len(data) == 0 {
+		return nil
+	}
+	reward := &core.DelegatorReward{}
+	err := types.FromBytes(data, reward)
+	if err != nil {
+		log.Panicf("Error reading delegator reward %X, error: %v",
+			data, err.Error())
+	}
+	return reward
+}
+
+// GetDelegatorRewards returns the rewards of the stake source delegated to all the holders.
+func (sv *StoreView) GetDelegatorRewards(source common.Address) []*core.DelegatorReward {
+	rewards := []*core.DelegatorReward{}
+	sv.Traverse(DelegatorRewardKeyPrefix(source), func(k, v common.Bytes) bool {
+		reward := &core.DelegatorReward{}
+		err := types.FromBytes(v, reward)
+		if err != nil {
+			log.Panicf("Error reading delegator reward %X, error: %v",
+				v, err.Error())
+		}
+		rewards = append(rewards, reward)
+		return true
+	})
+	return rewards
+}
+
+// SetDelegatorReward saves the rewards of the stake source delegated to the holder.
+func (sv *StoreView) SetDelegatorReward(reward *core.DelegatorReward) {
+	rewardBytes, err := types.ToBytes(reward)
+	if err != nil {
+		log.Panicf("Error writing delegator reward %v, error: %v",
+			reward, err.Error())
+	}
+	sv.Set(DelegatorRewardKey(reward.Source, reward.Holder), rewardBytes)
+}
+
+// DeleteDelegatorReward deletes the rewards of the stake source delegated to the holder.
+func (sv *StoreView) DeleteDelegatorReward(source common.Address, holder common.Address) {
+	sv.Delete(DelegatorRewardKey(source, holder))
+}
+
 type StakeWithHolder struct {
 	Holder common.Address
 	Stake  core.Stake
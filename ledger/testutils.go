@@ -223,7 +223,7 @@ func newTestLedger() (chainID string, ledger *Ledger, mempool *mp.Mempool) {
 }
 
 func newTesetValidatorManager(consensus core.ConsensusEngine) core.ValidatorManager {
-	proposerAddressStr := consensus.PrivateKey().PublicKey().Address().String()
+	proposerAddressStr := consensus.Signer().PublicKey().Address().String()
 	propser := core.NewValidator(proposerAddressStr, new(big.Int).SetUint64(999))
 
 	_, val2PubKey, err := crypto.TEST_GenerateKeyPairWithSeed("val2")
@@ -286,16 +286,14 @@ func newRawCoinbaseTx(chainID string, ledger *Ledger, sequence int) common.Bytes
 		outputs = append(outputs, output)
 	}
 
-	proposerSk := ledger.consensus.PrivateKey()
-	proposerPk := proposerSk.PublicKey()
+	proposerPk := ledger.consensus.Signer().PublicKey()
 	coinbaseTx := &types.CoinbaseTx{
 		Proposer:    types.TxInput{Address: proposerPk.Address(), Sequence: uint64(sequence)},
 		Outputs:     outputs,
 		BlockHeight: 2,
 	}
 
-	signBytes := coinbaseTx.SignBytes(chainID)
-	sig, err := proposerSk.Sign(signBytes)
+	sig, err := ledger.signTransaction(coinbaseTx)
 	if err != nil {
 		panic("Failed to sign the coinbase transaction")
 	}
@@ -0,0 +1,55 @@
+package ledger
+
+import (
+	"fmt"
+
+	"github.com/pandoprojects/pando/core"
+	exec "github.com/pandoprojects/pando/ledger/execution"
+	st "github.com/pandoprojects/pando/ledger/state"
+	"github.com/pandoprojects/pando/ledger/types"
+	"github.com/pandoprojects/pando/ledger/vm"
+)
+
+// TracerFactory returns the tracer to attach to the EVM while re-executing the smart contract
+// transaction at the given index of the block, or nil to execute it without tracing.
+type TracerFactory func(txIndex int, tx *types.SmartContractTx) vm.Tracer
+
+// TraceBlockTxs re-executes the transactions of the given block on the state of its parent block,
+// up to and including the transaction at index lastTxIndex (all of them if lastTxIndex is negative).
+// The transactions are replayed on a private copy of the state, the ledger state and the recorded
+// receipts are left untouched.
+func (ledger *Ledger) TraceBlockTxs(block *core.Block, lastTxIndex int, newTracer TracerFactory) error {
+	extParentBlock, err := ledger.chain.FindBlock(block.Parent)
+	if err != nil {
+		return fmt.Errorf("Failed to find the parent block: %v, err: %v", block.Parent.Hex(), err)
+	}
+
+	state := st.NewLedgerState(ledger.state.GetChainID(), ledger.db, nil)
+	if res := state.ResetState(extParentBlock.Block); res.IsError() {
+		return fmt.Errorf("Failed to load the state of block %v: %v", block.Parent.Hex(), res.Message)
+	}
+	executor := exec.NewExecutor(ledger.db, ledger.chain, state, ledger.consensus, ledger.valMgr, ledger)
+	executor.SetSkipSanityCheck(true) // the block has already been validated
+
+	for idx, rawTx := range block.Txs {
+		if lastTxIndex >= 0 && idx > lastTxIndex {
+			break
+		}
+		tx, err := types.TxFromBytes(rawTx)
+		if err != nil {
+			return fmt.Errorf("Failed to parse transaction %v: %v", idx, err)
+		}
+
+		var tracer vm.Tracer
+		if sctx, ok := tx.(*types.SmartContractTx); ok && newTracer != nil {
+			tracer = newTracer(idx, sctx)
+		}
+		executor.SetTracer(tracer)
+
+		// Processing the checked view does not record the tx receipts
+		if _, res := executor.CheckTx(tx); res.IsError() {
+			return fmt.Errorf("Failed to re-execute transaction %v: %v", idx, res.Message)
+		}
+	}
+	return nil
+}
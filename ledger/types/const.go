@@ -4,6 +4,7 @@ import (
 	"math/big"
 
 	"github.com/pandoprojects/pando/common"
+	"github.com/pandoprojects/pando/core"
 )
 
 const (
@@ -116,19 +117,47 @@ func GetMinimumTransactionFeePTXWei(blockHeight uint64) *big.Int {
 	return new(big.Int).SetUint64(MinimumTransactionFeePTXWeiDec2022)
 }
 
+// GetRegularTxGas returns the gas a regular (non smart contract) transaction is deemed to consume,
+// used to derive the effective gas price from its fee
+func GetRegularTxGas(blockHeight uint64) uint64 {
+	if blockHeight < common.HeightJune2022FeeAdjustment {
+		return GasRegularTx
+	}
+	return GasRegularTxJune2021
+}
+
 // Special handling for many-to-many SendTx
 func GetSendTxMinimumTransactionFeePTXWei(numAccountsAffected uint64, blockHeight uint64) *big.Int {
+	return GetSendTxMinimumTransactionFee(GetDefaultGovernanceParams(blockHeight), numAccountsAffected, blockHeight)
+}
+
+// GetSendTxMinimumTransactionFee returns the minimum fee of a many-to-many SendTx under the given
+// governance parameters
+func GetSendTxMinimumTransactionFee(params *core.GovernanceParams, numAccountsAffected uint64, blockHeight uint64) *big.Int {
 	if blockHeight < common.HeightJune2022FeeAdjustment {
-		return new(big.Int).SetUint64(MinimumTransactionFeePTXWei) // backward compatiblity
+		return new(big.Int).Set(params.MinimumTransactionFeePTXWei) // backward compatiblity
 	}
 
 	if numAccountsAffected < 2 {
 		numAccountsAffected = 2
 	}
 
-	// minSendTxFee = numAccountsAffected * MinimumTransactionFeePTXWeiDec2022 / 2
-	minSendTxFee := big.NewInt(1).Mul(new(big.Int).SetUint64(numAccountsAffected), new(big.Int).SetUint64(MinimumTransactionFeePTXWeiDec2022))
+	// minSendTxFee = numAccountsAffected * MinimumTransactionFeePTXWei / 2
+	minSendTxFee := big.NewInt(1).Mul(new(big.Int).SetUint64(numAccountsAffected), params.MinimumTransactionFeePTXWei)
 	minSendTxFee = big.NewInt(1).Div(minSendTxFee, new(big.Int).SetUint64(2))
 
 	return minSendTxFee
 }
+
+// GetDefaultGovernanceParams returns the governance parameters hardcoded in the protocol, which
+// apply until the validators change them
+func GetDefaultGovernanceParams(blockHeight uint64) *core.GovernanceParams {
+	return &core.GovernanceParams{
+		MinimumGasPrice:             GetMinimumGasPrice(blockHeight),
+		MaximumTxGasLimit:           GetMaxGasLimit(blockHeight),
+		MinimumTransactionFeePTXWei: GetMinimumTransactionFeePTXWei(blockHeight),
+		MinValidatorStakeDeposit:    new(big.Int).Set(core.MinValidatorStakeDeposit),
+		MinGuardianStakeDeposit:     new(big.Int).Set(core.MinGuardianStakeDeposit),
+		GuardianRoundLength:         0, // the local configuration
+	}
+}
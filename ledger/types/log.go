@@ -21,6 +21,7 @@ import (
 	"math/big"
 
 	"github.com/pandoprojects/pando/common"
+	"github.com/pandoprojects/pando/core"
 	"github.com/pandoprojects/pando/rlp"
 )
 
@@ -59,6 +60,25 @@ func (l *Log) DecodeRLP(s *rlp.Stream) error {
 	return err
 }
 
+// Bloom returns the bloom filter of the log address and topics.
+func (l *Log) Bloom() core.Bloom {
+	var bloom core.Bloom
+	bloom.AddBytes(l.Address.Bytes())
+	for _, topic := range l.Topics {
+		bloom.AddBytes(topic[:])
+	}
+	return bloom
+}
+
+// LogsBloom returns the bloom filter covering all the given logs.
+func LogsBloom(logs []*Log) core.Bloom {
+	var bloom core.Bloom
+	for _, log := range logs {
+		bloom.Or(log.Bloom())
+	}
+	return bloom
+}
+
 // BalanceChange represents a contract balance transfer event.
 type BalanceChange struct {
 	// address of the account
@@ -37,6 +37,11 @@ const (
 	TxWithdrawStake
 	TxDepositStakeV2
 	TxStakeRewardDistribution
+	TxEquivocationEvidence
+	TxGovernanceProposal
+	TxGovernanceVote
+	TxValidatorCommission
+	TxClaimStakeReward
 )
 
 func Fuzz(data []byte) int {
@@ -114,6 +119,26 @@ func TxFromBytes(raw []byte) (Tx, error) {
 		data := &StakeRewardDistributionTx{}
 		err = s.Decode(data)
 		return data, err
+	} else if txType == TxEquivocationEvidence {
+		data := &EquivocationEvidenceTx{}
+		err = s.Decode(data)
+		return data, err
+	} else if txType == TxGovernanceProposal {
+		data := &GovernanceProposalTx{}
+		err = s.Decode(data)
+		return data, err
+	} else if txType == TxGovernanceVote {
+		data := &GovernanceVoteTx{}
+		err = s.Decode(data)
+		return data, err
+	} else if txType == TxValidatorCommission {
+		data := &ValidatorCommissionTx{}
+		err = s.Decode(data)
+		return data, err
+	} else if txType == TxClaimStakeReward {
+		data := &ClaimStakeRewardTx{}
+		err = s.Decode(data)
+		return data, err
 	} else {
 		return nil, fmt.Errorf("Unknown TX type: %v", txType)
 	}
@@ -147,6 +172,16 @@ func TxToBytes(t Tx) ([]byte, error) {
 		txType = TxDepositStakeV2
 	case *StakeRewardDistributionTx:
 		txType = TxStakeRewardDistribution
+	case *EquivocationEvidenceTx:
+		txType = TxEquivocationEvidence
+	case *GovernanceProposalTx:
+		txType = TxGovernanceProposal
+	case *GovernanceVoteTx:
+		txType = TxGovernanceVote
+	case *ValidatorCommissionTx:
+		txType = TxValidatorCommission
+	case *ClaimStakeRewardTx:
+		txType = TxClaimStakeReward
 	default:
 		return nil, errors.New("Unsupported message type")
 	}
@@ -35,6 +35,11 @@ Transaction Types:
  - WithdrawStakeTx         Withdraw stake from a target address (e.g. a validator)
  - SmartContractTx         Execute smart contract
  - StakeRewardDistribution Defines how stake reward is distributed
+ - EquivocationEvidenceTx  Transaction for slashing a validator that double-signed
+ - GovernanceProposalTx    Propose a change of the governance parameters
+ - GovernanceVoteTx        Vote for a governance proposal
+ - ValidatorCommissionTx   Set the commission of a validator on the rewards of its delegators
+ - ClaimStakeRewardTx      Claim the staking rewards delegated to a validator
 */
 
 // Gas of regular transactions
@@ -1018,6 +1023,193 @@ func (tx *StakeRewardDistributionTx) String() string {
 		tx.Holder.Address, tx.Beneficiary.Address, tx.SplitBasisPoint)
 }
 
+//-----------------------------------------------------------------------------
+
+// EquivocationEvidenceTx is added to a block by its proposer to report a validator that signed two
+// conflicting votes or proposals in the same epoch. The offender is identified by the evidence, and
+// a fraction of its validator stakes is slashed.
+type EquivocationEvidenceTx struct {
+	Proposer TxInput                   `json:"proposer"`
+	Evidence core.EquivocationEvidence `json:"evidence"`
+}
+
+func (_ *EquivocationEvidenceTx) AssertIsTx() {}
+
+func (tx *EquivocationEvidenceTx) SignBytes(chainID string) []byte {
+	signBytes := encodeToBytes(chainID)
+	sig := tx.Proposer.Signature
+	tx.Proposer.Signature = nil
+	txBytes, _ := TxToBytes(tx)
+	signBytes = append(signBytes, txBytes...)
+	signBytes = addPrefixForSignBytes(signBytes)
+
+	tx.Proposer.Signature = sig
+	return signBytes
+}
+
+func (tx *EquivocationEvidenceTx) SetSignature(addr common.Address, sig *crypto.Signature) bool {
+	if tx.Proposer.Address == addr {
+		tx.Proposer.Signature = sig
+		return true
+	}
+	return false
+}
+
+func (tx *EquivocationEvidenceTx) String() string {
+	return fmt.Sprintf("EquivocationEvidenceTx{proposer: %v, evidence: %v}",
+		tx.Proposer.Address, tx.Evidence.String())
+}
+
+//-----------------------------------------------------------------------------
+
+// GovernanceProposalTx is submitted by a validator to propose a change of the governance parameters,
+// e.g. the minimum gas price, from the activation height. The unset fields of the changes are left
+// unchanged. The proposer implicitly votes for the proposal.
+type GovernanceProposalTx struct {
+	Fee              Coins                 `json:"fee"`
+	Proposer         TxInput               `json:"proposer"`
+	Changes          core.GovernanceParams `json:"changes"`
+	ActivationHeight uint64                `json:"activation_height"`
+}
+
+func (_ *GovernanceProposalTx) AssertIsTx() {}
+
+func (tx *GovernanceProposalTx) SignBytes(chainID string) []byte {
+	signBytes := encodeToBytes(chainID)
+	sig := tx.Proposer.Signature
+	tx.Proposer.Signature = nil
+	txBytes, _ := TxToBytes(tx)
+	signBytes = append(signBytes, txBytes...)
+	signBytes = addPrefixForSignBytes(signBytes)
+
+	tx.Proposer.Signature = sig
+	return signBytes
+}
+
+func (tx *GovernanceProposalTx) SetSignature(addr common.Address, sig *crypto.Signature) bool {
+	if tx.Proposer.Address == addr {
+		tx.Proposer.Signature = sig
+		return true
+	}
+	return false
+}
+
+func (tx *GovernanceProposalTx) String() string {
+	return fmt.Sprintf("GovernanceProposalTx{proposer: %v, changes: %v, activation height: %v}",
+		tx.Proposer.Address, tx.Changes.String(), tx.ActivationHeight)
+}
+
+//-----------------------------------------------------------------------------
+
+// GovernanceVoteTx is submitted by a validator to vote for a governance proposal, identified by the
+// hash of its GovernanceProposalTx. The votes are weighted by the stakes of the validators.
+type GovernanceVoteTx struct {
+	Fee        Coins       `json:"fee"`
+	Voter      TxInput     `json:"voter"`
+	ProposalID common.Hash `json:"proposal_id"`
+}
+
+func (_ *GovernanceVoteTx) AssertIsTx() {}
+
+func (tx *GovernanceVoteTx) SignBytes(chainID string) []byte {
+	signBytes := encodeToBytes(chainID)
+	sig := tx.Voter.Signature
+	tx.Voter.Signature = nil
+	txBytes, _ := TxToBytes(tx)
+	signBytes = append(signBytes, txBytes...)
+	signBytes = addPrefixForSignBytes(signBytes)
+
+	tx.Voter.Signature = sig
+	return signBytes
+}
+
+func (tx *GovernanceVoteTx) SetSignature(addr common.Address, sig *crypto.Signature) bool {
+	if tx.Voter.Address == addr {
+		tx.Voter.Signature = sig
+		return true
+	}
+	return false
+}
+
+func (tx *GovernanceVoteTx) String() string {
+	return fmt.Sprintf("GovernanceVoteTx{voter: %v, proposal: %v}",
+		tx.Voter.Address, tx.ProposalID.Hex())
+}
+
+//-----------------------------------------------------------------------------
+
+// ValidatorCommissionTx is submitted by a validator to set its commission, i.e. the fraction of the
+// validator rewards it keeps before the rest is distributed to its delegators.
+type ValidatorCommissionTx struct {
+	Fee                  Coins   `json:"fee"`
+	Holder               TxInput `json:"holder"`
+	CommissionBasisPoint uint64  `json:"commission_basis_point"`
+}
+
+func (_ *ValidatorCommissionTx) AssertIsTx() {}
+
+func (tx *ValidatorCommissionTx) SignBytes(chainID string) []byte {
+	signBytes := encodeToBytes(chainID)
+	sig := tx.Holder.Signature
+	tx.Holder.Signature = nil
+	txBytes, _ := TxToBytes(tx)
+	signBytes = append(signBytes, txBytes...)
+	signBytes = addPrefixForSignBytes(signBytes)
+
+	tx.Holder.Signature = sig
+	return signBytes
+}
+
+func (tx *ValidatorCommissionTx) SetSignature(addr common.Address, sig *crypto.Signature) bool {
+	if tx.Holder.Address == addr {
+		tx.Holder.Signature = sig
+		return true
+	}
+	return false
+}
+
+func (tx *ValidatorCommissionTx) String() string {
+	return fmt.Sprintf("ValidatorCommissionTx{holder: %v, commission_basis_point: %v}",
+		tx.Holder.Address, tx.CommissionBasisPoint)
+}
+
+//-----------------------------------------------------------------------------
+
+// ClaimStakeRewardTx is submitted by a stake source to claim the rewards of its stake delegated to
+// the holder. When the source is the holder itself, the commission of the validator is claimed too.
+type ClaimStakeRewardTx struct {
+	Fee    Coins          `json:"fee"`
+	Source TxInput        `json:"source"`
+	Holder common.Address `json:"holder"`
+}
+
+func (_ *ClaimStakeRewardTx) AssertIsTx() {}
+
+func (tx *ClaimStakeRewardTx) SignBytes(chainID string) []byte {
+	signBytes := encodeToBytes(chainID)
+	sig := tx.Source.Signature
+	tx.Source.Signature = nil
+	txBytes, _ := TxToBytes(tx)
+	signBytes = append(signBytes, txBytes...)
+	signBytes = addPrefixForSignBytes(signBytes)
+
+	tx.Source.Signature = sig
+	return signBytes
+}
+
+func (tx *ClaimStakeRewardTx) SetSignature(addr common.Address, sig *crypto.Signature) bool {
+	if tx.Source.Address == addr {
+		tx.Source.Signature = sig
+		return true
+	}
+	return false
+}
+
+func (tx *ClaimStakeRewardTx) String() string {
+	return fmt.Sprintf("ClaimStakeRewardTx{source: %v, holder: %v}",
+		tx.Source.Address, tx.Holder)
+}
+
 // --------------- Utils --------------- //
 
 type EthereumTxWrapper struct {
@@ -0,0 +1,179 @@
+package vm
+
+import (
+	"math/big"
+	"time"
+
+	"github.com/pandoprojects/pando/common"
+	"github.com/pandoprojects/pando/common/hexutil"
+)
+
+// CallFrame is a message call or contract creation in the call tree built by the CallTracer.
+type CallFrame struct {
+	Type    string         `json:"type"`
+	From    common.Address `json:"from"`
+	To      common.Address `json:"to"`
+	Value   *hexutil.Big   `json:"value,omitempty"`
+	Gas     hexutil.Uint64 `json:"gas"`
+	GasUsed hexutil.Uint64 `json:"gasUsed"`
+	Input   hexutil.Bytes  `json:"input"`
+	Output  hexutil.Bytes  `json:"output,omitempty"`
+	Error   string         `json:"error,omitempty"`
+	Calls   []*CallFrame   `json:"calls,omitempty"`
+
+	depth   int    // interpreter depth at which the frame runs
+	gasBase uint64 // gas of the caller after the frame returns if the frame used no gas
+}
+
+// CallTracer is a Tracer which records the tree of the message calls and contract creations
+// of a transaction, instead of the individual steps.
+type CallTracer struct {
+	root      *CallFrame
+	callstack []*CallFrame
+}
+
+// NewCallTracer returns a new call tracer
+func NewCallTracer() *CallTracer {
+	return &CallTracer{}
+}
+
+// CaptureStart implements the Tracer interface to record the top level call.
+func (t *CallTracer) CaptureStart(from common.Address, to common.Address, create bool, input []byte, gas uint64, value *big.Int) error {
+	typ := CALL.String()
+	if create {
+		typ = CREATE.String()
+	}
+	t.root = &CallFrame{
+		Type:  typ,
+		From:  from,
+		To:    to,
+		Value: bigToHex(value),
+		Gas:   hexutil.Uint64(gas),
+		Input: common.CopyBytes(input),
+		depth: 1,
+	}
+	t.callstack = []*CallFrame{t.root}
+	return nil
+}
+
+// CaptureState implements the Tracer interface. The frames deeper than the current depth have
+// returned, and the call and create opcodes open new frames.
+func (t *CallTracer) CaptureState(env *EVM, pc uint64, op OpCode, gas, cost uint64, memory *Memory, stack *Stack, contract *Contract, depth int, err error) error {
+	if len(t.callstack) == 0 {
+		return nil
+	}
+	if err != nil {
+		return t.CaptureFault(env, pc, op, gas, cost, memory, stack, contract, depth, err)
+	}
+	t.unwind(env, depth, gas, stack)
+
+	frame := &CallFrame{
+		Type:  op.String(),
+		From:  contract.Address(),
+		depth: depth + 1,
+	}
+	switch op {
+	case CALL, CALLCODE:
+		if stack.len() < 5 {
+			return nil
+		}
+		frame.To = common.BigToAddress(stack.Back(1))
+		frame.Value = bigToHex(stack.Back(2))
+		frame.Input = memory.Get(stack.Back(3).Int64(), stack.Back(4).Int64())
+		frame.Gas = hexutil.Uint64(env.callGasTemp)
+		frame.gasBase = gas - cost + env.callGasTemp
+	case DELEGATECALL, STATICCALL:
+		if stack.len() < 4 {
+			return nil
+		}
+		frame.To = common.BigToAddress(stack.Back(1))
+		frame.Input = memory.Get(stack.Back(2).Int64(), stack.Back(3).Int64())
+		frame.Gas = hexutil.Uint64(env.callGasTemp)
+		frame.gasBase = gas - cost + env.callGasTemp
+	case CREATE, CREATE2:
+		if stack.len() < 3 {
+			return nil
+		}
+		frame.Value = bigToHex(stack.Back(0))
+		frame.Input = memory.Get(stack.Back(1).Int64(), stack.Back(2).Int64())
+		available := gas - cost
+		frame.Gas = hexutil.Uint64(available - available/64)
+		frame.gasBase = available
+	default:
+		return nil
+	}
+
+	parent := t.callstack[len(t.callstack)-1]
+	parent.Calls = append(parent.Calls, frame)
+	t.callstack = append(t.callstack, frame)
+	return nil
+}
+
+// CaptureFault implements the Tracer interface to record the error of the failing frame.
+func (t *CallTracer) CaptureFault(env *EVM, pc uint64, op OpCode, gas, cost uint64, memory *Memory, stack *Stack, contract *Contract, depth int, err error) error {
+	if len(t.callstack) == 0 {
+		return nil
+	}
+	t.unwind(env, depth, gas, nil)
+	frame := t.callstack[len(t.callstack)-1]
+	if frame.depth == depth && frame.Error == "" && err != nil {
+		frame.Error = err.Error()
+	}
+	return nil
+}
+
+// CaptureEnd implements the Tracer interface to complete the top level call.
+func (t *CallTracer) CaptureEnd(output []byte, gasUsed uint64, d time.Duration, err error) error {
+	if t.root == nil {
+		return nil
+	}
+	t.root.Output = common.CopyBytes(output)
+	t.root.GasUsed = hexutil.Uint64(gasUsed)
+	if err != nil && t.root.Error == "" {
+		t.root.Error = err.Error()
+	}
+	t.callstack = nil
+	return nil
+}
+
+// Result returns the top level call with its sub calls, or nil if nothing was traced.
+func (t *CallTracer) Result() *CallFrame {
+	return t.root
+}
+
+// unwind closes the frames which returned to the caller running at the given depth. The result
+// of the call is on top of the caller's stack, unless the caller itself is failing.
+func (t *CallTracer) unwind(env *EVM, depth int, gas uint64, stack *Stack) {
+	for len(t.callstack) > 1 && t.callstack[len(t.callstack)-1].depth > depth {
+		frame := t.callstack[len(t.callstack)-1]
+		t.callstack = t.callstack[:len(t.callstack)-1]
+
+		if frame.gasBase >= gas {
+			frame.GasUsed = hexutil.Uint64(frame.gasBase - gas)
+		}
+		if stack == nil || stack.len() == 0 {
+			continue
+		}
+		ret := stack.peek()
+		switch frame.Type {
+		case CREATE.String(), CREATE2.String():
+			if ret.Sign() != 0 {
+				frame.To = common.BigToAddress(ret)
+			}
+		default:
+			if in, ok := env.Interpreter().(*EVMInterpreter); ok {
+				frame.Output = common.CopyBytes(in.returnData)
+			}
+		}
+		if ret.Sign() == 0 && frame.Error == "" {
+			frame.Error = "internal failure"
+		}
+	}
+}
+
+func bigToHex(value *big.Int) *hexutil.Big {
+	if value == nil {
+		return nil
+	}
+	return (*hexutil.Big)(new(big.Int).Set(value))
+}
@@ -0,0 +1,77 @@
+package vm
+
+import (
+	"encoding/hex"
+	"math/big"
+	"testing"
+
+	"github.com/pandoprojects/pando/common"
+	"github.com/pandoprojects/pando/core"
+	"github.com/pandoprojects/pando/ledger/state"
+	"github.com/pandoprojects/pando/ledger/types"
+	"github.com/pandoprojects/pando/store/database/backend"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCallTracer(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	storeView := state.NewStoreView(0, common.Hash{}, backend.NewMemDatabase())
+	caller := types.MakeAccWithInitBalance("caller", types.NewCoins(90000000, 50000000000))
+	storeView.SetAccount(caller.Address, &caller.Account)
+
+	// The callee returns 0x2a as a 32 byte word:
+	// push 0x2a, push 0x0, mstore, push 0x20, push 0x0, return
+	callee := common.HexToAddress("0xc0")
+	calleeCode, _ := hex.DecodeString("602a60005260206000f3")
+	storeView.SetCode(callee, calleeCode)
+
+	// The contract calls the callee and returns its output:
+	// push 0x20, push 0x0, push 0x0, push 0x0, push 0x0, push20 callee, push2 0xffff, call,
+	// push 0x20, push 0x0, return
+	contract := common.HexToAddress("0xc1")
+	contractCode, _ := hex.DecodeString("60206000600060006000" + "73" + hex.EncodeToString(callee[:]) +
+		"61fffff160206000f3")
+	storeView.SetCode(contract, contractCode)
+	storeView.IncrementHeight()
+	storeView.Save()
+
+	parentBlock := &core.Block{BlockHeader: &core.BlockHeader{
+		ChainID:   "privatenet",
+		Height:    storeView.Height(),
+		Timestamp: big.NewInt(0),
+	}}
+	tx := &types.SmartContractTx{
+		From:     types.TxInput{Address: caller.Address},
+		To:       types.TxOutput{Address: contract},
+		GasLimit: 100000,
+		GasPrice: big.NewInt(1),
+	}
+
+	tracer := NewCallTracer()
+	ret, _, gasUsed, err := ExecuteWithConfig(parentBlock, tx, storeView, Config{Debug: true, Tracer: tracer})
+	require.Nil(err)
+	assert.Equal(common.BigToHash(big.NewInt(0x2a)).Bytes(), []byte(ret))
+
+	root := tracer.Result()
+	require.NotNil(root)
+	assert.Equal("CALL", root.Type)
+	assert.Equal(caller.Address, root.From)
+	assert.Equal(contract, root.To)
+	assert.Equal([]byte(ret), []byte(root.Output))
+	assert.True(uint64(root.GasUsed) > 0 && uint64(root.GasUsed) < gasUsed)
+	assert.Empty(root.Error)
+
+	require.Equal(1, len(root.Calls))
+	call := root.Calls[0]
+	assert.Equal("CALL", call.Type)
+	assert.Equal(contract, call.From)
+	assert.Equal(callee, call.To)
+	assert.Equal(uint64(0xffff), uint64(call.Gas))
+	assert.True(uint64(call.GasUsed) > 0 && uint64(call.GasUsed) < uint64(root.GasUsed))
+	assert.Equal([]byte(ret), []byte(call.Output))
+	assert.Empty(call.Error)
+	assert.Empty(call.Calls)
+}
@@ -13,6 +13,13 @@ import (
 
 // Execute executes the given smart contract
 func Execute(parentBlock *core.Block, tx *types.SmartContractTx, storeView *state.StoreView) (evmRet common.Bytes,
+	contractAddr common.Address, gasUsed uint64, evmErr error) {
+	return ExecuteWithConfig(parentBlock, tx, storeView, Config{})
+}
+
+// ExecuteWithConfig executes the given smart contract with the given interpreter options, e.g.
+// with a Tracer attached
+func ExecuteWithConfig(parentBlock *core.Block, tx *types.SmartContractTx, storeView *state.StoreView, config Config) (evmRet common.Bytes,
 	contractAddr common.Address, gasUsed uint64, evmErr error) {
 	context := Context{
 		CanTransfer: CanTransfer,
@@ -28,7 +35,6 @@ func Execute(parentBlock *core.Block, tx *types.SmartContractTx, storeView *stat
 	chainConfig := &params.ChainConfig{
 		ChainID: chainIDBigInt,
 	}
-	config := Config{}
 	evm := NewEVM(context, storeView, chainConfig, config)
 
 	value := tx.From.Coins.PTXWei
@@ -49,8 +55,7 @@ func Execute(parentBlock *core.Block, tx *types.SmartContractTx, storeView *stat
 	// if gasLimit > maxGasLimit {
 	// 	return common.Bytes{}, common.Address{}, 0, ErrInvalidGasLimit
 	// }
-	blockHeight := storeView.Height() + 1
-	maxGasLimit := types.GetMaxGasLimit(blockHeight)
+	maxGasLimit := storeView.GetGovernanceParams().MaximumTxGasLimit
 	if new(big.Int).SetUint64(gasLimit).Cmp(maxGasLimit) > 0 {
 		return common.Bytes{}, common.Address{}, 0, ErrInvalidGasLimit
 	}
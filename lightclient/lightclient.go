@@ -0,0 +1,357 @@
+package lightclient
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"sync"
+	"time"
+
+	lru "github.com/hashicorp/golang-lru"
+	log "github.com/sirupsen/logrus"
+	"github.com/spf13/viper"
+
+	"github.com/pandoprojects/pando/common"
+	"github.com/pandoprojects/pando/common/util"
+	"github.com/pandoprojects/pando/core"
+	"github.com/pandoprojects/pando/ledger/state"
+	"github.com/pandoprojects/pando/ledger/types"
+	"github.com/pandoprojects/pando/store"
+)
+
+// validatorSetCacheLimit is the number of validator sets proven from the states of recent blocks
+// kept in memory. The validator set of a block is selected from the state of its grandparent
+// along the HCC links, so only the last few are needed to follow the chain.
+const validatorSetCacheLimit = 16
+
+const (
+	rootKey         = "lc/root"
+	headKey         = "lc/head"
+	headerKeyPrefix = "lc/header/"
+	heightKeyPrefix = "lc/height/"
+)
+
+// LightClient follows the finalized headers of the chain without downloading the block bodies.
+// Starting from a trusted block, it verifies every header with the commit certificate of the
+// block, signed by the validator set which it proves from the state of the earlier blocks. The
+// accounts are then proven on demand against the state root of the verified headers.
+type LightClient struct {
+	source Source
+	store  store.Store
+	logger *log.Entry
+
+	mu      sync.Mutex
+	root    *core.BlockHeader   // The trusted block the light client started from.
+	head    *core.BlockHeader   // The latest verified header.
+	pending []*core.BlockHeader // The headers after head, not proven to be finalized yet.
+	valSets *lru.Cache          // Block hash -> validator set proven from the state of the block.
+
+	pollInterval time.Duration
+
+	// Life cycle
+	wg     *sync.WaitGroup
+	ctx    context.Context
+	cancel context.CancelFunc
+}
+
+// New creates a light client which trusts the block with the given height and hash, and follows
+// the chain from the source. The verified headers are saved to the store, and the light client
+// resumes from the last of them if it was already started from the same block.
+func New(source Source, st store.Store, trustedHeight uint64, trustedHash common.Hash) (*LightClient, error) {
+	valSets, _ := lru.New(validatorSetCacheLimit)
+	lc := &LightClient{
+		source:       source,
+		store:        st,
+		logger:       util.GetLoggerForModule("lightclient"),
+		valSets:      valSets,
+		pollInterval: time.Duration(viper.GetInt(common.CfgLightPollIntervalSecs)) * time.Second,
+		wg:           &sync.WaitGroup{},
+	}
+
+	var rootHash, headHash common.Hash
+	if st.Get([]byte(rootKey), &rootHash) == nil && rootHash == trustedHash && st.Get([]byte(headKey), &headHash) == nil {
+		root, err := lc.loadHeader(rootHash)
+		if err != nil {
+			return nil, err
+		}
+		head, err := lc.loadHeader(headHash)
+		if err != nil {
+			return nil, err
+		}
+		lc.root, lc.head = root, head
+		return lc, nil
+	}
+
+	root, err := source.Header(trustedHeight)
+	if err != nil {
+		return nil, fmt.Errorf("Failed to get the trusted block at height %v: %v", trustedHeight, err)
+	}
+	if root.Height != trustedHeight || root.Hash() != trustedHash {
+		return nil, fmt.Errorf("Trusted block mismatch, expected: %v at height %v, got: %v at height %v",
+			trustedHash.Hex(), trustedHeight, root.Hash().Hex(), root.Height)
+	}
+	if err := lc.saveHeader(root); err != nil {
+		return nil, err
+	}
+	if err := st.Put([]byte(rootKey), trustedHash); err != nil {
+		return nil, err
+	}
+	if err := st.Put([]byte(headKey), trustedHash); err != nil {
+		return nil, err
+	}
+	lc.root, lc.head = root, root
+	return lc, nil
+}
+
+// Start starts following the chain in the background.
+func (lc *LightClient) Start(ctx context.Context) {
+	c, cancel := context.WithCancel(ctx)
+	lc.ctx = c
+	lc.cancel = cancel
+
+	lc.wg.Add(1)
+	go lc.mainLoop()
+}
+
+// Stop notifies the light client to stop without blocking.
+func (lc *LightClient) Stop() {
+	lc.cancel()
+}
+
+// Wait blocks until the light client stops.
+func (lc *LightClient) Wait() {
+	lc.wg.Wait()
+}
+
+func (lc *LightClient) mainLoop() {
+	defer lc.wg.Done()
+
+	timer := time.NewTimer(0)
+	defer timer.Stop()
+	for {
+		select {
+		case <-lc.ctx.Done():
+			return
+		case <-timer.C:
+			if err := lc.Sync(); err != nil {
+				lc.logger.WithFields(log.Fields{"error": err}).Warn("Failed to sync headers")
+			}
+			timer.Reset(lc.pollInterval)
+		}
+	}
+}
+
+// Root returns the trusted block the light client started from.
+func (lc *LightClient) Root() *core.BlockHeader {
+	lc.mu.Lock()
+	defer lc.mu.Unlock()
+
+	return lc.root
+}
+
+// Head returns the latest verified header.
+func (lc *LightClient) Head() *core.BlockHeader {
+	lc.mu.Lock()
+	defer lc.mu.Unlock()
+
+	return lc.head
+}
+
+// HeaderByHeight returns the verified header at the given height.
+func (lc *LightClient) HeaderByHeight(height uint64) (*core.BlockHeader, error) {
+	lc.mu.Lock()
+	defer lc.mu.Unlock()
+
+	if height < lc.root.Height || height > lc.head.Height {
+		return nil, fmt.Errorf("Height %v is out of the verified range [%v, %v]", height, lc.root.Height, lc.head.Height)
+	}
+	var hash common.Hash
+	if err := lc.store.Get(heightKey(height), &hash); err != nil {
+		return nil, fmt.Errorf("Verified header at height %v is not found: %v", height, err)
+	}
+	return lc.loadHeader(hash)
+}
+
+// VerifyAccount fetches the account from the source, and checks its proof against the state root
+// of the verified header at the given height. It returns nil without an error if the proof shows
+// that the account does not exist.
+func (lc *LightClient) VerifyAccount(height uint64, address common.Address) (*types.Account, error) {
+	header, err := lc.HeaderByHeight(height)
+	if err != nil {
+		return nil, err
+	}
+	proof, err := lc.source.AccountProof(header.Hash(), address)
+	if err != nil {
+		return nil, fmt.Errorf("Failed to get the proof of account %v: %v", address.Hex(), err)
+	}
+	account, err := state.VerifyAccountProof(header.StateHash, address, proof)
+	if err != nil {
+		return nil, fmt.Errorf("Invalid proof of account %v at height %v: %v", address.Hex(), height, err)
+	}
+	return account, nil
+}
+
+// Sync follows the chain up to the latest block finalized by the source. The headers which cannot
+// be verified yet are kept until a later header or a commit certificate proves them.
+func (lc *LightClient) Sync() error {
+	lc.mu.Lock()
+	defer lc.mu.Unlock()
+
+	latest, err := lc.source.LatestFinalizedHeight()
+	if err != nil {
+		return err
+	}
+	for height := lc.tail().Height + 1; height <= latest; height++ {
+		header, err := lc.source.Header(height)
+		if err == nil {
+			err = lc.addHeader(header)
+		}
+		if err != nil {
+			lc.pending = nil
+			return err
+		}
+	}
+	if len(lc.pending) == 0 {
+		return nil
+	}
+
+	// The certificate of the last finalized block is in a child block which is not finalized yet.
+	last := len(lc.pending) - 1
+	cc, err := lc.source.CommitCertificate(lc.pending[last].Hash())
+	if err == nil {
+		err = lc.finalize(last, *cc)
+	}
+	if err != nil {
+		lc.pending = nil
+		return err
+	}
+	return nil
+}
+
+// tail returns the last header followed so far.
+func (lc *LightClient) tail() *core.BlockHeader {
+	if len(lc.pending) > 0 {
+		return lc.pending[len(lc.pending)-1]
+	}
+	return lc.head
+}
+
+// addHeader appends the next header to the pending ones. The HCC of the header may certify a
+// pending header, which finalizes it along with its ancestors.
+func (lc *LightClient) addHeader(header *core.BlockHeader) error {
+	tail := lc.tail()
+	if header.Height != tail.Height+1 || header.Parent != tail.Hash() {
+		return fmt.Errorf("Header %v at height %v does not extend %v at height %v",
+			header.Hash().Hex(), header.Height, tail.Hash().Hex(), tail.Height)
+	}
+	if res := header.Validate(lc.root.ChainID); res.IsError() {
+		return fmt.Errorf("Invalid header at height %v: %v", header.Height, res.Message)
+	}
+	lc.pending = append(lc.pending, header)
+
+	for i := len(lc.pending) - 2; i >= 0; i-- {
+		if lc.pending[i].Hash() == header.HCC.BlockHash {
+			return lc.finalize(i, header.HCC)
+		}
+	}
+	return nil
+}
+
+// finalize verifies the commit certificate of the pending header with the given index, and saves
+// it with the pending headers before it.
+func (lc *LightClient) finalize(index int, cc core.CommitCertificate) error {
+	block := lc.pending[index]
+	if cc.BlockHash != block.Hash() {
+		return fmt.Errorf("Commit certificate is for block %v instead of %v", cc.BlockHash.Hex(), block.Hash().Hex())
+	}
+	valSet, err := lc.validatorSetFor(block)
+	if err != nil {
+		return err
+	}
+	if err := ValidateCommitCertificate(valSet, block, cc); err != nil {
+		return fmt.Errorf("Invalid commit certificate for block %v at height %v: %v", block.Hash().Hex(), block.Height, err)
+	}
+
+	for _, header := range lc.pending[:index+1] {
+		if err := lc.saveHeader(header); err != nil {
+			return err
+		}
+	}
+	if err := lc.store.Put([]byte(headKey), block.Hash()); err != nil {
+		return err
+	}
+	lc.head = block
+	lc.pending = append([]*core.BlockHeader{}, lc.pending[index+1:]...)
+
+	lc.logger.WithFields(log.Fields{
+		"height": block.Height,
+		"hash":   block.Hash().Hex(),
+	}).Debug("Verified header")
+	return nil
+}
+
+// validatorSetFor returns the validator set which votes on the block. Like
+// Ledger.GetFinalizedValidatorCandidatePool, it is selected from the state of the grandparent of
+// the block along the HCC links, or of the closest trusted block.
+func (lc *LightClient) validatorSetFor(header *core.BlockHeader) (*core.ValidatorSet, error) {
+	current := header
+	for i := 0; i < 2; i++ {
+		if current.HCC.BlockHash.IsEmpty() || current.Hash() == lc.root.Hash() {
+			break
+		}
+		ancestor, err := lc.findHeader(current.HCC.BlockHash)
+		if err != nil {
+			return nil, fmt.Errorf("Failed to find block %v in the HCC of block %v: %v",
+				current.HCC.BlockHash.Hex(), current.Hash().Hex(), err)
+		}
+		current = ancestor
+	}
+
+	if valSet, ok := lc.valSets.Get(current.Hash()); ok {
+		return valSet.(*core.ValidatorSet), nil
+	}
+	proof, err := lc.source.VCPProof(current.Hash())
+	if err != nil {
+		return nil, fmt.Errorf("Failed to get the VCP proof of block %v: %v", current.Hash().Hex(), err)
+	}
+	valSet, err := ValidatorSetFromVCPProof(current.StateHash, proof)
+	if err != nil {
+		return nil, fmt.Errorf("Invalid VCP proof of block %v at height %v: %v", current.Hash().Hex(), current.Height, err)
+	}
+	lc.valSets.Add(current.Hash(), valSet)
+	return valSet, nil
+}
+
+// findHeader returns the pending or verified header with the given hash.
+func (lc *LightClient) findHeader(hash common.Hash) (*core.BlockHeader, error) {
+	for _, header := range lc.pending {
+		if header.Hash() == hash {
+			return header, nil
+		}
+	}
+	return lc.loadHeader(hash)
+}
+
+func (lc *LightClient) saveHeader(header *core.BlockHeader) error {
+	hash := header.Hash()
+	if err := lc.store.Put(headerKey(hash), header); err != nil {
+		return err
+	}
+	return lc.store.Put(heightKey(header.Height), hash)
+}
+
+func (lc *LightClient) loadHeader(hash common.Hash) (*core.BlockHeader, error) {
+	header := &core.BlockHeader{}
+	if err := lc.store.Get(headerKey(hash), header); err != nil {
+		return nil, err
+	}
+	return header, nil
+}
+
+func headerKey(hash common.Hash) common.Bytes {
+	return common.Bytes(headerKeyPrefix + hash.Hex())
+}
+
+func heightKey(height uint64) common.Bytes {
+	return common.Bytes(heightKeyPrefix + strconv.FormatUint(height, 10))
+}
@@ -0,0 +1,201 @@
+package lightclient
+
+import (
+	"testing"
+	"time"
+
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/pandoprojects/pando/common"
+	"github.com/pandoprojects/pando/common/util"
+	"github.com/pandoprojects/pando/consensus/simulation"
+	"github.com/pandoprojects/pando/core"
+	"github.com/pandoprojects/pando/ledger/state"
+	"github.com/pandoprojects/pando/rlp"
+	"github.com/pandoprojects/pando/store/database/backend"
+	"github.com/pandoprojects/pando/store/kvstore"
+)
+
+// newTestChain runs validators until they finalize the given height, and returns a provider
+// serving the chain of the first one.
+func newTestChain(require *require.Assertions, height uint64) (*simulation.Simulation, *Provider) {
+	viper.Set(common.CfgLogLevels, "*:error")
+	util.InitLog()
+
+	sim, err := simulation.New(simulation.Config{
+		Seed:          1,
+		NumValidators: 4,
+		MinDelay:      10 * time.Millisecond,
+		MaxDelay:      100 * time.Millisecond,
+	})
+	require.Nil(err)
+	require.True(sim.RunUntilFinalized(height, 10*time.Minute))
+
+	node := sim.Nodes()[0]
+	return sim, NewProvider(node.Chain, node.Consensus, node.Ledger.State().DB())
+}
+
+func newTestLightClient(require *require.Assertions, source Source, provider *Provider) *LightClient {
+	root, err := provider.Header(core.GenesisBlockHeight)
+	require.Nil(err)
+	lc, err := New(source, kvstore.NewKVStore(backend.NewMemDatabase()), root.Height, root.Hash())
+	require.Nil(err)
+	return lc
+}
+
+func TestLightClientSync(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	sim, provider := newTestChain(require, 10)
+	defer sim.Stop()
+	node := sim.Nodes()[0]
+
+	st := kvstore.NewKVStore(backend.NewMemDatabase())
+	root, err := provider.Header(core.GenesisBlockHeight)
+	require.Nil(err)
+	lc, err := New(provider, st, root.Height, root.Hash())
+	require.Nil(err)
+	require.Nil(lc.Sync())
+
+	lfb := node.Consensus.GetLastFinalizedBlock()
+	assert.Equal(lfb.Hash(), lc.Head().Hash())
+	for height := root.Height; height <= lfb.Height; height++ {
+		header, err := lc.HeaderByHeight(height)
+		require.Nil(err)
+		expected, err := provider.Header(height)
+		require.Nil(err)
+		assert.Equal(expected.Hash(), header.Hash())
+	}
+	_, err = lc.HeaderByHeight(lfb.Height + 1)
+	assert.NotNil(err)
+
+	// The accounts are proven against the verified headers.
+	address := node.PrivateKey.PublicKey().Address()
+	account, err := lc.VerifyAccount(lfb.Height, address)
+	require.Nil(err)
+	require.NotNil(account)
+	expected := state.NewStoreView(lfb.Height, lfb.StateHash, node.Ledger.State().DB()).GetAccount(address)
+	assert.Equal(expected.Balance.String(), account.Balance.String())
+
+	account, err = lc.VerifyAccount(lfb.Height, common.HexToAddress("0x1234"))
+	assert.Nil(err)
+	assert.Nil(account)
+
+	// The light client follows the chain as it grows, and resumes from the saved headers.
+	require.True(sim.RunUntilFinalized(lfb.Height+5, 10*time.Minute))
+	require.Nil(lc.Sync())
+	assert.Equal(node.Consensus.GetLastFinalizedBlock().Hash(), lc.Head().Hash())
+
+	resumed, err := New(provider, st, root.Height, root.Hash())
+	require.Nil(err)
+	assert.Equal(lc.Head().Hash(), resumed.Head().Hash())
+}
+
+func TestLightClientTrustedBlockMismatch(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	sim, provider := newTestChain(require, 3)
+	defer sim.Stop()
+
+	header, err := provider.Header(2)
+	require.Nil(err)
+	_, err = New(provider, kvstore.NewKVStore(backend.NewMemDatabase()), 1, header.Hash())
+	assert.NotNil(err)
+}
+
+// forgingSource alters the data of a provider.
+type forgingSource struct {
+	*Provider
+	forgeHeader   func(header *core.BlockHeader)
+	forgeVCPProof func(proof *core.VCPProof)
+}
+
+func (s *forgingSource) Header(height uint64) (*core.BlockHeader, error) {
+	header, err := s.Provider.Header(height)
+	if err != nil || s.forgeHeader == nil {
+		return header, err
+	}
+	raw, err := rlp.EncodeToBytes(header)
+	if err != nil {
+		return nil, err
+	}
+	forged := &core.BlockHeader{}
+	if err := rlp.DecodeBytes(raw, forged); err != nil {
+		return nil, err
+	}
+	s.forgeHeader(forged)
+	return forged, nil
+}
+
+func (s *forgingSource) VCPProof(blockHash common.Hash) (*core.VCPProof, error) {
+	proof, err := s.Provider.VCPProof(blockHash)
+	if err != nil || s.forgeVCPProof == nil {
+		return proof, err
+	}
+	s.forgeVCPProof(proof)
+	return proof, nil
+}
+
+func TestLightClientRejectsForgedHeader(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	sim, provider := newTestChain(require, 8)
+	defer sim.Stop()
+
+	// A different state root at height 4 invalidates the signature of the proposer.
+	source := &forgingSource{
+		Provider: provider,
+		forgeHeader: func(header *core.BlockHeader) {
+			if header.Height == 4 {
+				header.StateHash = common.BytesToHash([]byte("forged"))
+			}
+		},
+	}
+	lc := newTestLightClient(require, source, provider)
+	assert.NotNil(lc.Sync())
+	assert.True(lc.Head().Height < 4)
+
+	// A header re-signed by its proposer still lacks the votes of the validators.
+	source.forgeHeader = func(header *core.BlockHeader) {
+		if header.Height != 4 {
+			return
+		}
+		header.HCC = core.CommitCertificate{BlockHash: header.HCC.BlockHash, Votes: core.NewVoteSet()}
+		for _, node := range sim.Nodes() {
+			if node.PrivateKey.PublicKey().Address() == header.Proposer {
+				sig, err := node.PrivateKey.Sign(header.SignBytes())
+				require.Nil(err)
+				header.SetSignature(sig)
+			}
+		}
+	}
+	lc = newTestLightClient(require, source, provider)
+	assert.NotNil(lc.Sync())
+	assert.True(lc.Head().Height < 4)
+}
+
+func TestLightClientRejectsForgedVCPProof(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	sim, provider := newTestChain(require, 5)
+	defer sim.Stop()
+
+	source := &forgingSource{
+		Provider: provider,
+		forgeVCPProof: func(proof *core.VCPProof) {
+			for _, kv := range proof.GetKvs() {
+				kv.Val = append([]byte{}, kv.Val...)
+				kv.Val[len(kv.Val)-1] ^= 1
+			}
+		},
+	}
+	lc := newTestLightClient(require, source, provider)
+	assert.NotNil(lc.Sync())
+	assert.Equal(core.GenesisBlockHeight, lc.Head().Height)
+}
@@ -0,0 +1,292 @@
+package lightclient
+
+import (
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"math/rand"
+	"sync"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+
+	"github.com/pandoprojects/pando/common"
+	"github.com/pandoprojects/pando/core"
+	"github.com/pandoprojects/pando/dispatcher"
+	"github.com/pandoprojects/pando/ledger/state"
+	"github.com/pandoprojects/pando/netsync"
+	"github.com/pandoprojects/pando/p2p"
+	p2ptypes "github.com/pandoprojects/pando/p2p/types"
+	"github.com/pandoprojects/pando/rlp"
+)
+
+// maxPeerAttempts is the number of peers a PeerSource tries before giving up a request.
+const maxPeerAttempts = 3
+
+// The kinds of requests of the light client protocol, one per method of the Source interface.
+const (
+	requestLatestFinalizedHeight byte = iota
+	requestHeader
+	requestCommitCertificate
+	requestVCPProof
+	requestAccountProof
+)
+
+// request is a request of a light client, sent in the first entry of a DataRequest over
+// ChannelIDLightClient.
+type request struct {
+	ID      uint64
+	Kind    byte
+	Height  uint64
+	Hash    common.Hash
+	Address common.Address
+}
+
+// response answers the request with the same ID, in the payload of a DataResponse over
+// ChannelIDLightClient. The payload is the RLP encoded result, unless the error is set.
+type response struct {
+	ID      uint64
+	Error   string
+	Payload common.Bytes
+}
+
+var _ p2p.MessageHandler = (*Handler)(nil)
+
+// Handler handles the messages received over the ChannelIDLightClient channel. A full node serves
+// the requests of the light clients from its Provider, and a light node routes the responses to
+// its PeerSource.
+type Handler struct {
+	dispatcher *dispatcher.Dispatcher
+	provider   Source // nil if the node does not serve light clients
+
+	mu      sync.Mutex
+	nextID  uint64
+	pending map[uint64]chan *response
+}
+
+// NewHandler creates a handler sending its messages through the dispatcher. The provider may be
+// nil for a light node.
+func NewHandler(disp *dispatcher.Dispatcher, provider Source) *Handler {
+	return &Handler{
+		dispatcher: disp,
+		provider:   provider,
+		nextID:     rand.Uint64(),
+		pending:    make(map[uint64]chan *response),
+	}
+}
+
+// GetChannelIDs implements the p2p.MessageHandler interface.
+func (h *Handler) GetChannelIDs() []common.ChannelIDEnum {
+	return []common.ChannelIDEnum{
+		common.ChannelIDLightClient,
+	}
+}
+
+// ParseMessage implements the p2p.MessageHandler interface.
+func (h *Handler) ParseMessage(peerID string, channelID common.ChannelIDEnum, rawMessageBytes common.Bytes) (p2ptypes.Message, error) {
+	message := p2ptypes.Message{
+		PeerID:    peerID,
+		ChannelID: channelID,
+	}
+	data, err := netsync.DecodeMessage(rawMessageBytes)
+	message.Content = data
+	return message, err
+}
+
+// EncodeMessage implements the p2p.MessageHandler interface.
+func (h *Handler) EncodeMessage(message interface{}) (common.Bytes, error) {
+	return netsync.EncodeMessage(message)
+}
+
+// HandleMessage implements the p2p.MessageHandler interface.
+func (h *Handler) HandleMessage(message p2ptypes.Message) error {
+	switch content := message.Content.(type) {
+	case dispatcher.DataRequest:
+		if len(content.Entries) == 0 {
+			return errors.New("Empty light client request")
+		}
+		raw, err := hex.DecodeString(content.Entries[0])
+		if err != nil {
+			return err
+		}
+		req := &request{}
+		if err := rlp.DecodeBytes(raw, req); err != nil {
+			return err
+		}
+		// Reading the proofs may take a while, do not block the network.
+		go h.serve(message.PeerID, req)
+	case dispatcher.DataResponse:
+		resp := &response{}
+		if err := rlp.DecodeBytes(content.Payload, resp); err != nil {
+			return err
+		}
+		h.mu.Lock()
+		ch, ok := h.pending[resp.ID]
+		delete(h.pending, resp.ID)
+		h.mu.Unlock()
+		if ok {
+			ch <- resp
+		}
+	default:
+		return fmt.Errorf("Unexpected light client message: %v", message.Content)
+	}
+	return nil
+}
+
+// serve answers the request of a peer from the provider.
+func (h *Handler) serve(peerID string, req *request) {
+	resp := &response{ID: req.ID}
+	result, err := h.query(req)
+	if err == nil {
+		resp.Payload, err = rlp.EncodeToBytes(result)
+	}
+	if err != nil {
+		resp.Error = err.Error()
+	}
+
+	payload, err := rlp.EncodeToBytes(resp)
+	if err != nil {
+		log.WithFields(log.Fields{"error": err}).Warn("Failed to encode light client response")
+		return
+	}
+	h.dispatcher.SendData([]string{peerID}, dispatcher.DataResponse{
+		ChannelID: common.ChannelIDLightClient,
+		Payload:   payload,
+	})
+}
+
+func (h *Handler) query(req *request) (interface{}, error) {
+	if h.provider == nil {
+		return nil, errors.New("Light clients are not served")
+	}
+	switch req.Kind {
+	case requestLatestFinalizedHeight:
+		return h.provider.LatestFinalizedHeight()
+	case requestHeader:
+		return h.provider.Header(req.Height)
+	case requestCommitCertificate:
+		return h.provider.CommitCertificate(req.Hash)
+	case requestVCPProof:
+		return h.provider.VCPProof(req.Hash)
+	case requestAccountProof:
+		return h.provider.AccountProof(req.Hash, req.Address)
+	}
+	return nil, fmt.Errorf("Unknown light client request: %v", req.Kind)
+}
+
+// request sends the request to the peer, and waits for the response until the timeout.
+func (h *Handler) request(peerID string, req request, timeout time.Duration) (*response, error) {
+	ch := make(chan *response, 1)
+	h.mu.Lock()
+	h.nextID++
+	req.ID = h.nextID
+	h.pending[req.ID] = ch
+	h.mu.Unlock()
+
+	defer func() {
+		h.mu.Lock()
+		delete(h.pending, req.ID)
+		h.mu.Unlock()
+	}()
+
+	raw, err := rlp.EncodeToBytes(req)
+	if err != nil {
+		return nil, err
+	}
+	h.dispatcher.GetData([]string{peerID}, dispatcher.DataRequest{
+		ChannelID: common.ChannelIDLightClient,
+		Entries:   []string{hex.EncodeToString(raw)},
+	})
+
+	select {
+	case resp := <-ch:
+		return resp, nil
+	case <-time.After(timeout):
+		return nil, fmt.Errorf("Light client request to %v timed out", peerID)
+	}
+}
+
+var _ Source = (*PeerSource)(nil)
+
+// PeerSource follows the chain from the p2p peers of a light node. Each request is sent to a
+// random peer, and to another one if it fails.
+type PeerSource struct {
+	handler *Handler
+	timeout time.Duration
+}
+
+// NewPeerSource creates a source sending its requests through the handler.
+func NewPeerSource(handler *Handler, timeout time.Duration) *PeerSource {
+	return &PeerSource{
+		handler: handler,
+		timeout: timeout,
+	}
+}
+
+// LatestFinalizedHeight implements the Source interface.
+func (s *PeerSource) LatestFinalizedHeight() (uint64, error) {
+	var height uint64
+	err := s.call(request{Kind: requestLatestFinalizedHeight}, &height)
+	return height, err
+}
+
+// Header implements the Source interface.
+func (s *PeerSource) Header(height uint64) (*core.BlockHeader, error) {
+	header := &core.BlockHeader{}
+	if err := s.call(request{Kind: requestHeader, Height: height}, header); err != nil {
+		return nil, err
+	}
+	return header, nil
+}
+
+// CommitCertificate implements the Source interface.
+func (s *PeerSource) CommitCertificate(blockHash common.Hash) (*core.CommitCertificate, error) {
+	cc := &core.CommitCertificate{}
+	if err := s.call(request{Kind: requestCommitCertificate, Hash: blockHash}, cc); err != nil {
+		return nil, err
+	}
+	return cc, nil
+}
+
+// VCPProof implements the Source interface.
+func (s *PeerSource) VCPProof(blockHash common.Hash) (*core.VCPProof, error) {
+	proof := &core.VCPProof{}
+	if err := s.call(request{Kind: requestVCPProof, Hash: blockHash}, proof); err != nil {
+		return nil, err
+	}
+	return proof, nil
+}
+
+// AccountProof implements the Source interface.
+func (s *PeerSource) AccountProof(blockHash common.Hash, address common.Address) (state.StateProof, error) {
+	proof := state.StateProof{}
+	if err := s.call(request{Kind: requestAccountProof, Hash: blockHash, Address: address}, &proof); err != nil {
+		return nil, err
+	}
+	return proof, nil
+}
+
+func (s *PeerSource) call(req request, result interface{}) error {
+	peers := s.handler.dispatcher.Peers(true)
+	if len(peers) == 0 {
+		return errors.New("No peer to query")
+	}
+	rand.Shuffle(len(peers), func(i, j int) { peers[i], peers[j] = peers[j], peers[i] })
+
+	var err error
+	for i := 0; i < len(peers) && i < maxPeerAttempts; i++ {
+		var resp *response
+		resp, err = s.handler.request(peers[i], req, s.timeout)
+		if err != nil {
+			continue
+		}
+		if resp.Error != "" {
+			err = fmt.Errorf("Peer %v failed the light client request: %v", peers[i], resp.Error)
+			continue
+		}
+		if err = rlp.DecodeBytes(resp.Payload, result); err == nil {
+			return nil
+		}
+	}
+	return err
+}
@@ -0,0 +1,110 @@
+package lightclient
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/pandoprojects/pando/common"
+	"github.com/pandoprojects/pando/dispatcher"
+	"github.com/pandoprojects/pando/p2p"
+	p2ptypes "github.com/pandoprojects/pando/p2p/types"
+	msgl "github.com/pandoprojects/pando/p2pl/messenger"
+)
+
+// loopback is a p2p network with a single peer, which encodes the messages like the real
+// networks before passing them to the handlers of the peer.
+type loopback struct {
+	id       string
+	peer     *loopback
+	handlers map[common.ChannelIDEnum]p2p.MessageHandler
+}
+
+var _ p2p.Network = (*loopback)(nil)
+
+func newLoopbackPair() (*loopback, *loopback) {
+	a := &loopback{id: "a", handlers: make(map[common.ChannelIDEnum]p2p.MessageHandler)}
+	b := &loopback{id: "b", handlers: make(map[common.ChannelIDEnum]p2p.MessageHandler)}
+	a.peer, b.peer = b, a
+	return a, b
+}
+
+func (l *loopback) Start(ctx context.Context) error { return nil }
+func (l *loopback) Wait()                           {}
+func (l *loopback) Stop()                           {}
+
+func (l *loopback) Broadcast(message p2ptypes.Message, skipRametronenterprise bool) chan bool {
+	return l.BroadcastToNeighbors(message, 1, skipRametronenterprise)
+}
+
+func (l *loopback) BroadcastToNeighbors(message p2ptypes.Message, maxNumPeersToBroadcast int, skipRametronenterprise bool) chan bool {
+	success := make(chan bool, 1)
+	success <- l.Send(l.peer.id, message)
+	return success
+}
+
+func (l *loopback) Send(peerID string, message p2ptypes.Message) bool {
+	if peerID != l.peer.id {
+		return false
+	}
+	raw, err := l.handlers[message.ChannelID].EncodeMessage(message.Content)
+	if err != nil {
+		return false
+	}
+	handler := l.peer.handlers[message.ChannelID]
+	parsed, err := handler.ParseMessage(l.id, message.ChannelID, raw)
+	if err != nil {
+		return false
+	}
+	return handler.HandleMessage(parsed) == nil
+}
+
+func (l *loopback) Peers(skipRametronenterprise bool) []string { return []string{l.peer.id} }
+func (l *loopback) PeerURLs(skipRametronenterprise bool) []string {
+	return l.Peers(skipRametronenterprise)
+}
+func (l *loopback) PeerExists(peerID string) bool { return peerID == l.peer.id }
+func (l *loopback) ID() string                    { return l.id }
+func (l *loopback) RegisterMessageHandler(handler p2p.MessageHandler) {
+	for _, channelID := range handler.GetChannelIDs() {
+		l.handlers[channelID] = handler
+	}
+}
+
+func TestPeerSource(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	sim, provider := newTestChain(require, 6)
+	defer sim.Stop()
+	node := sim.Nodes()[0]
+
+	var noNetwork *msgl.Messenger
+	full, light := newLoopbackPair()
+	fullDispatcher := dispatcher.NewDispatcher(full, noNetwork)
+	fullHandler := NewHandler(fullDispatcher, provider)
+	full.RegisterMessageHandler(fullHandler)
+	lightDispatcher := dispatcher.NewDispatcher(light, noNetwork)
+	handler := NewHandler(lightDispatcher, nil)
+	light.RegisterMessageHandler(handler)
+
+	source := NewPeerSource(handler, 5*time.Second)
+	lc := newTestLightClient(require, source, provider)
+	require.Nil(lc.Sync())
+	lfb := node.Consensus.GetLastFinalizedBlock()
+	assert.Equal(lfb.Hash(), lc.Head().Hash())
+
+	address := node.PrivateKey.PublicKey().Address()
+	account, err := lc.VerifyAccount(lfb.Height, address)
+	require.Nil(err)
+	require.NotNil(account)
+
+	// The errors of the provider are passed to the light client, and a light node serves nothing.
+	_, err = source.Header(lfb.Height + 100)
+	assert.NotNil(err)
+	_, err = NewPeerSource(fullHandler, 5*time.Second).LatestFinalizedHeight()
+	assert.NotNil(err)
+}
@@ -0,0 +1,134 @@
+package lightclient
+
+import (
+	"encoding/hex"
+	"fmt"
+
+	rpcc "github.com/ybbus/jsonrpc"
+
+	"github.com/pandoprojects/pando/common"
+	"github.com/pandoprojects/pando/core"
+	"github.com/pandoprojects/pando/ledger/state"
+	"github.com/pandoprojects/pando/rlp"
+)
+
+var _ Source = (*RPCSource)(nil)
+
+// RPCSource follows the chain from the RPC API of a full node.
+type RPCSource struct {
+	client *rpcc.RPCClient
+}
+
+// NewRPCSource creates a source querying the RPC API at the given endpoint.
+func NewRPCSource(endpoint string) *RPCSource {
+	return &RPCSource{
+		client: rpcc.NewRPCClient(endpoint),
+	}
+}
+
+// LatestFinalizedHeight implements the Source interface.
+func (s *RPCSource) LatestFinalizedHeight() (uint64, error) {
+	result := struct {
+		LatestFinalizedBlockHeight common.JSONUint64 `json:"latest_finalized_block_height"`
+	}{}
+	if err := s.call("pando.GetStatus", struct{}{}, &result); err != nil {
+		return 0, err
+	}
+	return uint64(result.LatestFinalizedBlockHeight), nil
+}
+
+// Header implements the Source interface.
+func (s *RPCSource) Header(height uint64) (*core.BlockHeader, error) {
+	args := struct {
+		Height common.JSONUint64 `json:"height"`
+	}{common.JSONUint64(height)}
+	result := struct {
+		Header string `json:"header"`
+	}{}
+	if err := s.call("pando.GetBlockHeader", args, &result); err != nil {
+		return nil, err
+	}
+	header := &core.BlockHeader{}
+	if err := decodeHex(result.Header, header); err != nil {
+		return nil, err
+	}
+	return header, nil
+}
+
+// CommitCertificate implements the Source interface.
+func (s *RPCSource) CommitCertificate(blockHash common.Hash) (*core.CommitCertificate, error) {
+	args := struct {
+		BlockHash common.Hash `json:"block_hash"`
+	}{blockHash}
+	result := struct {
+		CommitCertificate string `json:"commit_certificate"`
+	}{}
+	if err := s.call("pando.GetCommitCertificate", args, &result); err != nil {
+		return nil, err
+	}
+	cc := &core.CommitCertificate{}
+	if err := decodeHex(result.CommitCertificate, cc); err != nil {
+		return nil, err
+	}
+	return cc, nil
+}
+
+// VCPProof implements the Source interface.
+func (s *RPCSource) VCPProof(blockHash common.Hash) (*core.VCPProof, error) {
+	args := struct {
+		BlockHash common.Hash `json:"block_hash"`
+	}{blockHash}
+	result := struct {
+		Proof string `json:"proof"`
+	}{}
+	if err := s.call("pando.GetVcpProof", args, &result); err != nil {
+		return nil, err
+	}
+	proof := &core.VCPProof{}
+	if err := decodeHex(result.Proof, proof); err != nil {
+		return nil, err
+	}
+	return proof, nil
+}
+
+// AccountProof implements the Source interface.
+func (s *RPCSource) AccountProof(blockHash common.Hash, address common.Address) (state.StateProof, error) {
+	args := struct {
+		Address   string      `json:"address"`
+		BlockHash common.Hash `json:"block_hash"`
+	}{address.Hex(), blockHash}
+	result := struct {
+		AccountProof []string `json:"account_proof"`
+	}{}
+	if err := s.call("pando.GetProof", args, &result); err != nil {
+		return nil, err
+	}
+	proof := state.StateProof{}
+	for _, node := range result.AccountProof {
+		raw, err := hex.DecodeString(node)
+		if err != nil {
+			return nil, err
+		}
+		proof = append(proof, raw)
+	}
+	return proof, nil
+}
+
+func (s *RPCSource) call(method string, args interface{}, result interface{}) error {
+	res, err := s.client.Call(method, args)
+	if err != nil {
+		return fmt.Errorf("Failed to call %v: %v", method, err)
+	}
+	if res.Error != nil {
+		return fmt.Errorf("Failed to call %v: %v", method, res.Error)
+	}
+	return res.GetObject(result)
+}
+
+func decodeHex(str string, value interface{}) error {
+	raw, err := hex.DecodeString(str)
+	if err != nil {
+		return err
+	}
+	return rlp.DecodeBytes(raw, value)
+}
@@ -0,0 +1,120 @@
+package lightclient
+
+import (
+	"fmt"
+
+	"github.com/pandoprojects/pando/blockchain"
+	"github.com/pandoprojects/pando/common"
+	"github.com/pandoprojects/pando/core"
+	"github.com/pandoprojects/pando/ledger/state"
+	"github.com/pandoprojects/pando/store/database"
+)
+
+// Source provides the chain data followed by a light client. The sources are not trusted: the
+// light client verifies everything it receives against the headers it has already verified.
+type Source interface {
+	// LatestFinalizedHeight returns the height of the latest block finalized by the source.
+	LatestFinalizedHeight() (uint64, error)
+
+	// Header returns the header of the finalized block at the given height.
+	Header(height uint64) (*core.BlockHeader, error)
+
+	// CommitCertificate returns a commit certificate of the block, i.e. the HCC of a child block
+	// which points to it.
+	CommitCertificate(blockHash common.Hash) (*core.CommitCertificate, error)
+
+	// VCPProof returns the merkle proof of the validator candidate pool in the state of the block.
+	VCPProof(blockHash common.Hash) (*core.VCPProof, error)
+
+	// AccountProof returns the merkle proof of the account in the state of the block.
+	AccountProof(blockHash common.Hash, address common.Address) (state.StateProof, error)
+}
+
+var _ Source = (*Provider)(nil)
+
+// Provider serves the data of a full node to the light clients, through the RPC API or the p2p
+// network.
+type Provider struct {
+	chain     *blockchain.Chain
+	consensus core.ConsensusEngine
+	db        database.Database
+}
+
+// NewProvider creates a Provider reading the blocks from the chain and the states from the database.
+func NewProvider(chain *blockchain.Chain, consensus core.ConsensusEngine, db database.Database) *Provider {
+	return &Provider{
+		chain:     chain,
+		consensus: consensus,
+		db:        db,
+	}
+}
+
+// LatestFinalizedHeight implements the Source interface.
+func (p *Provider) LatestFinalizedHeight() (uint64, error) {
+	return p.consensus.GetLastFinalizedBlock().Height, nil
+}
+
+// Header implements the Source interface.
+func (p *Provider) Header(height uint64) (*core.BlockHeader, error) {
+	for _, block := range p.chain.FindBlocksByHeight(height) {
+		if block.Status.IsFinalized() {
+			return block.BlockHeader, nil
+		}
+	}
+	return nil, fmt.Errorf("Finalized block at height %v is not found", height)
+}
+
+// CommitCertificate implements the Source interface.
+func (p *Provider) CommitCertificate(blockHash common.Hash) (*core.CommitCertificate, error) {
+	block, err := p.chain.FindBlock(blockHash)
+	if err != nil {
+		return nil, fmt.Errorf("Block %v is not found", blockHash.Hex())
+	}
+	for _, childHash := range block.Children {
+		child, err := p.chain.FindBlock(childHash)
+		if err != nil || child.HCC.BlockHash != blockHash {
+			continue
+		}
+		cc := child.HCC.Copy()
+		return &cc, nil
+	}
+	return nil, fmt.Errorf("No commit certificate for block %v", blockHash.Hex())
+}
+
+// VCPProof implements the Source interface.
+func (p *Provider) VCPProof(blockHash common.Hash) (*core.VCPProof, error) {
+	sv, err := p.storeView(blockHash)
+	if err != nil {
+		return nil, err
+	}
+	proof := &core.VCPProof{}
+	if err := sv.ProveVCP(state.ValidatorCandidatePoolKey(), proof); err != nil {
+		return nil, err
+	}
+	return proof, nil
+}
+
+// AccountProof implements the Source interface.
+func (p *Provider) AccountProof(blockHash common.Hash, address common.Address) (state.StateProof, error) {
+	sv, err := p.storeView(blockHash)
+	if err != nil {
+		return nil, err
+	}
+	return sv.ProveAccount(address)
+}
+
+// storeView opens the state of the block, which must have been processed and not pruned.
+func (p *Provider) storeView(blockHash common.Hash) (*state.StoreView, error) {
+	block, err := p.chain.FindBlock(blockHash)
+	if err != nil {
+		return nil, fmt.Errorf("Block %v is not found", blockHash.Hex())
+	}
+	if !block.Status.IsValid() {
+		return nil, fmt.Errorf("Block %v has not been processed, status: %v", blockHash.Hex(), block.Status)
+	}
+	sv := state.NewStoreView(block.Height, block.StateHash, p.db)
+	if sv == nil {
+		return nil, fmt.Errorf("State of block %v at height %v is no longer available", blockHash.Hex(), block.Height)
+	}
+	return sv, nil
+}
@@ -0,0 +1,92 @@
+package lightclient
+
+import (
+	"bytes"
+	"fmt"
+
+	"github.com/pandoprojects/pando/common"
+	"github.com/pandoprojects/pando/consensus"
+	"github.com/pandoprojects/pando/core"
+	"github.com/pandoprojects/pando/crypto"
+	"github.com/pandoprojects/pando/ledger/state"
+	"github.com/pandoprojects/pando/rlp"
+	"github.com/pandoprojects/pando/store/trie"
+)
+
+// ValidatorSetFromVCPProof checks the merkle proof of the validator candidate pool against the
+// state root, and returns the validator set selected from the proven pool.
+func ValidatorSetFromVCPProof(stateHash common.Hash, proof *core.VCPProof) (*core.ValidatorSet, error) {
+	serializedVCP, _, err := trie.VerifyProof(stateHash, state.ValidatorCandidatePoolKey(), hashedProof{proof})
+	if err != nil {
+		return nil, err
+	}
+	if len(serializedVCP) == 0 {
+		return nil, fmt.Errorf("validator candidate pool is absent from state %v", stateHash.Hex())
+	}
+
+	vcp := &core.ValidatorCandidatePool{}
+	err = rlp.DecodeBytes(serializedVCP, vcp)
+	if err != nil {
+		return nil, err
+	}
+	return consensus.SelectTopStakeHoldersAsValidators(vcp), nil
+}
+
+// ValidateVotes checks that the votes are signed by a majority of the validators for the block.
+func ValidateVotes(validatorSet *core.ValidatorSet, block *core.BlockHeader, voteSet *core.VoteSet) error {
+	if voteSet == nil || !validatorSet.HasMajority(voteSet) {
+		return fmt.Errorf("block doesn't have majority votes")
+	}
+	for _, vote := range voteSet.Votes() {
+		res := vote.Validate()
+		if !res.IsOK() {
+			return fmt.Errorf("vote is not valid, %v", res)
+		}
+		if vote.Block != block.Hash() {
+			return fmt.Errorf("vote is not for corresponding block")
+		}
+		_, err := validatorSet.GetValidator(vote.ID)
+		if err != nil {
+			return fmt.Errorf("can't find validator for vote")
+		}
+	}
+	return nil
+}
+
+// ValidateCommitCertificate checks that the certificate proves the commit of the block. The
+// aggregated votes are verified at once, instead of one signature per vote.
+func ValidateCommitCertificate(validatorSet *core.ValidatorSet, block *core.BlockHeader, cc core.CommitCertificate) error {
+	if cc.AggregatedVotes == nil {
+		return ValidateVotes(validatorSet, block, cc.Votes)
+	}
+	if cc.BlockHash != block.Hash() {
+		return fmt.Errorf("commit certificate is not for corresponding block")
+	}
+	if !cc.IsValid(validatorSet) {
+		return fmt.Errorf("commit certificate is not valid")
+	}
+	return nil
+}
+
+// hashedProof looks up the nodes of a VCP proof like the trie does, by their hash. Unlike
+// VCPProof.Get, it refuses the nodes which do not hash to their key, since the proofs come from
+// untrusted peers.
+type hashedProof struct {
+	proof *core.VCPProof
+}
+
+func (p hashedProof) Get(key []byte) ([]byte, error) {
+	value, err := p.proof.Get(key)
+	if err != nil {
+		return nil, err
+	}
+	if !bytes.Equal(crypto.Keccak256(value), key) {
+		return nil, fmt.Errorf("proof node %x does not match its hash", key)
+	}
+	return value, nil
+}
+
+func (p hashedProof) Has(key []byte) (bool, error) {
+	_, err := p.Get(key)
+	return err == nil, nil
+}
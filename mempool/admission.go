@@ -0,0 +1,331 @@
+package mempool
+
+import (
+	"fmt"
+	"math/big"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/spf13/viper"
+
+	"github.com/pandoprojects/pando/common"
+	"github.com/pandoprojects/pando/core"
+	"github.com/pandoprojects/pando/ledger/types"
+)
+
+// AdmissionReason is the reason code of an admission decision, reported to the RPC clients
+type AdmissionReason string
+
+const (
+	ReasonGasPriceTooLow      AdmissionReason = "gas_price_too_low"
+	ReasonSenderQuotaExceeded AdmissionReason = "sender_quota_exceeded"
+	ReasonPeerRateLimited     AdmissionReason = "peer_rate_limited"
+	ReasonTargetDenied        AdmissionReason = "target_denied"
+	ReasonTargetNotAllowed    AdmissionReason = "target_not_allowed"
+)
+
+// AdmissionError is returned when a transaction is refused by an admission policy
+type AdmissionError struct {
+	Policy  string
+	Reason  AdmissionReason
+	Message string
+}
+
+func (e *AdmissionError) Error() string {
+	return fmt.Sprintf("Transaction refused by the %v policy (%v): %v", e.Policy, e.Reason, e.Message)
+}
+
+// AdmissionCandidate is a transaction to be admitted into the mempool. The policies run before the
+// screening, so that a refused transaction leaves the screened state untouched.
+type AdmissionCandidate struct {
+	RawTx         common.Bytes
+	Tx            types.Tx // nil if the transaction cannot be decoded
+	TxInfo        *core.TxInfo
+	NumPendingTxs int  // number of the transactions of the sender in the mempool, including the queued ones
+	Replacement   bool // whether the transaction replaces a pending one with the same sequence
+}
+
+// AdmissionPolicy enforces a local policy on the transactions inserted into the mempool, on top of the
+// protocol rules checked by the screening. The policies are applied in the order they are added, and
+// the first refusal rejects the transaction.
+type AdmissionPolicy interface {
+	Name() string
+	Admit(candidate *AdmissionCandidate) *AdmissionError
+}
+
+// AddAdmissionPolicy appends the policy to the admission-policy chain of the mempool
+func (mp *Mempool) AddAdmissionPolicy(policy AdmissionPolicy) {
+	mp.mutex.Lock()
+	defer mp.mutex.Unlock()
+
+	mp.admissionPolicies = append(mp.admissionPolicies, policy)
+}
+
+// admitTx runs the transaction through the admission-policy chain, before it is screened.
+func (mp *Mempool) admitTx(rawTx common.Bytes, txInfo *core.TxInfo, replacement bool) error {
+	if len(mp.admissionPolicies) == 0 {
+		return nil
+	}
+
+	candidate := &AdmissionCandidate{
+		RawTx:       rawTx,
+		TxInfo:      txInfo,
+		Replacement: replacement,
+	}
+	if tx, err := types.TxFromBytes(rawTx); err == nil {
+		candidate.Tx = tx
+	}
+	if txGroup, ok := mp.addressToTxGroup[txInfo.Address]; ok {
+		candidate.NumPendingTxs += txGroup.txs.NumElements()
+	}
+	if queuedGroup, ok := mp.queuedTxs[txInfo.Address]; ok {
+		candidate.NumPendingTxs += len(queuedGroup.txs)
+		if _, exists := queuedGroup.txs[txInfo.Sequence]; exists {
+			candidate.Replacement = true
+		}
+	}
+
+	for _, policy := range mp.admissionPolicies {
+		if admissionErr := policy.Admit(candidate); admissionErr != nil {
+			logger.Debugf("Transaction refused, tx.hash: 0x%v, error: %v", getTransactionHash(rawTx), admissionErr)
+			return admissionErr
+		}
+	}
+	return nil
+}
+
+// createAdmissionPolicies creates the admission policies enabled in the config.
+func createAdmissionPolicies() []AdmissionPolicy {
+	policies := []AdmissionPolicy{}
+
+	if minGasPriceStr := viper.GetString(common.CfgMempoolMinGasPrice); minGasPriceStr != "" {
+		minGasPrice, ok := new(big.Int).SetString(minGasPriceStr, 10)
+		if ok && minGasPrice.Sign() > 0 {
+			policies = append(policies, NewMinGasPricePolicy(minGasPrice))
+		} else if !ok {
+			logger.Warnf("Invalid %v: %v", common.CfgMempoolMinGasPrice, minGasPriceStr)
+		}
+	}
+
+	if maxPendingTxs := viper.GetInt(common.CfgMempoolMaxPendingTxsPerSender); maxPendingTxs > 0 {
+		policies = append(policies, NewSenderQuotaPolicy(maxPendingTxs))
+	}
+
+	allowList := parseAddressList(common.CfgMempoolContractAllowList)
+	denyList := parseAddressList(common.CfgMempoolContractDenyList)
+	if len(allowList) > 0 || len(denyList) > 0 {
+		policies = append(policies, NewContractTargetPolicy(allowList, denyList))
+	}
+
+	return policies
+}
+
+// parseAddressList parses the comma separated addresses of the config key.
+func parseAddressList(key string) []common.Address {
+	addresses := []common.Address{}
+	for _, entry := range strings.Split(viper.GetString(key), ",") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+		if !common.IsHexAddress(entry) {
+			logger.Warnf("Invalid address in %v: %v", key, entry)
+			continue
+		}
+		addresses = append(addresses, common.HexToAddress(entry))
+	}
+	return addresses
+}
+
+// MinGasPricePolicy refuses the transactions paying an effective gas price below a local minimum. The
+// protocol minimum is already enforced by the screening, so the local minimum only matters above it.
+type MinGasPricePolicy struct {
+	minGasPrice *big.Int
+}
+
+var _ AdmissionPolicy = (*MinGasPricePolicy)(nil)
+
+// NewMinGasPricePolicy creates an instance of MinGasPricePolicy
+func NewMinGasPricePolicy(minGasPrice *big.Int) *MinGasPricePolicy {
+	return &MinGasPricePolicy{
+		minGasPrice: minGasPrice,
+	}
+}
+
+// Name implements the AdmissionPolicy interface
+func (p *MinGasPricePolicy) Name() string {
+	return "min_gas_price"
+}
+
+// Admit implements the AdmissionPolicy interface
+func (p *MinGasPricePolicy) Admit(candidate *AdmissionCandidate) *AdmissionError {
+	gasPrice := candidate.TxInfo.EffectiveGasPrice
+	if gasPrice != nil && gasPrice.Cmp(p.minGasPrice) >= 0 {
+		return nil
+	}
+	return &AdmissionError{
+		Policy:  p.Name(),
+		Reason:  ReasonGasPriceTooLow,
+		Message: fmt.Sprintf("effective gas price %v is below the minimum %v of this node", gasPrice, p.minGasPrice),
+	}
+}
+
+// SenderQuotaPolicy caps the number of the pending transactions of a sender, so that a single address
+// cannot fill the mempool. Replacing a pending transaction does not count against the quota.
+type SenderQuotaPolicy struct {
+	maxPendingTxs int
+}
+
+var _ AdmissionPolicy = (*SenderQuotaPolicy)(nil)
+
+// NewSenderQuotaPolicy creates an instance of SenderQuotaPolicy
+func NewSenderQuotaPolicy(maxPendingTxs int) *SenderQuotaPolicy {
+	return &SenderQuotaPolicy{
+		maxPendingTxs: maxPendingTxs,
+	}
+}
+
+// Name implements the AdmissionPolicy interface
+func (p *SenderQuotaPolicy) Name() string {
+	return "sender_quota"
+}
+
+// Admit implements the AdmissionPolicy interface
+func (p *SenderQuotaPolicy) Admit(candidate *AdmissionCandidate) *AdmissionError {
+	if candidate.Replacement || candidate.NumPendingTxs < p.maxPendingTxs {
+		return nil
+	}
+	return &AdmissionError{
+		Policy:  p.Name(),
+		Reason:  ReasonSenderQuotaExceeded,
+		Message: fmt.Sprintf("%v already has %v pending transactions", candidate.TxInfo.Address.Hex(), candidate.NumPendingTxs),
+	}
+}
+
+// ContractTargetPolicy restricts the contracts the smart contract transactions can call. The contracts
+// on the deny list are refused, and if the allow list is not empty, only the contracts on it are accepted.
+// The contract deployments have no target, and are not affected.
+type ContractTargetPolicy struct {
+	allowed map[common.Address]bool
+	denied  map[common.Address]bool
+}
+
+var _ AdmissionPolicy = (*ContractTargetPolicy)(nil)
+
+// NewContractTargetPolicy creates an instance of ContractTargetPolicy
+func NewContractTargetPolicy(allowList []common.Address, denyList []common.Address) *ContractTargetPolicy {
+	policy := &ContractTargetPolicy{
+		allowed: make(map[common.Address]bool),
+		denied:  make(map[common.Address]bool),
+	}
+	for _, address := range allowList {
+		policy.allowed[address] = true
+	}
+	for _, address := range denyList {
+		policy.denied[address] = true
+	}
+	return policy
+}
+
+// Name implements the AdmissionPolicy interface
+func (p *ContractTargetPolicy) Name() string {
+	return "contract_target"
+}
+
+// Admit implements the AdmissionPolicy interface
+func (p *ContractTargetPolicy) Admit(candidate *AdmissionCandidate) *AdmissionError {
+	tx, ok := candidate.Tx.(*types.SmartContractTx)
+	if !ok || tx.To.Address == (common.Address{}) {
+		return nil
+	}
+
+	target := tx.To.Address
+	if p.denied[target] {
+		return &AdmissionError{
+			Policy:  p.Name(),
+			Reason:  ReasonTargetDenied,
+			Message: fmt.Sprintf("contract %v is on the deny list of this node", target.Hex()),
+		}
+	}
+	if len(p.allowed) > 0 && !p.allowed[target] {
+		return &AdmissionError{
+			Policy:  p.Name(),
+			Reason:  ReasonTargetNotAllowed,
+			Message: fmt.Sprintf("contract %v is not on the allow list of this node", target.Hex()),
+		}
+	}
+	return nil
+}
+
+// peerRateLimiter limits the rate of the transactions each peer relays to the node, using a token
+// bucket per peer. A nil peerRateLimiter allows everything.
+type peerRateLimiter struct {
+	mutex *sync.Mutex
+
+	rate    float64 // tokens added to a bucket per second
+	burst   float64 // capacity of a bucket
+	buckets map[string]*peerBucket
+
+	now func() time.Time
+}
+
+type peerBucket struct {
+	tokens  float64
+	updated time.Time
+}
+
+const peerBucketIdleTimeout = 10 * time.Minute
+
+func newPeerRateLimiter(ratePerSecond float64, burst float64) *peerRateLimiter {
+	if ratePerSecond <= 0 {
+		return nil
+	}
+	if burst < 1 {
+		burst = 1
+	}
+	return &peerRateLimiter{
+		mutex:   &sync.Mutex{},
+		rate:    ratePerSecond,
+		burst:   burst,
+		buckets: make(map[string]*peerBucket),
+		now:     time.Now,
+	}
+}
+
+// allow takes a token from the bucket of the peer, and returns an AdmissionError if the bucket is empty.
+func (rl *peerRateLimiter) allow(peerID string) *AdmissionError {
+	if rl == nil {
+		return nil
+	}
+
+	rl.mutex.Lock()
+	defer rl.mutex.Unlock()
+
+	now := rl.now()
+	bucket, ok := rl.buckets[peerID]
+	if !ok {
+		for id, b := range rl.buckets {
+			if now.Sub(b.updated) > peerBucketIdleTimeout {
+				delete(rl.buckets, id)
+			}
+		}
+		bucket = &peerBucket{tokens: rl.burst, updated: now}
+		rl.buckets[peerID] = bucket
+	}
+	bucket.tokens += now.Sub(bucket.updated).Seconds() * rl.rate
+	if bucket.tokens > rl.burst {
+		bucket.tokens = rl.burst
+	}
+	bucket.updated = now
+
+	if bucket.tokens < 1 {
+		return &AdmissionError{
+			Policy:  "peer_rate_limit",
+			Reason:  ReasonPeerRateLimited,
+			Message: fmt.Sprintf("peer %v exceeded %v transactions per second", peerID, rl.rate),
+		}
+	}
+	bucket.tokens--
+	return nil
+}
@@ -0,0 +1,87 @@
+package mempool
+
+import (
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/pandoprojects/pando/common"
+	"github.com/pandoprojects/pando/core"
+	"github.com/pandoprojects/pando/ledger/types"
+)
+
+func newAdmissionCandidate(gasPrice int64, numPendingTxs int, tx types.Tx) *AdmissionCandidate {
+	return &AdmissionCandidate{
+		Tx: tx,
+		TxInfo: &core.TxInfo{
+			EffectiveGasPrice: big.NewInt(gasPrice),
+			Address:           common.HexToAddress("0xA1"),
+		},
+		NumPendingTxs: numPendingTxs,
+	}
+}
+
+func TestAdmissionPolicies(t *testing.T) {
+	assert := assert.New(t)
+
+	minGasPrice := NewMinGasPricePolicy(big.NewInt(1000))
+	assert.Nil(minGasPrice.Admit(newAdmissionCandidate(1000, 0, nil)))
+	admissionErr := minGasPrice.Admit(newAdmissionCandidate(999, 0, nil))
+	assert.NotNil(admissionErr)
+	assert.Equal(ReasonGasPriceTooLow, admissionErr.Reason)
+
+	senderQuota := NewSenderQuotaPolicy(2)
+	assert.Nil(senderQuota.Admit(newAdmissionCandidate(1, 1, nil)))
+	admissionErr = senderQuota.Admit(newAdmissionCandidate(1, 2, nil))
+	assert.NotNil(admissionErr)
+	assert.Equal(ReasonSenderQuotaExceeded, admissionErr.Reason)
+	replacement := newAdmissionCandidate(1, 2, nil)
+	replacement.Replacement = true
+	assert.Nil(senderQuota.Admit(replacement))
+
+	allowed := common.HexToAddress("0xC1")
+	denied := common.HexToAddress("0xC2")
+	other := common.HexToAddress("0xC3")
+	callTx := func(target common.Address) types.Tx {
+		return &types.SmartContractTx{To: types.TxOutput{Address: target}}
+	}
+	denyOnly := NewContractTargetPolicy(nil, []common.Address{denied})
+	assert.Nil(denyOnly.Admit(newAdmissionCandidate(1, 0, callTx(other))))
+	admissionErr = denyOnly.Admit(newAdmissionCandidate(1, 0, callTx(denied)))
+	assert.NotNil(admissionErr)
+	assert.Equal(ReasonTargetDenied, admissionErr.Reason)
+
+	allowOnly := NewContractTargetPolicy([]common.Address{allowed}, nil)
+	assert.Nil(allowOnly.Admit(newAdmissionCandidate(1, 0, callTx(allowed))))
+	assert.Nil(allowOnly.Admit(newAdmissionCandidate(1, 0, callTx(common.Address{})))) // deployment
+	assert.Nil(allowOnly.Admit(newAdmissionCandidate(1, 0, &types.SendTx{})))
+	admissionErr = allowOnly.Admit(newAdmissionCandidate(1, 0, callTx(other)))
+	assert.NotNil(admissionErr)
+	assert.Equal(ReasonTargetNotAllowed, admissionErr.Reason)
+}
+
+func TestPeerRateLimiter(t *testing.T) {
+	assert := assert.New(t)
+
+	assert.Nil(newPeerRateLimiter(0, 10))
+	assert.Nil(newPeerRateLimiter(0, 10).allow("peer1"))
+
+	now := time.Now()
+	limiter := newPeerRateLimiter(2, 3)
+	limiter.now = func() time.Time { return now }
+
+	for i := 0; i < 3; i++ {
+		assert.Nil(limiter.allow("peer1"))
+	}
+	admissionErr := limiter.allow("peer1")
+	assert.NotNil(admissionErr)
+	assert.Equal(ReasonPeerRateLimited, admissionErr.Reason)
+
+	// The buckets are per peer, and refill over time
+	assert.Nil(limiter.allow("peer2"))
+	now = now.Add(500 * time.Millisecond)
+	assert.Nil(limiter.allow("peer1"))
+	assert.NotNil(limiter.allow("peer1"))
+}
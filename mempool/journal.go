@@ -0,0 +1,135 @@
+package mempool
+
+import (
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+
+	"github.com/pandoprojects/pando/common"
+	"github.com/pandoprojects/pando/rlp"
+)
+
+// errNoActiveJournal is returned if a transaction is inserted while the journal is not open for writing.
+var errNoActiveJournal = errors.New("no active journal")
+
+// TxJournal is a rotating log of the raw transactions accepted by the mempool, so that the pending
+// transactions survive a node restart. The transactions are appended as they are accepted, and the
+// journal is periodically rewritten with the transactions still pending in the mempool. Unlike the
+// consensus WAL, the records are not fsynced, losing the last transactions in a crash is acceptable.
+//
+// The journal is a sequence of RLP encoded raw transactions. A torn record at the end of the journal
+// ends the loading. A nil TxJournal logs nothing. The journal is guarded by the mempool lock.
+type TxJournal struct {
+	path   string
+	writer *os.File
+	loaded []common.Bytes // transactions loaded from the journal, waiting to be screened again
+}
+
+// OpenTxJournal loads the transactions from the journal at the given path and opens it for writing,
+// creating it if it does not exist.
+func OpenTxJournal(path string) (*TxJournal, error) {
+	if err := os.MkdirAll(filepath.Dir(path), 0700); err != nil {
+		return nil, err
+	}
+	journal := &TxJournal{path: path}
+	if err := journal.load(); err != nil {
+		return nil, err
+	}
+	if err := journal.rotate(journal.loaded); err != nil {
+		return nil, err
+	}
+	return journal, nil
+}
+
+// Loaded returns the transactions loaded from the journal when it was opened.
+func (journal *TxJournal) Loaded() []common.Bytes {
+	if journal == nil {
+		return nil
+	}
+	return journal.loaded
+}
+
+// Close closes the journal file.
+func (journal *TxJournal) Close() error {
+	if journal == nil || journal.writer == nil {
+		return nil
+	}
+	err := journal.writer.Close()
+	journal.writer = nil
+	return err
+}
+
+func (journal *TxJournal) load() error {
+	input, err := os.Open(journal.path)
+	if os.IsNotExist(err) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	defer input.Close()
+
+	stream := rlp.NewStream(input, 0)
+	for {
+		rawTx := common.Bytes{}
+		if err := stream.Decode(&rawTx); err != nil {
+			if err != io.EOF {
+				logger.Warnf("Stopped loading the mempool journal %v: %v", journal.path, err)
+			}
+			break
+		}
+		journal.loaded = append(journal.loaded, rawTx)
+	}
+	logger.Infof("Loaded %v transactions from the mempool journal %v", len(journal.loaded), journal.path)
+	return nil
+}
+
+// insert appends the transaction to the journal.
+func (journal *TxJournal) insert(rawTx common.Bytes) error {
+	if journal == nil {
+		return nil
+	}
+	if journal.writer == nil {
+		return errNoActiveJournal
+	}
+	return rlp.Encode(journal.writer, rawTx)
+}
+
+// rotate replaces the journal with one holding the given transactions.
+func (journal *TxJournal) rotate(rawTxs []common.Bytes) error {
+	if journal == nil {
+		return nil
+	}
+	if journal.writer != nil {
+		if err := journal.writer.Close(); err != nil {
+			return err
+		}
+		journal.writer = nil
+	}
+
+	tmpPath := journal.path + ".new"
+	replacement, err := os.OpenFile(tmpPath, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, 0600)
+	if err != nil {
+		return err
+	}
+	for _, rawTx := range rawTxs {
+		if err = rlp.Encode(replacement, rawTx); err != nil {
+			replacement.Close()
+			return err
+		}
+	}
+	replacement.Close()
+
+	if err = os.Rename(tmpPath, journal.path); err != nil {
+		return err
+	}
+	sink, err := os.OpenFile(journal.path, os.O_WRONLY|os.O_APPEND, 0600)
+	if err != nil {
+		return err
+	}
+	journal.writer = sink
+
+	logger.Infof("Rotated the mempool journal %v, transactions: %v", journal.path, len(rawTxs))
+	return nil
+}
@@ -0,0 +1,57 @@
+package mempool
+
+import (
+	"io/ioutil"
+	"os"
+	"path"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/pandoprojects/pando/common"
+)
+
+func TestTxJournal(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	dir, err := ioutil.TempDir("", "journal")
+	require.Nil(err)
+	defer os.RemoveAll(dir)
+	journalPath := path.Join(dir, "mempool.journal")
+
+	journal, err := OpenTxJournal(journalPath)
+	require.Nil(err)
+	assert.Equal(0, len(journal.Loaded()))
+	require.Nil(journal.insert(common.Bytes("tx1")))
+	require.Nil(journal.insert(common.Bytes("tx2")))
+	require.Nil(journal.Close())
+
+	journal, err = OpenTxJournal(journalPath)
+	require.Nil(err)
+	assert.Equal([]common.Bytes{common.Bytes("tx1"), common.Bytes("tx2")}, journal.Loaded())
+
+	// The rotation drops the transactions no longer pending
+	require.Nil(journal.rotate([]common.Bytes{common.Bytes("tx2")}))
+	require.Nil(journal.insert(common.Bytes("tx3")))
+	require.Nil(journal.Close())
+
+	// A torn record at the end of the journal is ignored
+	file, err := os.OpenFile(journalPath, os.O_WRONLY|os.O_APPEND, 0600)
+	require.Nil(err)
+	_, err = file.Write([]byte{0x85, 't'})
+	require.Nil(err)
+	require.Nil(file.Close())
+
+	journal, err = OpenTxJournal(journalPath)
+	require.Nil(err)
+	defer journal.Close()
+	assert.Equal([]common.Bytes{common.Bytes("tx2"), common.Bytes("tx3")}, journal.Loaded())
+
+	// A nil journal logs nothing
+	var nilJournal *TxJournal
+	assert.Nil(nilJournal.insert(common.Bytes("tx4")))
+	assert.Nil(nilJournal.rotate(nil))
+	assert.Nil(nilJournal.Close())
+}
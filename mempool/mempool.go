@@ -5,10 +5,12 @@ import (
 	"encoding/hex"
 	"errors"
 	"math/big"
+	"sort"
 	"sync"
 	"time"
 
 	log "github.com/sirupsen/logrus"
+	"github.com/spf13/viper"
 
 	"github.com/pandoprojects/pando/common"
 	"github.com/pandoprojects/pando/common/clist"
@@ -30,9 +32,20 @@ func (m MempoolError) Error() string {
 
 const DuplicateTxError = MempoolError("Transaction already seen")
 const FastsyncSkipTxError = MempoolError("Skip tx during fastsync")
+const MempoolFullError = MempoolError("Mempool is full, please submit your transaction again later")
+const ReplacementUnderpricedError = MempoolError("Replacement transaction underpriced")
 
 const MaxMempoolTxCount int = 25600
 
+// insertedTxQueueSize is the capacity of the channel notifying newly inserted transactions.
+const insertedTxQueueSize int = 1024
+
+// journalSyncCheckInterval is the interval to check whether the node has synced, before screening
+// the transactions loaded from the journal again.
+const journalSyncCheckInterval = 1 * time.Second
+
+const defaultJournalRotateInterval = 1 * time.Hour
+
 //
 // mempoolTransaction implements the pqueue.Element interface
 //
@@ -105,8 +118,27 @@ func (mtg *mempoolTransactionGroup) IsEmpty() bool {
 	return mtg.txs.IsEmpty()
 }
 
-// RemoveTxs removes matching Txs from transaction group. Returns number of Txs removed.
-func (mtg *mempoolTransactionGroup) RemoveTxs(committedRawTxMap map[string]bool) (numRemoved int) {
+// FindTx returns the transaction with the given sequence, or nil if the group has none.
+func (mtg *mempoolTransactionGroup) FindTx(sequence uint64) *mempoolTransaction {
+	for _, elem := range *mtg.txs.ElementList() {
+		mptx := elem.(*mempoolTransaction)
+		if mptx.txInfo.Sequence == sequence {
+			return mptx
+		}
+	}
+	return nil
+}
+
+// NumBytes returns the total size of the transactions in the group.
+func (mtg *mempoolTransactionGroup) NumBytes() (numBytes int) {
+	for _, elem := range *mtg.txs.ElementList() {
+		numBytes += len(elem.(*mempoolTransaction).rawTransaction)
+	}
+	return
+}
+
+// RemoveTxs removes matching Txs from transaction group. Returns number of Txs removed and their total size.
+func (mtg *mempoolTransactionGroup) RemoveTxs(committedRawTxMap map[string]bool) (numRemoved int, numBytes int) {
 	elementList := mtg.txs.ElementList()
 	elemsTobeRemoved := []pqueue.Element{}
 	for _, elem := range *elementList {
@@ -120,6 +152,7 @@ func (mtg *mempoolTransactionGroup) RemoveTxs(committedRawTxMap map[string]bool)
 	for _, elem := range elemsTobeRemoved {
 		mtg.txs.Remove(elem.GetIndex())
 		numRemoved++
+		numBytes += len(elem.(*mempoolTransaction).rawTransaction)
 	}
 	return
 }
@@ -145,10 +178,29 @@ type Mempool struct {
 	dispatcher *dp.Dispatcher
 
 	newTxs           *clist.CList          // new transactions, to be gossiped to other nodes
+	insertedTxs      chan common.Bytes     // transactions that passed the screening, for subscribers such as the RPC server
 	candidateTxs     *pqueue.PriorityQueue // candidate transactions for new block assembly, ordered by the transaction fee (high to low)
 	txBookeepper     transactionBookkeeper
 	addressToTxGroup map[common.Address]*mempoolTransactionGroup
-	size             int
+	size             int // number of transactions in the candidate pool
+	sizeBytes        int // total size of the transactions in the candidate pool
+
+	maxTxCount       int // the lowest priority transaction groups are evicted beyond these limits
+	maxTxBytes       int
+	priceBumpPercent int // minimal gas price increase for a transaction to replace a pending one with the same sequence
+
+	queuedTxs              map[common.Address]*queuedTransactionGroup // transactions waiting for the sequence gaps before them to be filled
+	queuedSize             int
+	maxQueuedTxCount       int
+	maxQueuedTxsPerAccount int
+	queuedTxLifetime       time.Duration
+
+	journal               *TxJournal // journal of the accepted transactions, disabled if nil
+	journalRotateInterval time.Duration
+
+	gossip *txGossip // announces the transactions to the peers
+
+	admissionPolicies []AdmissionPolicy // local policies applied to the screened transactions
 
 	// Life cycle
 	wg      *sync.WaitGroup
@@ -160,16 +212,28 @@ type Mempool struct {
 
 // CreateMempool creates an instance of Mempool
 func CreateMempool(dispatcher *dp.Dispatcher, engine *consensus.ConsensusEngine) *Mempool {
-	return &Mempool{
-		mutex:            &sync.Mutex{},
-		consensus:        engine,
-		dispatcher:       dispatcher,
-		newTxs:           clist.New(),
-		candidateTxs:     pqueue.CreatePriorityQueue(),
-		addressToTxGroup: make(map[common.Address]*mempoolTransactionGroup),
-		txBookeepper:     createTransactionBookkeeper(defaultMaxNumTxs),
-		wg:               &sync.WaitGroup{},
+	mp := &Mempool{
+		mutex:                  &sync.Mutex{},
+		consensus:              engine,
+		dispatcher:             dispatcher,
+		newTxs:                 clist.New(),
+		insertedTxs:            make(chan common.Bytes, insertedTxQueueSize),
+		candidateTxs:           pqueue.CreatePriorityQueue(),
+		addressToTxGroup:       make(map[common.Address]*mempoolTransactionGroup),
+		txBookeepper:           createTransactionBookkeeper(defaultMaxNumTxs),
+		maxTxCount:             viper.GetInt(common.CfgMempoolMaxTxCount),
+		maxTxBytes:             viper.GetInt(common.CfgMempoolMaxTxBytes),
+		priceBumpPercent:       viper.GetInt(common.CfgMempoolPriceBumpPercent),
+		queuedTxs:              make(map[common.Address]*queuedTransactionGroup),
+		maxQueuedTxCount:       viper.GetInt(common.CfgMempoolMaxQueuedTxCount),
+		maxQueuedTxsPerAccount: viper.GetInt(common.CfgMempoolMaxQueuedTxsPerAccount),
+		queuedTxLifetime:       time.Duration(viper.GetInt(common.CfgMempoolQueuedTxLifetime)) * time.Second,
+		journalRotateInterval:  time.Duration(viper.GetInt(common.CfgMempoolJournalRotateInterval)) * time.Second,
+		wg:                     &sync.WaitGroup{},
 	}
+	mp.gossip = createTxGossip(&mp.txBookeepper)
+	mp.admissionPolicies = createAdmissionPolicies()
+	return mp
 }
 
 // SetLedger sets the ledger for the mempool
@@ -177,6 +241,12 @@ func (mp *Mempool) SetLedger(ledger core.Ledger) {
 	mp.ledger = ledger
 }
 
+// SetJournal sets the journal of the accepted transactions. The transactions loaded from the journal
+// are screened again once the node has synced.
+func (mp *Mempool) SetJournal(journal *TxJournal) {
+	mp.journal = journal
+}
+
 // InsertTransaction inserts the incoming transaction to mempool (submitted by the clients or relayed from peers)
 func (mp *Mempool) InsertTransaction(rawTx common.Bytes) error {
 	mp.mutex.Lock()
@@ -188,41 +258,49 @@ func (mp *Mempool) InsertTransaction(rawTx common.Bytes) error {
 		return DuplicateTxError
 	}
 
-	// if mp.size >= MaxMempoolTxCount {
-	// 	logger.Debugf("Mempool is full")
-	// 	return errors.New("mempool is full, please submit your transaction again later")
-	// }
-
-	var txInfo *core.TxInfo
-	var checkTxRes result.Result
-
 	// Delay tx verification when in fast sync
 	if mp.consensus.HasSynced() {
-		txInfo, checkTxRes = mp.ledger.ScreenTx(rawTx)
+		// Screening a transaction updates the screened view, so the pool checks whether it has
+		// room for the transaction first
+		txInfo, checkTxRes := mp.ledger.GetTxInfo(rawTx)
 		if !checkTxRes.IsOK() {
 			logger.Debugf("Transaction screening failed, tx: %v, error: %v", hex.EncodeToString(rawTx), checkTxRes.Message)
 			return errors.New(checkTxRes.Message)
 		}
 
-		// only record the transactions that passed the screening. This is because that
-		// an invalid transaction could becoume valid later on. For example, assume expected
-		// sequence for an account is 6. The account accidentally submits txA (seq = 7), got rejected.
-		// He then submit txB(seq = 6), and then txA(seq = 7) again. For the second submission, txA
-		// should not be rejected even though it has been submitted earlier.
-		mp.txBookeepper.record(rawTx)
-
-		txGroup, ok := mp.addressToTxGroup[txInfo.Address]
-		if ok {
-			txGroup.AddTx(rawTx, txInfo)
-			mp.candidateTxs.Remove(txGroup.index) // Need to re-insert txGroup into queue since its priority could change.
-		} else {
-			txGroup = createMempoolTransactionGroup(rawTx, txInfo)
-			mp.addressToTxGroup[txInfo.Address] = txGroup
+		// The admission policies run before the screening too, so a refused transaction has no effect
+		txGroup, pending := mp.findPendingTx(txInfo)
+		if err := mp.admitTx(rawTx, txInfo, pending != nil); err != nil {
+			return err
+		}
+		if pending != nil {
+			return mp.replaceTx(rawTx, txInfo, txGroup, pending)
+		}
+
+		evicted, err := mp.findTxGroupsToEvict(txInfo, len(rawTx))
+		if err != nil {
+			// A transaction ahead of the next sequence of the account goes to the queue instead
+			logger.Debugf("Mempool is full, tx: %v, txInfo: %v", hex.EncodeToString(rawTx), txInfo)
+			return mp.queueFutureTx(rawTx, err)
 		}
-		mp.candidateTxs.Push(txGroup)
-		logger.Debugf("rawTx: %v, txInfo: %v", hex.EncodeToString(rawTx), txInfo)
-		logger.Infof("Insert tx, tx.hash: 0x%v", getTransactionHash(rawTx))
-		mp.size++
+
+		txInfo, checkTxRes = mp.ledger.ScreenTx(rawTx)
+		if checkTxRes.Code == result.CodeInvalidSequence {
+			return mp.queueFutureTx(rawTx, errors.New(checkTxRes.Message))
+		}
+		if !checkTxRes.IsOK() {
+			logger.Debugf("Transaction screening failed, tx: %v, error: %v", hex.EncodeToString(rawTx), checkTxRes.Message)
+			return errors.New(checkTxRes.Message)
+		}
+
+		mp.evictTxGroups(evicted)
+		mp.insertCandidateTx(rawTx, txInfo)
+
+		// The transaction may fill the sequence gap before the queued transactions of the account
+		mp.promoteQueuedTxs(txInfo.Address, func(rawTx common.Bytes) result.Result {
+			_, res := mp.ledger.ScreenTx(rawTx)
+			return res
+		})
 
 		return nil
 	}
@@ -230,15 +308,280 @@ func (mp *Mempool) InsertTransaction(rawTx common.Bytes) error {
 	return FastsyncSkipTxError
 }
 
+// insertCandidateTx inserts the screened transaction to the candidate pool.
+func (mp *Mempool) insertCandidateTx(rawTx common.Bytes, txInfo *core.TxInfo) {
+	// only record the transactions that passed the screening. This is because that
+	// an invalid transaction could becoume valid later on. For example, assume expected
+	// sequence for an account is 6. The account accidentally submits txA (seq = 7), got rejected.
+	// He then submit txB(seq = 6), and then txA(seq = 7) again. For the second submission, txA
+	// should not be rejected even though it has been submitted earlier.
+	mp.txBookeepper.record(rawTx)
+
+	txGroup, ok := mp.addressToTxGroup[txInfo.Address]
+	if ok {
+		txGroup.AddTx(rawTx, txInfo)
+		mp.candidateTxs.Remove(txGroup.index) // Need to re-insert txGroup into queue since its priority could change.
+	} else {
+		txGroup = createMempoolTransactionGroup(rawTx, txInfo)
+		mp.addressToTxGroup[txInfo.Address] = txGroup
+	}
+	mp.candidateTxs.Push(txGroup)
+	logger.Debugf("rawTx: %v, txInfo: %v", hex.EncodeToString(rawTx), txInfo)
+	logger.Infof("Insert tx, tx.hash: 0x%v", getTransactionHash(rawTx))
+	mp.size++
+	mp.sizeBytes += len(rawTx)
+
+	mp.journalTx(rawTx)
+	mp.notifyInsertedTx(rawTx)
+}
+
+// findPendingTx returns the transaction in the candidate pool with the same sender and sequence as the
+// incoming transaction, along with its transaction group, or nil if there is none.
+func (mp *Mempool) findPendingTx(txInfo *core.TxInfo) (*mempoolTransactionGroup, *mempoolTransaction) {
+	txGroup, ok := mp.addressToTxGroup[txInfo.Address]
+	if !ok {
+		return nil, nil
+	}
+	return txGroup, txGroup.FindTx(txInfo.Sequence)
+}
+
+// queueFutureTx queues the transaction if its sequence is ahead of the next sequence of the sender, until
+// the sequence gap before it is filled. Otherwise, it returns the given error.
+func (mp *Mempool) queueFutureTx(rawTx common.Bytes, notFutureErr error) error {
+	txInfo, checkTxRes := mp.ledger.ScreenFutureTx(rawTx)
+	if checkTxRes.Code == result.CodeInvalidSequence {
+		logger.Debugf("Transaction rejected, tx: %v, error: %v", hex.EncodeToString(rawTx), notFutureErr)
+		return notFutureErr
+	}
+	if !checkTxRes.IsOK() {
+		logger.Debugf("Future transaction screening failed, tx: %v, error: %v", hex.EncodeToString(rawTx), checkTxRes.Message)
+		return errors.New(checkTxRes.Message)
+	}
+	return mp.queueTx(rawTx, txInfo)
+}
+
+// replaceTx replaces the pending transaction with the same sequence from the same address, if the
+// new transaction pays a high enough gas price and passes the screening.
+func (mp *Mempool) replaceTx(rawTx common.Bytes, txInfo *core.TxInfo, txGroup *mempoolTransactionGroup, pending *mempoolTransaction) error {
+	if !mp.isReplacementPriced(pending.txInfo, txInfo) {
+		logger.Debugf("Replacement transaction underpriced, tx: %v, txInfo: %v, replaced txInfo: %v",
+			hex.EncodeToString(rawTx), txInfo, pending.txInfo)
+		return ReplacementUnderpricedError
+	}
+
+	txInfo, checkTxRes := mp.ledger.ScreenReplacementTx(rawTx, pending.rawTransaction)
+	if !checkTxRes.IsOK() {
+		logger.Debugf("Replacement transaction screening failed, tx: %v, error: %v", hex.EncodeToString(rawTx), checkTxRes.Message)
+		return errors.New(checkTxRes.Message)
+	}
+
+	replacedRawTx := pending.rawTransaction
+	mp.txBookeepper.remove(replacedRawTx)
+	mp.txBookeepper.record(rawTx)
+
+	pending.rawTransaction = rawTx
+	pending.txInfo = txInfo
+	mp.candidateTxs.Remove(txGroup.index) // Need to re-insert txGroup into queue since its priority could change.
+	mp.candidateTxs.Push(txGroup)
+	mp.sizeBytes += len(rawTx) - len(replacedRawTx)
+
+	logger.Infof("Replace tx, tx.hash: 0x%v, replaced tx.hash: 0x%v", getTransactionHash(rawTx), getTransactionHash(replacedRawTx))
+
+	mp.journalTx(rawTx)
+	mp.notifyInsertedTx(rawTx)
+
+	return nil
+}
+
+// isReplacementPriced returns whether the replacement pays a gas price high enough to replace the
+// transaction with the same sequence.
+func (mp *Mempool) isReplacementPriced(replaced *core.TxInfo, replacement *core.TxInfo) bool {
+	minGasPrice := new(big.Int).Mul(replaced.EffectiveGasPrice, big.NewInt(int64(100+mp.priceBumpPercent)))
+	minGasPrice.Div(minGasPrice, big.NewInt(100))
+	return replacement.EffectiveGasPrice.Cmp(minGasPrice) >= 0 && replacement.EffectiveGasPrice.Cmp(replaced.EffectiveGasPrice) > 0
+}
+
+// findTxGroupsToEvict returns the lowest priority transaction groups of other addresses to evict for the
+// incoming transaction to fit in the mempool, without evicting them. It returns MempoolFullError if the
+// transaction does not pay a higher gas price than the groups it would evict.
+func (mp *Mempool) findTxGroupsToEvict(txInfo *core.TxInfo, txBytes int) ([]*mempoolTransactionGroup, error) {
+	count, numBytes := mp.size+1, mp.sizeBytes+txBytes
+	if mp.hasRoom(count, numBytes) {
+		return nil, nil
+	}
+
+	txGroups := []*mempoolTransactionGroup{}
+	for _, elem := range *mp.candidateTxs.ElementList() {
+		txGroup := elem.(*mempoolTransactionGroup)
+		if txGroup.address != txInfo.Address {
+			txGroups = append(txGroups, txGroup)
+		}
+	}
+	sort.Slice(txGroups, func(i, j int) bool {
+		return txGroups[i].Priority().Cmp(txGroups[j].Priority()) < 0
+	})
+
+	evicted := []*mempoolTransactionGroup{}
+	for _, txGroup := range txGroups {
+		if mp.hasRoom(count, numBytes) {
+			break
+		}
+		if txGroup.Priority().Cmp(txInfo.EffectiveGasPrice) >= 0 {
+			return nil, MempoolFullError
+		}
+		evicted = append(evicted, txGroup)
+		count -= txGroup.txs.NumElements()
+		numBytes -= txGroup.NumBytes()
+	}
+	if !mp.hasRoom(count, numBytes) {
+		return nil, MempoolFullError
+	}
+	return evicted, nil
+}
+
+func (mp *Mempool) hasRoom(count int, numBytes int) bool {
+	return (mp.maxTxCount <= 0 || count <= mp.maxTxCount) && (mp.maxTxBytes <= 0 || numBytes <= mp.maxTxBytes)
+}
+
+// evictTxGroups removes the transaction groups from the candidate pool.
+func (mp *Mempool) evictTxGroups(txGroups []*mempoolTransactionGroup) {
+	for _, txGroup := range txGroups {
+		mp.evictTxGroup(txGroup)
+	}
+}
+
+// evictTxGroup removes the transaction group from the candidate pool. The evicted transactions are
+// removed from the bookkeeper too, so that they can be submitted again, and the account is reset in
+// the screened view, so that its next transaction is screened against the sequence it expects.
+func (mp *Mempool) evictTxGroup(txGroup *mempoolTransactionGroup) {
+	for _, elem := range *txGroup.txs.ElementList() {
+		rawTx := elem.(*mempoolTransaction).rawTransaction
+		mp.txBookeepper.remove(rawTx)
+		mp.size--
+		mp.sizeBytes -= len(rawTx)
+		logger.Debugf("Evict tx, tx.hash: 0x%v", getTransactionHash(rawTx))
+	}
+	mp.candidateTxs.Remove(txGroup.index)
+	delete(mp.addressToTxGroup, txGroup.address)
+	mp.ledger.ResetScreenedAccount(txGroup.address)
+}
+
+func (mp *Mempool) journalTx(rawTx common.Bytes) {
+	if err := mp.journal.insert(rawTx); err != nil {
+		logger.Warnf("Failed to journal tx, tx.hash: 0x%v, error: %v", getTransactionHash(rawTx), err)
+	}
+}
+
+func (mp *Mempool) notifyInsertedTx(rawTx common.Bytes) {
+	select {
+	case mp.insertedTxs <- rawTx:
+	default:
+		logger.Debugf("Failed to notify inserted tx, tx.hash: 0x%v", getTransactionHash(rawTx))
+	}
+}
+
+// InsertedTransactions returns a channel that will be published with the transactions
+// inserted into the mempool.
+func (mp *Mempool) InsertedTransactions() chan common.Bytes {
+	return mp.insertedTxs
+}
+
 // Start needs to be called when the Mempool starts
 func (mp *Mempool) Start(ctx context.Context) error {
 	c, cancel := context.WithCancel(ctx)
 	mp.ctx = c
 	mp.cancel = cancel
 
+	if mp.journal != nil {
+		mp.wg.Add(1)
+		go mp.journalLoop()
+	}
+
+	if mp.dispatcher != nil && useTxAnnouncements() {
+		mp.wg.Add(1)
+		go mp.announceLoop()
+	}
+
 	return nil
 }
 
+// journalLoop screens the transactions loaded from the journal again once the node has synced, and
+// rotates the journal periodically. The journal is rotated a last time and closed when the mempool stops.
+func (mp *Mempool) journalLoop() {
+	defer mp.wg.Done()
+
+	loaded := mp.journal.Loaded()
+	syncTicker := time.NewTicker(journalSyncCheckInterval)
+	defer syncTicker.Stop()
+	rotateInterval := mp.journalRotateInterval
+	if rotateInterval <= 0 {
+		rotateInterval = defaultJournalRotateInterval
+	}
+	rotateTicker := time.NewTicker(rotateInterval)
+	defer rotateTicker.Stop()
+
+	for {
+		select {
+		case <-mp.ctx.Done():
+			mp.rotateJournal(loaded)
+			mp.mutex.Lock()
+			mp.journal.Close()
+			mp.mutex.Unlock()
+			return
+		case <-syncTicker.C:
+			if !mp.consensus.HasSynced() {
+				continue
+			}
+			syncTicker.Stop()
+			mp.insertJournalTxs(loaded)
+			loaded = nil
+		case <-rotateTicker.C:
+			mp.rotateJournal(loaded)
+		}
+	}
+}
+
+// insertJournalTxs inserts the transactions loaded from the journal, dropping those which have become invalid.
+func (mp *Mempool) insertJournalTxs(rawTxs []common.Bytes) {
+	numInserted := 0
+	for _, rawTx := range rawTxs {
+		if err := mp.InsertTransaction(rawTx); err != nil {
+			logger.Debugf("Dropped journaled tx, tx.hash: 0x%v, error: %v", getTransactionHash(rawTx), err)
+			continue
+		}
+		mp.BroadcastTx(rawTx)
+		numInserted++
+	}
+	logger.Infof("Inserted %v of the %v journaled transactions", numInserted, len(rawTxs))
+}
+
+// rotateJournal rewrites the journal with the transactions in the candidate pool, and the transactions
+// loaded from the journal which have not been inserted yet.
+func (mp *Mempool) rotateJournal(loaded []common.Bytes) {
+	mp.mutex.Lock()
+	defer mp.mutex.Unlock()
+
+	rawTxs := append([]common.Bytes{}, loaded...)
+	for _, txgElem := range *mp.candidateTxs.ElementList() {
+		txg := txgElem.(*mempoolTransactionGroup)
+		txs := []*mempoolTransaction{}
+		for _, txElem := range *txg.txs.ElementList() {
+			txs = append(txs, txElem.(*mempoolTransaction))
+		}
+		// The transactions of an account need to be screened in the order of their sequences
+		sort.Slice(txs, func(i, j int) bool {
+			return txs[i].txInfo.Sequence < txs[j].txInfo.Sequence
+		})
+		for _, tx := range txs {
+			rawTxs = append(rawTxs, tx.rawTransaction)
+		}
+	}
+
+	if err := mp.journal.rotate(rawTxs); err != nil {
+		logger.Warnf("Failed to rotate the mempool journal: %v", err)
+	}
+}
+
 // Stop needs to be called when the Mempool stops
 func (mp *Mempool) Stop() {
 	mp.cancel()
@@ -264,6 +607,11 @@ func (mp *Mempool) Size() int {
 	return mp.size
 }
 
+// SizeBytes returns the total size of the transactions in the Mempool
+func (mp *Mempool) SizeBytes() int {
+	return mp.sizeBytes
+}
+
 // Reap returns a list of valid raw transactions and remove these
 // transactions from the candidate pool. maxNumTxs == 0 means
 // none, maxNumTxs < 0 means uncapped. Note that Reap does NOT remove
@@ -295,6 +643,8 @@ func (mp *Mempool) ReapUnsafe(maxNumTxs int) []common.Bytes {
 		}
 		txGroup := mp.candidateTxs.Pop().(*mempoolTransactionGroup)
 		rawTx, txInfo := txGroup.PopTx()
+		mp.size--
+		mp.sizeBytes -= len(rawTx)
 
 		// Check for outdated txs
 		txHash := getTransactionHash(rawTx)
@@ -314,8 +664,6 @@ func (mp *Mempool) ReapUnsafe(maxNumTxs int) []common.Bytes {
 			hex.EncodeToString(rawTx), txInfo)
 	}
 
-	mp.size -= len(txs)
-
 	return txs
 }
 
@@ -370,6 +718,8 @@ func (mp *Mempool) UpdateUnsafe(committedRawTxs []common.Bytes) {
 	mp.removeTxs(invalidTxs)
 	removeInvalidTxTime := time.Since(start)
 
+	mp.promoteAllQueuedTxs(mp.ledger.ScreenTxUnsafe)
+
 	logger.Debugf("UpdateUnsafe: %d tx screened in %v, removeCommittedTxTime = %v, removed %d obsolete Txs in %v: %v,", count, screenTxTime, removeCommittedTxTime, len(invalidTxs), removeInvalidTxTime, invalidTxs)
 }
 
@@ -383,8 +733,9 @@ func (mp *Mempool) removeTxs(committedRawTxs []common.Bytes) {
 	elemsTobeRemoved := []pqueue.Element{}
 	for _, elem := range *elementList {
 		txGroup := elem.(*mempoolTransactionGroup)
-		numRemoved := txGroup.RemoveTxs(committedRawTxMap)
+		numRemoved, numBytes := txGroup.RemoveTxs(committedRawTxMap)
 		mp.size -= numRemoved
+		mp.sizeBytes -= numBytes
 		if txGroup.IsEmpty() {
 			delete(mp.addressToTxGroup, txGroup.address)
 			elemsTobeRemoved = append(elemsTobeRemoved, txGroup)
@@ -433,7 +784,11 @@ func (mp *Mempool) Flush() {
 	for !mp.candidateTxs.IsEmpty() {
 		mp.candidateTxs.Pop()
 	}
+	mp.addressToTxGroup = make(map[common.Address]*mempoolTransactionGroup)
+	mp.queuedTxs = make(map[common.Address]*queuedTransactionGroup)
 	mp.size = 0
+	mp.sizeBytes = 0
+	mp.queuedSize = 0
 }
 
 // BroadcastTx broadcast given raw transaction to the network
@@ -451,6 +806,16 @@ func (mp *Mempool) BroadcastTxUnsafe(tx common.Bytes) {
 		Payload:   tx,
 	}
 
-	peerIDs := []string{}
-	mp.dispatcher.SendData(peerIDs, data)
+	if !useTxAnnouncements() {
+		peerIDs := []string{}
+		mp.dispatcher.SendData(peerIDs, data)
+		return
+	}
+
+	// The peers speaking the announcement protocol get the transaction hash in the next
+	// announcement, the full transaction is flooded to the others
+	floodPeerIDs := mp.gossip.broadcast(tx, mp.dispatcher.Peers(true))
+	if len(floodPeerIDs) > 0 {
+		mp.dispatcher.SendData(floodPeerIDs, data)
+	}
 }
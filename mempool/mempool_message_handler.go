@@ -14,16 +14,19 @@ import (
 
 //
 // MempoolMessageHandler handles the messages received over the
-// ChannelIDTransaction channel
+// ChannelIDTransaction and ChannelIDTransactionAnnouncement channels
 //
 type MempoolMessageHandler struct {
-	mempool *Mempool
+	mempool        *Mempool
+	ingressLimiter *peerRateLimiter // limits the rate of the transactions relayed by each peer, disabled if nil
 }
 
 // CreateMempoolMessageHandler create an instance of the MempoolMessageHandler
 func CreateMempoolMessageHandler(mempool *Mempool) *MempoolMessageHandler {
 	return &MempoolMessageHandler{
 		mempool: mempool,
+		ingressLimiter: newPeerRateLimiter(viper.GetFloat64(common.CfgMempoolPeerTxRatePerSecond),
+			viper.GetFloat64(common.CfgMempoolPeerTxBurst)),
 	}
 }
 
@@ -31,12 +34,18 @@ func CreateMempoolMessageHandler(mempool *Mempool) *MempoolMessageHandler {
 func (mmh *MempoolMessageHandler) GetChannelIDs() []common.ChannelIDEnum {
 	return []common.ChannelIDEnum{
 		common.ChannelIDTransaction,
+		common.ChannelIDTransactionAnnouncement,
 	}
 }
 
 // EncodeMessage implements the p2p.MessageHandler interface
 func (mmh *MempoolMessageHandler) EncodeMessage(message interface{}) (common.Bytes, error) {
-	return rlp.EncodeToBytes(message)
+	switch message.(type) {
+	case dp.InventoryResponse, dp.DataRequest:
+		return dp.EncodeMessage(message)
+	default:
+		return rlp.EncodeToBytes(message)
+	}
 }
 
 func Fuzz(data []byte) int {
@@ -49,6 +58,16 @@ func Fuzz(data []byte) int {
 
 // ParseMessage implements the p2p.MessageHandler interface
 func (mmh *MempoolMessageHandler) ParseMessage(peerID string, channelID common.ChannelIDEnum, rawMessageBytes common.Bytes) (types.Message, error) {
+	if channelID == common.ChannelIDTransactionAnnouncement {
+		data, err := dp.DecodeMessage(rawMessageBytes)
+		message := types.Message{
+			PeerID:    peerID,
+			ChannelID: channelID,
+			Content:   data,
+		}
+		return message, err
+	}
+
 	var dataResponse dp.DataResponse
 	rlp.DecodeBytes(rawMessageBytes, &dataResponse)
 
@@ -63,12 +82,24 @@ func (mmh *MempoolMessageHandler) ParseMessage(peerID string, channelID common.C
 
 // HandleMessage implements the p2p.MessageHandler interface
 func (mmh *MempoolMessageHandler) HandleMessage(message types.Message) error {
+	if message.ChannelID == common.ChannelIDTransactionAnnouncement {
+		return mmh.handleAnnouncementMessage(message)
+	}
 	if message.ChannelID != common.ChannelIDTransaction {
 		return fmt.Errorf("Invalid channel for MempoolMessageHandler: %v", message.ChannelID)
 	}
 	rawTx := message.Content.(common.Bytes)
 	logger.Debugf("Received gossiped transaction: %v", hex.EncodeToString(rawTx))
 
+	if admissionErr := mmh.ingressLimiter.allow(message.PeerID); admissionErr != nil {
+		logger.Debugf("Dropped gossiped transaction, tx.hash: 0x%v, error: %v", getTransactionHash(rawTx), admissionErr)
+		return admissionErr
+	}
+
+	if useTxAnnouncements() {
+		mmh.mempool.gossip.markReceived(message.PeerID, rawTx)
+	}
+
 	err := mmh.mempool.InsertTransaction(rawTx)
 	if err == DuplicateTxError {
 		return nil
@@ -86,3 +117,19 @@ func (mmh *MempoolMessageHandler) HandleMessage(message types.Message) error {
 
 	return nil
 }
+
+func (mmh *MempoolMessageHandler) handleAnnouncementMessage(message types.Message) error {
+	if !useTxAnnouncements() {
+		return nil
+	}
+
+	switch content := message.Content.(type) {
+	case dp.InventoryResponse:
+		mmh.mempool.handleTxAnnouncement(message.PeerID, content.Entries)
+	case dp.DataRequest:
+		mmh.mempool.handleTxRequest(message.PeerID, content.Entries)
+	default:
+		return fmt.Errorf("Invalid message type on channel %v: %T", message.ChannelID, message.Content)
+	}
+	return nil
+}
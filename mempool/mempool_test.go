@@ -470,6 +470,25 @@ func (tl *TestLedger) ScreenTx(rawTx common.Bytes) (*core.TxInfo, result.Result)
 	return txInfo, result.OK
 }
 
+func (tl *TestLedger) ScreenReplacementTx(rawTx common.Bytes, replacedRawTx common.Bytes) (*core.TxInfo, result.Result) {
+	return tl.ScreenTx(rawTx)
+}
+
+func (tl *TestLedger) ScreenFutureTx(rawTx common.Bytes) (*core.TxInfo, result.Result) {
+	return tl.ScreenTx(rawTx)
+}
+
+func (tl *TestLedger) GetTxInfo(rawTx common.Bytes) (*core.TxInfo, result.Result) {
+	return &core.TxInfo{
+		EffectiveGasPrice: new(big.Int).SetUint64(tl.effectiveGasPriceList[tl.counter]),
+		Address:           common.HexToAddress(tl.addressList[tl.counter]),
+		Sequence:          tl.sequenceList[tl.counter],
+	}, result.OK
+}
+
+func (tl *TestLedger) ResetScreenedAccount(address common.Address) {
+}
+
 func (tl *TestLedger) GetCurrentBlock() *core.Block {
 	return nil
 }
@@ -502,6 +521,14 @@ func (tl *TestLedger) PruneState(endHeight uint64) error {
 	return nil
 }
 
+func (tl *TestLedger) AddEquivocationEvidence(evidence *core.EquivocationEvidence) result.Result {
+	return result.OK
+}
+
+func (tl *TestLedger) GetGovernanceParams(blockHash common.Hash) (*core.GovernanceParams, error) {
+	return nil, nil
+}
+
 func (tl *TestLedger) ApplyBlockTxsForChainCorrection(block *core.Block) (common.Hash, result.Result) {
 	return common.Hash{}, result.Result{}
 }
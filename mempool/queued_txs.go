@@ -0,0 +1,176 @@
+package mempool
+
+import (
+	"bytes"
+	"sort"
+	"time"
+
+	"github.com/pandoprojects/pando/common"
+	"github.com/pandoprojects/pando/common/result"
+	"github.com/pandoprojects/pando/core"
+)
+
+const QueuedTxLimitError = MempoolError("Too many queued transactions from the account")
+
+// queuedTransaction is a transaction whose sequence is ahead of the next sequence of its account
+type queuedTransaction struct {
+	rawTransaction common.Bytes
+	txInfo         *core.TxInfo
+	queuedAt       time.Time
+}
+
+// queuedTransactionGroup holds the queued transactions of one account, until the sequence gaps
+// before them are filled
+type queuedTransactionGroup struct {
+	address common.Address
+	txs     map[uint64]*queuedTransaction // sequence -> queued transaction
+}
+
+func createQueuedTransactionGroup(address common.Address) *queuedTransactionGroup {
+	return &queuedTransactionGroup{
+		address: address,
+		txs:     make(map[uint64]*queuedTransaction),
+	}
+}
+
+// SortedSequences returns the sequences of the queued transactions in ascending order.
+func (qtg *queuedTransactionGroup) SortedSequences() []uint64 {
+	sequences := make([]uint64, 0, len(qtg.txs))
+	for sequence := range qtg.txs {
+		sequences = append(sequences, sequence)
+	}
+	sort.Slice(sequences, func(i, j int) bool {
+		return sequences[i] < sequences[j]
+	})
+	return sequences
+}
+
+// queueTx holds the transaction until the sequence gap before it is filled. A queued transaction
+// with the same sequence is replaced if the new transaction pays a high enough gas price.
+func (mp *Mempool) queueTx(rawTx common.Bytes, txInfo *core.TxInfo) error {
+	txGroup, ok := mp.queuedTxs[txInfo.Address]
+	if !ok {
+		txGroup = createQueuedTransactionGroup(txInfo.Address)
+	}
+	mp.expireQueuedTxs(txGroup)
+
+	if queued, exists := txGroup.txs[txInfo.Sequence]; exists {
+		if bytes.Equal(queued.rawTransaction, rawTx) {
+			return DuplicateTxError
+		}
+		if !mp.isReplacementPriced(queued.txInfo, txInfo) {
+			return ReplacementUnderpricedError
+		}
+		mp.removeQueuedTx(txGroup, txInfo.Sequence)
+	} else if len(txGroup.txs) >= mp.maxQueuedTxsPerAccount {
+		return QueuedTxLimitError
+	} else if mp.queuedSize >= mp.maxQueuedTxCount {
+		return MempoolFullError
+	}
+
+	txGroup.txs[txInfo.Sequence] = &queuedTransaction{
+		rawTransaction: rawTx,
+		txInfo:         txInfo,
+		queuedAt:       time.Now(),
+	}
+	mp.queuedTxs[txInfo.Address] = txGroup
+	mp.queuedSize++
+
+	logger.Infof("Queue tx, tx.hash: 0x%v, address: %v, sequence: %v", getTransactionHash(rawTx), txInfo.Address.Hex(), txInfo.Sequence)
+
+	return nil
+}
+
+// promoteQueuedTxs moves the queued transactions of the account which have become executable to the
+// candidate pool, in the order of their sequences. The queued transactions with lower sequences than a
+// promoted one are dropped, since their sequences have been taken.
+func (mp *Mempool) promoteQueuedTxs(address common.Address, screenTx func(rawTx common.Bytes) result.Result) {
+	txGroup, ok := mp.queuedTxs[address]
+	if !ok {
+		return
+	}
+
+	skipped := []uint64{}
+	for _, sequence := range txGroup.SortedSequences() {
+		queued := txGroup.txs[sequence]
+		evicted, err := mp.findTxGroupsToEvict(queued.txInfo, len(queued.rawTransaction))
+		if err != nil {
+			break
+		}
+
+		checkTxRes := screenTx(queued.rawTransaction)
+		if checkTxRes.Code == result.CodeInvalidSequence {
+			// The sequence is still ahead, or has been taken
+			skipped = append(skipped, sequence)
+			continue
+		}
+		mp.removeQueuedTx(txGroup, sequence)
+		if checkTxRes.IsError() {
+			logger.Debugf("Drop queued tx, tx.hash: 0x%v, error: %v", getTransactionHash(queued.rawTransaction), checkTxRes.Message)
+			continue
+		}
+
+		for _, skippedSequence := range skipped {
+			mp.removeQueuedTx(txGroup, skippedSequence)
+		}
+		skipped = []uint64{}
+
+		mp.evictTxGroups(evicted)
+		mp.insertCandidateTx(queued.rawTransaction, queued.txInfo)
+		logger.Infof("Promote queued tx, tx.hash: 0x%v", getTransactionHash(queued.rawTransaction))
+	}
+
+	if len(txGroup.txs) == 0 {
+		delete(mp.queuedTxs, address)
+	}
+}
+
+// promoteAllQueuedTxs drops the expired queued transactions, and promotes the queued transactions which
+// have become executable, e.g. after a block from another proposer filled the sequence gaps.
+func (mp *Mempool) promoteAllQueuedTxs(screenTx func(rawTx common.Bytes) result.Result) {
+	for address, txGroup := range mp.queuedTxs {
+		mp.expireQueuedTxs(txGroup)
+		if len(txGroup.txs) == 0 {
+			delete(mp.queuedTxs, address)
+			continue
+		}
+		mp.promoteQueuedTxs(address, screenTx)
+	}
+}
+
+// expireQueuedTxs drops the queued transactions of the group which have been waiting for too long.
+func (mp *Mempool) expireQueuedTxs(txGroup *queuedTransactionGroup) {
+	for sequence, queued := range txGroup.txs {
+		if time.Since(queued.queuedAt) > mp.queuedTxLifetime {
+			logger.Debugf("Expire queued tx, tx.hash: 0x%v", getTransactionHash(queued.rawTransaction))
+			mp.removeQueuedTx(txGroup, sequence)
+		}
+	}
+}
+
+func (mp *Mempool) removeQueuedTx(txGroup *queuedTransactionGroup, sequence uint64) {
+	if _, exists := txGroup.txs[sequence]; !exists {
+		return
+	}
+	delete(txGroup.txs, sequence)
+	mp.queuedSize--
+}
+
+// QueuedSize returns the number of queued transactions in the Mempool
+func (mp *Mempool) QueuedSize() int {
+	return mp.queuedSize
+}
+
+// GetQueuedTransactionHashes returns the hashes of the queued transactions
+func (mp *Mempool) GetQueuedTransactionHashes() []string {
+	mp.mutex.Lock()
+	defer mp.mutex.Unlock()
+
+	txHashes := []string{}
+	for _, txGroup := range mp.queuedTxs {
+		for _, sequence := range txGroup.SortedSequences() {
+			txHashes = append(txHashes, "0x"+getTransactionHash(txGroup.txs[sequence].rawTransaction))
+		}
+	}
+	return txHashes
+}
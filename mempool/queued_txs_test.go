@@ -0,0 +1,75 @@
+package mempool
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/pandoprojects/pando/common"
+	"github.com/pandoprojects/pando/common/result"
+	"github.com/pandoprojects/pando/core"
+)
+
+func newQueuedTestTxInfo(sequence uint64) *core.TxInfo {
+	return &core.TxInfo{
+		Address:           common.HexToAddress("A1"),
+		Sequence:          sequence,
+		EffectiveGasPrice: big.NewInt(100),
+	}
+}
+
+func TestQueuedTxPromotion(t *testing.T) {
+	assert := assert.New(t)
+
+	mempool := CreateMempool(nil, nil)
+	address := common.HexToAddress("A1")
+
+	// Screens the transactions "txN" of sequence N against the next sequence of the account
+	nextSequence := uint64(6)
+	sequences := map[string]uint64{"tx5": 5, "tx6": 6, "tx7": 7, "tx8": 8}
+	screenTx := func(rawTx common.Bytes) result.Result {
+		if sequences[string(rawTx)] != nextSequence {
+			return result.Error("Invalid sequence").WithErrorCode(result.CodeInvalidSequence)
+		}
+		nextSequence++
+		return result.OK
+	}
+
+	assert.Nil(mempool.queueTx(common.Bytes("tx8"), newQueuedTestTxInfo(8)))
+	assert.Nil(mempool.queueTx(common.Bytes("tx7"), newQueuedTestTxInfo(7)))
+	assert.Equal(DuplicateTxError, mempool.queueTx(common.Bytes("tx7"), newQueuedTestTxInfo(7)))
+	assert.Equal(ReplacementUnderpricedError, mempool.queueTx(common.Bytes("tx7b"), newQueuedTestTxInfo(7)))
+
+	// Nothing is promoted until the gap is filled
+	mempool.promoteQueuedTxs(address, screenTx)
+	assert.Equal(2, mempool.QueuedSize())
+	assert.Equal(0, mempool.Size())
+
+	// The stale transactions are dropped once the later ones are promoted
+	assert.Nil(mempool.queueTx(common.Bytes("tx5"), newQueuedTestTxInfo(5)))
+	mempool.insertCandidateTx(common.Bytes("tx6"), newQueuedTestTxInfo(6))
+	nextSequence = 7
+	mempool.promoteQueuedTxs(address, screenTx)
+	assert.Equal(0, mempool.QueuedSize())
+	assert.Equal(3, mempool.Size())
+	assert.Equal(3, len(mempool.GetCandidateTransactionHashes()))
+}
+
+func TestQueuedTxLimits(t *testing.T) {
+	assert := assert.New(t)
+
+	mempool := CreateMempool(nil, nil)
+	mempool.maxQueuedTxsPerAccount = 2
+
+	assert.Nil(mempool.queueTx(common.Bytes("tx10"), newQueuedTestTxInfo(10)))
+	assert.Nil(mempool.queueTx(common.Bytes("tx11"), newQueuedTestTxInfo(11)))
+	assert.Equal(QueuedTxLimitError, mempool.queueTx(common.Bytes("tx12"), newQueuedTestTxInfo(12)))
+	assert.Equal(2, len(mempool.GetQueuedTransactionHashes()))
+
+	// The queued transactions expire after their lifetime
+	mempool.queuedTxLifetime = 0
+	mempool.promoteAllQueuedTxs(func(rawTx common.Bytes) result.Result { return result.OK })
+	assert.Equal(0, mempool.QueuedSize())
+	assert.Equal(0, mempool.Size())
+}
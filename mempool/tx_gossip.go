@@ -0,0 +1,389 @@
+package mempool
+
+import (
+	"container/list"
+	"sync"
+	"time"
+
+	"github.com/spf13/viper"
+
+	"github.com/pandoprojects/pando/common"
+	dp "github.com/pandoprojects/pando/dispatcher"
+)
+
+const (
+	txAnnounceInterval     = 100 * time.Millisecond // interval at which the batched hash announcements are sent
+	maxTxAnnouncementSize  = 256                    // max number of tx hashes in an announcement or a request
+	maxKnownTxsPerPeer     = 32768
+	maxAnnouncedTxs        = 16384 // number of recently announced transactions kept to serve the requests
+	txRequestTimeout       = 5 * time.Second
+	maxRequestedTxsPerPeer = 4096 // max number of transactions requested from a peer at a time
+	maxTxAnnouncers        = 8    // max number of peers remembered as having announced a requested transaction
+)
+
+// txCache is a bounded FIFO set of transaction hashes, optionally holding the raw transactions
+type txCache struct {
+	entries  map[string]common.Bytes // map: transaction hash -> raw transaction
+	order    list.List               // FIFO list of transaction hashes
+	capacity int
+}
+
+func newTxCache(capacity int) *txCache {
+	return &txCache{
+		entries:  make(map[string]common.Bytes),
+		capacity: capacity,
+	}
+}
+
+func (tc *txCache) add(txhash string, rawTx common.Bytes) {
+	if _, exists := tc.entries[txhash]; exists {
+		return
+	}
+	if tc.order.Len() >= tc.capacity { // remove the oldest transaction
+		oldest := tc.order.Front()
+		delete(tc.entries, oldest.Value.(string))
+		tc.order.Remove(oldest)
+	}
+	tc.entries[txhash] = rawTx
+	tc.order.PushBack(txhash)
+}
+
+func (tc *txCache) contains(txhash string) bool {
+	_, exists := tc.entries[txhash]
+	return exists
+}
+
+func (tc *txCache) get(txhash string) (common.Bytes, bool) {
+	rawTx, exists := tc.entries[txhash]
+	return rawTx, exists && rawTx != nil
+}
+
+// gossipPeer keeps track of the transactions exchanged with a peer
+type gossipPeer struct {
+	announcing bool     // whether the peer speaks the announcement protocol
+	greeted    bool     // whether the peer has been told this node speaks the announcement protocol
+	known      *txCache // hashes of the transactions the peer is known to have
+	pending    []string // hashes waiting to be announced to the peer
+	requested  int      // number of the transactions requested from the peer and not received yet
+}
+
+func newGossipPeer() *gossipPeer {
+	return &gossipPeer{
+		known: newTxCache(maxKnownTxsPerPeer),
+	}
+}
+
+// txGossip relays the transactions in two phases. The hashes of the new transactions are announced to
+// the peers in batches, and a peer requests only the transactions it has not seen. A peer is known to
+// speak the announcement protocol once it sends an announcement or a request, which every node does
+// when it first sees a peer. The full transactions are still flooded to the other peers, since the old
+// nodes drop the messages over ChannelIDTransactionAnnouncement.
+type txGossip struct {
+	mutex *sync.Mutex
+
+	txBookeepper *transactionBookkeeper
+	peers        map[string]*gossipPeer // map: peer ID -> gossip state of the peer
+	announced    *txCache               // recently announced transactions
+	requested    map[string]*txRequest  // map: transaction hash -> pending request of the transaction
+
+	now func() time.Time
+}
+
+// txRequest is a pending request of an announced transaction. If the peer does not send the transaction
+// before the request times out, it is requested from the next peer which has announced it.
+type txRequest struct {
+	peerID      string    // peer the transaction is requested from
+	requestedAt time.Time // time of the request
+	announcers  []string  // other peers which have announced the transaction, in order
+}
+
+func createTxGossip(txBookeepper *transactionBookkeeper) *txGossip {
+	return &txGossip{
+		mutex:        &sync.Mutex{},
+		txBookeepper: txBookeepper,
+		peers:        make(map[string]*gossipPeer),
+		announced:    newTxCache(maxAnnouncedTxs),
+		requested:    make(map[string]*txRequest),
+		now:          time.Now,
+	}
+}
+
+func (tg *txGossip) getPeer(peerID string) *gossipPeer {
+	peer, ok := tg.peers[peerID]
+	if !ok {
+		peer = newGossipPeer()
+		tg.peers[peerID] = peer
+	}
+	return peer
+}
+
+// broadcast queues the announcement of the transaction to the announcing peers which do not have it
+// yet, and returns the other peers which the full transaction should be sent to.
+func (tg *txGossip) broadcast(rawTx common.Bytes, peerIDs []string) (floodPeerIDs []string) {
+	tg.mutex.Lock()
+	defer tg.mutex.Unlock()
+
+	txhash := getTransactionHash(rawTx)
+	tg.announced.add(txhash, rawTx)
+
+	for _, peerID := range peerIDs {
+		peer := tg.getPeer(peerID)
+		if peer.known.contains(txhash) {
+			continue
+		}
+		peer.known.add(txhash, nil)
+		if peer.announcing {
+			peer.pending = append(peer.pending, txhash)
+		} else {
+			floodPeerIDs = append(floodPeerIDs, peerID)
+		}
+	}
+	return floodPeerIDs
+}
+
+// collectAnnouncements syncs the tracked peers with the given connected peers, and returns the peers
+// not greeted yet together with the pending announcements of the announcing peers.
+func (tg *txGossip) collectAnnouncements(peerIDs []string) (newPeerIDs []string, announcements map[string][]string) {
+	tg.mutex.Lock()
+	defer tg.mutex.Unlock()
+
+	connected := make(map[string]bool)
+	for _, peerID := range peerIDs {
+		connected[peerID] = true
+		peer := tg.getPeer(peerID)
+		if !peer.greeted {
+			peer.greeted = true
+			newPeerIDs = append(newPeerIDs, peerID)
+		}
+	}
+
+	announcements = make(map[string][]string)
+	for peerID, peer := range tg.peers {
+		if !connected[peerID] {
+			delete(tg.peers, peerID)
+			continue
+		}
+		if peer.announcing && len(peer.pending) > 0 {
+			announcements[peerID] = peer.pending
+			peer.pending = nil
+		}
+	}
+
+	return newPeerIDs, announcements
+}
+
+// collectRetries returns the transactions to request again, from the next connected peer which has
+// announced them, since the previous request timed out. The requests with no announcer left are dropped.
+func (tg *txGossip) collectRetries() (retries map[string][]string) {
+	tg.mutex.Lock()
+	defer tg.mutex.Unlock()
+
+	now := tg.now()
+	retries = make(map[string][]string)
+	for txhash, request := range tg.requested {
+		if now.Sub(request.requestedAt) <= txRequestTimeout {
+			continue
+		}
+		tg.releaseRequest(request)
+		if _, seen := tg.txBookeepper.getStatus(txhash); seen {
+			delete(tg.requested, txhash)
+			continue
+		}
+		request.peerID = ""
+		for len(request.announcers) > 0 && request.peerID == "" {
+			peerID := request.announcers[0]
+			request.announcers = request.announcers[1:]
+			if peer, ok := tg.peers[peerID]; ok && peer.requested < maxRequestedTxsPerPeer {
+				request.peerID = peerID
+			}
+		}
+		if request.peerID == "" {
+			delete(tg.requested, txhash)
+			continue
+		}
+		request.requestedAt = now
+		tg.peers[request.peerID].requested++
+		retries[request.peerID] = append(retries[request.peerID], txhash)
+	}
+	return retries
+}
+
+// releaseRequest frees the slot of the request in the quota of the requested peer.
+func (tg *txGossip) releaseRequest(request *txRequest) {
+	if peer, ok := tg.peers[request.peerID]; ok && peer.requested > 0 {
+		peer.requested--
+	}
+}
+
+// handleAnnouncement returns the announced transactions which have neither been seen nor requested
+// from another peer yet. The peer is remembered as an announcer of the transactions requested from
+// another peer, to request them again if that peer does not send them.
+func (tg *txGossip) handleAnnouncement(peerID string, txhashes []string) (missing []string) {
+	tg.mutex.Lock()
+	defer tg.mutex.Unlock()
+
+	peer := tg.getPeer(peerID)
+	peer.announcing = true
+
+	if len(txhashes) > maxTxAnnouncementSize {
+		txhashes = txhashes[:maxTxAnnouncementSize]
+	}
+	for _, txhash := range txhashes {
+		peer.known.add(txhash, nil)
+		if _, seen := tg.txBookeepper.getStatus(txhash); seen {
+			continue
+		}
+		if request, ok := tg.requested[txhash]; ok {
+			if request.peerID != peerID && len(request.announcers) < maxTxAnnouncers && !containsPeer(request.announcers, peerID) {
+				request.announcers = append(request.announcers, peerID)
+			}
+			continue
+		}
+		if peer.requested >= maxRequestedTxsPerPeer {
+			continue
+		}
+		peer.requested++
+		tg.requested[txhash] = &txRequest{
+			peerID:      peerID,
+			requestedAt: tg.now(),
+		}
+		missing = append(missing, txhash)
+	}
+	return missing
+}
+
+// handleRequest returns the requested transactions which have been announced recently.
+func (tg *txGossip) handleRequest(peerID string, txhashes []string) (rawTxs []common.Bytes) {
+	tg.mutex.Lock()
+	defer tg.mutex.Unlock()
+
+	peer := tg.getPeer(peerID)
+	peer.announcing = true
+
+	if len(txhashes) > maxTxAnnouncementSize {
+		txhashes = txhashes[:maxTxAnnouncementSize]
+	}
+	for _, txhash := range txhashes {
+		rawTx, ok := tg.announced.get(txhash)
+		if !ok {
+			continue
+		}
+		peer.known.add(txhash, nil)
+		rawTxs = append(rawTxs, rawTx)
+	}
+	return rawTxs
+}
+
+// markReceived records that the transaction was received from the peer.
+func (tg *txGossip) markReceived(peerID string, rawTx common.Bytes) {
+	tg.mutex.Lock()
+	defer tg.mutex.Unlock()
+
+	txhash := getTransactionHash(rawTx)
+	if request, ok := tg.requested[txhash]; ok {
+		tg.releaseRequest(request)
+		delete(tg.requested, txhash)
+	}
+	tg.getPeer(peerID).known.add(txhash, nil)
+}
+
+func containsPeer(peerIDs []string, peerID string) bool {
+	for _, id := range peerIDs {
+		if id == peerID {
+			return true
+		}
+	}
+	return false
+}
+
+// useTxAnnouncements indicates whether the transactions are announced to the peers. The libp2p network
+// relays the full transactions through gossipsub instead.
+func useTxAnnouncements() bool {
+	p2pOpt := common.P2POptEnum(viper.GetInt(common.CfgP2POpt))
+	return p2pOpt == common.P2POptOld
+}
+
+// announceLoop periodically sends the batched transaction announcements to the peers.
+func (mp *Mempool) announceLoop() {
+	defer mp.wg.Done()
+
+	ticker := time.NewTicker(txAnnounceInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-mp.ctx.Done():
+			return
+		case <-ticker.C:
+			mp.announceTxs()
+		}
+	}
+}
+
+func (mp *Mempool) announceTxs() {
+	newPeerIDs, announcements := mp.gossip.collectAnnouncements(mp.dispatcher.Peers(true))
+
+	// An empty announcement tells a new peer that this node speaks the announcement protocol
+	for _, peerID := range newPeerIDs {
+		mp.sendTxAnnouncement(peerID, []string{})
+	}
+
+	for peerID, txhashes := range announcements {
+		for start := 0; start < len(txhashes); start += maxTxAnnouncementSize {
+			end := start + maxTxAnnouncementSize
+			if end > len(txhashes) {
+				end = len(txhashes)
+			}
+			mp.sendTxAnnouncement(peerID, txhashes[start:end])
+		}
+	}
+
+	for peerID, txhashes := range mp.gossip.collectRetries() {
+		for start := 0; start < len(txhashes); start += maxTxAnnouncementSize {
+			end := start + maxTxAnnouncementSize
+			if end > len(txhashes) {
+				end = len(txhashes)
+			}
+			mp.requestTxs(peerID, txhashes[start:end])
+		}
+	}
+}
+
+func (mp *Mempool) sendTxAnnouncement(peerID string, txhashes []string) {
+	announcement := dp.InventoryResponse{
+		ChannelID: common.ChannelIDTransactionAnnouncement,
+		Entries:   txhashes,
+	}
+	mp.dispatcher.SendInventory([]string{peerID}, announcement)
+}
+
+// handleTxAnnouncement requests the announced transactions which have not been seen from the peer.
+func (mp *Mempool) handleTxAnnouncement(peerID string, txhashes []string) {
+	missing := mp.gossip.handleAnnouncement(peerID, txhashes)
+	if len(missing) == 0 {
+		return
+	}
+
+	mp.requestTxs(peerID, missing)
+}
+
+func (mp *Mempool) requestTxs(peerID string, txhashes []string) {
+	logger.Debugf("Request %v announced txs from peer %v", len(txhashes), peerID)
+
+	request := dp.DataRequest{
+		ChannelID: common.ChannelIDTransactionAnnouncement,
+		Entries:   txhashes,
+	}
+	mp.dispatcher.GetData([]string{peerID}, request)
+}
+
+// handleTxRequest sends the requested transactions to the peer over ChannelIDTransaction.
+func (mp *Mempool) handleTxRequest(peerID string, txhashes []string) {
+	for _, rawTx := range mp.gossip.handleRequest(peerID, txhashes) {
+		data := dp.DataResponse{
+			ChannelID: common.ChannelIDTransaction,
+			Payload:   rawTx,
+		}
+		mp.dispatcher.SendData([]string{peerID}, data)
+	}
+}
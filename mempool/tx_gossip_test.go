@@ -0,0 +1,128 @@
+package mempool
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/pandoprojects/pando/common"
+)
+
+func TestTxGossipAnnouncement(t *testing.T) {
+	assert := assert.New(t)
+
+	txBookeepper := createTransactionBookkeeper(defaultMaxNumTxs)
+	gossip := createTxGossip(&txBookeepper)
+
+	tx1 := common.Bytes("tx1")
+	tx2 := common.Bytes("tx2")
+	peerIDs := []string{"peer1", "peer2"}
+
+	// Both peers are greeted, and get the full transaction until they speak the announcement protocol
+	newPeerIDs, announcements := gossip.collectAnnouncements(peerIDs)
+	assert.ElementsMatch(peerIDs, newPeerIDs)
+	assert.Empty(announcements)
+	assert.ElementsMatch(peerIDs, gossip.broadcast(tx1, peerIDs))
+	assert.Empty(gossip.broadcast(tx1, peerIDs))
+
+	// peer1 announces a transaction, and becomes an announcing peer
+	missing := gossip.handleAnnouncement("peer1", []string{getTransactionHash(tx1), getTransactionHash(tx2)})
+	assert.Equal([]string{getTransactionHash(tx1), getTransactionHash(tx2)}, missing)
+
+	// The transaction is requested once, until it is received or the request times out
+	assert.Empty(gossip.handleAnnouncement("peer1", []string{getTransactionHash(tx2)}))
+	gossip.markReceived("peer1", tx2)
+	txBookeepper.record(tx2)
+	assert.Empty(gossip.handleAnnouncement("peer1", []string{getTransactionHash(tx2)}))
+
+	// The transaction is relayed to peer1 by announcement, and flooded to peer2 which is an old peer
+	tx3 := common.Bytes("tx3")
+	assert.Equal([]string{"peer2"}, gossip.broadcast(tx3, peerIDs))
+	assert.Equal([]string{"peer2"}, gossip.broadcast(tx2, peerIDs)) // peer1 announced tx2
+	newPeerIDs, announcements = gossip.collectAnnouncements(peerIDs)
+	assert.Empty(newPeerIDs)
+	assert.Equal(map[string][]string{"peer1": {getTransactionHash(tx3)}}, announcements)
+
+	// Disconnected peers are dropped, and greeted again once reconnected
+	newPeerIDs, _ = gossip.collectAnnouncements([]string{"peer2"})
+	assert.Empty(newPeerIDs)
+	newPeerIDs, _ = gossip.collectAnnouncements(peerIDs)
+	assert.Equal([]string{"peer1"}, newPeerIDs)
+}
+
+func TestTxGossipRequest(t *testing.T) {
+	assert := assert.New(t)
+
+	txBookeepper := createTransactionBookkeeper(defaultMaxNumTxs)
+	gossip := createTxGossip(&txBookeepper)
+
+	tx1 := common.Bytes("tx1")
+	tx2 := common.Bytes("tx2")
+	gossip.handleAnnouncement("peer1", []string{})
+	gossip.broadcast(tx1, []string{"peer1", "peer2"})
+
+	// Only the announced transactions are served
+	rawTxs := gossip.handleRequest("peer2", []string{getTransactionHash(tx1), getTransactionHash(tx2)})
+	assert.Equal([]common.Bytes{tx1}, rawTxs)
+
+	// The requesting peer has the transaction, and is not announced it again
+	_, announcements := gossip.collectAnnouncements([]string{"peer1", "peer2"})
+	assert.Equal(map[string][]string{"peer1": {getTransactionHash(tx1)}}, announcements)
+	assert.Empty(gossip.broadcast(tx1, []string{"peer1", "peer2"}))
+}
+
+func TestTxGossipRequestRetry(t *testing.T) {
+	assert := assert.New(t)
+
+	txBookeepper := createTransactionBookkeeper(defaultMaxNumTxs)
+	gossip := createTxGossip(&txBookeepper)
+	now := time.Now()
+	gossip.now = func() time.Time { return now }
+	peerIDs := []string{"peer1", "peer2", "peer3"}
+	gossip.collectAnnouncements(peerIDs)
+
+	// The transaction is requested from the first announcer only, the others are remembered
+	txhash := getTransactionHash(common.Bytes("tx1"))
+	assert.Equal([]string{txhash}, gossip.handleAnnouncement("peer1", []string{txhash}))
+	assert.Empty(gossip.handleAnnouncement("peer2", []string{txhash}))
+	assert.Empty(gossip.handleAnnouncement("peer3", []string{txhash}))
+	assert.Empty(gossip.collectRetries())
+
+	// peer1 does not send the transaction, which is requested from the next announcer
+	now = now.Add(txRequestTimeout + time.Second)
+	assert.Equal(map[string][]string{"peer2": {txhash}}, gossip.collectRetries())
+	assert.Empty(gossip.collectRetries())
+
+	// The disconnected announcers are skipped, and the request is dropped once none is left
+	gossip.collectAnnouncements([]string{"peer1", "peer2"})
+	now = now.Add(txRequestTimeout + time.Second)
+	assert.Empty(gossip.collectRetries())
+	assert.Equal([]string{txhash}, gossip.handleAnnouncement("peer1", []string{txhash}))
+}
+
+func TestTxGossipRequestQuota(t *testing.T) {
+	assert := assert.New(t)
+
+	txBookeepper := createTransactionBookkeeper(defaultMaxNumTxs)
+	gossip := createTxGossip(&txBookeepper)
+
+	txhashes := []string{}
+	for i := 0; i <= maxRequestedTxsPerPeer; i++ {
+		txhashes = append(txhashes, getTransactionHash(common.Bytes(fmt.Sprintf("tx%v", i))))
+	}
+	for start := 0; start < maxRequestedTxsPerPeer; start += maxTxAnnouncementSize {
+		assert.Equal(maxTxAnnouncementSize, len(gossip.handleAnnouncement("peer1", txhashes[start:start+maxTxAnnouncementSize])))
+	}
+
+	// peer1 has reached its quota, the extra transaction is requested from another peer
+	extra := txhashes[maxRequestedTxsPerPeer:]
+	assert.Empty(gossip.handleAnnouncement("peer1", extra))
+	assert.Equal(extra, gossip.handleAnnouncement("peer2", extra))
+
+	// A received transaction frees a slot of the quota
+	gossip.markReceived("peer1", common.Bytes("tx0"))
+	another := []string{getTransactionHash(common.Bytes("another"))}
+	assert.Equal(another, gossip.handleAnnouncement("peer1", another))
+}
@@ -5,6 +5,7 @@ import (
 	"container/list"
 	"context"
 	"math/rand"
+	"sort"
 	"sync"
 	"sync/atomic"
 	"time"
@@ -13,6 +14,7 @@ import (
 	"github.com/spf13/viper"
 	"github.com/pandoprojects/pando/blockchain"
 	"github.com/pandoprojects/pando/common"
+	"github.com/pandoprojects/pando/common/clock"
 	"github.com/pandoprojects/pando/common/util"
 	"github.com/pandoprojects/pando/core"
 	"github.com/pandoprojects/pando/dispatcher"
@@ -52,29 +54,36 @@ type PendingBlock struct {
 	createdAt  time.Time
 	status     RequestState
 	fromGossip bool
+	clock      clock.Clock
 }
 
 func NewPendingBlock(x common.Hash, peerIds []string, fromGossip bool) *PendingBlock {
+	return newPendingBlock(x, peerIds, fromGossip, clock.NewRealClock())
+}
+
+func newPendingBlock(x common.Hash, peerIds []string, fromGossip bool, clock clock.Clock) *PendingBlock {
+	now := clock.Now()
 	return &PendingBlock{
 		hash:       x,
-		lastUpdate: time.Now(),
-		createdAt:  time.Now(),
+		lastUpdate: now,
+		createdAt:  now,
 		peers:      peerIds,
 		status:     RequestToSendDataReq,
 		fromGossip: fromGossip,
+		clock:      clock,
 	}
 }
 
 func (pb *PendingBlock) HasTimedOut() bool {
-	return time.Since(pb.lastUpdate) > RequestTimeout
+	return pb.clock.Now().Sub(pb.lastUpdate) > RequestTimeout
 }
 
 func (pb *PendingBlock) HasExpired() bool {
-	return time.Since(pb.createdAt) > Expiration
+	return pb.clock.Now().Sub(pb.createdAt) > Expiration
 }
 
 func (pb *PendingBlock) UpdateTimestamp() {
-	pb.lastUpdate = time.Now()
+	pb.lastUpdate = pb.clock.Now()
 }
 
 type HeaderHeap []*PendingBlock
@@ -107,7 +116,10 @@ func (h *HeaderHeap) Pop() interface{} {
 type RequestManager struct {
 	logger *log.Entry
 
-	ticker *time.Ticker
+	clock      clock.Clock
+	rand       *rand.Rand
+	ticker     clock.Ticker
+	passTicker clock.Ticker
 
 	wg      *sync.WaitGroup
 	ctx     context.Context
@@ -150,7 +162,8 @@ func NewRequestManager(syncMgr *SyncManager, reporter *rp.Reporter) *RequestMana
 	}
 
 	rm := &RequestManager{
-		ticker: time.NewTicker(1 * time.Second),
+		clock: clock.NewRealClock(),
+		rand:  rand.New(rand.NewSource(time.Now().UnixNano())),
 
 		wg: &sync.WaitGroup{},
 
@@ -194,16 +207,14 @@ func (rm *RequestManager) mainLoop() {
 		case <-rm.ctx.Done():
 			rm.stopped = true
 			return
-		case <-rm.ticker.C:
+		case <-rm.ticker.C():
 			rm.tryToDownload()
 		}
 	}
 }
 
 func (rm *RequestManager) Start(ctx context.Context) {
-	c, cancel := context.WithCancel(ctx)
-	rm.ctx = c
-	rm.cancel = cancel
+	rm.init(ctx)
 
 	rm.wg.Add(1)
 	go rm.mainLoop()
@@ -212,8 +223,51 @@ func (rm *RequestManager) Start(ctx context.Context) {
 	go rm.passReadyBlocks()
 }
 
+// startStepping prepares the request manager like Start, but starts no goroutine, see step.
+func (rm *RequestManager) startStepping(ctx context.Context) {
+	rm.init(ctx)
+	rm.passReadyBlocksOnce()
+}
+
+// step handles one pending block notification or expired timer without blocking, and returns
+// false if there was nothing to do.
+func (rm *RequestManager) step() bool {
+	select {
+	case <-rm.blockNotify:
+		rm.passReadyBlocksOnce()
+		return true
+	default:
+	}
+
+	select {
+	case <-rm.ticker.C():
+		rm.tryToDownload()
+		return true
+	default:
+	}
+
+	select {
+	case <-rm.passTicker.C():
+		rm.passReadyBlocksOnce()
+		return true
+	default:
+	}
+
+	return false
+}
+
+func (rm *RequestManager) init(ctx context.Context) {
+	c, cancel := context.WithCancel(ctx)
+	rm.ctx = c
+	rm.cancel = cancel
+
+	rm.ticker = rm.clock.NewTicker(1 * time.Second)
+	rm.passTicker = rm.clock.NewTicker(1 * time.Second)
+}
+
 func (rm *RequestManager) Stop() {
 	rm.ticker.Stop()
+	rm.passTicker.Stop()
 	rm.cancel()
 }
 
@@ -239,7 +293,8 @@ func (rm *RequestManager) AddActivePeer(activePeerID string) {
 		minScore := MaxPeerActiveScore
 		minPID := ""
 		for pid, score := range rm.activePeers {
-			if score <= minScore {
+			// Ties are broken by peer ID so that the same peer is evicted regardless of the map order.
+			if score < minScore || (score == minScore && (minPID == "" || pid < minPID)) {
 				minScore = score
 				minPID = pid
 			}
@@ -301,8 +356,9 @@ func (rm *RequestManager) tryToDownload() {
 
 	hasUndownloadedBlocks := rm.pendingBlocks.Len() > 0 || len(rm.pendingBlocksByHash) > 0 || rm.pendingBlocksWithHeader.Len() > 0
 
-	minIntervalPassed := time.Since(rm.lastInventoryRequest) >= MinInventoryRequestInterval
-	maxIntervalPassed := time.Since(rm.lastInventoryRequest) >= MaxInventoryRequestInterval
+	sinceLastInventoryRequest := rm.clock.Now().Sub(rm.lastInventoryRequest)
+	minIntervalPassed := sinceLastInventoryRequest >= MinInventoryRequestInterval
+	maxIntervalPassed := sinceLastInventoryRequest >= MaxInventoryRequestInterval
 
 	if maxIntervalPassed || (hasUndownloadedBlocks && minIntervalPassed) {
 		if hasUndownloadedBlocks && rm.pendingBlocks.Len() > 1 {
@@ -317,7 +373,7 @@ func (rm *RequestManager) tryToDownload() {
 			}).Info("Sync progress")
 		}
 
-		rm.lastInventoryRequest = time.Now()
+		rm.lastInventoryRequest = rm.clock.Now()
 		req := rm.buildInventoryRequest()
 		rm.getInventory(req)
 	}
@@ -372,7 +428,7 @@ func (rm *RequestManager) downloadBlockFromHash() {
 		// }
 		if pendingBlock.status == RequestToSendDataReq ||
 			(!rm.ifDownloadByHeader && pendingBlock.status == RequestToSendBodyReq) {
-			randomPeerID := pendingBlock.peers[rand.Intn(len(pendingBlock.peers))]
+			randomPeerID := pendingBlock.peers[rm.rand.Intn(len(pendingBlock.peers))]
 			request := dispatcher.DataRequest{
 				ChannelID: common.ChannelIDBlock,
 				Entries:   []string{pendingBlock.hash.String()},
@@ -459,7 +515,7 @@ func (rm *RequestManager) downloadBlockFromHeader() {
 		if pendingBlock.status == RequestToSendBodyReq ||
 			(pendingBlock.status == RequestWaitingBodyResp && pendingBlock.HasTimedOut()) {
 
-			peersWithBlock := util.Shuffle(pendingBlock.peers)
+			peersWithBlock := rm.shuffle(pendingBlock.peers)
 			var randomPeerID string
 			for i := 0; i < len(peersWithBlock); i++ {
 				if rm.dispatcher.PeerExists(peersWithBlock[i]) { // the peer may have been purged
@@ -552,6 +608,7 @@ func (rm *RequestManager) getInventory(req dispatcher.InventoryRequest) {
 				}).Debugf("Skipping low score peer from active list")
 			}
 		}
+		sort.Strings(peersToRequest)
 		rm.logger.Debugf("Reuse activePeers: %v", peersToRequest)
 	}
 	rm.aplock.Unlock()
@@ -563,7 +620,7 @@ func (rm *RequestManager) getInventory(req dispatcher.InventoryRequest) {
 	}
 	if len(peersToRequest) < targetSize { // resample
 		allPeers := rm.syncMgr.dispatcher.Peers(true) // skip rametronenterprise
-		samples := util.Sample(allPeers, targetSize)
+		samples := rm.sample(allPeers, targetSize)
 		for _, sample := range samples {
 			duplicate := false
 			for _, pid := range peersToRequest {
@@ -630,7 +687,7 @@ func (rm *RequestManager) addHash(x common.Hash, peerIDs []string, fromGossip bo
 	var pendingBlock *PendingBlock
 	pendingBlockEl, ok := rm.pendingBlocksByHash[x.String()]
 	if !ok {
-		pendingBlock = NewPendingBlock(x, peerIDs, fromGossip)
+		pendingBlock = newPendingBlock(x, peerIDs, fromGossip, rm.clock)
 		pendingBlockEl = rm.pendingBlocks.PushBack(pendingBlock)
 		rm.pendingBlocksByHash[x.String()] = pendingBlockEl
 	}
@@ -729,55 +786,76 @@ func (rm *RequestManager) AddBlock(block *core.Block) {
 func (rm *RequestManager) passReadyBlocks() {
 	defer rm.wg.Done()
 
-	timer := time.NewTicker(time.Second)
-	defer timer.Stop()
-
 	for {
-		lfb := rm.syncMgr.consensus.GetLastFinalizedBlock()
-		height := lfb.Height + 1
-		parents := []*core.ExtendedBlock{lfb}
+		rm.passReadyBlocksOnce()
+
+		select {
+		case <-rm.ctx.Done():
+			return
+		case <-rm.blockNotify:
+		case <-rm.passTicker.C():
+		}
+	}
+}
 
-		for {
-			blocks := rm.chain.FindBlocksByHeight(height)
+// passReadyBlocksOnce passes down the pending blocks whose parent has been validated.
+func (rm *RequestManager) passReadyBlocksOnce() {
+	lfb := rm.syncMgr.consensus.GetLastFinalizedBlock()
+	height := lfb.Height + 1
+	parents := []*core.ExtendedBlock{lfb}
 
-			if len(blocks) == 0 {
-				break
-			}
+	for {
+		blocks := rm.chain.FindBlocksByHeight(height)
 
-			for _, block := range blocks {
-				if rm.dumpBlockCache.Contains(block.Hash()) {
-					continue
-				}
+		if len(blocks) == 0 {
+			break
+		}
 
-				// Check if block's parent has already been added to chain. If not, skip block
-				found := false
-				for _, parent := range parents {
-					if parent.Hash() == block.Parent && parent.Status.IsValid() {
-						found = true
-						break
-					}
-				}
-				if !found {
-					continue
-				}
+		for _, block := range blocks {
+			if rm.dumpBlockCache.Contains(block.Hash()) {
+				continue
+			}
 
-				rm.dumpBlockCache.Add(block.Hash(), struct{}{})
-				if block.Status.IsPending() {
-					rm.syncMgr.PassdownMessage(block.Block)
-					rm.tip.Store(block)
+			// Check if block's parent has already been added to chain. If not, skip block
+			found := false
+			for _, parent := range parents {
+				if parent.Hash() == block.Parent && parent.Status.IsValid() {
+					found = true
+					break
 				}
 			}
+			if !found {
+				continue
+			}
 
-			height++
-			parents = blocks
+			rm.dumpBlockCache.Add(block.Hash(), struct{}{})
+			if block.Status.IsPending() {
+				rm.syncMgr.PassdownMessage(block.Block)
+				rm.tip.Store(block)
+			}
 		}
 
-		select {
-		case <-rm.ctx.Done():
-			return
-		case <-rm.blockNotify:
-		case <-timer.C:
-		}
+		height++
+		parents = blocks
+	}
+}
+
+// sample returns up to sampleSize entries in random order, like util.Sample but with the random
+// source of the request manager.
+func (rm *RequestManager) sample(entries []string, sampleSize int) []string {
+	if sampleSize < 0 {
+		return []string{}
 	}
+	rm.rand.Shuffle(len(entries), func(i, j int) {
+		entries[i], entries[j] = entries[j], entries[i]
+	})
+	if sampleSize > len(entries) {
+		sampleSize = len(entries)
+	}
+	return entries[0:sampleSize]
+}
 
+// shuffle shuffles the given entries, like util.Shuffle.
+func (rm *RequestManager) shuffle(entries []string) []string {
+	return rm.sample(entries, len(entries))
 }
@@ -2,6 +2,7 @@ package netsync
 
 import (
 	"context"
+	"math/rand"
 	"reflect"
 	"strings"
 	"sync"
@@ -11,6 +12,7 @@ import (
 	"github.com/spf13/viper"
 	"github.com/pandoprojects/pando/blockchain"
 	"github.com/pandoprojects/pando/common"
+	"github.com/pandoprojects/pando/common/clock"
 	"github.com/pandoprojects/pando/common/util"
 	"github.com/pandoprojects/pando/core"
 	"github.com/pandoprojects/pando/dispatcher"
@@ -22,6 +24,7 @@ import (
 )
 
 const voteCacheLimit = 512
+const evidenceCacheLimit = 128
 
 var logger *log.Entry = log.WithFields(log.Fields{"prefix": "netsync"})
 
@@ -58,11 +61,13 @@ type SyncManager struct {
 
 	logger *log.Entry
 
-	voteCache *lru.Cache // Cache for votes
+	voteCache     *lru.Cache // Cache for votes
+	evidenceCache *lru.Cache // Cache for equivocation evidences
 }
 
 func NewSyncManager(chain *blockchain.Chain, cons core.ConsensusEngine, networkOld p2p.Network, network p2pl.Network, disp *dispatcher.Dispatcher, consumer MessageConsumer, reporter *rp.Reporter) *SyncManager {
 	voteCache, _ := lru.New(voteCacheLimit)
+	evidenceCache, _ := lru.New(evidenceCacheLimit)
 	sm := &SyncManager{
 		chain:      chain,
 		consensus:  cons,
@@ -71,7 +76,8 @@ func NewSyncManager(chain *blockchain.Chain, cons core.ConsensusEngine, networkO
 		wg:         &sync.WaitGroup{},
 		incoming:   make(chan p2ptypes.Message, viper.GetInt(common.CfgSyncMessageQueueSize)),
 
-		voteCache: voteCache,
+		voteCache:     voteCache,
+		evidenceCache: evidenceCache,
 	}
 	sm.requestMgr = NewRequestManager(sm, reporter)
 
@@ -95,6 +101,17 @@ func NewSyncManager(chain *blockchain.Chain, cons core.ConsensusEngine, networkO
 	return sm
 }
 
+// SetClock sets the clock of the block requests. It must be called before Start.
+func (sm *SyncManager) SetClock(clock clock.Clock) {
+	sm.requestMgr.clock = clock
+}
+
+// SetRandSeed seeds the selection of the peers to request blocks from, so that a simulation is
+// reproducible.
+func (sm *SyncManager) SetRandSeed(seed int64) {
+	sm.requestMgr.rand = rand.New(rand.NewSource(seed))
+}
+
 func (sm *SyncManager) Start(ctx context.Context) {
 	c, cancel := context.WithCancel(ctx)
 	sm.ctx = c
@@ -106,6 +123,29 @@ func (sm *SyncManager) Start(ctx context.Context) {
 	go sm.mainLoop()
 }
 
+// StartStepping prepares the sync manager like Start, but starts no goroutine: the caller drives
+// the sync manager with Step. This lets a simulator run several nodes deterministically.
+func (sm *SyncManager) StartStepping(ctx context.Context) {
+	c, cancel := context.WithCancel(ctx)
+	sm.ctx = c
+	sm.cancel = cancel
+
+	sm.requestMgr.startStepping(c)
+}
+
+// Step handles one queued message, or else one pending task of the request manager, without
+// blocking. It returns false if there was nothing to do.
+func (sm *SyncManager) Step() bool {
+	select {
+	case msg := <-sm.incoming:
+		sm.processMessage(msg)
+		return true
+	default:
+	}
+
+	return sm.requestMgr.step()
+}
+
 func (sm *SyncManager) Stop() {
 	sm.cancel()
 }
@@ -140,6 +180,7 @@ func (sm *SyncManager) GetChannelIDs() []common.ChannelIDEnum {
 		common.ChannelIDGuardian,
 		common.ChannelIDRametronenterpriseVote,
 		common.ChannelIDAggregatedRametronenterpriseVotes,
+		common.ChannelIDEquivocationEvidence,
 	}
 }
 
@@ -659,6 +700,23 @@ func (m *SyncManager) handleDataResponse(peerID string, data *dispatcher.DataRes
 			"peer":            peerID,
 		}).Debug("Received aggregated rametronenterprise vote")
 		m.handleAggregatedRametronenterpriseVotes(vote)
+	case common.ChannelIDEquivocationEvidence:
+		evidence := &core.EquivocationEvidence{}
+		err := rlp.DecodeBytes(data.Payload, evidence)
+		if err != nil {
+			m.logger.WithFields(log.Fields{
+				"channelID": data.ChannelID,
+				"payload":   data.Payload,
+				"error":     err,
+				"peerID":    peerID,
+			}).Warn("Failed to decode DataResponse payload")
+			return
+		}
+		m.logger.WithFields(log.Fields{
+			"evidence": evidence,
+			"peer":     peerID,
+		}).Debug("Received equivocation evidence")
+		m.handleEquivocationEvidence(evidence)
 	case common.ChannelIDHeader:
 		headers := &Headers{}
 		err := rlp.DecodeBytes(data.Payload, headers)
@@ -814,6 +872,38 @@ func (sm *SyncManager) handleVote(vote core.Vote) {
 	}
 }
 
+func (sm *SyncManager) handleEquivocationEvidence(evidence *core.EquivocationEvidence) {
+	hash := evidence.Hash()
+	if sm.evidenceCache.Contains(hash) {
+		return
+	}
+	if res := evidence.Validate(sm.chain.ChainID); res.IsError() {
+		sm.logger.WithFields(log.Fields{
+			"evidence": evidence,
+			"error":    res.Message,
+		}).Warn("Ignoring invalid equivocation evidence")
+		return
+	}
+	sm.evidenceCache.Add(hash, struct{}{})
+
+	sm.PassdownMessage(evidence)
+
+	p2pOpt := common.P2POptEnum(viper.GetInt(common.CfgP2POpt))
+	if p2pOpt != common.P2POptLibp2p {
+		// Need to manually gossip if not using Libp2p
+		payload, err := rlp.EncodeToBytes(evidence)
+		if err != nil {
+			sm.logger.WithFields(log.Fields{"evidence": evidence}).Error("Failed to encode equivocation evidence")
+			return
+		}
+		msg := dispatcher.DataResponse{
+			ChannelID: common.ChannelIDEquivocationEvidence,
+			Payload:   payload,
+		}
+		sm.dispatcher.SendData([]string{}, msg)
+	}
+}
+
 func (sm *SyncManager) handleGuardianVote(vote *core.AggregatedVotes) {
 	sm.PassdownMessage(vote)
 }
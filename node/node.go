@@ -14,12 +14,14 @@ import (
 	"github.com/pandoprojects/pando/crypto"
 	dp "github.com/pandoprojects/pando/dispatcher"
 	ld "github.com/pandoprojects/pando/ledger"
+	"github.com/pandoprojects/pando/lightclient"
 	mp "github.com/pandoprojects/pando/mempool"
 	"github.com/pandoprojects/pando/netsync"
 	"github.com/pandoprojects/pando/p2p"
 	"github.com/pandoprojects/pando/p2pl"
 	rp "github.com/pandoprojects/pando/report"
 	"github.com/pandoprojects/pando/rpc"
+	"github.com/pandoprojects/pando/signer"
 	"github.com/pandoprojects/pando/snapshot"
 	"github.com/pandoprojects/pando/store"
 	"github.com/pandoprojects/pando/store/database"
@@ -50,6 +52,7 @@ type Node struct {
 type Params struct {
 	ChainID             string
 	PrivateKey          *crypto.PrivateKey
+	Signer              core.Signer // Signs the consensus messages, with PrivateKey if nil.
 	Root                *core.Block
 	NetworkOld          p2p.Network
 	Network             p2pl.Network
@@ -58,17 +61,27 @@ type Params struct {
 	SnapshotPath        string
 	ChainImportDirPath  string
 	ChainCorrectionPath string
+	ConsensusWAL        *consensus.WAL // Write-ahead log of the consensus messages, disabled if nil.
+	MempoolJournal      *mp.TxJournal  // Journal of the pending transactions, disabled if nil.
 }
 
 func NewNode(params *Params) *Node {
 	store := kvstore.NewKVStore(params.DB)
 	chain := blockchain.NewChain(params.ChainID, store, params.Root)
+	chain.SetAccountTxIndexEnabled(viper.GetBool(common.CfgStorageIndexAccountTxs))
 	params.RollingDB.SetChain(chain)
 
 	validatorManager := consensus.NewRotatingValidatorManager()
 	dispatcher := dp.NewDispatcher(params.NetworkOld, params.Network)
 
-	consensus := consensus.NewConsensusEngine(params.PrivateKey, store, chain, dispatcher, validatorManager)
+	consensusSigner := params.Signer
+	if consensusSigner == nil {
+		consensusSigner = signer.NewLocalSigner(params.PrivateKey)
+	}
+	consensus := consensus.NewConsensusEngineWithSigner(consensusSigner, store, chain, dispatcher, validatorManager)
+	if params.ConsensusWAL != nil {
+		consensus.SetWAL(params.ConsensusWAL)
+	}
 	reporter := rp.NewReporter(dispatcher, consensus, chain)
 
 	// TODO: check if this is a guardian node
@@ -79,13 +92,19 @@ func NewNode(params *Params) *Node {
 	validatorManager.SetConsensusEngine(consensus)
 	consensus.SetLedger(ledger)
 	mempool.SetLedger(ledger)
+	if params.MempoolJournal != nil {
+		mempool.SetJournal(params.MempoolJournal)
+	}
 	txMsgHandler := mp.CreateMempoolMessageHandler(mempool)
+	lightHandler := lightclient.NewHandler(dispatcher, lightclient.NewProvider(chain, consensus, params.RollingDB))
 
 	if !reflect.ValueOf(params.Network).IsNil() {
 		params.Network.RegisterMessageHandler(txMsgHandler)
+		params.Network.RegisterMessageHandler(lightHandler)
 	}
 	if !reflect.ValueOf(params.NetworkOld).IsNil() {
 		params.NetworkOld.RegisterMessageHandler(txMsgHandler)
+		params.NetworkOld.RegisterMessageHandler(lightHandler)
 	}
 
 	currentHeight := consensus.GetLastFinalizedBlock().Height
@@ -137,6 +156,20 @@ func (n *Node) Start(ctx context.Context) {
 	n.Mempool.Start(n.ctx)
 	n.reporter.Start(n.ctx)
 
+	go func() {
+		if err := n.Chain.RebuildLogIndex(n.ctx, n.Consensus.GetLastFinalizedBlock().Height); err != nil && err != context.Canceled {
+			log.Printf("Failed to rebuild log index: %v", err)
+		}
+	}()
+
+	if n.Chain.AccountTxIndexEnabled() {
+		go func() {
+			if err := n.Chain.RebuildAccountTxIndex(n.ctx, n.Consensus.GetLastFinalizedBlock().Height); err != nil && err != context.Canceled {
+				log.Printf("Failed to rebuild account tx index: %v", err)
+			}
+		}()
+	}
+
 	if viper.GetBool(common.CfgRPCEnabled) {
 		n.RPC.Start(n.ctx)
 	}
@@ -151,6 +184,7 @@ func (n *Node) Stop() {
 func (n *Node) Wait() {
 	n.Consensus.Wait()
 	n.SyncManager.Wait()
+	n.Mempool.Wait()
 	if n.RPC != nil {
 		n.RPC.Wait()
 	}
@@ -112,6 +112,9 @@ func CreateConnection(netconn net.Conn, config ConnectionConfig) *Connection {
 	channelNATMapping := createDefaultChannel(common.ChannelIDNATMapping)
 	channelRametronenterpriseVote := createDefaultChannel(common.ChannelIDRametronenterpriseVote)
 	channelRametronenterpriseAggregatedVotes := createDefaultChannel(common.ChannelIDAggregatedRametronenterpriseVotes)
+	channelEquivocationEvidence := createDefaultChannel(common.ChannelIDEquivocationEvidence)
+	channelLightClient := createDefaultChannel(common.ChannelIDLightClient)
+	channelTransactionAnnouncement := createDefaultChannel(common.ChannelIDTransactionAnnouncement)
 	channels := []*Channel{
 		&channelCheckpoint,
 		&channelHeader,
@@ -125,6 +128,9 @@ func CreateConnection(netconn net.Conn, config ConnectionConfig) *Connection {
 		&channelNATMapping,
 		&channelRametronenterpriseVote,
 		&channelRametronenterpriseAggregatedVotes,
+		&channelEquivocationEvidence,
+		&channelLightClient,
+		&channelTransactionAnnouncement,
 	}
 
 	success, channelGroup := createChannelGroup(getDefaultChannelGroupConfig(), channels)
@@ -697,7 +697,7 @@ func (msgr *Messenger) printStats() {
 	defer msgr.statsLock.Unlock()
 
 	ret := "Received bytes:"
-	for k := byte(0); k <= byte(common.ChannelIDAggregatedRametronenterpriseVotes); k++ {
+	for k := byte(0); k <= byte(common.ChannelIDTransactionAnnouncement); k++ {
 		v, ok := msgr.statsCounter[common.ChannelIDEnum(k)]
 		if !ok {
 			continue
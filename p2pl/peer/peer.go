@@ -32,6 +32,9 @@ var Channels = []cmn.ChannelIDEnum{
 	cmn.ChannelIDGuardian,
 	cmn.ChannelIDRametronenterpriseVote,
 	cmn.ChannelIDAggregatedRametronenterpriseVotes,
+	cmn.ChannelIDEquivocationEvidence,
+	cmn.ChannelIDLightClient,
+	cmn.ChannelIDTransactionAnnouncement,
 }
 
 //
@@ -1,19 +1,26 @@
 package rpc
 
 import (
+	"bytes"
 	"encoding/hex"
+	"errors"
 	"fmt"
+	"math/big"
 
 	"github.com/pandoprojects/pando/common"
+	"github.com/pandoprojects/pando/core"
 	"github.com/pandoprojects/pando/ledger/state"
 	"github.com/pandoprojects/pando/ledger/types"
 	"github.com/pandoprojects/pando/ledger/vm"
+	"github.com/pandoprojects/pando/ledger/vm/params"
 )
 
 // ------------------------------- CallSmartContract -----------------------------------
 
 type CallSmartContractArgs struct {
-	SctxBytes string `json:"sctx_bytes"`
+	SctxBytes string            `json:"sctx_bytes"`
+	Height    common.JSONUint64 `json:"height"`     // optional, call on top of the state of the finalized block at this height
+	BlockHash common.Hash       `json:"block_hash"` // optional, call on top of the state of this block
 }
 
 type CallSmartContractResult struct {
@@ -25,12 +32,25 @@ type CallSmartContractResult struct {
 
 // CallSmartContract calls the smart contract. However, calling a smart contract does NOT modify
 // the globally consensus state. It can be used for dry run, or for retrieving info from smart contracts
-// without actually spending gas.
+// without actually spending gas. By default the call is executed on top of the latest delivered state,
+// with Height or BlockHash it is executed on top of the state of the given block, as if it were included
+// in the next block.
 func (t *PandoRPCService) CallSmartContract(args *CallSmartContractArgs, result *CallSmartContractResult) (err error) {
 	var ledgerState *state.StoreView
-	ledgerState, err = t.ledger.GetDeliveredSnapshot()
-	if err != nil {
-		return err
+	var parentBlock *core.Block
+	if args.Height == 0 && args.BlockHash.IsEmpty() {
+		ledgerState, err = t.ledger.GetDeliveredSnapshot()
+		if err != nil {
+			return err
+		}
+		parentBlock = t.ledger.State().ParentBlock()
+	} else {
+		var block *core.ExtendedBlock
+		ledgerState, block, err = t.historicalStoreView(uint64(args.Height), args.BlockHash)
+		if err != nil {
+			return err
+		}
+		parentBlock = block.Block
 	}
 
 	blockHeight := ledgerState.Height() + 1 // the view points to the parent of the current block
@@ -52,7 +72,6 @@ func (t *PandoRPCService) CallSmartContract(args *CallSmartContractArgs, result
 		return fmt.Errorf("Failed to parse SmartContractTx: %v", args.SctxBytes)
 	}
 
-	parentBlock := t.ledger.State().ParentBlock()
 	vmRet, contractAddr, gasUsed, vmErr := vm.Execute(parentBlock, sctx, ledgerState)
 	ledgerState.Save()
 
@@ -65,3 +84,170 @@ func (t *PandoRPCService) CallSmartContract(args *CallSmartContractArgs, result
 
 	return nil
 }
+
+// ------------------------------- EstimateGas -----------------------------------
+
+type EstimateGasArgs struct {
+	TxBytes string `json:"tx_bytes"` // the signatures of the transaction are not checked
+}
+
+type EstimateGasResult struct {
+	GasLimit   common.JSONUint64 `json:"gas_limit"`   // lowest gas limit with which the transaction succeeds
+	GasUsed    common.JSONUint64 `json:"gas_used"`    // gas consumed when executed with that gas limit
+	MinimumFee *common.JSONBig   `json:"minimum_fee"` // minimum fee in PTXWei at the minimum gas price
+}
+
+// EstimateGas estimates the gas needed by the transaction. A smart contract transaction is executed
+// on a copy of the screened state, and the lowest gas limit with which it succeeds is searched for.
+// For the other transaction types, the gas and the minimum fee are derived from the regular tx gas.
+func (t *PandoRPCService) EstimateGas(args *EstimateGasArgs, result *EstimateGasResult) (err error) {
+	txBytes, err := hex.DecodeString(args.TxBytes)
+	if err != nil {
+		return err
+	}
+	tx, err := types.TxFromBytes(txBytes)
+	if err != nil {
+		return fmt.Errorf("Failed to parse transaction, error: %v", err)
+	}
+
+	ledgerState, err := t.ledger.GetScreenedSnapshot()
+	if err != nil {
+		return err
+	}
+	blockHeight := ledgerState.Height() + 1 // the view points to the parent of the current block
+	params := ledgerState.GetGovernanceParams()
+
+	var gasLimit, gasUsed uint64
+	var minimumFee *big.Int
+	switch tx := tx.(type) {
+	case *types.SmartContractTx:
+		if blockHeight < common.HeightEnableSmartContract {
+			return fmt.Errorf("Smart contract feature not enabled until block height %v.", common.HeightEnableSmartContract)
+		}
+		gasLimit, gasUsed, err = estimateGas(t.ledger.State().ParentBlock(), tx, ledgerState)
+		if err != nil {
+			return err
+		}
+		minimumFee = new(big.Int).Mul(new(big.Int).SetUint64(gasLimit), params.MinimumGasPrice)
+	case *types.SendTx:
+		numAccountsAffected := uint64(len(tx.Inputs) + len(tx.Outputs))
+		if numAccountsAffected < 2 {
+			numAccountsAffected = 2
+		}
+		gasLimit = types.GetRegularTxGas(blockHeight) / 2 * numAccountsAffected
+		gasUsed = gasLimit
+		minimumFee = types.GetSendTxMinimumTransactionFee(params, numAccountsAffected, blockHeight)
+	case *types.CoinbaseTx, *types.SlashTx:
+		return errors.New("Cannot estimate the gas of coinbase and slash transactions, they can only be created by the validators")
+	default:
+		gasLimit = types.GetRegularTxGas(blockHeight)
+		gasUsed = gasLimit
+		minimumFee = new(big.Int).Set(params.MinimumTransactionFeePTXWei)
+	}
+
+	result.GasLimit = common.JSONUint64(gasLimit)
+	result.GasUsed = common.JSONUint64(gasUsed)
+	result.MinimumFee = (*common.JSONBig)(minimumFee)
+	return nil
+}
+
+// estimateGas binary searches the lowest gas limit with which the smart contract transaction executes
+// successfully on top of the given state, which is left untouched. The upper bound is the gas limit of
+// the transaction (the maximum gas limit if not set), capped by what the sender can afford at the gas
+// price of the transaction. The gas used at a given limit may be lower than the limit itself, e.g. when
+// gas is refunded or withheld from sub calls, so the search is not based on the gas used alone.
+func estimateGas(parentBlock *core.Block, sctx *types.SmartContractTx, ledgerState *state.StoreView) (gasLimit uint64, gasUsed uint64, err error) {
+	hi := ledgerState.GetGovernanceParams().MaximumTxGasLimit.Uint64()
+	if sctx.GasLimit != 0 && sctx.GasLimit < hi {
+		hi = sctx.GasLimit
+	}
+	if sctx.GasPrice != nil && sctx.GasPrice.Sign() > 0 {
+		available := big.NewInt(0)
+		if account := ledgerState.GetAccount(sctx.From.Address); account != nil {
+			available.Set(account.Balance.NoNil().PTXWei)
+		}
+		if value := sctx.From.Coins.PTXWei; value != nil {
+			available.Sub(available, value)
+		}
+		if available.Sign() <= 0 {
+			return 0, 0, fmt.Errorf("insufficient PTXWei balance of %v to pay for gas", sctx.From.Address.Hex())
+		}
+		allowance := new(big.Int).Div(available, sctx.GasPrice)
+		if allowance.IsUint64() && allowance.Uint64() < hi {
+			hi = allowance.Uint64()
+		}
+	}
+
+	execute := func(limit uint64) (common.Bytes, uint64, error, error) {
+		view, err := ledgerState.Copy()
+		if err != nil {
+			return nil, 0, nil, err
+		}
+		tx := *sctx
+		tx.GasLimit = limit
+		vmRet, _, used, vmErr := vm.Execute(parentBlock, &tx, view)
+		return vmRet, used, vmErr, nil
+	}
+
+	vmRet, used, vmErr, err := execute(hi)
+	if err != nil {
+		return 0, 0, err
+	}
+	if vmErr == vm.ErrExecutionReverted {
+		if reason := revertReason(vmRet); reason != "" {
+			return 0, 0, fmt.Errorf("execution reverted: %v", reason)
+		}
+		return 0, 0, errors.New("execution reverted")
+	}
+	if vmErr != nil {
+		return 0, 0, fmt.Errorf("gas required exceeds allowance (%v) or always failing transaction: %v", hi, vmErr)
+	}
+	gasUsed = used
+
+	// A limit below the gas used fails. Most transactions succeed with the gas used plus what is
+	// withheld from the sub calls, try that first to narrow the search.
+	lo := used - 1
+	if optimistic := (used + params.CallStipend) * 64 / 63; optimistic < hi {
+		_, used, vmErr, err := execute(optimistic)
+		if err != nil {
+			return 0, 0, err
+		}
+		if vmErr == nil {
+			hi, gasUsed = optimistic, used
+		} else {
+			lo = optimistic
+		}
+	}
+	for lo+1 < hi {
+		mid := lo + (hi-lo)/2
+		_, used, vmErr, err := execute(mid)
+		if err != nil {
+			return 0, 0, err
+		}
+		if vmErr == nil {
+			hi, gasUsed = mid, used
+		} else {
+			lo = mid
+		}
+	}
+	return hi, gasUsed, nil
+}
+
+// revertReason decodes the reason string of a revert with an Error(string) payload.
+func revertReason(ret []byte) string {
+	selector := []byte{0x08, 0xc3, 0x79, 0xa0}
+	if len(ret) < 4+64 || !bytes.Equal(ret[:4], selector) {
+		return ""
+	}
+	data := ret[4:]
+	offset := new(big.Int).SetBytes(data[:32])
+	if !offset.IsUint64() || offset.Uint64()+32 > uint64(len(data)) {
+		return ""
+	}
+	start := offset.Uint64() + 32
+	length := new(big.Int).SetBytes(data[offset.Uint64():start])
+	if !length.IsUint64() || start+length.Uint64() > uint64(len(data)) {
+		return ""
+	}
+	return string(data[start : start+length.Uint64()])
+}
@@ -0,0 +1,86 @@
+package rpc
+
+import (
+	"encoding/hex"
+	"math/big"
+	"strings"
+	"testing"
+
+	"github.com/pandoprojects/pando/common"
+	"github.com/pandoprojects/pando/core"
+	"github.com/pandoprojects/pando/ledger/state"
+	"github.com/pandoprojects/pando/ledger/types"
+	"github.com/pandoprojects/pando/ledger/vm"
+	"github.com/pandoprojects/pando/store/database/backend"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEstimateGas(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	storeView := state.NewStoreView(0, common.Hash{}, backend.NewMemDatabase())
+	caller := types.MakeAccWithInitBalance("caller", types.NewCoins(90000000, 50000000000))
+	storeView.SetAccount(caller.Address, &caller.Account)
+
+	// The contract stores 0x1 at slot 0x0: push 0x1, push 0x0, sstore, stop
+	contract := common.HexToAddress("0xc1")
+	code, _ := hex.DecodeString("600160005500")
+	storeView.SetCode(contract, code)
+
+	// The reverter reverts with Error("no"): the payload is stored at offset 0x0 and returned
+	reverter := common.HexToAddress("0xc2")
+	payload := "08c379a0" +
+		"0000000000000000000000000000000000000000000000000000000000000020" +
+		"0000000000000000000000000000000000000000000000000000000000000002" +
+		"6e6f000000000000000000000000000000000000000000000000000000000000"
+	payload += strings.Repeat("0", 4*64-len(payload))
+	code, _ = hex.DecodeString("7f" + payload[:64] + "600052" + "7f" + payload[64:128] + "602052" +
+		"7f" + payload[128:192] + "604052" + "7f" + payload[192:] + "606052" + "60646000fd")
+	storeView.SetCode(reverter, code)
+	storeView.IncrementHeight()
+	storeView.Save()
+
+	parentBlock := &core.Block{BlockHeader: &core.BlockHeader{
+		ChainID:   "privatenet",
+		Height:    storeView.Height(),
+		Timestamp: big.NewInt(0),
+	}}
+	tx := &types.SmartContractTx{
+		From:     types.TxInput{Address: caller.Address},
+		To:       types.TxOutput{Address: contract},
+		GasPrice: big.NewInt(0),
+	}
+
+	gasLimit, gasUsed, err := estimateGas(parentBlock, tx, storeView)
+	require.Nil(err)
+	assert.True(gasUsed > 0 && gasUsed <= gasLimit)
+
+	// The estimate is the lowest gas limit with which the transaction succeeds
+	execute := func(limit uint64) error {
+		view, err := storeView.Copy()
+		require.Nil(err)
+		tx := *tx
+		tx.GasLimit = limit
+		_, _, _, vmErr := vm.Execute(parentBlock, &tx, view)
+		return vmErr
+	}
+	assert.Nil(execute(gasLimit))
+	assert.NotNil(execute(gasLimit - 1))
+
+	// The state is left untouched
+	assert.Equal(common.Hash{}, storeView.GetState(contract, common.Hash{}))
+
+	// A gas limit too low for the transaction fails
+	tx.GasLimit = gasLimit - 1
+	_, _, err = estimateGas(parentBlock, tx, storeView)
+	assert.NotNil(err)
+
+	// The revert reason is reported
+	tx.GasLimit = 0
+	tx.To.Address = reverter
+	_, _, err = estimateGas(parentBlock, tx, storeView)
+	require.NotNil(err)
+	assert.Equal("execution reverted: no", err.Error())
+}
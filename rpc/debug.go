@@ -0,0 +1,257 @@
+package rpc
+
+import (
+	"fmt"
+
+	"github.com/pandoprojects/pando/common"
+	"github.com/pandoprojects/pando/common/math"
+	"github.com/pandoprojects/pando/core"
+	"github.com/pandoprojects/pando/crypto"
+	"github.com/pandoprojects/pando/ledger/types"
+	"github.com/pandoprojects/pando/ledger/vm"
+)
+
+//
+// Debug tracing methods. debug_traceTransaction and debug_traceBlock re-execute committed
+// transactions on the state of the parent block with an EVM tracer attached. They are served
+// only if enabled with the rpc.debugEnabled config.
+//
+
+// TracerCall selects the call tracer, which returns the call tree of the transaction instead of
+// the struct logs of its individual steps.
+const TracerCall = "callTracer"
+
+// TraceConfig holds the tracing options, e.g. {"tracer": "callTracer"} or {"disableMemory": true}.
+type TraceConfig struct {
+	Tracer         string `json:"tracer"`
+	DisableStorage bool   `json:"disableStorage"`
+	DisableMemory  bool   `json:"disableMemory"`
+	DisableStack   bool   `json:"disableStack"`
+	Limit          int    `json:"limit"` // maximum number of struct logs, zero means unlimited
+}
+
+// StructLogRes is the RPC representation of a vm.StructLog.
+type StructLogRes struct {
+	Pc      uint64             `json:"pc"`
+	Op      string             `json:"op"`
+	Gas     uint64             `json:"gas"`
+	GasCost uint64             `json:"gasCost"`
+	Depth   int                `json:"depth"`
+	Error   string             `json:"error,omitempty"`
+	Stack   *[]string          `json:"stack,omitempty"`
+	Memory  *[]string          `json:"memory,omitempty"`
+	Storage *map[string]string `json:"storage,omitempty"`
+}
+
+// ExecutionResult is the output of the struct logger for a transaction.
+type ExecutionResult struct {
+	Gas         uint64         `json:"gas"`
+	Failed      bool           `json:"failed"`
+	ReturnValue string         `json:"returnValue"`
+	StructLogs  []StructLogRes `json:"structLogs"`
+}
+
+// TxTraceResult is the trace of a transaction of a block.
+type TxTraceResult struct {
+	TxHash  common.Hash `json:"txHash"`
+	TxIndex uint64      `json:"txIndex"`
+	Result  interface{} `json:"result"` // *ExecutionResult or *vm.CallFrame depending on the tracer
+}
+
+// DebugRPCService implements the debug_* methods.
+type DebugRPCService struct {
+	svc *PandoRPCService
+}
+
+// TraceTransaction traces the smart contract transaction with the given hash.
+// Params: [txHash, traceConfig (optional)]
+func (d *DebugRPCService) TraceTransaction(args EthArgs, result *interface{}) (err error) {
+	hash, err := args.hash(0)
+	if err != nil {
+		return err
+	}
+	config, err := traceConfigArg(args, 1)
+	if err != nil {
+		return err
+	}
+
+	raw, block, found := d.svc.chain.FindTxByHash(hash)
+	if !found {
+		return fmt.Errorf("transaction %v is not found", hash.Hex())
+	}
+	if tx, err := types.TxFromBytes(raw); err != nil {
+		return err
+	} else if _, ok := tx.(*types.SmartContractTx); !ok {
+		return fmt.Errorf("transaction %v is not a smart contract transaction", hash.Hex())
+	}
+	txIndex := -1
+	for idx, txBytes := range block.Txs {
+		if string(txBytes) == string(raw) {
+			txIndex = idx
+			break
+		}
+	}
+	if txIndex < 0 {
+		return fmt.Errorf("transaction %v is not found in block %v", hash.Hex(), block.Hash().Hex())
+	}
+	traces, err := d.svc.traceBlock(block, txIndex, config)
+	if err != nil {
+		return err
+	}
+	if len(traces) == 0 || traces[len(traces)-1].TxIndex != uint64(txIndex) {
+		return fmt.Errorf("transaction %v was not traced", hash.Hex())
+	}
+	*result = traces[len(traces)-1].Result
+	return nil
+}
+
+// TraceBlock traces the smart contract transactions of a block.
+// Params: [blockHash or blockNumber, traceConfig (optional)]
+func (d *DebugRPCService) TraceBlock(args EthArgs, result *[]*TxTraceResult) (err error) {
+	var ref string
+	if err = args.decode(0, &ref); err != nil {
+		return err
+	}
+	config, err := traceConfigArg(args, 1)
+	if err != nil {
+		return err
+	}
+
+	var block *core.ExtendedBlock
+	if len(ref) == 2+2*common.HashLength {
+		block, err = d.svc.chain.FindBlock(common.HexToHash(ref))
+		if err != nil {
+			return fmt.Errorf("block %v is not found", ref)
+		}
+	} else {
+		height, err := (&EthRPCService{svc: d.svc}).resolveBlockTag(ref)
+		if err != nil {
+			return err
+		}
+		for _, b := range d.svc.chain.FindBlocksByHeight(height) {
+			if b.Status.IsFinalized() {
+				block = b
+				break
+			}
+		}
+		if block == nil {
+			return fmt.Errorf("finalized block at height %v is not found", height)
+		}
+	}
+
+	*result, err = d.svc.traceBlock(block, -1, config)
+	return err
+}
+
+func traceConfigArg(args EthArgs, idx int) (*TraceConfig, error) {
+	config := &TraceConfig{}
+	if args.has(idx) {
+		if err := args.decode(idx, config); err != nil {
+			return nil, err
+		}
+	}
+	if config.Tracer != "" && config.Tracer != TracerCall {
+		return nil, fmt.Errorf("unsupported tracer %v", config.Tracer)
+	}
+	return config, nil
+}
+
+// traceBlock re-executes the transactions of the block up to lastTxIndex (all of them if negative)
+// and returns the traces of the smart contract transactions.
+func (t *PandoRPCService) traceBlock(block *core.ExtendedBlock, lastTxIndex int, config *TraceConfig) ([]*TxTraceResult, error) {
+	if !block.Status.IsValid() {
+		return nil, fmt.Errorf("block %v has not been processed, status: %v", block.Hash().Hex(), block.Status)
+	}
+
+	tracers := make(map[int]vm.Tracer)
+	err := t.ledger.TraceBlockTxs(block.Block, lastTxIndex, func(txIndex int, tx *types.SmartContractTx) vm.Tracer {
+		if lastTxIndex >= 0 && txIndex != lastTxIndex {
+			return nil // only the requested transaction needs to be traced
+		}
+		var tracer vm.Tracer
+		if config.Tracer == TracerCall {
+			tracer = vm.NewCallTracer()
+		} else {
+			tracer = vm.NewStructLogger(&vm.LogConfig{
+				DisableMemory:  config.DisableMemory,
+				DisableStack:   config.DisableStack,
+				DisableStorage: config.DisableStorage,
+				Limit:          config.Limit,
+			})
+		}
+		tracers[txIndex] = tracer
+		return tracer
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	blockHash := block.Hash()
+	traces := []*TxTraceResult{}
+	for idx, rawTx := range block.Txs {
+		tracer, ok := tracers[idx]
+		if !ok {
+			continue
+		}
+		txHash := crypto.Keccak256Hash(rawTx)
+		trace := &TxTraceResult{
+			TxHash:  txHash,
+			TxIndex: uint64(idx),
+		}
+		switch tracer := tracer.(type) {
+		case *vm.CallTracer:
+			trace.Result = tracer.Result()
+		case *vm.StructLogger:
+			gasUsed := uint64(0)
+			if receipt, ok := t.chain.FindTxReceiptByHash(blockHash, txHash); ok {
+				gasUsed = receipt.GasUsed
+			}
+			trace.Result = &ExecutionResult{
+				Gas:         gasUsed,
+				Failed:      tracer.Error() != nil,
+				ReturnValue: fmt.Sprintf("%x", tracer.Output()),
+				StructLogs:  formatStructLogs(tracer.StructLogs()),
+			}
+		}
+		traces = append(traces, trace)
+	}
+	return traces, nil
+}
+
+// formatStructLogs formats the struct logs the same way as the Ethereum clients: the stack items
+// and storage slots as 32 byte hex words, and the memory in rows of 32 bytes.
+func formatStructLogs(logs []vm.StructLog) []StructLogRes {
+	formatted := make([]StructLogRes, len(logs))
+	for i, log := range logs {
+		formatted[i] = StructLogRes{
+			Pc:      log.Pc,
+			Op:      log.Op.String(),
+			Gas:     log.Gas,
+			GasCost: log.GasCost,
+			Depth:   log.Depth,
+			Error:   log.ErrorString(),
+		}
+		if log.Stack != nil {
+			stack := make([]string, len(log.Stack))
+			for j, value := range log.Stack {
+				stack[j] = fmt.Sprintf("%x", math.PaddedBigBytes(value, 32))
+			}
+			formatted[i].Stack = &stack
+		}
+		if log.Memory != nil {
+			memory := make([]string, 0, (len(log.Memory)+31)/32)
+			for j := 0; j+32 <= len(log.Memory); j += 32 {
+				memory = append(memory, fmt.Sprintf("%x", log.Memory[j:j+32]))
+			}
+			formatted[i].Memory = &memory
+		}
+		if log.Storage != nil {
+			storage := make(map[string]string)
+			for k, v := range log.Storage {
+				storage[fmt.Sprintf("%x", k)] = fmt.Sprintf("%x", v)
+			}
+			formatted[i].Storage = &storage
+		}
+	}
+	return formatted
+}
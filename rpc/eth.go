@@ -0,0 +1,929 @@
+package rpc
+
+import (
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"strings"
+
+	"github.com/pandoprojects/pando/blockchain"
+	"github.com/pandoprojects/pando/common"
+	"github.com/pandoprojects/pando/common/hexutil"
+	"github.com/pandoprojects/pando/core"
+	"github.com/pandoprojects/pando/crypto"
+	"github.com/pandoprojects/pando/ledger/state"
+	"github.com/pandoprojects/pando/ledger/types"
+	"github.com/pandoprojects/pando/ledger/vm"
+	"github.com/pandoprojects/pando/rlp"
+	"github.com/pandoprojects/pando/version"
+)
+
+//
+// Ethereum compatible JSON-RPC adapter. The eth_*, net_* and web3_* methods are
+// served by the EthRPCService, NetRPCService and Web3RPCService respectively, and
+// ethMethodMapper translates the Ethereum style method names into the
+// "Service.Method" form expected by net/rpc.
+//
+
+const (
+	ethBlockTagLatest    = "latest"
+	ethBlockTagEarliest  = "earliest"
+	ethBlockTagPending   = "pending"
+	ethBlockTagSafe      = "safe"
+	ethBlockTagFinalized = "finalized"
+)
+
+var (
+	ethEmptyUncleHash = common.HexToHash("1dcc4de8dec75d7aab85b567b6ccd41ad312451b948a7413f0a142fd40d49347")
+	ethEmptyNonce     = hexutil.Bytes(make([]byte, 8))
+)
+
+// ethMethodMapper maps "eth_getBalance" to "eth.GetBalance". Method names already
+// in the "Service.Method" form are left untouched.
+func ethMethodMapper(method string) string {
+	if strings.Contains(method, ".") {
+		return method
+	}
+	idx := strings.Index(method, "_")
+	if idx <= 0 || idx == len(method)-1 {
+		return method
+	}
+	return method[:idx] + "." + strings.ToUpper(method[idx+1:idx+2]) + method[idx+2:]
+}
+
+// EthArgs holds the positional parameters of an Ethereum JSON-RPC call.
+type EthArgs []json.RawMessage
+
+func (args EthArgs) has(idx int) bool {
+	return idx < len(args) && len(args[idx]) > 0 && string(args[idx]) != "null"
+}
+
+func (args EthArgs) decode(idx int, v interface{}) error {
+	if !args.has(idx) {
+		return fmt.Errorf("missing value for required argument %v", idx)
+	}
+	if err := json.Unmarshal(args[idx], v); err != nil {
+		return fmt.Errorf("invalid argument %v: %v", idx, err)
+	}
+	return nil
+}
+
+func (args EthArgs) address(idx int) (common.Address, error) {
+	var addr common.Address
+	err := args.decode(idx, &addr)
+	return addr, err
+}
+
+func (args EthArgs) hash(idx int) (common.Hash, error) {
+	var hash common.Hash
+	err := args.decode(idx, &hash)
+	return hash, err
+}
+
+// blockTag returns the block number or tag at the given position, defaulting to "latest".
+func (args EthArgs) blockTag(idx int) (string, error) {
+	if !args.has(idx) {
+		return ethBlockTagLatest, nil
+	}
+	var tag string
+	if err := args.decode(idx, &tag); err != nil {
+		return "", err
+	}
+	return tag, nil
+}
+
+func (args EthArgs) boolean(idx int) (bool, error) {
+	if !args.has(idx) {
+		return false, nil
+	}
+	var b bool
+	err := args.decode(idx, &b)
+	return b, err
+}
+
+// EthCallArgs represents the transaction object of eth_call and eth_estimateGas.
+type EthCallArgs struct {
+	From     *common.Address `json:"from"`
+	To       *common.Address `json:"to"`
+	Gas      *hexutil.Uint64 `json:"gas"`
+	GasPrice *hexutil.Big    `json:"gasPrice"`
+	Value    *hexutil.Big    `json:"value"`
+	Data     *hexutil.Bytes  `json:"data"`
+	Input    *hexutil.Bytes  `json:"input"`
+}
+
+// EthFilterArgs represents the filter object of eth_getLogs.
+type EthFilterArgs struct {
+	BlockHash *common.Hash      `json:"blockHash"`
+	FromBlock string            `json:"fromBlock"`
+	ToBlock   string            `json:"toBlock"`
+	Address   ethAddressList    `json:"address"`
+	Topics    []ethTopicOptions `json:"topics"`
+}
+
+// ethAddressList accepts either a single address or an array of addresses.
+type ethAddressList []common.Address
+
+func (l *ethAddressList) UnmarshalJSON(data []byte) error {
+	if len(data) > 0 && data[0] == '[' {
+		var addrs []common.Address
+		if err := json.Unmarshal(data, &addrs); err != nil {
+			return err
+		}
+		*l = addrs
+		return nil
+	}
+	if string(data) == "null" {
+		*l = nil
+		return nil
+	}
+	var addr common.Address
+	if err := json.Unmarshal(data, &addr); err != nil {
+		return err
+	}
+	*l = ethAddressList{addr}
+	return nil
+}
+
+// ethTopicOptions accepts null (wildcard), a single topic or an array of alternative topics.
+type ethTopicOptions []common.Hash
+
+func (o *ethTopicOptions) UnmarshalJSON(data []byte) error {
+	if string(data) == "null" {
+		*o = nil
+		return nil
+	}
+	if len(data) > 0 && data[0] == '[' {
+		var topics []*common.Hash
+		if err := json.Unmarshal(data, &topics); err != nil {
+			return err
+		}
+		*o = nil
+		for _, topic := range topics {
+			if topic != nil {
+				*o = append(*o, *topic)
+			}
+		}
+		return nil
+	}
+	var topic common.Hash
+	if err := json.Unmarshal(data, &topic); err != nil {
+		return err
+	}
+	*o = ethTopicOptions{topic}
+	return nil
+}
+
+// EthBlock is the Ethereum representation of a block.
+type EthBlock struct {
+	Number           hexutil.Uint64 `json:"number"`
+	Hash             common.Hash    `json:"hash"`
+	ParentHash       common.Hash    `json:"parentHash"`
+	Nonce            hexutil.Bytes  `json:"nonce"`
+	Sha3Uncles       common.Hash    `json:"sha3Uncles"`
+	LogsBloom        core.Bloom     `json:"logsBloom"`
+	TransactionsRoot common.Hash    `json:"transactionsRoot"`
+	StateRoot        common.Hash    `json:"stateRoot"`
+	ReceiptsRoot     common.Hash    `json:"receiptsRoot"`
+	Miner            common.Address `json:"miner"`
+	Difficulty       hexutil.Uint64 `json:"difficulty"`
+	TotalDifficulty  hexutil.Uint64 `json:"totalDifficulty"`
+	ExtraData        hexutil.Bytes  `json:"extraData"`
+	Size             hexutil.Uint64 `json:"size"`
+	GasLimit         hexutil.Uint64 `json:"gasLimit"`
+	GasUsed          hexutil.Uint64 `json:"gasUsed"`
+	Timestamp        hexutil.Uint64 `json:"timestamp"`
+	Transactions     []interface{}  `json:"transactions"`
+	Uncles           []common.Hash  `json:"uncles"`
+}
+
+// EthTransaction is the Ethereum representation of a smart contract transaction.
+type EthTransaction struct {
+	BlockHash        *common.Hash    `json:"blockHash"`
+	BlockNumber      *hexutil.Uint64 `json:"blockNumber"`
+	TransactionIndex *hexutil.Uint64 `json:"transactionIndex"`
+	Hash             common.Hash     `json:"hash"`
+	From             common.Address  `json:"from"`
+	To               *common.Address `json:"to"`
+	Nonce            hexutil.Uint64  `json:"nonce"`
+	Gas              hexutil.Uint64  `json:"gas"`
+	GasPrice         *hexutil.Big    `json:"gasPrice"`
+	Value            *hexutil.Big    `json:"value"`
+	Input            hexutil.Bytes   `json:"input"`
+	V                *hexutil.Big    `json:"v"`
+	R                *hexutil.Big    `json:"r"`
+	S                *hexutil.Big    `json:"s"`
+}
+
+// EthReceipt is the Ethereum representation of a transaction receipt.
+type EthReceipt struct {
+	TransactionHash   common.Hash     `json:"transactionHash"`
+	TransactionIndex  hexutil.Uint64  `json:"transactionIndex"`
+	BlockHash         common.Hash     `json:"blockHash"`
+	BlockNumber       hexutil.Uint64  `json:"blockNumber"`
+	From              common.Address  `json:"from"`
+	To                *common.Address `json:"to"`
+	CumulativeGasUsed hexutil.Uint64  `json:"cumulativeGasUsed"`
+	GasUsed           hexutil.Uint64  `json:"gasUsed"`
+	EffectiveGasPrice *hexutil.Big    `json:"effectiveGasPrice"`
+	ContractAddress   *common.Address `json:"contractAddress"`
+	Logs              []*EthLog       `json:"logs"`
+	LogsBloom         core.Bloom      `json:"logsBloom"`
+	Type              hexutil.Uint64  `json:"type"`
+	Status            hexutil.Uint64  `json:"status"`
+}
+
+// EthLog is the Ethereum representation of a contract log event.
+type EthLog struct {
+	Address          common.Address `json:"address"`
+	Topics           []common.Hash  `json:"topics"`
+	Data             hexutil.Bytes  `json:"data"`
+	BlockNumber      hexutil.Uint64 `json:"blockNumber"`
+	TransactionHash  common.Hash    `json:"transactionHash"`
+	TransactionIndex hexutil.Uint64 `json:"transactionIndex"`
+	BlockHash        common.Hash    `json:"blockHash"`
+	LogIndex         hexutil.Uint64 `json:"logIndex"`
+	Removed          bool           `json:"removed"`
+}
+
+// EthSyncing is the result of eth_syncing while the node is catching up.
+type EthSyncing struct {
+	StartingBlock hexutil.Uint64 `json:"startingBlock"`
+	CurrentBlock  hexutil.Uint64 `json:"currentBlock"`
+	HighestBlock  hexutil.Uint64 `json:"highestBlock"`
+}
+
+// ------------------------------- web3 -----------------------------------
+
+// Web3RPCService implements the web3_* methods.
+type Web3RPCService struct {
+	svc *PandoRPCService
+}
+
+func (w *Web3RPCService) ClientVersion(args EthArgs, result *string) (err error) {
+	*result = fmt.Sprintf("Pando/v%v-%v", version.Version, version.GitHash)
+	return nil
+}
+
+func (w *Web3RPCService) Sha3(args EthArgs, result *hexutil.Bytes) (err error) {
+	var data hexutil.Bytes
+	if err = args.decode(0, &data); err != nil {
+		return err
+	}
+	*result = crypto.Keccak256(data)
+	return nil
+}
+
+// ------------------------------- net -----------------------------------
+
+// NetRPCService implements the net_* methods.
+type NetRPCService struct {
+	svc *PandoRPCService
+}
+
+func (n *NetRPCService) Version(args EthArgs, result *string) (err error) {
+	*result = n.svc.ethChainID().String()
+	return nil
+}
+
+func (n *NetRPCService) Listening(args EthArgs, result *bool) (err error) {
+	*result = true
+	return nil
+}
+
+func (n *NetRPCService) PeerCount(args EthArgs, result *hexutil.Uint64) (err error) {
+	*result = hexutil.Uint64(len(n.svc.dispatcher.Peers(false)))
+	return nil
+}
+
+// ------------------------------- eth -----------------------------------
+
+// EthRPCService implements the eth_* methods.
+type EthRPCService struct {
+	svc *PandoRPCService
+}
+
+func (e *EthRPCService) ChainId(args EthArgs, result *hexutil.Big) (err error) {
+	*result = hexutil.Big(*e.svc.ethChainID())
+	return nil
+}
+
+func (e *EthRPCService) BlockNumber(args EthArgs, result *hexutil.Uint64) (err error) {
+	*result = hexutil.Uint64(e.svc.consensus.GetLastFinalizedBlock().Height)
+	return nil
+}
+
+func (e *EthRPCService) Syncing(args EthArgs, result *interface{}) (err error) {
+	if e.svc.consensus.HasSynced() {
+		*result = false
+		return nil
+	}
+	current := e.svc.consensus.GetLastFinalizedBlock().Height
+	*result = EthSyncing{
+		StartingBlock: hexutil.Uint64(e.svc.chain.Root().Height),
+		CurrentBlock:  hexutil.Uint64(current),
+		HighestBlock:  hexutil.Uint64(current),
+	}
+	return nil
+}
+
+func (e *EthRPCService) Accounts(args EthArgs, result *[]common.Address) (err error) {
+	*result = []common.Address{}
+	return nil
+}
+
+func (e *EthRPCService) GasPrice(args EthArgs, result *hexutil.Big) (err error) {
+	ledgerState, err := e.svc.ledger.GetFinalizedSnapshot()
+	if err != nil {
+		return err
+	}
+	*result = hexutil.Big(*ledgerState.GetGovernanceParams().MinimumGasPrice)
+	return nil
+}
+
+func (e *EthRPCService) GetBalance(args EthArgs, result *hexutil.Big) (err error) {
+	address, err := args.address(0)
+	if err != nil {
+		return err
+	}
+	view, err := e.stateAt(args, 1)
+	if err != nil {
+		return err
+	}
+	*result = hexutil.Big(*view.GetBalance(address))
+	return nil
+}
+
+func (e *EthRPCService) GetTransactionCount(args EthArgs, result *hexutil.Uint64) (err error) {
+	address, err := args.address(0)
+	if err != nil {
+		return err
+	}
+	view, err := e.stateAt(args, 1)
+	if err != nil {
+		return err
+	}
+	// off-by-one, ETH tx nonce starts from 0, while Pando tx sequence starts from 1
+	*result = hexutil.Uint64(view.GetNonce(address))
+	return nil
+}
+
+func (e *EthRPCService) GetCode(args EthArgs, result *hexutil.Bytes) (err error) {
+	address, err := args.address(0)
+	if err != nil {
+		return err
+	}
+	view, err := e.stateAt(args, 1)
+	if err != nil {
+		return err
+	}
+	*result = view.GetCode(address)
+	return nil
+}
+
+func (e *EthRPCService) GetStorageAt(args EthArgs, result *common.Hash) (err error) {
+	address, err := args.address(0)
+	if err != nil {
+		return err
+	}
+	var position hexutil.Big
+	if err = args.decode(1, &position); err != nil {
+		return err
+	}
+	view, err := e.stateAt(args, 2)
+	if err != nil {
+		return err
+	}
+	*result = view.GetState(address, common.BigToHash(position.ToInt()))
+	return nil
+}
+
+func (e *EthRPCService) Call(args EthArgs, result *hexutil.Bytes) (err error) {
+	evmRet, _, vmErr, err := e.doCall(args)
+	if err != nil {
+		return err
+	}
+	if vmErr != nil {
+		return fmt.Errorf("execution reverted: %v", vmErr)
+	}
+	*result = hexutil.Bytes(evmRet)
+	return nil
+}
+
+func (e *EthRPCService) EstimateGas(args EthArgs, result *hexutil.Uint64) (err error) {
+	callArgs, ledgerState, parentBlock, err := e.callContext(args)
+	if err != nil {
+		return err
+	}
+	sctx := callArgs.toSmartContractTx(ledgerState.GetGovernanceParams())
+	if callArgs.Gas == nil {
+		sctx.GasLimit = 0 // search up to the maximum gas limit
+	}
+	if callArgs.GasPrice == nil {
+		sctx.GasPrice = big.NewInt(0) // do not cap the gas limit by the balance of the sender
+	}
+	gasLimit, _, err := estimateGas(parentBlock, sctx, ledgerState)
+	if err != nil {
+		return err
+	}
+	*result = hexutil.Uint64(gasLimit)
+	return nil
+}
+
+func (e *EthRPCService) SendRawTransaction(args EthArgs, result *common.Hash) (err error) {
+	var raw hexutil.Bytes
+	if err = args.decode(0, &raw); err != nil {
+		return err
+	}
+	broadcastResult := &BroadcastRawTransactionAsyncResult{}
+	err = e.svc.BroadcastRawEthTransactionAsync(&BroadcastRawTransactionAsyncArgs{
+		TxBytes: raw.String(),
+	}, broadcastResult)
+	if err != nil {
+		return err
+	}
+	*result = common.HexToHash(broadcastResult.TxHash)
+	return nil
+}
+
+func (e *EthRPCService) GetBlockByNumber(args EthArgs, result **EthBlock) (err error) {
+	tag, err := args.blockTag(0)
+	if err != nil {
+		return err
+	}
+	fullTx, err := args.boolean(1)
+	if err != nil {
+		return err
+	}
+	height, err := e.resolveBlockTag(tag)
+	if err != nil {
+		return err
+	}
+	block := e.svc.findFinalizedBlockByHeight(height)
+	if block == nil {
+		*result = nil
+		return nil
+	}
+	*result = e.toEthBlock(block, fullTx)
+	return nil
+}
+
+func (e *EthRPCService) GetBlockByHash(args EthArgs, result **EthBlock) (err error) {
+	hash, err := args.hash(0)
+	if err != nil {
+		return err
+	}
+	fullTx, err := args.boolean(1)
+	if err != nil {
+		return err
+	}
+	block, err := e.svc.chain.FindBlock(hash)
+	if err != nil {
+		*result = nil
+		return nil
+	}
+	*result = e.toEthBlock(block, fullTx)
+	return nil
+}
+
+func (e *EthRPCService) GetBlockTransactionCountByNumber(args EthArgs, result **hexutil.Uint64) (err error) {
+	tag, err := args.blockTag(0)
+	if err != nil {
+		return err
+	}
+	height, err := e.resolveBlockTag(tag)
+	if err != nil {
+		return err
+	}
+	block := e.svc.findFinalizedBlockByHeight(height)
+	if block == nil {
+		*result = nil
+		return nil
+	}
+	count := hexutil.Uint64(len(block.Txs))
+	*result = &count
+	return nil
+}
+
+func (e *EthRPCService) GetTransactionByHash(args EthArgs, result **EthTransaction) (err error) {
+	hash, err := args.hash(0)
+	if err != nil {
+		return err
+	}
+	raw, block, found := e.svc.chain.FindTxByHash(hash)
+	if !found {
+		*result = nil
+		return nil
+	}
+	for idx, txBytes := range block.Txs {
+		if string(txBytes) == string(raw) {
+			*result = toEthTransaction(block, uint64(idx), txBytes)
+			return nil
+		}
+	}
+	*result = nil
+	return nil
+}
+
+func (e *EthRPCService) GetTransactionReceipt(args EthArgs, result **EthReceipt) (err error) {
+	hash, err := args.hash(0)
+	if err != nil {
+		return err
+	}
+	raw, block, found := e.svc.chain.FindTxByHash(hash)
+	if !found {
+		*result = nil
+		return nil
+	}
+	nativeHash := crypto.Keccak256Hash(raw)
+	for _, receipt := range e.svc.ethBlockReceipts(block) {
+		if receipt.TransactionHash == hash || receipt.nativeHash == nativeHash {
+			*result = receipt.EthReceipt
+			return nil
+		}
+	}
+	*result = nil
+	return nil
+}
+
+func (e *EthRPCService) GetLogs(args EthArgs, result *[]*EthLog) (err error) {
+	filter := EthFilterArgs{}
+	if args.has(0) {
+		if err = args.decode(0, &filter); err != nil {
+			return err
+		}
+	}
+
+	logFilter := newEthLogFilter(filter.Address, filter.Topics)
+	var entries []*blockchain.LogEntry
+	if filter.BlockHash != nil {
+		if _, err := e.svc.chain.FindBlock(*filter.BlockHash); err != nil {
+			return fmt.Errorf("block %v not found", filter.BlockHash.Hex())
+		}
+		entries = e.svc.chain.FindBlockLogs(*filter.BlockHash, logFilter)
+	} else {
+		fromTag, toTag := filter.FromBlock, filter.ToBlock
+		if fromTag == "" {
+			fromTag = ethBlockTagLatest
+		}
+		if toTag == "" {
+			toTag = ethBlockTagLatest
+		}
+		from, err := e.resolveBlockTag(fromTag)
+		if err != nil {
+			return err
+		}
+		to, err := e.resolveBlockTag(toTag)
+		if err != nil {
+			return err
+		}
+		if entries, err = e.svc.chain.FindLogs(from, to, logFilter); err != nil {
+			return err
+		}
+	}
+
+	*result = e.svc.toEthLogs(entries)
+	return nil
+}
+
+// ------------------------------ Utils ------------------------------
+
+func (t *PandoRPCService) ethChainID() *big.Int {
+	height := t.consensus.GetLastFinalizedBlock().Height
+	return types.MapChainID(t.consensus.Chain().ChainID, height)
+}
+
+// findFinalizedBlockByHeight returns the finalized block at the given height, or nil if not found.
+func (t *PandoRPCService) findFinalizedBlockByHeight(height uint64) *core.ExtendedBlock {
+	for _, b := range t.chain.FindBlocksByHeight(height) {
+		if b.Status.IsFinalized() {
+			return b
+		}
+	}
+	return nil
+}
+
+// resolveBlockTag converts a block tag or hex encoded block number into a block height.
+func (e *EthRPCService) resolveBlockTag(tag string) (uint64, error) {
+	switch tag {
+	case ethBlockTagLatest, ethBlockTagPending, ethBlockTagSafe, ethBlockTagFinalized:
+		return e.svc.consensus.GetLastFinalizedBlock().Height, nil
+	case ethBlockTagEarliest:
+		return e.svc.chain.Root().Height, nil
+	}
+	height, err := hexutil.DecodeUint64(tag)
+	if err != nil {
+		return 0, fmt.Errorf("invalid block number %v: %v", tag, err)
+	}
+	return height, nil
+}
+
+// stateAt returns the state view referred to by the block tag at the given argument position.
+func (e *EthRPCService) stateAt(args EthArgs, idx int) (*state.StoreView, error) {
+	tag, err := args.blockTag(idx)
+	if err != nil {
+		return nil, err
+	}
+	switch tag {
+	case ethBlockTagLatest, ethBlockTagSafe, ethBlockTagFinalized:
+		return e.svc.ledger.GetFinalizedSnapshot()
+	case ethBlockTagPending:
+		return e.svc.ledger.GetScreenedSnapshot()
+	}
+	height, err := e.resolveBlockTag(tag)
+	if err != nil {
+		return nil, err
+	}
+	view, _, err := e.svc.historicalStoreView(height, common.Hash{})
+	return view, err
+}
+
+// doCall dry-runs the call object of eth_call/eth_estimateGas on top of the last finalized state, or
+// on top of the state of the block given by a block number.
+func (e *EthRPCService) doCall(args EthArgs) (evmRet common.Bytes, gasUsed uint64, vmErr error, err error) {
+	callArgs, ledgerState, parentBlock, err := e.callContext(args)
+	if err != nil {
+		return nil, 0, nil, err
+	}
+	sctx := callArgs.toSmartContractTx(ledgerState.GetGovernanceParams())
+	evmRet, _, gasUsed, vmErr = vm.Execute(parentBlock, sctx, ledgerState)
+	return evmRet, gasUsed, vmErr, nil
+}
+
+// callContext decodes the call object at param 0, and opens the state referred to by the block tag
+// at param 1 together with the block the call is executed on top of.
+func (e *EthRPCService) callContext(args EthArgs) (callArgs *EthCallArgs, ledgerState *state.StoreView, parentBlock *core.Block, err error) {
+	callArgs = &EthCallArgs{}
+	if err = args.decode(0, callArgs); err != nil {
+		return nil, nil, nil, err
+	}
+
+	tag := ethBlockTagLatest
+	if args.has(1) {
+		if tag, err = args.blockTag(1); err != nil {
+			return nil, nil, nil, err
+		}
+	}
+	switch tag {
+	case ethBlockTagLatest, ethBlockTagSafe, ethBlockTagFinalized:
+		// Same state as the other methods, e.g. eth_getBalance, at the same block tag.
+		if ledgerState, err = e.svc.ledger.GetFinalizedSnapshot(); err != nil {
+			return nil, nil, nil, err
+		}
+		block := e.svc.findFinalizedBlockByHeight(ledgerState.Height())
+		if block == nil {
+			return nil, nil, nil, fmt.Errorf("finalized block at height %v not found", ledgerState.Height())
+		}
+		parentBlock = block.Block
+	case ethBlockTagPending:
+		if ledgerState, err = e.svc.ledger.GetScreenedSnapshot(); err != nil {
+			return nil, nil, nil, err
+		}
+		parentBlock = e.svc.ledger.State().ParentBlock()
+	default:
+		height, err := e.resolveBlockTag(tag)
+		if err != nil {
+			return nil, nil, nil, err
+		}
+		view, block, err := e.svc.historicalStoreView(height, common.Hash{})
+		if err != nil {
+			return nil, nil, nil, err
+		}
+		ledgerState, parentBlock = view, block.Block
+	}
+	blockHeight := ledgerState.Height() + 1 // the view points to the parent of the current block
+	if blockHeight < common.HeightEnableSmartContract {
+		return nil, nil, nil, fmt.Errorf("Smart contract feature not enabled until block height %v.", common.HeightEnableSmartContract)
+	}
+	return callArgs, ledgerState, parentBlock, nil
+}
+
+func (c *EthCallArgs) toSmartContractTx(params *core.GovernanceParams) *types.SmartContractTx {
+	sctx := &types.SmartContractTx{
+		GasLimit: params.MaximumTxGasLimit.Uint64(),
+		GasPrice: new(big.Int).Set(params.MinimumGasPrice),
+	}
+	if c.From != nil {
+		sctx.From.Address = *c.From
+	}
+	sctx.From.Coins = types.NewCoins(0, 0)
+	if c.Value != nil {
+		sctx.From.Coins.PTXWei = c.Value.ToInt()
+	}
+	if c.To != nil {
+		sctx.To.Address = *c.To
+	}
+	if c.Gas != nil {
+		sctx.GasLimit = uint64(*c.Gas)
+	}
+	if c.GasPrice != nil {
+		sctx.GasPrice = c.GasPrice.ToInt()
+	}
+	if c.Input != nil {
+		sctx.Data = common.Bytes(*c.Input)
+	} else if c.Data != nil {
+		sctx.Data = common.Bytes(*c.Data)
+	}
+	return sctx
+}
+
+// ethTxHash returns the ETH tx hash for transactions signed by ETH wallets, and the native hash otherwise.
+func ethTxHash(block *core.ExtendedBlock, txBytes common.Bytes) common.Hash {
+	if hash, err := blockchain.CalcEthTxHash(block, txBytes); err == nil {
+		return hash
+	}
+	return crypto.Keccak256Hash(txBytes)
+}
+
+func toEthTransaction(block *core.ExtendedBlock, idx uint64, txBytes common.Bytes) *EthTransaction {
+	tx, err := types.TxFromBytes(txBytes)
+	if err != nil {
+		return nil
+	}
+	sctx, ok := tx.(*types.SmartContractTx)
+	if !ok {
+		return nil
+	}
+
+	blockHash := block.Hash()
+	blockNumber := hexutil.Uint64(block.Height)
+	txIndex := hexutil.Uint64(idx)
+	ethTx := &EthTransaction{
+		BlockHash:        &blockHash,
+		BlockNumber:      &blockNumber,
+		TransactionIndex: &txIndex,
+		Hash:             ethTxHash(block, txBytes),
+		From:             sctx.From.Address,
+		Nonce:            hexutil.Uint64(sctx.From.Sequence - 1), // off-by-one, ETH tx nonce starts from 0
+		Gas:              hexutil.Uint64(sctx.GasLimit),
+		GasPrice:         (*hexutil.Big)(sctx.GasPrice),
+		Value:            (*hexutil.Big)(sctx.From.Coins.NoNil().PTXWei),
+		Input:            hexutil.Bytes(sctx.Data),
+	}
+	if (sctx.To.Address != common.Address{}) {
+		to := sctx.To.Address
+		ethTx.To = &to
+	}
+	if sctx.From.Signature != nil {
+		r, s, v := crypto.DecodeSignature(sctx.From.Signature)
+		ethTx.R, ethTx.S, ethTx.V = (*hexutil.Big)(r), (*hexutil.Big)(s), (*hexutil.Big)(v)
+	}
+	return ethTx
+}
+
+func (e *EthRPCService) toEthBlock(block *core.ExtendedBlock, fullTx bool) *EthBlock {
+	ethBlock := &EthBlock{
+		Number:           hexutil.Uint64(block.Height),
+		Hash:             block.Hash(),
+		ParentHash:       block.Parent,
+		Nonce:            ethEmptyNonce,
+		Sha3Uncles:       ethEmptyUncleHash,
+		TransactionsRoot: block.TxHash,
+		StateRoot:        block.StateHash,
+		ReceiptsRoot:     core.EmptyRootHash,
+		Miner:            block.Proposer,
+		ExtraData:        hexutil.Bytes{},
+		Transactions:     []interface{}{},
+		Uncles:           []common.Hash{},
+	}
+	if block.Timestamp != nil {
+		ethBlock.Timestamp = hexutil.Uint64(block.Timestamp.Uint64())
+	}
+	if raw, err := rlp.EncodeToBytes(block.Block); err == nil {
+		ethBlock.Size = hexutil.Uint64(len(raw))
+	}
+	params, err := e.svc.ledger.GetGovernanceParams(block.Parent)
+	if err != nil || params == nil {
+		params = types.GetDefaultGovernanceParams(block.Height)
+	}
+	ethBlock.GasLimit = hexutil.Uint64(params.MaximumTxGasLimit.Uint64())
+
+	receipts := e.svc.ethBlockReceipts(block)
+	for _, receipt := range receipts {
+		ethBlock.GasUsed += receipt.GasUsed
+		ethBlock.LogsBloom.Or(receipt.LogsBloom)
+	}
+
+	for idx, txBytes := range block.Txs {
+		if !fullTx {
+			ethBlock.Transactions = append(ethBlock.Transactions, ethTxHash(block, txBytes))
+			continue
+		}
+		if ethTx := toEthTransaction(block, uint64(idx), txBytes); ethTx != nil {
+			ethBlock.Transactions = append(ethBlock.Transactions, ethTx)
+		}
+	}
+	return ethBlock
+}
+
+type ethReceiptWithNativeHash struct {
+	*EthReceipt
+	nativeHash common.Hash
+}
+
+// ethBlockReceipts builds the ETH receipts of all smart contract transactions in the given block.
+func (t *PandoRPCService) ethBlockReceipts(block *core.ExtendedBlock) []ethReceiptWithNativeHash {
+	receipts := []ethReceiptWithNativeHash{}
+	blockHash := block.Hash()
+	cumulativeGasUsed := uint64(0)
+	logIndex := uint64(0)
+	for idx, txBytes := range block.Txs {
+		tx, err := types.TxFromBytes(txBytes)
+		if err != nil {
+			continue
+		}
+		sctx, ok := tx.(*types.SmartContractTx)
+		if !ok {
+			continue
+		}
+		nativeHash := crypto.Keccak256Hash(txBytes)
+		entry, found := t.chain.FindTxReceiptByHash(blockHash, nativeHash)
+		if !found {
+			continue
+		}
+
+		cumulativeGasUsed += entry.GasUsed
+		txHash := ethTxHash(block, txBytes)
+		receipt := &EthReceipt{
+			TransactionHash:   txHash,
+			TransactionIndex:  hexutil.Uint64(idx),
+			BlockHash:         blockHash,
+			BlockNumber:       hexutil.Uint64(block.Height),
+			From:              sctx.From.Address,
+			CumulativeGasUsed: hexutil.Uint64(cumulativeGasUsed),
+			GasUsed:           hexutil.Uint64(entry.GasUsed),
+			EffectiveGasPrice: (*hexutil.Big)(sctx.GasPrice),
+			Logs:              []*EthLog{},
+		}
+		if (sctx.To.Address != common.Address{}) {
+			to := sctx.To.Address
+			receipt.To = &to
+		} else if (entry.ContractAddress != common.Address{}) {
+			contractAddr := entry.ContractAddress
+			receipt.ContractAddress = &contractAddr
+		}
+		if entry.EvmErr == "" {
+			receipt.Status = 1
+		}
+		for _, l := range entry.Logs {
+			receipt.Logs = append(receipt.Logs, &EthLog{
+				Address:          l.Address,
+				Topics:           l.Topics,
+				Data:             hexutil.Bytes(l.Data),
+				BlockNumber:      hexutil.Uint64(block.Height),
+				TransactionHash:  txHash,
+				TransactionIndex: hexutil.Uint64(idx),
+				BlockHash:        blockHash,
+				LogIndex:         hexutil.Uint64(logIndex),
+			})
+			receipt.LogsBloom.Or(l.Bloom())
+			logIndex++
+		}
+		receipts = append(receipts, ethReceiptWithNativeHash{
+			EthReceipt: receipt,
+			nativeHash: nativeHash,
+		})
+	}
+	return receipts
+}
+
+// newEthLogFilter converts the eth_getLogs address and topic filters into a chain log filter.
+func newEthLogFilter(addresses []common.Address, topics []ethTopicOptions) *blockchain.LogFilter {
+	filter := &blockchain.LogFilter{Addresses: addresses}
+	for _, options := range topics {
+		filter.Topics = append(filter.Topics, []common.Hash(options))
+	}
+	return filter
+}
+
+// ethLogMatches checks whether the log satisfies the address and topic filters.
+func ethLogMatches(log *EthLog, addresses []common.Address, topics []ethTopicOptions) bool {
+	return newEthLogFilter(addresses, topics).Matches(&types.Log{Address: log.Address, Topics: log.Topics})
+}
+
+// toEthLogs converts the indexed log entries into ETH logs.
+func (t *PandoRPCService) toEthLogs(entries []*blockchain.LogEntry) []*EthLog {
+	logs := []*EthLog{}
+	blocks := make(map[common.Hash]*core.ExtendedBlock)
+	for _, entry := range entries {
+		block, ok := blocks[entry.BlockHash]
+		if !ok {
+			var err error
+			if block, err = t.chain.FindBlock(entry.BlockHash); err != nil {
+				continue
+			}
+			blocks[entry.BlockHash] = block
+		}
+		logs = append(logs, &EthLog{
+			Address:          entry.Log.Address,
+			Topics:           entry.Log.Topics,
+			Data:             hexutil.Bytes(entry.Log.Data),
+			BlockNumber:      hexutil.Uint64(entry.BlockHeight),
+			TransactionHash:  ethTxHash(block, block.Txs[entry.TxIndex]),
+			TransactionIndex: hexutil.Uint64(entry.TxIndex),
+			BlockHash:        entry.BlockHash,
+			LogIndex:         hexutil.Uint64(entry.LogIndex),
+		})
+	}
+	return logs
+}
@@ -0,0 +1,107 @@
+package rpc
+
+import (
+	"bytes"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"net/rpc"
+	"testing"
+
+	"github.com/pandoprojects/pando/common"
+	"github.com/pandoprojects/pando/rpc/lib/rpc-codec/jsonrpc2"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEthMethodMapper(t *testing.T) {
+	assert := assert.New(t)
+
+	assert.Equal("eth.GetBalance", ethMethodMapper("eth_getBalance"))
+	assert.Equal("net.Version", ethMethodMapper("net_version"))
+	assert.Equal("web3.ClientVersion", ethMethodMapper("web3_clientVersion"))
+	assert.Equal("pando.GetStatus", ethMethodMapper("pando.GetStatus"))
+	assert.Equal("JSONRPC2.Batch", ethMethodMapper("JSONRPC2.Batch"))
+	assert.Equal("eth_", ethMethodMapper("eth_"))
+	assert.Equal("foo", ethMethodMapper("foo"))
+}
+
+func TestEthRPCOverHTTP(t *testing.T) {
+	assert := assert.New(t)
+
+	s := rpc.NewServer()
+	s.RegisterName("web3", &Web3RPCService{})
+	server := httptest.NewServer(jsonrpc2.HTTPHandlerWithMapper(s, ethMethodMapper))
+	defer server.Close()
+
+	post := func(body string) string {
+		resp, err := http.Post(server.URL, "application/json", bytes.NewBufferString(body))
+		assert.Nil(err)
+		defer resp.Body.Close()
+		raw, err := ioutil.ReadAll(resp.Body)
+		assert.Nil(err)
+		return string(raw)
+	}
+
+	// keccak256("hello")
+	expected := "0x1c8aff950685c2ed4bc3174f3472287b56d9517b9c948127319a09a7a36deac8"
+
+	single := post(`{"jsonrpc":"2.0","id":1,"method":"web3_sha3","params":["0x68656c6c6f"]}`)
+	res := struct {
+		Result string `json:"result"`
+	}{}
+	assert.Nil(json.Unmarshal([]byte(single), &res))
+	assert.Equal(expected, res.Result)
+
+	batch := post(`[{"jsonrpc":"2.0","id":1,"method":"web3_sha3","params":["0x68656c6c6f"]},` +
+		`{"jsonrpc":"2.0","id":2,"method":"eth_unknown","params":[]}]`)
+	replies := []struct {
+		ID     int             `json:"id"`
+		Result string          `json:"result"`
+		Error  *jsonrpc2.Error `json:"error"`
+	}{}
+	assert.Nil(json.Unmarshal([]byte(batch), &replies))
+	assert.Equal(2, len(replies))
+	for _, reply := range replies { // replies of a batch may come in any order
+		if reply.ID == 1 {
+			assert.Equal(expected, reply.Result)
+		} else if assert.NotNil(reply.Error) {
+			assert.Equal(-32601, reply.Error.Code)
+		}
+	}
+}
+
+func TestEthLogMatches(t *testing.T) {
+	assert := assert.New(t)
+
+	addr1 := common.HexToAddress("0x1")
+	addr2 := common.HexToAddress("0x2")
+	topicA := common.HexToHash("0xa")
+	topicB := common.HexToHash("0xb")
+	topicC := common.HexToHash("0xc")
+	log := &EthLog{Address: addr1, Topics: []common.Hash{topicA, topicB}}
+
+	assert.True(ethLogMatches(log, nil, nil))
+	assert.True(ethLogMatches(log, []common.Address{addr2, addr1}, nil))
+	assert.False(ethLogMatches(log, []common.Address{addr2}, nil))
+	assert.True(ethLogMatches(log, nil, []ethTopicOptions{{topicA}}))
+	assert.True(ethLogMatches(log, nil, []ethTopicOptions{nil, {topicC, topicB}}))
+	assert.False(ethLogMatches(log, nil, []ethTopicOptions{{topicB}}))
+	assert.False(ethLogMatches(log, nil, []ethTopicOptions{nil, nil, {topicC}}))
+}
+
+func TestEthFilterArgsUnmarshal(t *testing.T) {
+	assert := assert.New(t)
+
+	filter := EthFilterArgs{}
+	raw := `{"fromBlock":"0x1","toBlock":"latest","address":"0x0000000000000000000000000000000000000001",` +
+		`"topics":[null,"0x000000000000000000000000000000000000000000000000000000000000000a",` +
+		`["0x000000000000000000000000000000000000000000000000000000000000000b",null]]}`
+	assert.Nil(json.Unmarshal([]byte(raw), &filter))
+	assert.Equal("0x1", filter.FromBlock)
+	assert.Equal(1, len(filter.Address))
+	assert.Equal(3, len(filter.Topics))
+	assert.Nil(filter.Topics[0])
+	assert.Equal(common.HexToHash("0xa"), filter.Topics[1][0])
+	assert.Equal(1, len(filter.Topics[2]))
+}
@@ -0,0 +1,549 @@
+package rpc
+
+import (
+	"context"
+	"encoding/hex"
+	"fmt"
+	"math/big"
+	"net"
+	"strings"
+	"sync"
+
+	"github.com/pandoprojects/pando/blockchain"
+	"github.com/pandoprojects/pando/common"
+	"github.com/pandoprojects/pando/core"
+	"github.com/pandoprojects/pando/crypto"
+	"github.com/pandoprojects/pando/ledger/types"
+	"github.com/pandoprojects/pando/rlp"
+	"github.com/pandoprojects/pando/rpc/lib/rpc-codec/jsonrpc2"
+	"github.com/pandoprojects/pando/rpc/pb"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+)
+
+// maxGrpcBlockRange is the maximum number of blocks returned by GetBlocksByRange, as for JSON-RPC.
+const maxGrpcBlockRange = 5000
+
+//
+// The gRPC API mirrors the query and broadcast methods of PandoRPCService, see rpc/pb/pando.proto.
+// The methods delegate to the JSON-RPC implementation and convert the results, except for the
+// block methods which encode the blocks straight from the chain.
+//
+
+// PandoGrpcService implements the pb.PandoServer interface.
+type PandoGrpcService struct {
+	svc *PandoRPCService
+}
+
+var _ pb.PandoServer = (*PandoGrpcService)(nil)
+
+// NewGrpcServer creates a gRPC server which serves the node API. The calls are rate limited
+// by client IP if a rate limiter is given.
+func NewGrpcServer(svc *PandoRPCService, rl *RateLimiter) *grpc.Server {
+	var opts []grpc.ServerOption
+	if rl != nil {
+		opts = append(opts,
+			grpc.UnaryInterceptor(func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+				if err := rl.allowGrpc(ctx, info.FullMethod); err != nil {
+					return nil, err
+				}
+				return handler(ctx, req)
+			}),
+			grpc.StreamInterceptor(func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+				if err := rl.allowGrpc(ss.Context(), info.FullMethod); err != nil {
+					return err
+				}
+				return handler(srv, ss)
+			}))
+	}
+	server := grpc.NewServer(opts...)
+	pb.RegisterPandoServer(server, &PandoGrpcService{svc: svc})
+	return server
+}
+
+// allowGrpc applies the rate limit of the JSON-RPC method mirrored by the gRPC method,
+// e.g. "/pando.Pando/GetStatus" is priced as "pando.GetStatus".
+func (rl *RateLimiter) allowGrpc(ctx context.Context, fullMethod string) error {
+	method := "pando." + fullMethod[strings.LastIndex(fullMethod, "/")+1:]
+	client := ""
+	if p, ok := peer.FromContext(ctx); ok && p.Addr != nil {
+		client = p.Addr.String()
+		if host, _, err := net.SplitHostPort(client); err == nil {
+			client = host
+		}
+	}
+	if err := rl.Allow(client, method); err != nil {
+		return status.Error(codes.ResourceExhausted, err.Error())
+	}
+	return nil
+}
+
+func (g *PandoGrpcService) GetVersion(ctx context.Context, req *pb.GetVersionRequest) (*pb.GetVersionResponse, error) {
+	result := &GetVersionResult{}
+	if err := g.svc.GetVersion(&GetVersionArgs{}, result); err != nil {
+		return nil, err
+	}
+	return &pb.GetVersionResponse{
+		Version:   result.Version,
+		GitHash:   result.GitHash,
+		Timestamp: result.Timestamp,
+	}, nil
+}
+
+func (g *PandoGrpcService) GetStatus(ctx context.Context, req *pb.GetStatusRequest) (*pb.GetStatusResponse, error) {
+	result := &GetStatusResult{}
+	if err := g.svc.GetStatus(&GetStatusArgs{}, result); err != nil {
+		return nil, err
+	}
+	return &pb.GetStatusResponse{
+		Address:                    result.Address,
+		ChainId:                    result.ChainID,
+		PeerId:                     result.PeerID,
+		LatestFinalizedBlockHash:   result.LatestFinalizedBlockHash.Bytes(),
+		LatestFinalizedBlockHeight: uint64(result.LatestFinalizedBlockHeight),
+		LatestFinalizedBlockTime:   bigToUint64((*big.Int)(result.LatestFinalizedBlockTime)),
+		LatestFinalizedBlockEpoch:  uint64(result.LatestFinalizedBlockEpoch),
+		CurrentEpoch:               uint64(result.CurrentEpoch),
+		CurrentHeight:              uint64(result.CurrentHeight),
+		CurrentTime:                bigToUint64((*big.Int)(result.CurrentTime)),
+		Syncing:                    result.Syncing,
+		GenesisBlockHash:           result.GenesisBlockHash.Bytes(),
+		SnapshotBlockHeight:        uint64(result.SnapshotBlockHeight),
+		SnapshotBlockHash:          result.SnapshotBlockHash.Bytes(),
+	}, nil
+}
+
+func (g *PandoGrpcService) GetAccount(ctx context.Context, req *pb.GetAccountRequest) (*pb.GetAccountResponse, error) {
+	result := &GetAccountResult{}
+	err := g.svc.GetAccount(&GetAccountArgs{
+		Address:   common.BytesToAddress(req.Address).Hex(),
+		Height:    common.JSONUint64(req.Height),
+		BlockHash: common.BytesToHash(req.BlockHash),
+		Preview:   req.Preview,
+	}, result)
+	if err != nil {
+		return nil, err
+	}
+	account := result.Account
+	balance := account.Balance.NoNil()
+	return &pb.GetAccountResponse{
+		Account: &pb.Account{
+			Address:                common.BytesToAddress(req.Address).Bytes(),
+			Sequence:               account.Sequence,
+			Balance:                &pb.Coins{PandoWei: balance.PandoWei.String(), PtxWei: balance.PTXWei.String()},
+			LastUpdatedBlockHeight: account.LastUpdatedBlockHeight,
+			Root:                   account.Root.Bytes(),
+			CodeHash:               account.CodeHash.Bytes(),
+		},
+	}, nil
+}
+
+func (g *PandoGrpcService) GetTransaction(ctx context.Context, req *pb.GetTransactionRequest) (*pb.GetTransactionResponse, error) {
+	result := &GetTransactionResult{}
+	if err := g.svc.GetTransaction(&GetTransactionArgs{Hash: common.BytesToHash(req.Hash).Hex()}, result); err != nil {
+		return nil, err
+	}
+	resp := &pb.GetTransactionResponse{
+		BlockHash:   result.BlockHash.Bytes(),
+		BlockHeight: uint64(result.BlockHeight),
+		Status:      string(result.Status),
+	}
+	if result.Tx != nil {
+		raw, err := types.TxToBytes(result.Tx)
+		if err != nil {
+			return nil, err
+		}
+		resp.Transaction = &pb.Transaction{
+			Hash:           result.TxHash.Bytes(),
+			Type:           uint32(result.Type),
+			Raw:            raw,
+			Receipt:        toPbReceipt(result.Receipt),
+			BalanceChanges: toPbBalanceChanges(result.BalanceChanges),
+		}
+	}
+	return resp, nil
+}
+
+func (g *PandoGrpcService) GetPendingTransactions(ctx context.Context, req *pb.GetPendingTransactionsRequest) (*pb.GetPendingTransactionsResponse, error) {
+	result := &GetPendingTransactionsResult{}
+	if err := g.svc.GetPendingTransactions(&GetPendingTransactionsArgs{}, result); err != nil {
+		return nil, err
+	}
+	resp := &pb.GetPendingTransactionsResponse{}
+	for _, hash := range result.TxHashes {
+		resp.TxHashes = append(resp.TxHashes, common.HexToHash(hash).Bytes())
+	}
+	return resp, nil
+}
+
+func (g *PandoGrpcService) GetBlock(ctx context.Context, req *pb.GetBlockRequest) (*pb.Block, error) {
+	hash := common.BytesToHash(req.Hash)
+	if hash.IsEmpty() {
+		return nil, status.Error(codes.InvalidArgument, "Block hash must be specified")
+	}
+	block, err := g.svc.chain.FindBlock(hash)
+	if err != nil {
+		return nil, status.Errorf(codes.NotFound, "block %v is not found", hash.Hex())
+	}
+	return g.svc.toPbBlock(block)
+}
+
+func (g *PandoGrpcService) GetBlockByHeight(ctx context.Context, req *pb.GetBlockByHeightRequest) (*pb.Block, error) {
+	block := g.svc.findFinalizedBlockByHeight(req.Height)
+	if block == nil {
+		return nil, status.Errorf(codes.NotFound, "finalized block at height %v is not found", req.Height)
+	}
+	return g.svc.toPbBlock(block)
+}
+
+func (g *PandoGrpcService) GetBlocksByRange(ctx context.Context, req *pb.GetBlocksByRangeRequest) (*pb.GetBlocksByRangeResponse, error) {
+	if req.Start > req.End {
+		return nil, status.Error(codes.InvalidArgument, "Starting block must be less than ending block")
+	}
+	if req.End-req.Start > maxGrpcBlockRange {
+		return nil, status.Errorf(codes.InvalidArgument, "Can't retrieve more than %v blocks at a time", maxGrpcBlockRange)
+	}
+	resp := &pb.GetBlocksByRangeResponse{}
+	for height := req.Start; height <= req.End; height++ {
+		block := g.svc.findFinalizedBlockByHeight(height)
+		if block == nil {
+			continue
+		}
+		pbBlock, err := g.svc.toPbBlock(block)
+		if err != nil {
+			return nil, err
+		}
+		resp.Blocks = append(resp.Blocks, pbBlock)
+	}
+	return resp, nil
+}
+
+func (g *PandoGrpcService) GetLogs(ctx context.Context, req *pb.GetLogsRequest) (*pb.GetLogsResponse, error) {
+	args := &GetLogsArgs{
+		FromHeight: common.JSONUint64(req.FromHeight),
+		ToHeight:   common.JSONUint64(req.ToHeight),
+	}
+	for _, address := range req.Addresses {
+		args.Addresses = append(args.Addresses, common.BytesToAddress(address))
+	}
+	for _, filter := range req.Topics {
+		alternatives := []common.Hash{}
+		for _, topic := range filter.Alternatives {
+			alternatives = append(alternatives, common.BytesToHash(topic))
+		}
+		args.Topics = append(args.Topics, alternatives)
+	}
+	result := &GetLogsResult{}
+	if err := g.svc.GetLogs(args, result); err != nil {
+		return nil, err
+	}
+	resp := &pb.GetLogsResponse{}
+	for _, log := range result.Logs {
+		data, err := hex.DecodeString(log.Data)
+		if err != nil {
+			return nil, err
+		}
+		resp.Logs = append(resp.Logs, &pb.LogResult{
+			Address:     log.Address.Bytes(),
+			Topics:      hashesToBytes(log.Topics),
+			Data:        data,
+			BlockHash:   log.BlockHash.Bytes(),
+			BlockHeight: uint64(log.BlockHeight),
+			TxHash:      log.TxHash.Bytes(),
+			TxIndex:     uint64(log.TxIndex),
+			LogIndex:    uint64(log.LogIndex),
+		})
+	}
+	return resp, nil
+}
+
+func (g *PandoGrpcService) GetCode(ctx context.Context, req *pb.GetCodeRequest) (*pb.GetCodeResponse, error) {
+	result := &GetCodeResult{}
+	err := g.svc.GetCode(&GetCodeArgs{
+		Address:   common.BytesToAddress(req.Address).Hex(),
+		Height:    common.JSONUint64(req.Height),
+		BlockHash: common.BytesToHash(req.BlockHash),
+	}, result)
+	if err != nil {
+		return nil, err
+	}
+	code, err := hex.DecodeString(result.Code)
+	if err != nil {
+		return nil, err
+	}
+	return &pb.GetCodeResponse{Code: code}, nil
+}
+
+func (g *PandoGrpcService) GetStorageAt(ctx context.Context, req *pb.GetStorageAtRequest) (*pb.GetStorageAtResponse, error) {
+	result := &GetStorageAtResult{}
+	err := g.svc.GetStorageAt(&GetStorageAtArgs{
+		Address:         common.BytesToAddress(req.Address).Hex(),
+		StoragePosition: common.BytesToHash(req.Position).Hex(),
+		Height:          common.JSONUint64(req.Height),
+		BlockHash:       common.BytesToHash(req.BlockHash),
+	}, result)
+	if err != nil {
+		return nil, err
+	}
+	value, err := hex.DecodeString(result.Value)
+	if err != nil {
+		return nil, err
+	}
+	return &pb.GetStorageAtResponse{Value: value}, nil
+}
+
+func (g *PandoGrpcService) GetPeers(ctx context.Context, req *pb.GetPeersRequest) (*pb.GetPeersResponse, error) {
+	result := &GetPeersResult{}
+	if err := g.svc.GetPeers(&GetPeersArgs{}, result); err != nil {
+		return nil, err
+	}
+	return &pb.GetPeersResponse{Peers: result.Peers}, nil
+}
+
+func (g *PandoGrpcService) CallSmartContract(ctx context.Context, req *pb.CallSmartContractRequest) (*pb.CallSmartContractResponse, error) {
+	result := &CallSmartContractResult{}
+	err := g.svc.CallSmartContract(&CallSmartContractArgs{
+		SctxBytes: hex.EncodeToString(req.SctxBytes),
+		Height:    common.JSONUint64(req.Height),
+		BlockHash: common.BytesToHash(req.BlockHash),
+	}, result)
+	if err != nil {
+		return nil, err
+	}
+	vmReturn, err := hex.DecodeString(result.VmReturn)
+	if err != nil {
+		return nil, err
+	}
+	return &pb.CallSmartContractResponse{
+		VmReturn:        vmReturn,
+		ContractAddress: result.ContractAddress.Bytes(),
+		GasUsed:         uint64(result.GasUsed),
+		VmError:         result.VmError,
+	}, nil
+}
+
+func (g *PandoGrpcService) EstimateGas(ctx context.Context, req *pb.EstimateGasRequest) (*pb.EstimateGasResponse, error) {
+	result := &EstimateGasResult{}
+	if err := g.svc.EstimateGas(&EstimateGasArgs{TxBytes: hex.EncodeToString(req.TxBytes)}, result); err != nil {
+		return nil, err
+	}
+	resp := &pb.EstimateGasResponse{
+		GasLimit: uint64(result.GasLimit),
+		GasUsed:  uint64(result.GasUsed),
+	}
+	if result.MinimumFee != nil {
+		resp.MinimumFee = (*big.Int)(result.MinimumFee).String()
+	}
+	return resp, nil
+}
+
+// grpcTxError reports the transactions refused by the admission policies of the mempool as FailedPrecondition.
+func grpcTxError(err error) error {
+	if rpcErr, ok := err.(*jsonrpc2.Error); ok && rpcErr.Code == txRejectedCode {
+		return status.Error(codes.FailedPrecondition, rpcErr.Message)
+	}
+	return err
+}
+
+func (g *PandoGrpcService) BroadcastRawTransaction(ctx context.Context, req *pb.BroadcastRawTransactionRequest) (*pb.BroadcastRawTransactionResponse, error) {
+	result := &BroadcastRawTransactionResult{}
+	if err := g.svc.BroadcastRawTransaction(&BroadcastRawTransactionArgs{TxBytes: hex.EncodeToString(req.TxBytes)}, result); err != nil {
+		return nil, grpcTxError(err)
+	}
+	resp := &pb.BroadcastRawTransactionResponse{TxHash: common.HexToHash(result.TxHash).Bytes()}
+	if result.Block != nil {
+		resp.BlockHash = result.Block.Hash().Bytes()
+		resp.BlockHeight = result.Block.Height
+	}
+	return resp, nil
+}
+
+func (g *PandoGrpcService) BroadcastRawTransactionAsync(ctx context.Context, req *pb.BroadcastRawTransactionRequest) (*pb.BroadcastRawTransactionAsyncResponse, error) {
+	result := &BroadcastRawTransactionAsyncResult{}
+	if err := g.svc.BroadcastRawTransactionAsync(&BroadcastRawTransactionAsyncArgs{TxBytes: hex.EncodeToString(req.TxBytes)}, result); err != nil {
+		return nil, grpcTxError(err)
+	}
+	return &pb.BroadcastRawTransactionAsyncResponse{TxHash: common.HexToHash(result.TxHash).Bytes()}, nil
+}
+
+// StreamFinalizedBlocks sends the finalized blocks from the requested height on. The blocks are
+// read from the chain, and the stream waits for the next block to be finalized once it has
+// caught up.
+func (g *PandoGrpcService) StreamFinalizedBlocks(req *pb.StreamFinalizedBlocksRequest, stream pb.Pando_StreamFinalizedBlocksServer) error {
+	if root := g.svc.chain.Root(); req.FromHeight < root.Height {
+		return status.Errorf(codes.OutOfRange, "blocks below the snapshot height %v are not available", root.Height)
+	}
+
+	finalized := g.svc.finalizedBlockNotifier.subscribe()
+	defer g.svc.finalizedBlockNotifier.unsubscribe(finalized)
+
+	next := req.FromHeight
+	for {
+		for block := g.svc.findFinalizedBlockByHeight(next); block != nil; block = g.svc.findFinalizedBlockByHeight(next) {
+			pbBlock, err := g.svc.toPbBlock(block)
+			if err != nil {
+				return err
+			}
+			if err := stream.Send(pbBlock); err != nil {
+				return err
+			}
+			next++
+		}
+
+		select {
+		case <-stream.Context().Done():
+			return stream.Context().Err()
+		case <-finalized:
+		}
+	}
+}
+
+// toPbBlock encodes the block with its transactions, receipts and balance changes.
+func (t *PandoRPCService) toPbBlock(block *core.ExtendedBlock) (*pb.Block, error) {
+	hcc, err := rlp.EncodeToBytes(block.HCC)
+	if err != nil {
+		return nil, err
+	}
+	var guardianVotes, rametronenterpriseVotes []byte
+	if block.GuardianVotes != nil {
+		if guardianVotes, err = rlp.EncodeToBytes(block.GuardianVotes); err != nil {
+			return nil, err
+		}
+	}
+	if block.RametronenterpriseVotes != nil {
+		if rametronenterpriseVotes, err = rlp.EncodeToBytes(block.RametronenterpriseVotes); err != nil {
+			return nil, err
+		}
+	}
+
+	blockHash := block.Hash()
+	pbBlock := &pb.Block{
+		ChainId:                 block.ChainID,
+		Epoch:                   block.Epoch,
+		Height:                  block.Height,
+		Parent:                  block.Parent.Bytes(),
+		TransactionsHash:        block.TxHash.Bytes(),
+		StateHash:               block.StateHash.Bytes(),
+		Timestamp:               bigToUint64(block.Timestamp),
+		Proposer:                block.Proposer.Bytes(),
+		Hcc:                     hcc,
+		GuardianVotes:           guardianVotes,
+		RametronenterpriseVotes: rametronenterpriseVotes,
+		Children:                hashesToBytes(block.Children),
+		Status:                  uint32(block.Status),
+		Hash:                    blockHash.Bytes(),
+	}
+	for _, raw := range block.Txs {
+		tx, err := types.TxFromBytes(raw)
+		if err != nil {
+			return nil, fmt.Errorf("Failed to parse transaction of block %v: %v", blockHash.Hex(), err)
+		}
+		hash := crypto.Keccak256Hash(raw)
+		pbTx := &pb.Transaction{
+			Hash: hash.Bytes(),
+			Type: uint32(getTxType(tx)),
+			Raw:  raw,
+		}
+		if receipt, found := t.chain.FindTxReceiptByHash(blockHash, hash); found {
+			pbTx.Receipt = toPbReceipt(receipt)
+		}
+		if balanceChanges, found := t.chain.FindTxBalanceChangesByHash(blockHash, hash); found {
+			pbTx.BalanceChanges = toPbBalanceChanges(balanceChanges)
+		}
+		pbBlock.Transactions = append(pbBlock.Transactions, pbTx)
+	}
+	return pbBlock, nil
+}
+
+func toPbReceipt(receipt *blockchain.TxReceiptEntry) *pb.Receipt {
+	if receipt == nil {
+		return nil
+	}
+	pbReceipt := &pb.Receipt{
+		EvmRet:          receipt.EvmRet,
+		ContractAddress: receipt.ContractAddress.Bytes(),
+		GasUsed:         receipt.GasUsed,
+		EvmErr:          receipt.EvmErr,
+	}
+	for _, log := range receipt.Logs {
+		pbReceipt.Logs = append(pbReceipt.Logs, &pb.Log{
+			Address: log.Address.Bytes(),
+			Topics:  hashesToBytes(log.Topics),
+			Data:    log.Data,
+		})
+	}
+	return pbReceipt
+}
+
+func toPbBalanceChanges(entry *blockchain.TxBalanceChangesEntry) []*pb.BalanceChange {
+	if entry == nil {
+		return nil
+	}
+	changes := []*pb.BalanceChange{}
+	for _, change := range entry.BalanceChanges {
+		delta := "0"
+		if change.Delta != nil {
+			delta = change.Delta.String()
+		}
+		changes = append(changes, &pb.BalanceChange{
+			Address:    change.Address.Bytes(),
+			TokenType:  uint32(change.TokenType),
+			IsNegative: change.IsNegative,
+			Delta:      delta,
+		})
+	}
+	return changes
+}
+
+func hashesToBytes(hashes []common.Hash) [][]byte {
+	res := make([][]byte, len(hashes))
+	for i, hash := range hashes {
+		res[i] = hash.Bytes()
+	}
+	return res
+}
+
+func bigToUint64(value *big.Int) uint64 {
+	if value == nil || !value.IsUint64() {
+		return 0
+	}
+	return value.Uint64()
+}
+
+// blockNotifier wakes up the subscribers when a block is finalized. The notifications do not
+// carry the block and are coalesced, the subscribers read the blocks from the chain.
+type blockNotifier struct {
+	mu          sync.Mutex
+	subscribers map[chan struct{}]struct{}
+}
+
+func newBlockNotifier() *blockNotifier {
+	return &blockNotifier{subscribers: make(map[chan struct{}]struct{})}
+}
+
+func (n *blockNotifier) subscribe() chan struct{} {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	ch := make(chan struct{}, 1)
+	n.subscribers[ch] = struct{}{}
+	return ch
+}
+
+func (n *blockNotifier) unsubscribe(ch chan struct{}) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	delete(n.subscribers, ch)
+}
+
+func (n *blockNotifier) notify() {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	for ch := range n.subscribers {
+		select {
+		case ch <- struct{}{}:
+		default: // a notification is already pending
+		}
+	}
+}
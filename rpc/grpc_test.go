@@ -0,0 +1,78 @@
+package rpc
+
+import (
+	"context"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/pandoprojects/pando/blockchain"
+	"github.com/pandoprojects/pando/core"
+	"github.com/pandoprojects/pando/rpc/pb"
+	"github.com/pandoprojects/pando/store/database/backend"
+	"github.com/pandoprojects/pando/store/kvstore"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/test/bufconn"
+)
+
+func TestGrpcStreamFinalizedBlocks(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	chain := blockchain.NewChain("privatenet", kvstore.NewKVStore(backend.NewMemDatabase()), core.CreateTestBlock("g0", ""))
+	for _, pair := range [][2]string{{"g1", "g0"}, {"g2", "g1"}, {"g3", "g2"}} {
+		_, err := chain.AddBlock(core.CreateTestBlock(pair[0], pair[1]))
+		require.Nil(err)
+		chain.MarkBlockValid(core.GetTestBlock(pair[0]).Hash())
+	}
+	require.Nil(chain.FinalizePreviousBlocks(core.GetTestBlock("g2").Hash()))
+	svc := &PandoRPCService{chain: chain, finalizedBlockNotifier: newBlockNotifier()}
+
+	listener := bufconn.Listen(1 << 20)
+	server := NewGrpcServer(svc, nil)
+	go server.Serve(listener)
+	defer server.Stop()
+
+	conn, err := grpc.Dial("bufnet", grpc.WithInsecure(), grpc.WithContextDialer(func(ctx context.Context, addr string) (net.Conn, error) {
+		return listener.Dial()
+	}))
+	require.Nil(err)
+	defer conn.Close()
+	client := pb.NewPandoClient(conn)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	block, err := client.GetBlockByHeight(ctx, &pb.GetBlockByHeightRequest{Height: 2})
+	require.Nil(err)
+	assert.Equal(core.GetTestBlock("g2").Hash().Bytes(), block.Hash)
+	assert.Equal(core.GetTestBlock("g1").Hash().Bytes(), block.Parent)
+	assert.Equal(uint64(2), block.Height)
+
+	_, err = client.GetBlockByHeight(ctx, &pb.GetBlockByHeightRequest{Height: 3})
+	assert.NotNil(err) // not finalized yet
+
+	blocks, err := client.GetBlocksByRange(ctx, &pb.GetBlocksByRangeRequest{Start: 1, End: 3})
+	require.Nil(err)
+	require.Equal(2, len(blocks.Blocks))
+	assert.Equal(uint64(1), blocks.Blocks[0].Height)
+
+	// The stream catches up from the chain, then waits for the next finalized block
+	stream, err := client.StreamFinalizedBlocks(ctx, &pb.StreamFinalizedBlocksRequest{FromHeight: 1})
+	require.Nil(err)
+	for _, name := range []string{"g1", "g2"} {
+		block, err := stream.Recv()
+		require.Nil(err)
+		assert.Equal(core.GetTestBlock(name).Hash().Bytes(), block.Hash)
+	}
+
+	require.Nil(chain.FinalizePreviousBlocks(core.GetTestBlock("g3").Hash()))
+	svc.finalizedBlockNotifier.notify()
+
+	block, err = stream.Recv()
+	require.Nil(err)
+	assert.Equal(core.GetTestBlock("g3").Hash().Bytes(), block.Hash)
+	assert.Equal(uint64(3), block.Height)
+}
@@ -193,6 +193,19 @@ func TestMalformedInput(t *testing.T) {
 	ServeConn(srv) // must return, not loop
 }
 
+func TestServerRequestUnmarshal(t *testing.T) {
+	var r serverRequest
+	if err := json.Unmarshal([]byte(`{"jsonrpc":"2.0","method":"Arith.Add","params":[{"A":1,"B":2}],"id":7}`), &r); err != nil {
+		t.Fatalf("Unmarshal: %v", err)
+	}
+	if r.Method != "Arith.Add" || r.Params == nil || r.ID == nil || string(*r.ID) != "7" {
+		t.Errorf("Unmarshal: got %+v", r)
+	}
+	if err := json.Unmarshal([]byte(`{"jsonrpc":"1.0","method":"Arith.Add","id":7}`), &r); err == nil {
+		t.Errorf("Unmarshal: expected error for bad version")
+	}
+}
+
 func TestMalformedOutput(t *testing.T) {
 	cli, srv := net.Pipe()
 	go srv.Write([]byte(`{"id":0,"result":null,"error":null}`))
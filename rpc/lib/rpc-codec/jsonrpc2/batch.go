@@ -14,6 +14,7 @@ type JSONRPC2 struct{}
 // BatchArg is a param for internal RPC JSONRPC2.Batch.
 type BatchArg struct {
 	srv  *rpc.Server
+	opts ServerOptions
 	reqs []*json.RawMessage
 	Ctx
 }
@@ -22,7 +23,7 @@ type BatchArg struct {
 func (JSONRPC2) Batch(arg BatchArg, replies *[]*json.RawMessage) (err error) {
 	cli, srv := net.Pipe()
 	defer cli.Close()
-	go arg.srv.ServeCodec(NewServerCodecContext(arg.Context(), srv, arg.srv))
+	go arg.srv.ServeCodec(NewServerCodecWithOptions(arg.Context(), srv, arg.srv, arg.opts))
 
 	replyc := make(chan *json.RawMessage, len(arg.reqs))
 	donec := make(chan struct{}, 1)
@@ -14,6 +14,7 @@ var (
 	errParams      = NewError(-32602, "invalid params")
 	errInternal    = NewError(-32603, "internal error")
 	errServer      = NewError(-32000, "server error")
+	errLimit       = NewError(-32005, "limit exceeded")
 	errServerError = NewError(-32001, "jsonrpc2.Error: json.Marshal failed")
 )
 
@@ -45,7 +45,8 @@ func (conn *httpServerConn) Close() error {
 }
 
 type httpHandler struct {
-	rpc *rpc.Server
+	rpc  *rpc.Server
+	opts ServerOptions
 }
 
 // HTTPHandler returns handler for HTTP requests which will execute
@@ -58,7 +59,22 @@ func HTTPHandler(srv *rpc.Server) http.Handler {
 	if srv == nil {
 		srv = rpc.DefaultServer
 	}
-	return &httpHandler{srv}
+	return &httpHandler{rpc: srv}
+}
+
+// HTTPHandlerWithMapper is HTTPHandler with given mapper applied to the
+// method name of every incoming request.
+func HTTPHandlerWithMapper(srv *rpc.Server, mapper MethodMapper) http.Handler {
+	return HTTPHandlerWithOptions(srv, ServerOptions{Mapper: mapper})
+}
+
+// HTTPHandlerWithOptions is HTTPHandler with given options applied to every
+// incoming request.
+func HTTPHandlerWithOptions(srv *rpc.Server, opts ServerOptions) http.Handler {
+	if srv == nil {
+		srv = rpc.DefaultServer
+	}
+	return &httpHandler{rpc: srv, opts: opts}
 }
 
 func (h *httpHandler) ServeHTTP(w http.ResponseWriter, req *http.Request) {
@@ -76,7 +92,7 @@ func (h *httpHandler) ServeHTTP(w http.ResponseWriter, req *http.Request) {
 
 	ctx := context.WithValue(context.Background(), httpRequestContextKey, req)
 	conn := &httpServerConn{req: req.Body, res: w}
-	_ = h.rpc.ServeRequest(NewServerCodecContext(ctx, conn, h.rpc))
+	_ = h.rpc.ServeRequest(NewServerCodecWithOptions(ctx, conn, h.rpc, h.opts))
 	if !conn.replied {
 		w.WriteHeader(http.StatusNoContent)
 	}
@@ -3,7 +3,9 @@ package jsonrpc2_test
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"io/ioutil"
@@ -171,3 +173,61 @@ func TestHTTPClientContentType(t *testing.T) {
 		ts.Close()
 	}
 }
+
+func TestHTTPServerOptions(t *testing.T) {
+	const jSum = `{"jsonrpc":"2.0","id":0,"method":"Svc.Sum","params":[3,5]}`
+	const jRes = `{"jsonrpc":"2.0","id":0,"result":8}`
+	const jLimit = `{"jsonrpc":"2.0","id":0,"error":{"code":-32005,"message":"too many calls"}}`
+	const jBatchLimit = `{"jsonrpc":"2.0","id":null,"error":{"code":-32005,"message":"batch of 3 requests exceeds the limit of 2"}}`
+
+	calls := 0
+	limiter := func(ctx context.Context, method string) error {
+		if method != "Svc.Sum" {
+			t.Errorf("Limiter(%v), want Svc.Sum", method)
+		}
+		if jsonrpc2.HTTPRequestFromContext(ctx) == nil {
+			t.Errorf("Limiter(%v), no HTTP request in the context", method)
+		}
+		calls++
+		if calls > 2 {
+			return errors.New("too many calls")
+		}
+		return nil
+	}
+	ts := httptest.NewServer(jsonrpc2.HTTPHandlerWithOptions(nil, jsonrpc2.ServerOptions{
+		Limiter:      limiter,
+		MaxBatchSize: 2,
+	}))
+	defer ts.Close()
+
+	cases := []struct {
+		body  string
+		reply string
+	}{
+		{"[" + jSum + "," + jSum + "," + jSum + "]", jBatchLimit},
+		{"[" + jSum + "," + jSum + "]", "[" + jRes + "," + jRes + "]"},
+		{jSum, jLimit},
+		{"[" + jSum + "]", "[" + jLimit + "]"},
+	}
+	for _, c := range cases {
+		resp, err := http.Post(ts.URL, "application/json", strings.NewReader(c.body))
+		if err != nil {
+			t.Fatalf("Post(%s), err = %v", c.body, err)
+		}
+		got, err := ioutil.ReadAll(resp.Body)
+		resp.Body.Close()
+		if err != nil {
+			t.Errorf("ReadAll(), err = %v", err)
+		}
+		var jgot, jwant interface{}
+		if err := json.Unmarshal(got, &jgot); err != nil {
+			t.Errorf("Post(%s), output err = %v\ngot: %#q", c.body, err, string(bytes.TrimRight(got, "\n")))
+		}
+		if err := json.Unmarshal([]byte(c.reply), &jwant); err != nil {
+			t.Errorf("Post(%s), expect err = %v\nexp: %#q", c.body, err, c.reply)
+		}
+		if !reflect.DeepEqual(jgot, jwant) {
+			t.Errorf("Post(%s)\nexp: %#q\ngot: %#q", c.body, c.reply, string(bytes.TrimRight(got, "\n")))
+		}
+	}
+}
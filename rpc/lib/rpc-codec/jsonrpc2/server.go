@@ -9,6 +9,7 @@ import (
 	"context"
 	"encoding/json"
 	"errors"
+	"fmt"
 	"io"
 	"net/rpc"
 	"sync"
@@ -26,9 +27,11 @@ type serverCodec struct {
 	c        io.Closer
 	srv      *rpc.Server
 	ctx      context.Context
+	opts     ServerOptions
 
 	// temporary work space
-	req serverRequest
+	req           serverRequest
+	serviceMethod string
 
 	// JSON-RPC clients can use arbitrary json values as request IDs.
 	// Package rpc expects uint64 request IDs.
@@ -74,6 +77,39 @@ func NewServerCodecContext(ctx context.Context, conn io.ReadWriteCloser, srv *rp
 	return codec
 }
 
+// MethodMapper translates the method name of an incoming request into the
+// "Service.Method" form expected by net/rpc. It allows serving clients which
+// use a different naming convention, e.g. "eth_getBalance".
+type MethodMapper func(method string) string
+
+// Limiter decides whether a request for the given "Service.Method" is
+// served, it is consulted for every request including requests inside a
+// batch. A returned *Error is sent to the client as is, any other error is
+// sent with the "limit exceeded" code.
+type Limiter func(ctx context.Context, method string) error
+
+// ServerOptions customizes the handling of the incoming requests.
+type ServerOptions struct {
+	Mapper       MethodMapper // applied to the method name of every request
+	Limiter      Limiter      // rejects the requests over the limits
+	MaxBatchSize int          // maximum number of requests in a batch, zero means unlimited
+}
+
+// NewServerCodecWithMapper is NewServerCodecContext with given mapper applied
+// to the method name of every incoming request, including requests inside
+// a batch.
+func NewServerCodecWithMapper(ctx context.Context, conn io.ReadWriteCloser, srv *rpc.Server, mapper MethodMapper) rpc.ServerCodec {
+	return NewServerCodecWithOptions(ctx, conn, srv, ServerOptions{Mapper: mapper})
+}
+
+// NewServerCodecWithOptions is NewServerCodecContext with given options
+// applied to every incoming request, including requests inside a batch.
+func NewServerCodecWithOptions(ctx context.Context, conn io.ReadWriteCloser, srv *rpc.Server, opts ServerOptions) rpc.ServerCodec {
+	codec := NewServerCodecContext(ctx, conn, srv)
+	codec.(*serverCodec).opts = opts
+	return codec
+}
+
 type serverRequest struct {
 	Version string           `json:"jsonrpc"`
 	Method  string           `json:"method"`
@@ -90,8 +126,11 @@ func (r *serverRequest) reset() {
 
 func (r *serverRequest) UnmarshalJSON(raw []byte) error {
 	r.reset()
-	type req *serverRequest
-	if err := json.Unmarshal(raw, req(r)); err != nil {
+	// The named struct type has no methods, so the decoding does not recurse. A named pointer
+	// type is not enough, since recent encoding/json versions still find the UnmarshalJSON
+	// method of the pointed type.
+	type req serverRequest
+	if err := json.Unmarshal(raw, (*req)(r)); err != nil {
 		return errors.New("bad request")
 	}
 
@@ -170,6 +209,10 @@ func (c *serverCodec) ReadRequestHeader(r *rpc.Request) (err error) {
 	}
 
 	r.ServiceMethod = c.req.Method
+	if c.opts.Mapper != nil && c.req.Method != batchMethod {
+		r.ServiceMethod = c.opts.Mapper(c.req.Method)
+	}
+	c.serviceMethod = r.ServiceMethod
 
 	// JSON request id can be any JSON value;
 	// RPC package expects uint64.  Translate to
@@ -190,6 +233,14 @@ func (c *serverCodec) ReadRequestBody(x interface{}) error {
 	if x == nil {
 		return nil
 	}
+	if c.opts.Limiter != nil && c.req.Method != batchMethod {
+		if err := c.opts.Limiter(c.ctx, c.serviceMethod); err != nil {
+			if e, ok := err.(*Error); ok {
+				return e
+			}
+			return NewError(errLimit.Code, err.Error())
+		}
+	}
 	if x, ok := x.(WithContext); ok {
 		x.SetContext(c.ctx)
 	}
@@ -199,12 +250,16 @@ func (c *serverCodec) ReadRequestBody(x interface{}) error {
 	if c.req.Method == batchMethod {
 		arg := x.(*BatchArg)
 		arg.srv = c.srv
+		arg.opts = c.opts
 		if err := json.Unmarshal(*c.req.Params, &arg.reqs); err != nil {
 			return NewError(errParams.Code, err.Error())
 		}
 		if len(arg.reqs) == 0 {
 			return errRequest
 		}
+		if c.opts.MaxBatchSize > 0 && len(arg.reqs) > c.opts.MaxBatchSize {
+			return NewError(errLimit.Code, fmt.Sprintf("batch of %d requests exceeds the limit of %d", len(arg.reqs), c.opts.MaxBatchSize))
+		}
 		return nil
 	}
 
@@ -0,0 +1,4 @@
+//go:generate protoc --go_out=plugins=grpc:. pando.proto
+
+// Package pb contains the protobuf messages and the gRPC service definition of the node API.
+package pb
@@ -7,20 +7,23 @@ import (
 	"log"
 	"math/big"
 	"math/rand"
-	"strings"
+	"strconv"
 	"time"
 
 	"github.com/spf13/viper"
 
 	"github.com/pandoprojects/pando/blockchain"
-	"github.com/pandoprojects/pando/crypto/bls"
 
 	"github.com/pandoprojects/pando/common"
+	"github.com/pandoprojects/pando/consensus"
 	"github.com/pandoprojects/pando/core"
 	"github.com/pandoprojects/pando/crypto"
+	"github.com/pandoprojects/pando/ledger/execution"
 	"github.com/pandoprojects/pando/ledger/state"
 	"github.com/pandoprojects/pando/ledger/types"
+	"github.com/pandoprojects/pando/lightclient"
 	"github.com/pandoprojects/pando/mempool"
+	"github.com/pandoprojects/pando/rlp"
 	"github.com/pandoprojects/pando/version"
 )
 
@@ -45,10 +48,11 @@ func (t *PandoRPCService) GetVersion(args *GetVersionArgs, result *GetVersionRes
 // ------------------------------- GetAccount -----------------------------------
 
 type GetAccountArgs struct {
-	Name    string            `json:"name"`
-	Address string            `json:"address"`
-	Height  common.JSONUint64 `json:"height"`
-	Preview bool              `json:"preview"` // preview the account balance from the ScreenedView
+	Name      string            `json:"name"`
+	Address   string            `json:"address"`
+	Height    common.JSONUint64 `json:"height"`
+	BlockHash common.Hash       `json:"block_hash"` // alternative to height, query the state of the given block
+	Preview   bool              `json:"preview"`    // preview the account balance from the ScreenedView
 }
 
 type GetAccountResult struct {
@@ -64,7 +68,7 @@ func (t *PandoRPCService) GetAccount(args *GetAccountArgs, result *GetAccountRes
 	result.Address = args.Address
 	height := uint64(args.Height)
 
-	if height == 0 { // get the latest
+	if height == 0 && args.BlockHash.IsEmpty() { // get the latest
 		var ledgerState *state.StoreView
 		if args.Preview {
 			ledgerState, err = t.ledger.GetScreenedSnapshot()
@@ -83,34 +87,15 @@ func (t *PandoRPCService) GetAccount(args *GetAccountArgs, result *GetAccountRes
 
 		result.Account = account
 	} else {
-		blocks := t.chain.FindBlocksByHeight(height)
-		if len(blocks) == 0 {
-			result.Account = nil
-			return nil
-		}
-
-		deliveredView, err := t.ledger.GetDeliveredSnapshot()
+		ledgerState, _, err := t.historicalStoreView(height, args.BlockHash)
 		if err != nil {
 			return err
 		}
-		db := deliveredView.GetDB()
-
-		for _, b := range blocks {
-			if b.Status.IsFinalized() {
-				stateRoot := b.StateHash
-				ledgerState := state.NewStoreView(height, stateRoot, db)
-				if ledgerState == nil { // might have been pruned
-					return fmt.Errorf("the account details for height %v is not available, it might have been pruned", height)
-				}
-				account := ledgerState.GetAccount(address)
-				if account == nil {
-					return fmt.Errorf("Account with address %v is not found", address.Hex())
-				}
-				result.Account = account
-				break
-			}
+		account := ledgerState.GetAccount(address)
+		if account == nil {
+			return fmt.Errorf("Account with address %v is not found", address.Hex())
 		}
-
+		result.Account = account
 	}
 
 	return nil
@@ -222,18 +207,98 @@ func (t *PandoRPCService) GetTransaction(args *GetTransactionArgs, result *GetTr
 	return nil
 }
 
+// ------------------------------ GetAccountTransactions -----------------------------------
+
+const (
+	defaultAccountTxsPageSize = 20
+	maxAccountTxsPageSize     = 100
+)
+
+type GetAccountTransactionsArgs struct {
+	Address  string            `json:"address"`
+	Page     common.JSONUint64 `json:"page"`      // 0-based, pages are ordered from the newest transactions
+	PageSize common.JSONUint64 `json:"page_size"` // defaults to 20, at most 100
+}
+
+type AccountTransaction struct {
+	BlockHash   common.Hash       `json:"block_hash"`
+	BlockHeight common.JSONUint64 `json:"block_height"`
+	TxHash      common.Hash       `json:"hash"`
+	Type        byte              `json:"type"`
+	Tx          types.Tx          `json:"transaction"`
+}
+
+type GetAccountTransactionsResult struct {
+	Address      string                `json:"address"`
+	TotalCount   common.JSONUint64     `json:"total_count"`
+	Page         common.JSONUint64     `json:"page"`
+	PageSize     common.JSONUint64     `json:"page_size"`
+	Transactions []*AccountTransaction `json:"transactions"`
+}
+
+func (t *PandoRPCService) GetAccountTransactions(args *GetAccountTransactionsArgs, result *GetAccountTransactionsResult) (err error) {
+	if !t.chain.AccountTxIndexEnabled() {
+		return fmt.Errorf("Account transaction index is not enabled, set %v to enable it", common.CfgStorageIndexAccountTxs)
+	}
+	if args.Address == "" {
+		return errors.New("Address must be specified")
+	}
+	address := common.HexToAddress(args.Address)
+
+	pageSize := uint64(args.PageSize)
+	if pageSize == 0 {
+		pageSize = defaultAccountTxsPageSize
+	}
+	if pageSize > maxAccountTxsPageSize {
+		return fmt.Errorf("Page size can't exceed %v", maxAccountTxsPageSize)
+	}
+
+	total, entries := t.chain.FindAccountTxs(address, uint64(args.Page)*pageSize, pageSize)
+
+	result.Address = address.Hex()
+	result.TotalCount = common.JSONUint64(total)
+	result.Page = args.Page
+	result.PageSize = common.JSONUint64(pageSize)
+	result.Transactions = []*AccountTransaction{}
+	for _, entry := range entries {
+		block, err := t.chain.FindBlock(entry.BlockHash)
+		if err != nil || entry.Index >= uint64(len(block.Txs)) {
+			continue
+		}
+		tx, err := types.TxFromBytes(block.Txs[entry.Index])
+		if err != nil {
+			return err
+		}
+		result.Transactions = append(result.Transactions, &AccountTransaction{
+			BlockHash:   entry.BlockHash,
+			BlockHeight: common.JSONUint64(entry.BlockHeight),
+			TxHash:      entry.TxHash,
+			Type:        getTxType(tx),
+			Tx:          tx,
+		})
+	}
+
+	return nil
+}
+
 // ------------------------------ GetPendingTransactions -----------------------------------
 
 type GetPendingTransactionsArgs struct {
 }
 
 type GetPendingTransactionsResult struct {
-	TxHashes []string `json:"tx_hashes"`
+	TxHashes       []string `json:"tx_hashes"`
+	PendingCount   int      `json:"pending_count"`
+	QueuedTxHashes []string `json:"queued_tx_hashes"` // waiting for the sequence gaps before them to be filled
+	QueuedCount    int      `json:"queued_count"`
 }
 
 func (t *PandoRPCService) GetPendingTransactions(args *GetPendingTransactionsArgs, result *GetPendingTransactionsResult) (err error) {
 	pendingTxHashes := t.mempool.GetCandidateTransactionHashes()
 	result.TxHashes = pendingTxHashes
+	result.PendingCount = len(pendingTxHashes)
+	result.QueuedTxHashes = t.mempool.GetQueuedTransactionHashes()
+	result.QueuedCount = len(result.QueuedTxHashes)
 	return nil
 }
 
@@ -302,6 +367,11 @@ const (
 	TxTypeWithdrawStake
 	TxTypeDepositStakeTxV2
 	TxTypeStakeRewardDistributionTx
+	TxTypeEquivocationEvidenceTx
+	TxTypeGovernanceProposalTx
+	TxTypeGovernanceVoteTx
+	TxTypeValidatorCommissionTx
+	TxTypeClaimStakeRewardTx
 )
 
 func (t *PandoRPCService) GetBlock(args *GetBlockArgs, result *GetBlockResult) (err error) {
@@ -492,6 +562,67 @@ func (t *PandoRPCService) GetBlocksByRange(args *GetBlocksByRangeArgs, result *G
 	return
 }
 
+// ------------------------------ GetLogs -----------------------------------
+
+type GetLogsArgs struct {
+	FromHeight common.JSONUint64 `json:"from_height"` // 0 means the first block of the longest range ending at to_height
+	ToHeight   common.JSONUint64 `json:"to_height"`   // 0 means the last finalized block
+	Addresses  []common.Address  `json:"addresses"`
+	Topics     [][]common.Hash   `json:"topics"` // topics[i] lists the alternatives for the i-th topic, empty matches any
+}
+
+type LogResult struct {
+	Address     common.Address    `json:"address"`
+	Topics      []common.Hash     `json:"topics"`
+	Data        string            `json:"data"`
+	BlockHash   common.Hash       `json:"block_hash"`
+	BlockHeight common.JSONUint64 `json:"block_height"`
+	TxHash      common.Hash       `json:"tx_hash"`
+	TxIndex     common.JSONUint64 `json:"tx_index"`
+	LogIndex    common.JSONUint64 `json:"log_index"`
+}
+
+type GetLogsResult struct {
+	Logs []*LogResult `json:"logs"`
+}
+
+func (t *PandoRPCService) GetLogs(args *GetLogsArgs, result *GetLogsResult) (err error) {
+	finalizedHeight := t.consensus.GetLastFinalizedBlock().Height
+	toHeight := uint64(args.ToHeight)
+	if toHeight == 0 || toHeight > finalizedHeight {
+		toHeight = finalizedHeight
+	}
+
+	fromHeight := uint64(args.FromHeight)
+	if fromHeight == 0 && toHeight >= blockchain.MaxLogQueryRange {
+		fromHeight = toHeight - blockchain.MaxLogQueryRange + 1
+	}
+
+	filter := &blockchain.LogFilter{
+		Addresses: args.Addresses,
+		Topics:    args.Topics,
+	}
+	entries, err := t.chain.FindLogs(fromHeight, toHeight, filter)
+	if err != nil {
+		return err
+	}
+
+	result.Logs = []*LogResult{}
+	for _, entry := range entries {
+		result.Logs = append(result.Logs, &LogResult{
+			Address:     entry.Log.Address,
+			Topics:      entry.Log.Topics,
+			Data:        hex.EncodeToString(entry.Log.Data),
+			BlockHash:   entry.BlockHash,
+			BlockHeight: common.JSONUint64(entry.BlockHeight),
+			TxHash:      entry.TxHash,
+			TxIndex:     common.JSONUint64(entry.TxIndex),
+			LogIndex:    common.JSONUint64(entry.LogIndex),
+		})
+	}
+	return nil
+}
+
 // ------------------------------ GetStatus -----------------------------------
 
 type GetStatusArgs struct{}
@@ -711,21 +842,15 @@ type GetGuardianInfoResult struct {
 }
 
 func (t *PandoRPCService) GetGuardianInfo(args *GetGuardianInfoArgs, result *GetGuardianInfoResult) (err error) {
-	privKey := t.consensus.PrivateKey()
-	blsKey, err := bls.GenKey(strings.NewReader(common.Bytes2Hex(privKey.PublicKey().ToBytes())))
-	if err != nil {
-		return fmt.Errorf("Failed to get BLS key: %v", err.Error())
-	}
-
-	result.Address = privKey.PublicKey().Address().Hex()
-	result.BLSPubkey = hex.EncodeToString(blsKey.PublicKey().ToBytes())
-	popBytes := blsKey.PopProve().ToBytes()
-	result.BLSPop = hex.EncodeToString(popBytes)
-
-	sig, err := privKey.Sign(popBytes)
+	signer := t.consensus.Signer()
+	pop, sig, err := signer.BLSPop()
 	if err != nil {
 		return fmt.Errorf("Failed to generate signature: %v", err.Error())
 	}
+
+	result.Address = signer.PublicKey().Address().Hex()
+	result.BLSPubkey = hex.EncodeToString(signer.BLSPublicKey().ToBytes())
+	result.BLSPop = hex.EncodeToString(pop.ToBytes())
 	result.Signature = hex.EncodeToString(sig.ToBytes())
 
 	return nil
@@ -744,21 +869,15 @@ type GetRametronenterpriseInfoResult struct {
 }
 
 func (t *PandoRPCService) GetRametronenterpriseInfo(args *GetRametronenterpriseInfoArgs, result *GetRametronenterpriseInfoResult) (err error) {
-	privKey := t.consensus.PrivateKey()
-	blsKey, err := bls.GenKey(strings.NewReader(common.Bytes2Hex(privKey.PublicKey().ToBytes())))
-	if err != nil {
-		return fmt.Errorf("Failed to get BLS key: %v", err.Error())
-	}
-
-	result.Address = privKey.PublicKey().Address().Hex()
-	result.BLSPubkey = hex.EncodeToString(blsKey.PublicKey().ToBytes())
-	popBytes := blsKey.PopProve().ToBytes()
-	result.BLSPop = hex.EncodeToString(popBytes)
-
-	sig, err := privKey.Sign(popBytes)
+	signer := t.consensus.Signer()
+	pop, sig, err := signer.BLSPop()
 	if err != nil {
 		return fmt.Errorf("Failed to generate signature: %v", err.Error())
 	}
+
+	result.Address = signer.PublicKey().Address().Hex()
+	result.BLSPubkey = hex.EncodeToString(signer.BLSPublicKey().ToBytes())
+	result.BLSPop = hex.EncodeToString(pop.ToBytes())
 	result.Signature = hex.EncodeToString(sig.ToBytes())
 
 	return nil
@@ -940,8 +1059,9 @@ func (t *PandoRPCService) GetAllPendingRametronenterpriseStakeReturns(
 // ------------------------------- GetCode -----------------------------------
 
 type GetCodeArgs struct {
-	Address string            `json:"address"`
-	Height  common.JSONUint64 `json:"height"`
+	Address   string            `json:"address"`
+	Height    common.JSONUint64 `json:"height"`
+	BlockHash common.Hash       `json:"block_hash"` // alternative to height, query the state of the given block
 }
 
 type GetCodeResult struct {
@@ -957,41 +1077,17 @@ func (t *PandoRPCService) GetCode(args *GetCodeArgs, result *GetCodeResult) (err
 	result.Address = args.Address
 	height := uint64(args.Height)
 
-	if height == 0 { // get the latest
-		var ledgerState *state.StoreView
+	var ledgerState *state.StoreView
+	if height == 0 && args.BlockHash.IsEmpty() { // get the latest
 		ledgerState, err = t.ledger.GetFinalizedSnapshot()
-		if err != nil {
-			return err
-		}
-		codeBytes := ledgerState.GetCode(address)
-		result.Code = hex.EncodeToString(codeBytes)
 	} else {
-		blocks := t.chain.FindBlocksByHeight(height)
-		if len(blocks) == 0 {
-			result.Code = ""
-			return nil
-		}
-
-		deliveredView, err := t.ledger.GetDeliveredSnapshot()
-		if err != nil {
-			return err
-		}
-		db := deliveredView.GetDB()
-
-		for _, b := range blocks {
-			if b.Status.IsFinalized() {
-				stateRoot := b.StateHash
-				ledgerState := state.NewStoreView(height, stateRoot, db)
-				if ledgerState == nil { // might have been pruned
-					return fmt.Errorf("the account details for height %v is not available, it might have been pruned", height)
-				}
-				codeBytes := ledgerState.GetCode(address)
-				result.Code = hex.EncodeToString(codeBytes)
-				break
-			}
-		}
-
+		ledgerState, _, err = t.historicalStoreView(height, args.BlockHash)
+	}
+	if err != nil {
+		return err
 	}
+	codeBytes := ledgerState.GetCode(address)
+	result.Code = hex.EncodeToString(codeBytes)
 
 	return nil
 }
@@ -1002,6 +1098,7 @@ type GetStorageAtArgs struct {
 	Address         string            `json:"address"`
 	StoragePosition string            `json:"storage_positon"`
 	Height          common.JSONUint64 `json:"height"`
+	BlockHash       common.Hash       `json:"block_hash"` // alternative to height, query the state of the given block
 }
 
 type GetStorageAtResult struct {
@@ -1016,46 +1113,561 @@ func (t *PandoRPCService) GetStorageAt(args *GetStorageAtArgs, result *GetStorag
 	key := common.HexToHash(args.StoragePosition)
 	height := uint64(args.Height)
 
-	if height == 0 { // get the latest
-		var ledgerState *state.StoreView
+	var ledgerState *state.StoreView
+	if height == 0 && args.BlockHash.IsEmpty() { // get the latest
 		ledgerState, err = t.ledger.GetFinalizedSnapshot()
+	} else {
+		ledgerState, _, err = t.historicalStoreView(height, args.BlockHash)
+	}
+	if err != nil {
+		return err
+	}
+	value := ledgerState.GetState(address, key)
+	result.Value = hex.EncodeToString(value.Bytes())
+
+	return nil
+}
+
+// ------------------------------- GetProof -----------------------------------
+
+// maxProofStorageKeys is the maximum number of storage slots that can be proven in one GetProof call.
+const maxProofStorageKeys = 64
+
+type GetProofArgs struct {
+	Address     string            `json:"address"`
+	StorageKeys []string          `json:"storage_keys"`
+	Height      common.JSONUint64 `json:"height"`
+	BlockHash   common.Hash       `json:"block_hash"` // alternative to height, prove against the state of the given block
+}
+
+type StorageProofResult struct {
+	Key   string   `json:"key"`
+	Value string   `json:"value"`
+	Proof []string `json:"proof"` // hex encoded trie nodes from the storage root of the account
+}
+
+type GetProofResult struct {
+	BlockHash     common.Hash          `json:"block_hash"`
+	BlockHeight   common.JSONUint64    `json:"block_height"`
+	StateRoot     common.Hash          `json:"state_root"`
+	Address       string               `json:"address"`
+	Account       *types.Account       `json:"account"`       // nil if the proof shows the account does not exist
+	AccountProof  []string             `json:"account_proof"` // hex encoded trie nodes from the state root
+	StorageProofs []StorageProofResult `json:"storage_proofs"`
+}
+
+// GetProof returns the merkle proofs of an account and of some of its storage slots against the state
+// root of a block, so they can be checked with state.VerifyAccountProof and state.VerifyStorageProof.
+func (t *PandoRPCService) GetProof(args *GetProofArgs, result *GetProofResult) (err error) {
+	if args.Address == "" {
+		return errors.New("address must be specified")
+	}
+	if len(args.StorageKeys) > maxProofStorageKeys {
+		return fmt.Errorf("cannot prove more than %v storage keys at a time", maxProofStorageKeys)
+	}
+	address := common.HexToAddress(args.Address)
+	height := uint64(args.Height)
+	if height == 0 && args.BlockHash.IsEmpty() { // prove against the latest finalized block
+		height = t.consensus.GetLastFinalizedBlock().Height
+	}
+
+	ledgerState, block, err := t.historicalStoreView(height, args.BlockHash)
+	if err != nil {
+		return err
+	}
+
+	accountProof, err := ledgerState.ProveAccount(address)
+	if err != nil {
+		return err
+	}
+	result.BlockHash = block.Hash()
+	result.BlockHeight = common.JSONUint64(block.Height)
+	result.StateRoot = block.StateHash
+	result.Address = args.Address
+	result.Account = ledgerState.GetAccount(address)
+	result.AccountProof = encodeStateProof(accountProof)
+	result.StorageProofs = []StorageProofResult{}
+	for _, k := range args.StorageKeys {
+		key := common.HexToHash(k)
+		storageProof, err := ledgerState.ProveStorage(address, key)
 		if err != nil {
 			return err
 		}
-		value := ledgerState.GetState(address, key)
-		result.Value = hex.EncodeToString(value.Bytes())
+		result.StorageProofs = append(result.StorageProofs, StorageProofResult{
+			Key:   k,
+			Value: hex.EncodeToString(ledgerState.GetState(address, key).Bytes()),
+			Proof: encodeStateProof(storageProof),
+		})
+	}
+
+	return nil
+}
+
+func encodeStateProof(proof state.StateProof) []string {
+	nodes := make([]string, len(proof))
+	for i, node := range proof {
+		nodes[i] = hex.EncodeToString(node)
+	}
+	return nodes
+}
+
+// ------------------------------ GetBlockHeader -----------------------------------
+
+type GetBlockHeaderArgs struct {
+	Height common.JSONUint64 `json:"height"`
+}
+
+type GetBlockHeaderResult struct {
+	BlockHash common.Hash `json:"block_hash"`
+	Header    string      `json:"header"` // hex encoded RLP of the header
+}
+
+// GetBlockHeader returns the header of the finalized block at the given height, RLP encoded so
+// that a light client can recompute its hash.
+func (t *PandoRPCService) GetBlockHeader(args *GetBlockHeaderArgs, result *GetBlockHeaderResult) (err error) {
+	provider, err := t.lightProvider()
+	if err != nil {
+		return err
+	}
+	header, err := provider.Header(uint64(args.Height))
+	if err != nil {
+		return err
+	}
+	raw, err := rlp.EncodeToBytes(header)
+	if err != nil {
+		return err
+	}
+	result.BlockHash = header.Hash()
+	result.Header = hex.EncodeToString(raw)
+	return nil
+}
+
+// ------------------------------ GetCommitCertificate -----------------------------------
+
+type GetCommitCertificateArgs struct {
+	BlockHash common.Hash `json:"block_hash"`
+}
+
+type GetCommitCertificateResult struct {
+	CommitCertificate string `json:"commit_certificate"` // hex encoded RLP of the certificate
+}
+
+// GetCommitCertificate returns a commit certificate of the block, taken from the HCC of one of
+// its children.
+func (t *PandoRPCService) GetCommitCertificate(args *GetCommitCertificateArgs, result *GetCommitCertificateResult) (err error) {
+	provider, err := t.lightProvider()
+	if err != nil {
+		return err
+	}
+	cc, err := provider.CommitCertificate(args.BlockHash)
+	if err != nil {
+		return err
+	}
+	raw, err := rlp.EncodeToBytes(cc)
+	if err != nil {
+		return err
+	}
+	result.CommitCertificate = hex.EncodeToString(raw)
+	return nil
+}
+
+// ------------------------------ GetVcpProof -----------------------------------
+
+type GetVcpProofArgs struct {
+	BlockHash common.Hash `json:"block_hash"`
+}
+
+type GetVcpProofResult struct {
+	StateRoot common.Hash `json:"state_root"`
+	Proof     string      `json:"proof"` // hex encoded RLP of the core.VCPProof
+}
+
+// GetVcpProof returns the merkle proof of the validator candidate pool against the state root of
+// the block, so that a light client can prove the validator set.
+func (t *PandoRPCService) GetVcpProof(args *GetVcpProofArgs, result *GetVcpProofResult) (err error) {
+	provider, err := t.lightProvider()
+	if err != nil {
+		return err
+	}
+	block, err := t.chain.FindBlock(args.BlockHash)
+	if err != nil {
+		return fmt.Errorf("Block %v is not found", args.BlockHash.Hex())
+	}
+	proof, err := provider.VCPProof(args.BlockHash)
+	if err != nil {
+		return err
+	}
+	raw, err := rlp.EncodeToBytes(proof)
+	if err != nil {
+		return err
+	}
+	result.StateRoot = block.StateHash
+	result.Proof = hex.EncodeToString(raw)
+	return nil
+}
+
+// lightProvider returns the data provider of the light clients, reading the states from the
+// delivered state database.
+func (t *PandoRPCService) lightProvider() (*lightclient.Provider, error) {
+	deliveredView, err := t.ledger.GetDeliveredSnapshot()
+	if err != nil {
+		return nil, err
+	}
+	return lightclient.NewProvider(t.chain, t.consensus, deliveredView.GetDB()), nil
+}
+
+// ------------------------------ GetValidatorLiveness -----------------------------------
+
+type GetValidatorLivenessArgs struct {
+	Address string `json:"address"` // optional, all the current validators if not specified
+}
+
+type ValidatorLivenessInfo struct {
+	Address            string              `json:"address"`
+	Uptime             string              `json:"uptime"` // fraction of the blocks of the window voted for
+	SignedBlocks       common.JSONUint64   `json:"signed_blocks"`
+	MissedBlocks       common.JSONUint64   `json:"missed_blocks"`
+	ProposedSlots      common.JSONUint64   `json:"proposed_slots"`
+	MissedSlots        common.JSONUint64   `json:"missed_slots"`
+	LastSignedHeight   common.JSONUint64   `json:"last_signed_height"`
+	LastProposedHeight common.JSONUint64   `json:"last_proposed_height"`
+	MissedBlockHeights []common.JSONUint64 `json:"missed_block_heights"`
+}
+
+// JailIntentInfo is a local, advisory jail intent. It is not recorded on-chain.
+type JailIntentInfo struct {
+	Address string            `json:"address"`
+	Height  common.JSONUint64 `json:"height"`
+	Uptime  string            `json:"uptime"`
+}
+
+type GetValidatorLivenessResult struct {
+	Window             common.JSONUint64        `json:"window"`
+	LastRecordedHeight common.JSONUint64        `json:"last_recorded_height"`
+	Validators         []*ValidatorLivenessInfo `json:"validators"`
+	JailIntents        []*JailIntentInfo        `json:"jail_intents"`
+}
+
+// GetValidatorLiveness reports the liveness of the validators, as seen by the queried node. The
+// records are built from the votes the node received, so different nodes may report different
+// values. The jail intents are advisory: they are kept locally by the node and are neither
+// recorded on-chain nor enforced by the protocol.
+func (t *PandoRPCService) GetValidatorLiveness(args *GetValidatorLivenessArgs, result *GetValidatorLivenessResult) (err error) {
+	addresses := []common.Address{}
+	if args.Address != "" {
+		addresses = append(addresses, common.HexToAddress(args.Address))
 	} else {
-		blocks := t.chain.FindBlocksByHeight(height)
-		if len(blocks) == 0 {
-			result.Value = ""
-			return nil
+		lfb := t.consensus.GetLastFinalizedBlock()
+		for _, v := range t.consensus.GetValidatorManager().GetValidatorSet(lfb.Hash()).Validators() {
+			addresses = append(addresses, v.Address)
+		}
+	}
+
+	result.Window = common.JSONUint64(blockchain.LivenessWindow)
+	if next := t.chain.LivenessNextHeight(); next > 0 {
+		result.LastRecordedHeight = common.JSONUint64(next - 1)
+	}
+
+	result.Validators = []*ValidatorLivenessInfo{}
+	for _, address := range addresses {
+		liveness := t.chain.GetValidatorLiveness(address)
+		info := &ValidatorLivenessInfo{
+			Address:            address.Hex(),
+			Uptime:             strconv.FormatFloat(liveness.Uptime(), 'f', 4, 64),
+			SignedBlocks:       common.JSONUint64(liveness.SignedBlocks),
+			MissedBlocks:       common.JSONUint64(liveness.MissedBlocks),
+			ProposedSlots:      common.JSONUint64(liveness.ProposedSlots),
+			MissedSlots:        common.JSONUint64(liveness.MissedSlots),
+			LastSignedHeight:   common.JSONUint64(liveness.LastSignedHeight),
+			LastProposedHeight: common.JSONUint64(liveness.LastProposedHeight),
+			MissedBlockHeights: []common.JSONUint64{},
+		}
+		for _, height := range liveness.MissedBlockHeights {
+			info.MissedBlockHeights = append(info.MissedBlockHeights, common.JSONUint64(height))
+		}
+		result.Validators = append(result.Validators, info)
+	}
+
+	result.JailIntents = []*JailIntentInfo{}
+	for _, intent := range t.chain.GetJailIntents() {
+		if args.Address != "" && intent.Address != addresses[0] {
+			continue
+		}
+		result.JailIntents = append(result.JailIntents, &JailIntentInfo{
+			Address: intent.Address.Hex(),
+			Height:  common.JSONUint64(intent.Height),
+			Uptime:  strconv.FormatFloat(float64(intent.UptimeBasisPoint)/10000, 'f', 4, 64),
+		})
+	}
+
+	return nil
+}
+
+// ------------------------------ GetGovernanceParams -----------------------------------
+
+type GetGovernanceParamsArgs struct{}
+
+type GetGovernanceParamsResult struct {
+	BlockHeight common.JSONUint64          `json:"block_height"` // the latest finalized block
+	Params      *core.GovernanceParams     `json:"params"`       // in effect for the next block
+	Pending     []*core.GovernanceProposal `json:"pending"`      // waiting for their activation height
+}
+
+func (t *PandoRPCService) GetGovernanceParams(args *GetGovernanceParamsArgs, result *GetGovernanceParamsResult) (err error) {
+	finalizedView, err := t.ledger.GetFinalizedSnapshot()
+	if err != nil {
+		return err
+	}
+
+	result.BlockHeight = common.JSONUint64(finalizedView.Height())
+	result.Params = finalizedView.GetGovernanceParams()
+	result.Pending = []*core.GovernanceProposal{}
+	for _, proposalID := range finalizedView.GetPendingGovernanceProposals() {
+		if proposal := finalizedView.GetGovernanceProposal(proposalID); proposal != nil {
+			result.Pending = append(result.Pending, proposal)
 		}
+	}
+
+	return nil
+}
+
+// ------------------------------ PreviewValidatorSet -----------------------------------
+
+type PreviewValidatorSetArgs struct {
+	Deposits    []StakeDepositPreview    `json:"deposits"`
+	Withdrawals []StakeWithdrawalPreview `json:"withdrawals"`
+}
+
+type StakeDepositPreview struct {
+	Source string          `json:"source"`
+	Holder string          `json:"holder"`
+	Amount *common.JSONBig `json:"amount"` // in PandoWei
+}
 
-		deliveredView, err := t.ledger.GetDeliveredSnapshot()
+type StakeWithdrawalPreview struct {
+	Source string `json:"source"`
+	Holder string `json:"holder"`
+}
+
+type PreviewValidator struct {
+	Address string          `json:"address"`
+	Stake   *common.JSONBig `json:"stake"`
+}
+
+type PreviewValidatorSetResult struct {
+	BlockHeight     common.JSONUint64   `json:"block_height"` // the latest finalized block the preview is based on
+	Validators      []*PreviewValidator `json:"validators"`
+	Joined          []string            `json:"joined"`
+	Left            []string            `json:"left"`
+	InclusionHeight common.JSONUint64   `json:"inclusion_height"` // earliest block to include the stake transactions
+	EffectiveHeight common.JSONUint64   `json:"effective_height"` // first block validated by the resulting validator set
+}
+
+// PreviewValidatorSet applies hypothetical stake deposits and withdrawals to a copy of the validator
+// candidate pool of the latest finalized block, and returns the resulting validator set. The stake
+// transactions are only included when the proposer is not behind the majority of the validators.
+// Once included, they take effect two blocks later, since the validator set of a block is selected
+// from the state of the block certified by its parent. Unlike the guardian pool, which is only
+// updated at checkpoints, the validator set does not wait for the next checkpoint.
+func (t *PandoRPCService) PreviewValidatorSet(args *PreviewValidatorSetArgs, result *PreviewValidatorSetResult) (err error) {
+	finalizedView, err := t.ledger.GetFinalizedSnapshot()
+	if err != nil {
+		return err
+	}
+	vcp := finalizedView.GetValidatorCandidatePool()
+	if vcp == nil {
+		return errors.New("Failed to retrieve the validator candidate pool")
+	}
+
+	tip := t.consensus.GetTipToExtend()
+	inclusionHeight := tip.Height + 1
+	if !t.consensus.ShouldIncludeValidatorUpdateTxs(tip) {
+		// The proposer of the next block lags behind the majority of the validators.
+		inclusionHeight++
+	}
+
+	minValidatorStake := finalizedView.GetGovernanceParams().MinValidatorStakeDeposit
+	preview := vcp.Copy()
+	for _, deposit := range args.Deposits {
+		if deposit.Amount == nil {
+			return fmt.Errorf("Amount of the deposit from %v must be specified", deposit.Source)
+		}
+		err = preview.DepositStakeWithMinimum(common.HexToAddress(deposit.Source), common.HexToAddress(deposit.Holder),
+			(*big.Int)(deposit.Amount), minValidatorStake)
 		if err != nil {
-			return err
+			return fmt.Errorf("Failed to deposit stake from %v to %v: %v", deposit.Source, deposit.Holder, err)
 		}
-		db := deliveredView.GetDB()
+	}
+	for _, withdrawal := range args.Withdrawals {
+		err = preview.WithdrawStake(common.HexToAddress(withdrawal.Source), common.HexToAddress(withdrawal.Holder), inclusionHeight)
+		if err != nil {
+			return fmt.Errorf("Failed to withdraw stake from %v: %v", withdrawal.Holder, err)
+		}
+	}
 
-		for _, b := range blocks {
-			if b.Status.IsFinalized() {
-				stateRoot := b.StateHash
-				ledgerState := state.NewStoreView(height, stateRoot, db)
-				if ledgerState == nil { // might have been pruned
-					return fmt.Errorf("the account details for height %v is not available, it might have been pruned", height)
-				}
-				value := ledgerState.GetState(address, key)
-				result.Value = hex.EncodeToString(value.Bytes())
-				break
-			}
+	current := consensus.SelectTopStakeHoldersAsValidators(vcp)
+	next := consensus.SelectTopStakeHoldersAsValidators(preview)
+
+	result.BlockHeight = common.JSONUint64(finalizedView.Height())
+	result.InclusionHeight = common.JSONUint64(inclusionHeight)
+	result.EffectiveHeight = common.JSONUint64(inclusionHeight + 2)
+	result.Validators = []*PreviewValidator{}
+	result.Joined = []string{}
+	result.Left = []string{}
+	for _, v := range next.Validators() {
+		result.Validators = append(result.Validators, &PreviewValidator{
+			Address: v.Address.Hex(),
+			Stake:   (*common.JSONBig)(v.Stake),
+		})
+		if _, err := current.GetValidator(v.Address); err != nil {
+			result.Joined = append(result.Joined, v.Address.Hex())
+		}
+	}
+	for _, v := range current.Validators() {
+		if _, err := next.GetValidator(v.Address); err != nil {
+			result.Left = append(result.Left, v.Address.Hex())
 		}
 	}
 
 	return nil
 }
 
+// ------------------------------ GetDelegatorRewards -----------------------------------
+
+type GetDelegatorRewardsArgs struct {
+	Address string `json:"address"` // the stake source
+}
+
+type DelegatorRewardResult struct {
+	Holder  string          `json:"holder"`
+	Stake   *common.JSONBig `json:"stake"`   // in PandoWei
+	Pending *common.JSONBig `json:"pending"` // in PTXWei
+}
+
+type GetDelegatorRewardsResult struct {
+	BlockHeight common.JSONUint64        `json:"block_height"` // the latest finalized block
+	Rewards     []*DelegatorRewardResult `json:"rewards"`
+	Commission  *common.JSONBig          `json:"commission"` // unclaimed commission, if the address is a validator
+	Total       *common.JSONBig          `json:"total"`
+}
+
+// GetDelegatorRewards returns the staking rewards of the address which can be claimed with the
+// ClaimStakeRewardTx, per validator it delegates to, as of the latest finalized block.
+func (t *PandoRPCService) GetDelegatorRewards(args *GetDelegatorRewardsArgs, result *GetDelegatorRewardsResult) (err error) {
+	if args.Address == "" {
+		return errors.New("Address must be specified")
+	}
+	source := common.HexToAddress(args.Address)
+
+	finalizedView, err := t.ledger.GetFinalizedSnapshot()
+	if err != nil {
+		return err
+	}
+	vcp := finalizedView.GetValidatorCandidatePool()
+	if vcp == nil {
+		return errors.New("Failed to retrieve the validator candidate pool")
+	}
+
+	// The delegators may have rewards left after withdrawing their stakes
+	holders := []common.Address{}
+	seen := make(map[common.Address]bool)
+	for _, candidate := range vcp.SortedCandidates {
+		if candidate.SourceStake(source).Sign() > 0 {
+			holders = append(holders, candidate.Holder)
+			seen[candidate.Holder] = true
+		}
+	}
+	for _, delegatorReward := range finalizedView.GetDelegatorRewards(source) {
+		if !seen[delegatorReward.Holder] {
+			holders = append(holders, delegatorReward.Holder)
+			seen[delegatorReward.Holder] = true
+		}
+	}
+
+	result.BlockHeight = common.JSONUint64(finalizedView.Height())
+	result.Rewards = []*DelegatorRewardResult{}
+	result.Commission = (*common.JSONBig)(big.NewInt(0))
+	total := big.NewInt(0)
+	for _, holder := range holders {
+		reward, commission := execution.GetPendingStakeReward(finalizedView, source, holder)
+		stake := big.NewInt(0)
+		if candidate := vcp.FindStakeDelegate(holder); candidate != nil {
+			stake = candidate.SourceStake(source)
+		}
+		result.Rewards = append(result.Rewards, &DelegatorRewardResult{
+			Holder:  holder.Hex(),
+			Stake:   (*common.JSONBig)(stake),
+			Pending: (*common.JSONBig)(reward),
+		})
+		total.Add(total, reward)
+		if source == holder {
+			result.Commission = (*common.JSONBig)(commission)
+			total.Add(total, commission)
+		}
+	}
+	if !seen[source] {
+		// a validator may have commission left without any stake of its own
+		_, commission := execution.GetPendingStakeReward(finalizedView, source, source)
+		result.Commission = (*common.JSONBig)(commission)
+		total.Add(total, commission)
+	}
+	result.Total = (*common.JSONBig)(total)
+
+	return nil
+}
+
 // ------------------------------ Utils ------------------------------
 
+// StatePrunedError is returned when the state of the requested block has already been pruned.
+type StatePrunedError struct {
+	Height    uint64
+	BlockHash common.Hash
+}
+
+func (e *StatePrunedError) Error() string {
+	return fmt.Sprintf("state pruned: the state of block %v at height %v is no longer available", e.BlockHash.Hex(), e.Height)
+}
+
+// historicalStoreView opens a StoreView at the state root of the block with the given hash or, if the
+// hash is empty, of the finalized block at the given height. It returns a StatePrunedError if the
+// state root has been pruned.
+func (t *PandoRPCService) historicalStoreView(height uint64, blockHash common.Hash) (*state.StoreView, *core.ExtendedBlock, error) {
+	var block *core.ExtendedBlock
+	if !blockHash.IsEmpty() {
+		b, err := t.chain.FindBlock(blockHash)
+		if err != nil {
+			return nil, nil, fmt.Errorf("Block %v is not found", blockHash.Hex())
+		}
+		if height != 0 && b.Height != height {
+			return nil, nil, fmt.Errorf("Block %v is at height %v instead of %v", blockHash.Hex(), b.Height, height)
+		}
+		if !b.Status.IsValid() {
+			return nil, nil, fmt.Errorf("Block %v has not been processed, status: %v", blockHash.Hex(), b.Status)
+		}
+		block = b
+	} else {
+		for _, b := range t.chain.FindBlocksByHeight(height) {
+			if b.Status.IsFinalized() {
+				block = b
+				break
+			}
+		}
+		if block == nil {
+			return nil, nil, fmt.Errorf("Finalized block at height %v is not found", height)
+		}
+	}
+
+	deliveredView, err := t.ledger.GetDeliveredSnapshot()
+	if err != nil {
+		return nil, nil, err
+	}
+	ledgerState := state.NewStoreView(block.Height, block.StateHash, deliveredView.GetDB())
+	if ledgerState == nil {
+		return nil, nil, &StatePrunedError{Height: block.Height, BlockHash: block.Hash()}
+	}
+	return ledgerState, block, nil
+}
+
 func (t *PandoRPCService) gatherTxs(block *core.ExtendedBlock, txs *[]interface{}, includeEthTxHashes bool) error {
 	// Parse and fulfill Txs.
 	//var tx types.Tx
@@ -1131,6 +1743,16 @@ func getTxType(tx types.Tx) byte {
 		t = TxTypeDepositStakeTxV2
 	case *types.StakeRewardDistributionTx:
 		t = TxTypeStakeRewardDistributionTx
+	case *types.EquivocationEvidenceTx:
+		t = TxTypeEquivocationEvidenceTx
+	case *types.GovernanceProposalTx:
+		t = TxTypeGovernanceProposalTx
+	case *types.GovernanceVoteTx:
+		t = TxTypeGovernanceVoteTx
+	case *types.ValidatorCommissionTx:
+		t = TxTypeValidatorCommissionTx
+	case *types.ClaimStakeRewardTx:
+		t = TxTypeClaimStakeRewardTx
 	}
 
 	return t
@@ -0,0 +1,67 @@
+package rpc
+
+import (
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/pandoprojects/pando/common"
+	"github.com/pandoprojects/pando/common/util"
+	"github.com/pandoprojects/pando/consensus/simulation"
+	"github.com/pandoprojects/pando/core"
+)
+
+func TestPreviewValidatorSet(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	viper.Set(common.CfgLogLevels, "*:error")
+	util.InitLog()
+
+	sim, err := simulation.New(simulation.Config{
+		Seed:          1,
+		NumValidators: 4,
+		MinDelay:      10 * time.Millisecond,
+		MaxDelay:      100 * time.Millisecond,
+	})
+	require.Nil(err)
+	defer sim.Stop()
+	require.True(sim.RunUntilFinalized(3, 10*time.Minute))
+
+	node := sim.Nodes()[0]
+	svc := &PandoRPCService{ledger: node.Ledger, consensus: node.Consensus, chain: node.Chain}
+	validator := node.PrivateKey.PublicKey().Address()
+	newcomer := common.HexToAddress("0x1234")
+
+	args := &PreviewValidatorSetArgs{
+		Deposits: []StakeDepositPreview{{
+			Source: newcomer.Hex(),
+			Holder: newcomer.Hex(),
+			Amount: (*common.JSONBig)(new(big.Int).Set(core.MinValidatorStakeDeposit)),
+		}},
+		Withdrawals: []StakeWithdrawalPreview{{Source: validator.Hex(), Holder: validator.Hex()}},
+	}
+	result := &PreviewValidatorSetResult{}
+	require.Nil(svc.PreviewValidatorSet(args, result))
+
+	assert.Equal(4, len(result.Validators))
+	assert.Equal([]string{newcomer.Hex()}, result.Joined)
+	assert.Equal([]string{validator.Hex()}, result.Left)
+	tip := node.Consensus.GetTipToExtend()
+	assert.True(uint64(result.InclusionHeight) > tip.Height)
+	assert.Equal(uint64(result.InclusionHeight)+2, uint64(result.EffectiveHeight))
+
+	// The preview leaves the validator candidate pool untouched.
+	view, err := node.Ledger.GetFinalizedSnapshot()
+	require.Nil(err)
+	assert.Nil(view.GetValidatorCandidatePool().FindStakeDelegate(newcomer))
+	assert.Equal(core.MinValidatorStakeDeposit.String(), view.GetValidatorCandidatePool().FindStakeDelegate(validator).TotalStake().String())
+
+	// Deposits below the minimum stake are rejected.
+	args.Deposits[0].Amount = (*common.JSONBig)(big.NewInt(1))
+	assert.NotNil(svc.PreviewValidatorSet(args, &PreviewValidatorSetResult{}))
+}
@@ -0,0 +1,151 @@
+package rpc
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/pandoprojects/pando/rpc/lib/rpc-codec/jsonrpc2"
+)
+
+// rateLimitCode is the JSON-RPC error code of the calls rejected by the rate limiter.
+const rateLimitCode = -32005
+
+// bucketIdleTimeout is how long the bucket of a client is kept after its last call.
+const bucketIdleTimeout = 10 * time.Minute
+
+// RateLimiter limits the rate of the RPC calls of each client IP. Each call takes the cost of
+// its method from the token bucket of the client, and the buckets refill at a constant rate.
+// Cheap methods like GetStatus can thus be called much more often than expensive ones like
+// GetBlocksByRange, while a single client cannot exhaust the node.
+type RateLimiter struct {
+	mu sync.Mutex
+
+	rate        float64            // tokens added to a bucket per second
+	burst       float64            // capacity of a bucket
+	defaultCost float64            // cost of the methods not in costs
+	costs       map[string]float64 // cost by lower case "service.method"
+
+	buckets   map[string]*tokenBucket
+	lastSweep time.Time
+	now       func() time.Time
+}
+
+type tokenBucket struct {
+	tokens  float64
+	updated time.Time
+}
+
+// NewRateLimiter creates a new rate limiter. The method costs are given in the "method:cost"
+// form, separated by commas, e.g. "pando.GetStatus:1,eth_call:10". Both the "pando.GetStatus"
+// and the "eth_getBalance" spellings are accepted.
+func NewRateLimiter(ratePerSecond float64, burst float64, defaultCost float64, methodCosts string) (*RateLimiter, error) {
+	if ratePerSecond <= 0 || burst <= 0 {
+		return nil, fmt.Errorf("invalid rate limit: %v per second, burst %v", ratePerSecond, burst)
+	}
+	rl := &RateLimiter{
+		rate:        ratePerSecond,
+		burst:       burst,
+		defaultCost: defaultCost,
+		costs:       make(map[string]float64),
+		buckets:     make(map[string]*tokenBucket),
+		now:         time.Now,
+	}
+	for _, entry := range strings.Split(methodCosts, ",") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+		idx := strings.LastIndex(entry, ":")
+		if idx <= 0 {
+			return nil, fmt.Errorf("invalid method cost: %v", entry)
+		}
+		cost, err := strconv.ParseFloat(entry[idx+1:], 64)
+		if err != nil || cost < 0 {
+			return nil, fmt.Errorf("invalid method cost: %v", entry)
+		}
+		rl.costs[strings.ToLower(ethMethodMapper(entry[:idx]))] = cost
+	}
+	return rl, nil
+}
+
+// Allow takes the cost of the method from the bucket of the client, and returns an error
+// if the bucket does not hold enough tokens.
+func (rl *RateLimiter) Allow(client string, method string) error {
+	cost, ok := rl.costs[strings.ToLower(method)]
+	if !ok {
+		cost = rl.defaultCost
+	}
+
+	rl.mu.Lock()
+	defer rl.mu.Unlock()
+
+	now := rl.now()
+	if now.Sub(rl.lastSweep) > bucketIdleTimeout {
+		for key, bucket := range rl.buckets {
+			if now.Sub(bucket.updated) > bucketIdleTimeout {
+				delete(rl.buckets, key)
+			}
+		}
+		rl.lastSweep = now
+	}
+
+	bucket, ok := rl.buckets[client]
+	if !ok {
+		bucket = &tokenBucket{tokens: rl.burst, updated: now}
+		rl.buckets[client] = bucket
+	}
+	bucket.tokens += now.Sub(bucket.updated).Seconds() * rl.rate
+	if bucket.tokens > rl.burst {
+		bucket.tokens = rl.burst
+	}
+	bucket.updated = now
+
+	if cost > bucket.tokens {
+		wait := time.Duration((cost - bucket.tokens) / rl.rate * float64(time.Second))
+		return jsonrpc2.NewError(rateLimitCode, fmt.Sprintf("rate limit exceeded for %v, retry in %v", method, wait.Round(time.Millisecond)))
+	}
+	bucket.tokens -= cost
+	return nil
+}
+
+// Limiter returns the jsonrpc2.Limiter which applies the rate limits to the client IP of
+// the HTTP request or websocket connection the call came from.
+func (rl *RateLimiter) Limiter() jsonrpc2.Limiter {
+	return func(ctx context.Context, method string) error {
+		return rl.Allow(clientIPFromContext(ctx), method)
+	}
+}
+
+type clientIPKey struct{}
+
+// withClientIP records the client IP of the request in the context.
+func withClientIP(ctx context.Context, r *http.Request) context.Context {
+	return context.WithValue(ctx, clientIPKey{}, clientIP(r))
+}
+
+func clientIPFromContext(ctx context.Context) string {
+	if ip, ok := ctx.Value(clientIPKey{}).(string); ok {
+		return ip
+	}
+	if r := jsonrpc2.HTTPRequestFromContext(ctx); r != nil {
+		return clientIP(r)
+	}
+	return ""
+}
+
+func clientIP(r *http.Request) string {
+	if r == nil {
+		return ""
+	}
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		return r.RemoteAddr
+	}
+	return host
+}
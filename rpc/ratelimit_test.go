@@ -0,0 +1,50 @@
+package rpc
+
+import (
+	"testing"
+	"time"
+
+	"github.com/pandoprojects/pando/rpc/lib/rpc-codec/jsonrpc2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRateLimiter(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	_, err := NewRateLimiter(10, 20, 1, "pando.GetStatus")
+	assert.NotNil(err)
+	_, err = NewRateLimiter(0, 20, 1, "")
+	assert.NotNil(err)
+
+	rl, err := NewRateLimiter(10, 20, 2, "pando.GetStatus:1, pando.GetBlocksByRange:20,eth_call:10")
+	require.Nil(err)
+	now := time.Unix(1600000000, 0)
+	rl.now = func() time.Time { return now }
+
+	// The expensive call takes the whole budget of the client
+	assert.Nil(rl.Allow("10.0.0.1", "pando.GetBlocksByRange"))
+	err = rl.Allow("10.0.0.1", "pando.GetStatus")
+	require.NotNil(err)
+	rpcErr, ok := err.(*jsonrpc2.Error)
+	require.True(ok)
+	assert.Equal(rateLimitCode, rpcErr.Code)
+
+	// Other clients are not affected
+	assert.Nil(rl.Allow("10.0.0.2", "pando.GetStatus"))
+
+	// The budget refills over time, the eth_ spelling and unlisted methods are priced too
+	now = now.Add(time.Second)
+	assert.Nil(rl.Allow("10.0.0.1", "eth.Call"))
+	assert.NotNil(rl.Allow("10.0.0.1", "pando.GetStatus"))
+	now = now.Add(300 * time.Millisecond)
+	assert.Nil(rl.Allow("10.0.0.1", "pando.GetAccount"))
+	assert.Nil(rl.Allow("10.0.0.1", "pando.GetStatus"))
+	assert.NotNil(rl.Allow("10.0.0.1", "pando.GetStatus"))
+
+	// Idle buckets are dropped
+	now = now.Add(2 * bucketIdleTimeout)
+	assert.Nil(rl.Allow("10.0.0.3", "pando.GetStatus"))
+	assert.Equal(1, len(rl.buckets))
+}
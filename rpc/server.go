@@ -27,9 +27,10 @@ import (
 	"github.com/pandoprojects/pando/rpc/lib/rpc-codec/jsonrpc2"
 	"golang.org/x/net/netutil"
 	"golang.org/x/net/websocket"
+	"google.golang.org/grpc"
 )
 
-var logger *log.Entry
+var logger *log.Entry = log.WithFields(log.Fields{"prefix": "rpc"})
 
 type PandoRPCService struct {
 	mempool    *mempool.Mempool
@@ -38,6 +39,9 @@ type PandoRPCService struct {
 	chain      *blockchain.Chain
 	consensus  *consensus.ConsensusEngine
 
+	subscriptions          *SubscriptionHub
+	finalizedBlockNotifier *blockNotifier
+
 	// Life cycle
 	wg      *sync.WaitGroup
 	ctx     context.Context
@@ -49,10 +53,11 @@ type PandoRPCService struct {
 type PandoRPCServer struct {
 	*PandoRPCService
 
-	server   *http.Server
-	handler  *rpc.Server
-	router   *mux.Router
-	listener net.Listener
+	server     *http.Server
+	handler    *rpc.Server
+	router     *mux.Router
+	listener   net.Listener
+	grpcServer *grpc.Server
 }
 
 // NewPandoRPCServer creates a new instance of PandoRPCServer.
@@ -69,23 +74,62 @@ func NewPandoRPCServer(mempool *mempool.Mempool, ledger *ledger.Ledger, dispatch
 	t.dispatcher = dispatcher
 	t.chain = chain
 	t.consensus = consensus
+	t.subscriptions = NewSubscriptionHub(viper.GetInt(common.CfgRPCWsMaxSubscriptions),
+		viper.GetInt(common.CfgRPCWsSubscriptionQueueSize))
+	t.finalizedBlockNotifier = newBlockNotifier()
 
 	s := rpc.NewServer()
 	s.RegisterName("pando", t.PandoRPCService)
 
+	// Optional Ethereum compatible namespaces, e.g. eth_getBalance is served by EthRPCService.GetBalance,
+	// and the debug namespace, e.g. debug_traceTransaction is served by DebugRPCService.TraceTransaction
+	var mapper jsonrpc2.MethodMapper
+	if viper.GetBool(common.CfgRPCEthEnabled) {
+		s.RegisterName("eth", &EthRPCService{svc: t.PandoRPCService})
+		s.RegisterName("net", &NetRPCService{svc: t.PandoRPCService})
+		s.RegisterName("web3", &Web3RPCService{svc: t.PandoRPCService})
+		mapper = ethMethodMapper
+	}
+	if viper.GetBool(common.CfgRPCDebugEnabled) {
+		s.RegisterName("debug", &DebugRPCService{svc: t.PandoRPCService})
+		mapper = ethMethodMapper
+	}
+
 	t.handler = s
 
+	// Batch size and per client IP rate limits, applied to each call of a batch as well
+	opts := jsonrpc2.ServerOptions{
+		Mapper:       mapper,
+		MaxBatchSize: viper.GetInt(common.CfgRPCMaxBatchSize),
+	}
+	var rl *RateLimiter
+	if viper.GetBool(common.CfgRPCRateLimitEnabled) {
+		var err error
+		rl, err = NewRateLimiter(viper.GetFloat64(common.CfgRPCRateLimitPerSecond), viper.GetFloat64(common.CfgRPCRateLimitBurst),
+			viper.GetFloat64(common.CfgRPCRateLimitDefaultCost), viper.GetString(common.CfgRPCRateLimitMethodCosts))
+		if err != nil {
+			logger.Fatalf("Invalid RPC rate limit config: %v", err)
+		}
+		opts.Limiter = rl.Limiter()
+	}
+
 	t.router = mux.NewRouter()
 	t.router.Handle("/", &defaultHTTPHandler{})
-	t.router.Handle("/rpc", corsMiddleware(TimeoutHandler(jsonrpc2.HTTPHandler(s), viper.GetDuration(common.CfgRPCTimeoutSecs)*time.Second, "")))
+	t.router.Handle("/rpc", corsMiddleware(TimeoutHandler(jsonrpc2.HTTPHandlerWithOptions(s, opts), viper.GetDuration(common.CfgRPCTimeoutSecs)*time.Second, "")))
 	t.router.Handle("/ws", websocket.Handler(func(ws *websocket.Conn) {
-		s.ServeCodec(jsonrpc2.NewServerCodec(ws, s))
+		t.serveWebsocket(ws, func(ctx context.Context) {
+			s.ServeCodec(jsonrpc2.NewServerCodecWithOptions(withClientIP(ctx, ws.Request()), ws, s, opts))
+		})
 	}))
 
 	t.server = &http.Server{
 		Handler: t.router,
 	}
 
+	if viper.GetBool(common.CfgRPCGrpcEnabled) {
+		t.grpcServer = NewGrpcServer(t.PandoRPCService, rl)
+	}
+
 	logger = util.GetLoggerForModule("rpc")
 
 	return t
@@ -102,16 +146,25 @@ func (t *PandoRPCServer) Start(ctx context.Context) {
 
 	t.wg.Add(1)
 	go t.txCallback()
+
+	t.wg.Add(1)
+	go t.subscriptionLoop()
 }
 
 func (t *PandoRPCServer) mainLoop() {
 	defer t.wg.Done()
 
 	go t.serve()
+	if t.grpcServer != nil {
+		go t.serveGrpc()
+	}
 
 	<-t.ctx.Done()
 	t.stopped = true
 	t.server.Shutdown(t.ctx)
+	if t.grpcServer != nil {
+		t.grpcServer.Stop()
+	}
 }
 
 func (t *PandoRPCServer) serve() {
@@ -131,6 +184,21 @@ func (t *PandoRPCServer) serve() {
 	logger.Info(t.server.Serve(ll))
 }
 
+func (t *PandoRPCServer) serveGrpc() {
+	address := viper.GetString(common.CfgRPCAddress)
+	port := viper.GetString(common.CfgRPCGrpcPort)
+	l, err := net.Listen("tcp", address+":"+port)
+	if err != nil {
+		logger.WithFields(log.Fields{"error": err}).Fatal("Failed to create gRPC listener")
+	} else {
+		logger.WithFields(log.Fields{"address": address, "port": port}).Info("gRPC server started")
+	}
+	defer l.Close()
+
+	ll := netutil.LimitListener(l, viper.GetInt(common.CfgRPCMaxConnections))
+	logger.Info(t.grpcServer.Serve(ll))
+}
+
 func corsMiddleware(handler http.Handler) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		//Allow CORS here By * or specific origin
@@ -0,0 +1,449 @@
+package rpc
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"sync"
+
+	"github.com/pandoprojects/pando/common"
+	"github.com/pandoprojects/pando/common/hexutil"
+	"github.com/pandoprojects/pando/core"
+	"github.com/pandoprojects/pando/crypto"
+	"github.com/pandoprojects/pando/rpc/lib/rpc-codec/jsonrpc2"
+)
+
+// Subscription topics
+const (
+	SubscriptionTopicNewHeads        = "newHeads"        // blocks validated and added to the chain
+	SubscriptionTopicFinalizedBlocks = "finalizedBlocks" // finalized blocks
+	SubscriptionTopicPendingTxs      = "pendingTxs"      // hashes of the transactions inserted into the mempool
+	SubscriptionTopicLogs            = "logs"            // smart contract logs of the finalized blocks
+)
+
+// subscriptionNotificationMethod is the method name of the notifications pushed to the subscribers.
+const subscriptionNotificationMethod = "pando_subscription"
+
+var (
+	ErrSubscriptionNotSupported = errors.New("subscriptions are only supported over websocket connections")
+	ErrSubscriptionTopicUnknown = errors.New("unknown subscription topic")
+	ErrSubscriptionLimit        = errors.New("too many subscriptions on this connection")
+	ErrSubscriptionNotFound     = errors.New("subscription not found")
+)
+
+type wsConnectionKey struct{}
+
+type subscription struct {
+	id        string
+	topic     string
+	addresses []common.Address
+	topics    []ethTopicOptions
+}
+
+// SubscriptionHeader is the block header pushed to the newHeads and finalizedBlocks subscribers.
+type SubscriptionHeader struct {
+	ChainID   string            `json:"chain_id"`
+	Epoch     common.JSONUint64 `json:"epoch"`
+	Height    common.JSONUint64 `json:"height"`
+	Parent    common.Hash       `json:"parent"`
+	TxHash    common.Hash       `json:"transactions_hash"`
+	StateHash common.Hash       `json:"state_hash"`
+	Timestamp *common.JSONBig   `json:"timestamp"`
+	Proposer  common.Address    `json:"proposer"`
+	Hash      common.Hash       `json:"hash"`
+	Txs       []common.Hash     `json:"transactions"`
+	NumTxs    common.JSONUint64 `json:"num_txs"`
+}
+
+type subscriptionNotification struct {
+	Version string                         `json:"jsonrpc"`
+	Method  string                         `json:"method"`
+	Params  subscriptionNotificationParams `json:"params"`
+}
+
+type subscriptionNotificationParams struct {
+	Subscription string      `json:"subscription"`
+	Result       interface{} `json:"result"`
+}
+
+// SubscriptionHub keeps track of the websocket connections and their subscriptions,
+// and fans out the chain events to the subscribers.
+type SubscriptionHub struct {
+	mu    sync.RWMutex
+	conns map[*wsConnection]struct{}
+
+	maxSubscriptions int
+	queueSize        int
+}
+
+// NewSubscriptionHub creates a new instance of SubscriptionHub.
+func NewSubscriptionHub(maxSubscriptions, queueSize int) *SubscriptionHub {
+	if queueSize <= 0 {
+		queueSize = 1
+	}
+	return &SubscriptionHub{
+		conns:            make(map[*wsConnection]struct{}),
+		maxSubscriptions: maxSubscriptions,
+		queueSize:        queueSize,
+	}
+}
+
+// connect registers a websocket connection and starts delivering its notifications.
+func (h *SubscriptionHub) connect(conn io.WriteCloser) *wsConnection {
+	c := &wsConnection{
+		hub:   h,
+		conn:  conn,
+		queue: make(chan []byte, h.queueSize),
+		done:  make(chan struct{}),
+		subs:  make(map[string]*subscription),
+	}
+
+	h.mu.Lock()
+	h.conns[c] = struct{}{}
+	h.mu.Unlock()
+
+	go c.writeLoop()
+	return c
+}
+
+// disconnect drops the connection together with all of its subscriptions.
+func (h *SubscriptionHub) disconnect(c *wsConnection) {
+	h.mu.Lock()
+	delete(h.conns, c)
+	h.mu.Unlock()
+
+	c.close()
+}
+
+// hasSubscribers returns whether any connection subscribes to the given topic.
+func (h *SubscriptionHub) hasSubscribers(topic string) bool {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+
+	for c := range h.conns {
+		if c.hasTopic(topic) {
+			return true
+		}
+	}
+	return false
+}
+
+// publish pushes the result to all the subscribers of the topic.
+func (h *SubscriptionHub) publish(topic string, result interface{}) {
+	h.deliver(topic, func(sub *subscription) (interface{}, bool) {
+		return result, true
+	})
+}
+
+// publishLogs pushes each log to the logs subscribers whose filter it matches.
+func (h *SubscriptionHub) publishLogs(logs []*EthLog) {
+	for _, log := range logs {
+		h.deliver(SubscriptionTopicLogs, func(sub *subscription) (interface{}, bool) {
+			return log, ethLogMatches(log, sub.addresses, sub.topics)
+		})
+	}
+}
+
+func (h *SubscriptionHub) deliver(topic string, resultFor func(sub *subscription) (interface{}, bool)) {
+	h.mu.RLock()
+	conns := make([]*wsConnection, 0, len(h.conns))
+	for c := range h.conns {
+		conns = append(conns, c)
+	}
+	h.mu.RUnlock()
+
+	for _, c := range conns {
+		for _, sub := range c.subscriptionsOf(topic) {
+			result, ok := resultFor(sub)
+			if !ok {
+				continue
+			}
+			if !c.notify(sub.id, result) {
+				logger.Warnf("Closing websocket connection which fell behind its subscriptions")
+				h.disconnect(c)
+				break
+			}
+		}
+	}
+}
+
+// wsConnection is a websocket connection with subscriptions. Its notifications are
+// delivered in order through a bounded queue, so a slow client never blocks the
+// publishers. A client which lets the queue fill up is disconnected.
+type wsConnection struct {
+	hub   *SubscriptionHub
+	conn  io.WriteCloser
+	queue chan []byte
+	done  chan struct{}
+
+	mu     sync.Mutex
+	subs   map[string]*subscription
+	closed bool
+}
+
+func (c *wsConnection) writeLoop() {
+	for {
+		select {
+		case <-c.done:
+			return
+		case msg := <-c.queue:
+			if _, err := c.conn.Write(msg); err != nil {
+				logger.Debugf("Failed to write subscription notification: %v", err)
+				c.hub.disconnect(c)
+				return
+			}
+		}
+	}
+}
+
+func (c *wsConnection) subscribe(sub *subscription) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.closed {
+		return ErrSubscriptionNotFound
+	}
+	if c.hub.maxSubscriptions > 0 && len(c.subs) >= c.hub.maxSubscriptions {
+		return ErrSubscriptionLimit
+	}
+	c.subs[sub.id] = sub
+	return nil
+}
+
+func (c *wsConnection) unsubscribe(id string) bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	_, ok := c.subs[id]
+	delete(c.subs, id)
+	return ok
+}
+
+func (c *wsConnection) hasTopic(topic string) bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	for _, sub := range c.subs {
+		if sub.topic == topic {
+			return true
+		}
+	}
+	return false
+}
+
+func (c *wsConnection) subscriptionsOf(topic string) []*subscription {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	subs := []*subscription{}
+	for _, sub := range c.subs {
+		if sub.topic == topic {
+			subs = append(subs, sub)
+		}
+	}
+	return subs
+}
+
+// notify enqueues a notification, it returns false if the queue of the connection is full.
+func (c *wsConnection) notify(id string, result interface{}) bool {
+	msg, err := json.Marshal(subscriptionNotification{
+		Version: "2.0",
+		Method:  subscriptionNotificationMethod,
+		Params: subscriptionNotificationParams{
+			Subscription: id,
+			Result:       result,
+		},
+	})
+	if err != nil {
+		logger.Errorf("Failed to marshal subscription notification: %v", err)
+		return true
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.closed {
+		return true
+	}
+	select {
+	case c.queue <- msg:
+		return true
+	default:
+		return false
+	}
+}
+
+func (c *wsConnection) close() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.closed {
+		return
+	}
+	c.closed = true
+	c.subs = make(map[string]*subscription)
+	close(c.done)
+	c.conn.Close()
+}
+
+func newSubscriptionID() (string, error) {
+	id := make([]byte, 16)
+	if _, err := rand.Read(id); err != nil {
+		return "", err
+	}
+	return hexutil.Encode(id), nil
+}
+
+// ------------------------------- Subscribe -----------------------------------
+
+type SubscribeArgs struct {
+	jsonrpc2.Ctx
+
+	Topic   string            `json:"topic"`
+	Address ethAddressList    `json:"address"` // logs only, a single address or a list of addresses
+	Topics  []ethTopicOptions `json:"topics"`  // logs only, same semantic as the eth_getLogs topics
+}
+
+type SubscribeResult struct {
+	SubscriptionID string `json:"subscription_id"`
+}
+
+// Subscribe creates a subscription on the websocket connection of the request. The events are
+// pushed as "pando_subscription" notifications carrying the subscription id and the result.
+func (t *PandoRPCService) Subscribe(args *SubscribeArgs, result *SubscribeResult) (err error) {
+	c, ok := wsConnectionFromContext(args.Context())
+	if !ok {
+		return ErrSubscriptionNotSupported
+	}
+
+	switch args.Topic {
+	case SubscriptionTopicNewHeads, SubscriptionTopicFinalizedBlocks, SubscriptionTopicPendingTxs, SubscriptionTopicLogs:
+	default:
+		return fmt.Errorf("%v: %v", ErrSubscriptionTopicUnknown, args.Topic)
+	}
+
+	id, err := newSubscriptionID()
+	if err != nil {
+		return err
+	}
+	sub := &subscription{
+		id:    id,
+		topic: args.Topic,
+	}
+	if args.Topic == SubscriptionTopicLogs {
+		sub.addresses = args.Address
+		sub.topics = args.Topics
+	}
+	if err = c.subscribe(sub); err != nil {
+		return err
+	}
+
+	result.SubscriptionID = id
+	return nil
+}
+
+// ------------------------------- Unsubscribe -----------------------------------
+
+type UnsubscribeArgs struct {
+	jsonrpc2.Ctx
+
+	SubscriptionID string `json:"subscription_id"`
+}
+
+type UnsubscribeResult struct {
+	Success bool `json:"success"`
+}
+
+func (t *PandoRPCService) Unsubscribe(args *UnsubscribeArgs, result *UnsubscribeResult) (err error) {
+	c, ok := wsConnectionFromContext(args.Context())
+	if !ok {
+		return ErrSubscriptionNotSupported
+	}
+	if !c.unsubscribe(args.SubscriptionID) {
+		return ErrSubscriptionNotFound
+	}
+	result.Success = true
+	return nil
+}
+
+// ------------------------------ Event sources ------------------------------
+
+func wsConnectionFromContext(ctx context.Context) (*wsConnection, bool) {
+	if ctx == nil {
+		return nil, false
+	}
+	c, ok := ctx.Value(wsConnectionKey{}).(*wsConnection)
+	return c, ok
+}
+
+// serveWebsocket serves the JSON-RPC requests of a websocket connection, which can also hold subscriptions.
+func (t *PandoRPCService) serveWebsocket(conn io.ReadWriteCloser, serve func(ctx context.Context)) {
+	c := t.subscriptions.connect(conn)
+	defer t.subscriptions.disconnect(c)
+
+	serve(context.WithValue(context.Background(), wsConnectionKey{}, c))
+}
+
+// subscriptionLoop publishes the new heads and the pending transactions to the subscribers.
+func (t *PandoRPCService) subscriptionLoop() {
+	defer t.wg.Done()
+
+	for {
+		select {
+		case <-t.ctx.Done():
+			return
+		case block := <-t.consensus.ValidatedBlocks():
+			if t.subscriptions.hasSubscribers(SubscriptionTopicNewHeads) {
+				t.subscriptions.publish(SubscriptionTopicNewHeads, newSubscriptionHeader(block))
+			}
+		case rawTx := <-t.mempool.InsertedTransactions():
+			if t.subscriptions.hasSubscribers(SubscriptionTopicPendingTxs) {
+				t.subscriptions.publish(SubscriptionTopicPendingTxs, crypto.Keccak256Hash(rawTx))
+			}
+		}
+	}
+}
+
+// publishFinalizedBlock publishes the finalized block and its logs to the subscribers.
+func (t *PandoRPCService) publishFinalizedBlock(block *core.Block) {
+	if t.subscriptions.hasSubscribers(SubscriptionTopicFinalizedBlocks) {
+		t.subscriptions.publish(SubscriptionTopicFinalizedBlocks, newSubscriptionHeader(block))
+	}
+
+	if !t.subscriptions.hasSubscribers(SubscriptionTopicLogs) {
+		return
+	}
+	eb, err := t.chain.FindBlock(block.Hash())
+	if err != nil {
+		logger.Warnf("Failed to load finalized block for log subscribers, height=%v: %v", block.Height, err)
+		return
+	}
+	logs := []*EthLog{}
+	for _, receipt := range t.ethBlockReceipts(eb) {
+		logs = append(logs, receipt.Logs...)
+	}
+	t.subscriptions.publishLogs(logs)
+}
+
+func newSubscriptionHeader(block *core.Block) *SubscriptionHeader {
+	header := &SubscriptionHeader{
+		ChainID:   block.ChainID,
+		Epoch:     common.JSONUint64(block.Epoch),
+		Height:    common.JSONUint64(block.Height),
+		Parent:    block.Parent,
+		TxHash:    block.TxHash,
+		StateHash: block.StateHash,
+		Timestamp: (*common.JSONBig)(block.Timestamp),
+		Proposer:  block.Proposer,
+		Hash:      block.Hash(),
+		Txs:       make([]common.Hash, 0, len(block.Txs)),
+		NumTxs:    common.JSONUint64(len(block.Txs)),
+	}
+	for _, tx := range block.Txs {
+		header.Txs = append(header.Txs, crypto.Keccak256Hash(tx))
+	}
+	return header
+}
@@ -0,0 +1,138 @@
+package rpc
+
+import (
+	"context"
+	"encoding/json"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/pandoprojects/pando/common"
+	"github.com/stretchr/testify/assert"
+)
+
+type mockWsConn struct {
+	mu      sync.Mutex
+	msgs    [][]byte
+	blocked chan struct{}
+	closed  bool
+}
+
+func (m *mockWsConn) Write(p []byte) (int, error) {
+	if m.blocked != nil {
+		<-m.blocked
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.msgs = append(m.msgs, append([]byte{}, p...))
+	return len(p), nil
+}
+
+func (m *mockWsConn) Close() error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.closed = true
+	return nil
+}
+
+func (m *mockWsConn) messages() [][]byte {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.msgs
+}
+
+func (m *mockWsConn) isClosed() bool {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.closed
+}
+
+func subscribeForTest(svc *PandoRPCService, c *wsConnection, args *SubscribeArgs) (string, error) {
+	args.SetContext(context.WithValue(context.Background(), wsConnectionKey{}, c))
+	result := &SubscribeResult{}
+	err := svc.Subscribe(args, result)
+	return result.SubscriptionID, err
+}
+
+func TestSubscriptionPublish(t *testing.T) {
+	assert := assert.New(t)
+
+	svc := &PandoRPCService{subscriptions: NewSubscriptionHub(2, 16)}
+	conn := &mockWsConn{}
+	c := svc.subscriptions.connect(conn)
+	defer svc.subscriptions.disconnect(c)
+
+	headsID, err := subscribeForTest(svc, c, &SubscribeArgs{Topic: SubscriptionTopicNewHeads})
+	assert.Nil(err)
+	logsID, err := subscribeForTest(svc, c, &SubscribeArgs{
+		Topic:   SubscriptionTopicLogs,
+		Address: ethAddressList{common.HexToAddress("0x1")},
+	})
+	assert.Nil(err)
+	_, err = subscribeForTest(svc, c, &SubscribeArgs{Topic: SubscriptionTopicPendingTxs})
+	assert.Equal(ErrSubscriptionLimit, err)
+
+	assert.True(svc.subscriptions.hasSubscribers(SubscriptionTopicNewHeads))
+	assert.False(svc.subscriptions.hasSubscribers(SubscriptionTopicFinalizedBlocks))
+
+	svc.subscriptions.publish(SubscriptionTopicNewHeads, "head")
+	svc.subscriptions.publishLogs([]*EthLog{
+		{Address: common.HexToAddress("0x2")},
+		{Address: common.HexToAddress("0x1")},
+	})
+
+	assert.Eventually(func() bool { return len(conn.messages()) == 2 }, time.Second, 10*time.Millisecond)
+	notification := struct {
+		Method string `json:"method"`
+		Params struct {
+			Subscription string          `json:"subscription"`
+			Result       json.RawMessage `json:"result"`
+		} `json:"params"`
+	}{}
+	assert.Nil(json.Unmarshal(conn.messages()[0], &notification))
+	assert.Equal("pando_subscription", notification.Method)
+	assert.Equal(headsID, notification.Params.Subscription)
+	assert.Nil(json.Unmarshal(conn.messages()[1], &notification))
+	assert.Equal(logsID, notification.Params.Subscription)
+
+	unsubArgs := &UnsubscribeArgs{SubscriptionID: headsID}
+	unsubArgs.SetContext(context.WithValue(context.Background(), wsConnectionKey{}, c))
+	assert.Nil(svc.Unsubscribe(unsubArgs, &UnsubscribeResult{}))
+	assert.Equal(ErrSubscriptionNotFound, svc.Unsubscribe(unsubArgs, &UnsubscribeResult{}))
+	assert.False(svc.subscriptions.hasSubscribers(SubscriptionTopicNewHeads))
+}
+
+func TestSubscriptionRequiresWebsocket(t *testing.T) {
+	assert := assert.New(t)
+
+	svc := &PandoRPCService{subscriptions: NewSubscriptionHub(2, 16)}
+	args := &SubscribeArgs{Topic: SubscriptionTopicNewHeads}
+	args.SetContext(context.Background())
+	assert.Equal(ErrSubscriptionNotSupported, svc.Subscribe(args, &SubscribeResult{}))
+
+	c := svc.subscriptions.connect(&mockWsConn{})
+	defer svc.subscriptions.disconnect(c)
+	_, err := subscribeForTest(svc, c, &SubscribeArgs{Topic: "unknown"})
+	assert.NotNil(err)
+}
+
+func TestSubscriptionSlowConsumerIsDisconnected(t *testing.T) {
+	assert := assert.New(t)
+
+	svc := &PandoRPCService{subscriptions: NewSubscriptionHub(2, 1)}
+	conn := &mockWsConn{blocked: make(chan struct{})}
+	defer close(conn.blocked)
+	c := svc.subscriptions.connect(conn)
+
+	_, err := subscribeForTest(svc, c, &SubscribeArgs{Topic: SubscriptionTopicPendingTxs})
+	assert.Nil(err)
+
+	// The writer holds one notification and the queue another, the next one overflows.
+	for i := 0; i < 3; i++ {
+		svc.subscriptions.publish(SubscriptionTopicPendingTxs, i)
+		time.Sleep(10 * time.Millisecond)
+	}
+
+	assert.True(conn.isClosed())
+	assert.False(svc.subscriptions.hasSubscribers(SubscriptionTopicPendingTxs))
+}
@@ -15,10 +15,20 @@ import (
 	"github.com/pandoprojects/pando/crypto"
 	"github.com/pandoprojects/pando/ledger/types"
 	"github.com/pandoprojects/pando/mempool"
+	"github.com/pandoprojects/pando/rpc/lib/rpc-codec/jsonrpc2"
 )
 
 const txTimeout = 60 * time.Second
 
+// txRejectedCode is the JSON-RPC error code of the transactions refused by the admission policies of the mempool.
+const txRejectedCode = -32003
+
+// TxRejectedData is the data of the JSON-RPC error of a transaction refused by an admission policy.
+type TxRejectedData struct {
+	Policy string `json:"policy"`
+	Reason string `json:"reason"`
+}
+
 type Callback struct {
 	txHash   string
 	created  time.Time
@@ -112,6 +122,9 @@ func (t *PandoRPCService) txCallback() {
 				}
 			}
 
+			t.publishFinalizedBlock(block)
+			t.finalizedBlockNotifier.notify()
+
 			logger.Infof("Done processing finalized block, height=%v", block.Height)
 		case <-timer.C:
 			logger.Debugf("txCallbackManager.Trim()")
@@ -190,7 +203,7 @@ func (t *PandoRPCService) BroadcastRawTransaction(
 		logger.Infof("Broadcasted raw transaction (sync): %v, hash: %v", hex.EncodeToString(txBytes), hash.Hex())
 	} else {
 		logger.Warnf("Failed to broadcast raw transaction (sync): %v, hash: %v, err: %v", hex.EncodeToString(txBytes), hash.Hex(), err)
-		return err
+		return insertTxError(err)
 	}
 
 	finalized := make(chan *core.Block)
@@ -283,7 +296,22 @@ func (t *PandoRPCService) BroadcastRawTransactionAsync(
 
 	logger.Warnf("Failed to broadcast raw transaction (async): %v, hash: %v, err: %v", hex.EncodeToString(txBytes), hash.Hex(), err)
 
-	return err
+	return insertTxError(err)
+}
+
+// insertTxError converts the refusal of an admission policy into a JSON-RPC error carrying the
+// reason code, so that the clients can tell why their transaction was refused.
+func insertTxError(err error) error {
+	admissionErr, ok := err.(*mempool.AdmissionError)
+	if !ok {
+		return err
+	}
+	rpcErr := jsonrpc2.NewError(txRejectedCode, admissionErr.Error())
+	rpcErr.Data = TxRejectedData{
+		Policy: admissionErr.Policy,
+		Reason: string(admissionErr.Reason),
+	}
+	return rpcErr
 }
 
 // ------------------------------- BroadcastRawEthTransaction -----------------------------------
@@ -7,6 +7,8 @@ import (
 	"github.com/stretchr/testify/assert"
 	"github.com/pandoprojects/pando/common"
 	"github.com/pandoprojects/pando/core"
+	"github.com/pandoprojects/pando/mempool"
+	"github.com/pandoprojects/pando/rpc/lib/rpc-codec/jsonrpc2"
 )
 
 func TestTxCallbackManager(t *testing.T) {
@@ -55,3 +57,20 @@ func TestTxCallbackManager(t *testing.T) {
 	assert.Equal(1, len(m.txHashToCallback))
 	assert.Equal(1, len(m.callbacks))
 }
+
+func TestInsertTxError(t *testing.T) {
+	assert := assert.New(t)
+
+	err := insertTxError(mempool.DuplicateTxError)
+	assert.Equal(mempool.DuplicateTxError, err)
+
+	err = insertTxError(&mempool.AdmissionError{
+		Policy:  "sender_quota",
+		Reason:  mempool.ReasonSenderQuotaExceeded,
+		Message: "too many pending transactions",
+	})
+	rpcErr, ok := err.(*jsonrpc2.Error)
+	assert.True(ok)
+	assert.Equal(txRejectedCode, rpcErr.Code)
+	assert.Equal(TxRejectedData{Policy: "sender_quota", Reason: "sender_quota_exceeded"}, rpcErr.Data)
+}
@@ -0,0 +1,162 @@
+package signer
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"sync"
+
+	"github.com/pandoprojects/pando/common"
+	"github.com/pandoprojects/pando/core"
+	"github.com/pandoprojects/pando/crypto"
+)
+
+// SignState is the high-water mark of the messages signed for a validator.
+type SignState struct {
+	HasVote    bool        `json:"has_vote"`
+	VoteEpoch  uint64      `json:"vote_epoch"`
+	VoteHeight uint64      `json:"vote_height"`
+	VoteBlock  common.Hash `json:"vote_block"`
+
+	HasProposal   bool        `json:"has_proposal"`
+	ProposalEpoch uint64      `json:"proposal_epoch"`
+	ProposalHash  common.Hash `json:"proposal_hash"` // hash of the sign bytes of the proposal
+
+	HasGuardianVote      bool        `json:"has_guardian_vote"`
+	GuardianVoteHeight   uint64      `json:"guardian_vote_height"`
+	GuardianVoteSignHash common.Hash `json:"guardian_vote_sign_hash"`
+}
+
+// HighWaterMark keeps track of the last messages signed for a validator, and refuses to sign
+// the messages which conflict with them or go back in time:
+//   - a vote in an earlier epoch, at a lower height in the same epoch, or for another block at
+//     the same height and epoch;
+//   - a proposal in an earlier epoch, or another proposal in the same epoch;
+//   - a guardian vote at a lower height, or for another block at the same height.
+//
+// Signing the same message again is allowed. When a path is given, the mark is persisted before
+// each signature is released, so that the protection survives restarts.
+type HighWaterMark struct {
+	mu    sync.Mutex
+	path  string
+	state SignState
+}
+
+// NewHighWaterMark creates a high-water mark persisted at the given path, and loads the state
+// saved there if any. The mark is kept in memory only if the path is empty.
+func NewHighWaterMark(path string) (*HighWaterMark, error) {
+	hwm := &HighWaterMark{path: path}
+	if path == "" {
+		return hwm, nil
+	}
+	raw, err := ioutil.ReadFile(path)
+	if os.IsNotExist(err) {
+		return hwm, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(raw, &hwm.state); err != nil {
+		return nil, fmt.Errorf("Failed to parse signer state %v: %v", path, err)
+	}
+	return hwm, nil
+}
+
+// State returns the current high-water mark.
+func (hwm *HighWaterMark) State() SignState {
+	hwm.mu.Lock()
+	defer hwm.mu.Unlock()
+
+	return hwm.state
+}
+
+// approveVote checks the vote against the mark, and raises the mark to the vote.
+func (hwm *HighWaterMark) approveVote(vote core.Vote) error {
+	hwm.mu.Lock()
+	defer hwm.mu.Unlock()
+
+	s := hwm.state
+	if s.HasVote {
+		if vote.Epoch < s.VoteEpoch {
+			return fmt.Errorf("Vote epoch %v is below the high-water mark %v", vote.Epoch, s.VoteEpoch)
+		}
+		if vote.Epoch == s.VoteEpoch {
+			if vote.Height < s.VoteHeight {
+				return fmt.Errorf("Vote height %v is below the high-water mark %v in epoch %v", vote.Height, s.VoteHeight, vote.Epoch)
+			}
+			if vote.Height == s.VoteHeight && vote.Block != s.VoteBlock {
+				return fmt.Errorf("Already voted for block %v at height %v in epoch %v", s.VoteBlock.Hex(), vote.Height, vote.Epoch)
+			}
+		}
+	}
+
+	s.HasVote = true
+	s.VoteEpoch = vote.Epoch
+	s.VoteHeight = vote.Height
+	s.VoteBlock = vote.Block
+	return hwm.update(s)
+}
+
+// approveProposal checks the proposal against the mark, and raises the mark to the proposal.
+func (hwm *HighWaterMark) approveProposal(header *core.BlockHeader) error {
+	hwm.mu.Lock()
+	defer hwm.mu.Unlock()
+
+	signHash := crypto.Keccak256Hash(header.SignBytes())
+	s := hwm.state
+	if s.HasProposal {
+		if header.Epoch < s.ProposalEpoch {
+			return fmt.Errorf("Proposal epoch %v is below the high-water mark %v", header.Epoch, s.ProposalEpoch)
+		}
+		if header.Epoch == s.ProposalEpoch && signHash != s.ProposalHash {
+			return fmt.Errorf("Already proposed another block in epoch %v", header.Epoch)
+		}
+	}
+
+	s.HasProposal = true
+	s.ProposalEpoch = header.Epoch
+	s.ProposalHash = signHash
+	return hwm.update(s)
+}
+
+// approveGuardianVote checks the guardian vote against the mark, and raises the mark to the vote.
+func (hwm *HighWaterMark) approveGuardianVote(height uint64, signBytes common.Bytes) error {
+	hwm.mu.Lock()
+	defer hwm.mu.Unlock()
+
+	signHash := crypto.Keccak256Hash(signBytes)
+	s := hwm.state
+	if s.HasGuardianVote {
+		if height < s.GuardianVoteHeight {
+			return fmt.Errorf("Guardian vote height %v is below the high-water mark %v", height, s.GuardianVoteHeight)
+		}
+		if height == s.GuardianVoteHeight && signHash != s.GuardianVoteSignHash {
+			return fmt.Errorf("Already signed another guardian vote at height %v", height)
+		}
+	}
+
+	s.HasGuardianVote = true
+	s.GuardianVoteHeight = height
+	s.GuardianVoteSignHash = signHash
+	return hwm.update(s)
+}
+
+// update persists the new state, and adopts it if it could be persisted.
+func (hwm *HighWaterMark) update(state SignState) error {
+	if hwm.path != "" {
+		raw, err := json.MarshalIndent(state, "", "  ")
+		if err != nil {
+			return err
+		}
+		tmpPath := hwm.path + ".tmp"
+		if err := ioutil.WriteFile(tmpPath, raw, 0600); err != nil {
+			return fmt.Errorf("Failed to persist signer state: %v", err)
+		}
+		if err := os.Rename(tmpPath, hwm.path); err != nil {
+			return fmt.Errorf("Failed to persist signer state: %v", err)
+		}
+	}
+	hwm.state = state
+	return nil
+}
@@ -0,0 +1,127 @@
+package signer
+
+import (
+	"io/ioutil"
+	"math/big"
+	"os"
+	"path"
+	"testing"
+
+	"github.com/pandoprojects/pando/common"
+	"github.com/pandoprojects/pando/core"
+	"github.com/pandoprojects/pando/crypto"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func createTestVote(privKey *crypto.PrivateKey, block string, height, epoch uint64) core.Vote {
+	return core.Vote{
+		Block:  common.HexToHash(block),
+		Height: height,
+		Epoch:  epoch,
+		ID:     privKey.PublicKey().Address(),
+	}
+}
+
+func createTestHeader(privKey *crypto.PrivateKey, height, epoch uint64, stateHash string) *core.BlockHeader {
+	return &core.BlockHeader{
+		ChainID:   "privatenet",
+		Epoch:     epoch,
+		Height:    height,
+		StateHash: common.HexToHash(stateHash),
+		Timestamp: big.NewInt(1),
+		Proposer:  privKey.PublicKey().Address(),
+	}
+}
+
+func TestHighWaterMarkVotes(t *testing.T) {
+	assert := assert.New(t)
+
+	privKey, _, _ := crypto.GenerateKeyPair()
+	signer := NewLocalSigner(privKey)
+
+	vote := createTestVote(privKey, "b1", 10, 5)
+	sig, err := signer.SignVote(vote)
+	assert.Nil(err)
+	assert.True(sig.Verify(vote.SignBytes(), privKey.PublicKey().Address()))
+
+	// Signing the same vote again is allowed.
+	_, err = signer.SignVote(vote)
+	assert.Nil(err)
+
+	// Another block at the same height and epoch is refused.
+	_, err = signer.SignVote(createTestVote(privKey, "b2", 10, 5))
+	assert.NotNil(err)
+
+	// Going back in height within the epoch, or back in epoch, is refused.
+	_, err = signer.SignVote(createTestVote(privKey, "b0", 9, 5))
+	assert.NotNil(err)
+	_, err = signer.SignVote(createTestVote(privKey, "b3", 11, 4))
+	assert.NotNil(err)
+
+	// Moving forward is allowed, including repeating a vote for a lower block in a later epoch.
+	_, err = signer.SignVote(createTestVote(privKey, "b3", 11, 5))
+	assert.Nil(err)
+	_, err = signer.SignVote(createTestVote(privKey, "b1", 10, 6))
+	assert.Nil(err)
+
+	// Votes of other validators are refused.
+	other, _, _ := crypto.GenerateKeyPair()
+	_, err = signer.SignVote(createTestVote(other, "b4", 12, 7))
+	assert.NotNil(err)
+}
+
+func TestHighWaterMarkProposals(t *testing.T) {
+	assert := assert.New(t)
+
+	privKey, _, _ := crypto.GenerateKeyPair()
+	signer := NewLocalSigner(privKey)
+
+	header := createTestHeader(privKey, 10, 5, "c1")
+	sig, err := signer.SignProposal(header)
+	assert.Nil(err)
+	assert.True(sig.Verify(header.SignBytes(), privKey.PublicKey().Address()))
+
+	_, err = signer.SignProposal(header)
+	assert.Nil(err)
+	_, err = signer.SignProposal(createTestHeader(privKey, 10, 5, "c2"))
+	assert.NotNil(err)
+	_, err = signer.SignProposal(createTestHeader(privKey, 9, 4, "c3"))
+	assert.NotNil(err)
+	_, err = signer.SignProposal(createTestHeader(privKey, 11, 6, "c4"))
+	assert.Nil(err)
+}
+
+func TestHighWaterMarkPersistence(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	dir, err := ioutil.TempDir("", "signer")
+	require.Nil(err)
+	defer os.RemoveAll(dir)
+	statePath := path.Join(dir, "signer_state.json")
+
+	privKey, _, _ := crypto.GenerateKeyPair()
+	hwm, err := NewHighWaterMark(statePath)
+	require.Nil(err)
+	signer := NewLocalSignerWithHighWaterMark(privKey, hwm)
+
+	_, err = signer.SignVote(createTestVote(privKey, "b1", 10, 5))
+	require.Nil(err)
+	votes := &core.AggregatedVotes{Block: common.HexToHash("b1"), Gcp: common.HexToHash("g1")}
+	_, err = signer.SignGuardianVote(100, votes)
+	require.Nil(err)
+
+	// The mark survives a restart of the signer.
+	hwm, err = NewHighWaterMark(statePath)
+	require.Nil(err)
+	assert.Equal(uint64(5), hwm.State().VoteEpoch)
+	signer = NewLocalSignerWithHighWaterMark(privKey, hwm)
+
+	_, err = signer.SignVote(createTestVote(privKey, "b2", 10, 5))
+	assert.NotNil(err)
+	_, err = signer.SignGuardianVote(100, &core.AggregatedVotes{Block: common.HexToHash("b2"), Gcp: common.HexToHash("g1")})
+	assert.NotNil(err)
+	_, err = signer.SignGuardianVote(100, votes)
+	assert.Nil(err)
+}
@@ -0,0 +1,138 @@
+package signer
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/pandoprojects/pando/common"
+	"github.com/pandoprojects/pando/common/util"
+	"github.com/pandoprojects/pando/core"
+	"github.com/pandoprojects/pando/crypto"
+	"github.com/pandoprojects/pando/crypto/bls"
+	"github.com/pandoprojects/pando/ledger/types"
+)
+
+var logger = util.GetLoggerForModule("signer")
+
+var _ core.Signer = (*LocalSigner)(nil)
+
+// LocalSigner signs with keys held in the current process. It is used by the node when the
+// validator key is loaded from the local keystore, and by the signer server.
+type LocalSigner struct {
+	privKey *crypto.PrivateKey
+	blsKey  *bls.SecretKey
+	hwm     *HighWaterMark
+}
+
+// NewLocalSigner creates a signer for the given validator key, which keeps its high-water mark
+// in memory.
+func NewLocalSigner(privKey *crypto.PrivateKey) *LocalSigner {
+	hwm, _ := NewHighWaterMark("")
+	return NewLocalSignerWithHighWaterMark(privKey, hwm)
+}
+
+// NewLocalSignerWithHighWaterMark creates a signer for the given validator key which enforces
+// the given high-water mark.
+func NewLocalSignerWithHighWaterMark(privKey *crypto.PrivateKey, hwm *HighWaterMark) *LocalSigner {
+	blsKey, err := DeriveBLSKey(privKey.PublicKey())
+	if err != nil {
+		logger.Panic(err)
+	}
+	return &LocalSigner{
+		privKey: privKey,
+		blsKey:  blsKey,
+		hwm:     hwm,
+	}
+}
+
+// DeriveBLSKey derives the BLS key of a validator from its public key.
+func DeriveBLSKey(pubKey *crypto.PublicKey) (*bls.SecretKey, error) {
+	return bls.GenKey(strings.NewReader(common.Bytes2Hex(pubKey.ToBytes())))
+}
+
+// PublicKey implements the core.Signer interface.
+func (ls *LocalSigner) PublicKey() *crypto.PublicKey {
+	return ls.privKey.PublicKey()
+}
+
+// BLSPublicKey implements the core.Signer interface.
+func (ls *LocalSigner) BLSPublicKey() *bls.PublicKey {
+	return ls.blsKey.PublicKey()
+}
+
+// BLSPop implements the core.Signer interface.
+func (ls *LocalSigner) BLSPop() (*bls.Signature, *crypto.Signature, error) {
+	pop := ls.blsKey.PopProve()
+	sig, err := ls.privKey.Sign(pop.ToBytes())
+	if err != nil {
+		return nil, nil, err
+	}
+	return pop, sig, nil
+}
+
+// SignVote implements the core.Signer interface.
+func (ls *LocalSigner) SignVote(vote core.Vote) (*crypto.Signature, error) {
+	if vote.ID != ls.privKey.PublicKey().Address() {
+		return nil, fmt.Errorf("Vote of %v cannot be signed by %v", vote.ID.Hex(), ls.privKey.PublicKey().Address().Hex())
+	}
+	if err := ls.hwm.approveVote(vote); err != nil {
+		return nil, err
+	}
+	return ls.privKey.Sign(vote.SignBytes())
+}
+
+// SignVoteBLS implements the core.Signer interface.
+func (ls *LocalSigner) SignVoteBLS(vote core.Vote) (*bls.Signature, error) {
+	if vote.ID != ls.privKey.PublicKey().Address() {
+		return nil, fmt.Errorf("Vote of %v cannot be signed by %v", vote.ID.Hex(), ls.privKey.PublicKey().Address().Hex())
+	}
+	if err := ls.hwm.approveVote(vote); err != nil {
+		return nil, err
+	}
+	return ls.blsKey.Sign(vote.BLSSignBytes()), nil
+}
+
+// SignProposal implements the core.Signer interface.
+func (ls *LocalSigner) SignProposal(header *core.BlockHeader) (*crypto.Signature, error) {
+	if header.Proposer != ls.privKey.PublicKey().Address() {
+		return nil, fmt.Errorf("Block proposed by %v cannot be signed by %v", header.Proposer.Hex(), ls.privKey.PublicKey().Address().Hex())
+	}
+	if err := ls.hwm.approveProposal(header); err != nil {
+		return nil, err
+	}
+	return ls.privKey.Sign(header.SignBytes())
+}
+
+// SignGuardianVote implements the core.Signer interface.
+func (ls *LocalSigner) SignGuardianVote(height uint64, vote *core.AggregatedVotes) (*bls.Signature, error) {
+	signBytes := vote.SignBytes()
+	if err := ls.hwm.approveGuardianVote(height, signBytes); err != nil {
+		return nil, err
+	}
+	return ls.blsKey.Sign(signBytes), nil
+}
+
+// SignTx implements the core.Signer interface. Only the transactions a block proposer adds to
+// its blocks are signed, so that a compromised node cannot spend the funds of the validator.
+func (ls *LocalSigner) SignTx(chainID string, rawTx common.Bytes) (*crypto.Signature, error) {
+	tx, err := types.TxFromBytes(rawTx)
+	if err != nil {
+		return nil, err
+	}
+
+	var proposer common.Address
+	switch tx := tx.(type) {
+	case *types.CoinbaseTx:
+		proposer = tx.Proposer.Address
+	case *types.SlashTx:
+		proposer = tx.Proposer.Address
+	case *types.EquivocationEvidenceTx:
+		proposer = tx.Proposer.Address
+	default:
+		return nil, fmt.Errorf("Transaction type %T cannot be signed", tx)
+	}
+	if proposer != ls.privKey.PublicKey().Address() {
+		return nil, fmt.Errorf("Transaction of %v cannot be signed by %v", proposer.Hex(), ls.privKey.PublicKey().Address().Hex())
+	}
+	return ls.privKey.Sign(tx.SignBytes(chainID))
+}
@@ -0,0 +1,88 @@
+package signer
+
+import (
+	"encoding/binary"
+	"fmt"
+	"io"
+
+	"github.com/pandoprojects/pando/common"
+	"github.com/pandoprojects/pando/crypto"
+	"github.com/pandoprojects/pando/crypto/bls"
+	"github.com/pandoprojects/pando/rlp"
+)
+
+// The remote signer protocol exchanges length prefixed RLP messages over a TLS connection on
+// which both ends authenticate with a certificate. Each request is answered by one response
+// before the next request is sent.
+
+// maxMessageSize is the maximum size of a request or a response.
+const maxMessageSize = 4 * 1024 * 1024
+
+const (
+	methodPublicKey byte = iota
+	methodBLSPublicKey
+	methodBLSPop
+	methodSignVote
+	methodSignProposal
+	methodSignGuardianVote
+	methodSignTx
+	methodSignVoteBLS
+)
+
+type request struct {
+	Method  byte
+	Payload common.Bytes
+}
+
+type response struct {
+	Error   string
+	Payload common.Bytes
+}
+
+type guardianVoteRequest struct {
+	Height uint64
+	Block  common.Hash
+	Gcp    common.Hash
+}
+
+type txRequest struct {
+	ChainID string
+	RawTx   common.Bytes
+}
+
+type blsPopResponse struct {
+	Pop       *bls.Signature
+	Signature *crypto.Signature
+}
+
+func writeMessage(w io.Writer, msg interface{}) error {
+	raw, err := rlp.EncodeToBytes(msg)
+	if err != nil {
+		return err
+	}
+	if len(raw) > maxMessageSize {
+		return fmt.Errorf("Message size %v exceeds the limit %v", len(raw), maxMessageSize)
+	}
+	var size [4]byte
+	binary.BigEndian.PutUint32(size[:], uint32(len(raw)))
+	if _, err := w.Write(append(size[:], raw...)); err != nil {
+		return err
+	}
+	return nil
+}
+
+func readMessage(r io.Reader, msg interface{}) error {
+	var size [4]byte
+	if _, err := io.ReadFull(r, size[:]); err != nil {
+		return err
+	}
+	n := binary.BigEndian.Uint32(size[:])
+	if n > maxMessageSize {
+		return fmt.Errorf("Message size %v exceeds the limit %v", n, maxMessageSize)
+	}
+	raw := make([]byte, n)
+	if _, err := io.ReadFull(r, raw); err != nil {
+		return err
+	}
+	return rlp.DecodeBytes(raw, msg)
+}
@@ -0,0 +1,229 @@
+package signer
+
+import (
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"net"
+	"sync"
+	"time"
+
+	"github.com/pandoprojects/pando/common"
+	"github.com/pandoprojects/pando/core"
+	"github.com/pandoprojects/pando/crypto"
+	"github.com/pandoprojects/pando/crypto/bls"
+	"github.com/pandoprojects/pando/ledger/types"
+	"github.com/pandoprojects/pando/rlp"
+)
+
+var _ core.Signer = (*RemoteSigner)(nil)
+
+// RemoteSigner forwards the signing requests to a signer server, so that the validator keys are
+// kept off the node host. The signatures returned by the server are verified before use.
+type RemoteSigner struct {
+	address   string
+	tlsConfig *tls.Config
+	timeout   time.Duration
+
+	mu   sync.Mutex
+	conn net.Conn
+
+	pubKey    *crypto.PublicKey
+	blsPubKey *bls.PublicKey
+}
+
+// NewRemoteSigner connects to the signer server at the given address, and retrieves the public
+// keys of the validator.
+func NewRemoteSigner(address string, tlsConfig *tls.Config, timeout time.Duration) (*RemoteSigner, error) {
+	rs := &RemoteSigner{
+		address:   address,
+		tlsConfig: tlsConfig,
+		timeout:   timeout,
+	}
+
+	raw, err := rs.call(methodPublicKey, nil)
+	if err != nil {
+		return nil, err
+	}
+	if rs.pubKey, err = crypto.PublicKeyFromBytes(raw); err != nil {
+		return nil, err
+	}
+
+	raw, err = rs.call(methodBLSPublicKey, nil)
+	if err != nil {
+		return nil, err
+	}
+	if rs.blsPubKey, err = bls.PublicKeyFromBytes(raw); err != nil {
+		return nil, err
+	}
+
+	logger.Infof("Connected to remote signer %v, address: %v", address, rs.pubKey.Address().Hex())
+	return rs, nil
+}
+
+// Close closes the connection to the signer server.
+func (rs *RemoteSigner) Close() {
+	rs.mu.Lock()
+	defer rs.mu.Unlock()
+
+	rs.closeConn()
+}
+
+// PublicKey implements the core.Signer interface.
+func (rs *RemoteSigner) PublicKey() *crypto.PublicKey {
+	return rs.pubKey
+}
+
+// BLSPublicKey implements the core.Signer interface.
+func (rs *RemoteSigner) BLSPublicKey() *bls.PublicKey {
+	return rs.blsPubKey
+}
+
+// BLSPop implements the core.Signer interface.
+func (rs *RemoteSigner) BLSPop() (*bls.Signature, *crypto.Signature, error) {
+	raw, err := rs.call(methodBLSPop, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+	res := &blsPopResponse{}
+	if err := rlp.DecodeBytes(raw, res); err != nil {
+		return nil, nil, err
+	}
+	if !res.Signature.Verify(res.Pop.ToBytes(), rs.pubKey.Address()) {
+		return nil, nil, errors.New("Invalid signature of the BLS proof of possession")
+	}
+	return res.Pop, res.Signature, nil
+}
+
+// SignVote implements the core.Signer interface.
+func (rs *RemoteSigner) SignVote(vote core.Vote) (*crypto.Signature, error) {
+	payload, err := rlp.EncodeToBytes(vote)
+	if err != nil {
+		return nil, err
+	}
+	return rs.sign(methodSignVote, payload, vote.SignBytes())
+}
+
+// SignVoteBLS implements the core.Signer interface.
+func (rs *RemoteSigner) SignVoteBLS(vote core.Vote) (*bls.Signature, error) {
+	payload, err := rlp.EncodeToBytes(vote)
+	if err != nil {
+		return nil, err
+	}
+	raw, err := rs.call(methodSignVoteBLS, payload)
+	if err != nil {
+		return nil, err
+	}
+	sig, err := bls.SignatureFromBytes(raw)
+	if err != nil {
+		return nil, err
+	}
+	if !sig.Verify(vote.BLSSignBytes(), rs.blsPubKey) {
+		return nil, errors.New("Invalid BLS vote signature from the remote signer")
+	}
+	return sig, nil
+}
+
+// SignProposal implements the core.Signer interface.
+func (rs *RemoteSigner) SignProposal(header *core.BlockHeader) (*crypto.Signature, error) {
+	payload, err := rlp.EncodeToBytes(header)
+	if err != nil {
+		return nil, err
+	}
+	return rs.sign(methodSignProposal, payload, header.SignBytes())
+}
+
+// SignGuardianVote implements the core.Signer interface.
+func (rs *RemoteSigner) SignGuardianVote(height uint64, vote *core.AggregatedVotes) (*bls.Signature, error) {
+	payload, err := rlp.EncodeToBytes(guardianVoteRequest{
+		Height: height,
+		Block:  vote.Block,
+		Gcp:    vote.Gcp,
+	})
+	if err != nil {
+		return nil, err
+	}
+	raw, err := rs.call(methodSignGuardianVote, payload)
+	if err != nil {
+		return nil, err
+	}
+	sig, err := bls.SignatureFromBytes(raw)
+	if err != nil {
+		return nil, err
+	}
+	if !sig.Verify(vote.SignBytes(), rs.blsPubKey) {
+		return nil, errors.New("Invalid guardian vote signature from the remote signer")
+	}
+	return sig, nil
+}
+
+// SignTx implements the core.Signer interface.
+func (rs *RemoteSigner) SignTx(chainID string, rawTx common.Bytes) (*crypto.Signature, error) {
+	tx, err := types.TxFromBytes(rawTx)
+	if err != nil {
+		return nil, err
+	}
+	payload, err := rlp.EncodeToBytes(txRequest{
+		ChainID: chainID,
+		RawTx:   rawTx,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return rs.sign(methodSignTx, payload, tx.SignBytes(chainID))
+}
+
+// sign sends a signing request, and verifies that the returned signature is a signature of
+// signBytes by the validator.
+func (rs *RemoteSigner) sign(method byte, payload common.Bytes, signBytes common.Bytes) (*crypto.Signature, error) {
+	raw, err := rs.call(method, payload)
+	if err != nil {
+		return nil, err
+	}
+	sig, err := crypto.SignatureFromBytes(raw)
+	if err != nil {
+		return nil, err
+	}
+	if !sig.Verify(signBytes, rs.pubKey.Address()) {
+		return nil, errors.New("Invalid signature from the remote signer")
+	}
+	return sig, nil
+}
+
+// call sends a request to the server and waits for the response. The connection is
+// re-established if it has been closed.
+func (rs *RemoteSigner) call(method byte, payload common.Bytes) (common.Bytes, error) {
+	rs.mu.Lock()
+	defer rs.mu.Unlock()
+
+	if rs.conn == nil {
+		dialer := &net.Dialer{Timeout: rs.timeout}
+		conn, err := tls.DialWithDialer(dialer, "tcp", rs.address, rs.tlsConfig)
+		if err != nil {
+			return nil, fmt.Errorf("Failed to connect to remote signer %v: %v", rs.address, err)
+		}
+		rs.conn = conn
+	}
+
+	rs.conn.SetDeadline(time.Now().Add(rs.timeout))
+	if err := writeMessage(rs.conn, request{Method: method, Payload: payload}); err != nil {
+		rs.closeConn()
+		return nil, fmt.Errorf("Failed to send request to remote signer: %v", err)
+	}
+	res := &response{}
+	if err := readMessage(rs.conn, res); err != nil {
+		rs.closeConn()
+		return nil, fmt.Errorf("Failed to read response from remote signer: %v", err)
+	}
+	if res.Error != "" {
+		return nil, fmt.Errorf("Remote signer refused to sign: %v", res.Error)
+	}
+	return res.Payload, nil
+}
+
+func (rs *RemoteSigner) closeConn() {
+	if rs.conn != nil {
+		rs.conn.Close()
+		rs.conn = nil
+	}
+}
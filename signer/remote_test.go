@@ -0,0 +1,167 @@
+package signer
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"io/ioutil"
+	"math/big"
+	"net"
+	"os"
+	"path"
+	"testing"
+	"time"
+
+	"github.com/pandoprojects/pando/common"
+	"github.com/pandoprojects/pando/core"
+	"github.com/pandoprojects/pando/crypto"
+	"github.com/pandoprojects/pando/ledger/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type testCA struct {
+	cert *x509.Certificate
+	key  *ecdsa.PrivateKey
+	dir  string
+}
+
+// newTestCA creates a CA, and saves its certificate as <name>.crt in the given directory.
+func newTestCA(require *require.Assertions, dir, name string) *testCA {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.Nil(err)
+	template := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: name},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		IsCA:                  true,
+		BasicConstraintsValid: true,
+		KeyUsage:              x509.KeyUsageCertSign,
+	}
+	raw, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	require.Nil(err)
+	cert, err := x509.ParseCertificate(raw)
+	require.Nil(err)
+	writePEM(require, path.Join(dir, name+".crt"), "CERTIFICATE", raw)
+	return &testCA{cert: cert, key: key, dir: dir}
+}
+
+// issue creates a certificate signed by the CA, and returns the paths of the certificate and its key.
+func (ca *testCA) issue(require *require.Assertions, name string, serial int64) (string, string) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.Nil(err)
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(serial),
+		Subject:      pkix.Name{CommonName: name},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		KeyUsage:     x509.KeyUsageDigitalSignature,
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth, x509.ExtKeyUsageClientAuth},
+		IPAddresses:  []net.IP{net.ParseIP("127.0.0.1")},
+	}
+	raw, err := x509.CreateCertificate(rand.Reader, template, ca.cert, &key.PublicKey, ca.key)
+	require.Nil(err)
+	keyRaw, err := x509.MarshalECPrivateKey(key)
+	require.Nil(err)
+
+	certPath := path.Join(ca.dir, name+".crt")
+	keyPath := path.Join(ca.dir, name+".key")
+	writePEM(require, certPath, "CERTIFICATE", raw)
+	writePEM(require, keyPath, "EC PRIVATE KEY", keyRaw)
+	return certPath, keyPath
+}
+
+func writePEM(require *require.Assertions, file, blockType string, raw []byte) {
+	require.Nil(ioutil.WriteFile(file, pem.EncodeToMemory(&pem.Block{Type: blockType, Bytes: raw}), 0600))
+}
+
+func TestRemoteSigner(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	dir, err := ioutil.TempDir("", "signer")
+	require.Nil(err)
+	defer os.RemoveAll(dir)
+
+	ca := newTestCA(require, dir, "ca")
+	caPath := path.Join(dir, "ca.crt")
+	serverCert, serverKey := ca.issue(require, "server", 2)
+	clientCert, clientKey := ca.issue(require, "client", 3)
+
+	serverTLS, err := LoadTLSConfig(serverCert, serverKey, caPath, true)
+	require.Nil(err)
+	privKey, _, _ := crypto.GenerateKeyPair()
+	local := NewLocalSigner(privKey)
+	server := NewServer(local, "127.0.0.1:0", serverTLS)
+	require.Nil(server.Start())
+	defer server.Stop()
+	address := server.Addr().String()
+
+	clientTLS, err := LoadTLSConfig(clientCert, clientKey, caPath, false)
+	require.Nil(err)
+	remote, err := NewRemoteSigner(address, clientTLS, 5*time.Second)
+	require.Nil(err)
+	defer remote.Close()
+
+	assert.Equal(privKey.PublicKey().Address(), remote.PublicKey().Address())
+	assert.Equal(local.BLSPublicKey().ToBytes(), remote.BLSPublicKey().ToBytes())
+
+	pop, popSig, err := remote.BLSPop()
+	require.Nil(err)
+	assert.True(popSig.Verify(pop.ToBytes(), privKey.PublicKey().Address()))
+
+	// The high-water mark is enforced by the server.
+	vote := createTestVote(privKey, "b1", 10, 5)
+	sig, err := remote.SignVote(vote)
+	require.Nil(err)
+	assert.True(sig.Verify(vote.SignBytes(), privKey.PublicKey().Address()))
+	_, err = remote.SignVote(createTestVote(privKey, "b2", 10, 5))
+	assert.NotNil(err)
+	blsVoteSig, err := remote.SignVoteBLS(vote)
+	require.Nil(err)
+	assert.True(blsVoteSig.Verify(vote.BLSSignBytes(), local.BLSPublicKey()))
+	_, err = remote.SignVoteBLS(createTestVote(privKey, "b2", 10, 5))
+	assert.NotNil(err)
+
+	header := createTestHeader(privKey, 10, 5, "c1")
+	sig, err = remote.SignProposal(header)
+	require.Nil(err)
+	assert.True(sig.Verify(header.SignBytes(), privKey.PublicKey().Address()))
+
+	votes := &core.AggregatedVotes{Block: common.HexToHash("b1"), Gcp: common.HexToHash("a1")}
+	blsSig, err := remote.SignGuardianVote(100, votes)
+	require.Nil(err)
+	assert.True(blsSig.Verify(votes.SignBytes(), local.BLSPublicKey()))
+
+	// Only the transactions of a block proposer are signed.
+	coinbaseTx := &types.CoinbaseTx{
+		Proposer:    types.TxInput{Address: privKey.PublicKey().Address()},
+		BlockHeight: 10,
+	}
+	rawTx, err := types.TxToBytes(coinbaseTx)
+	require.Nil(err)
+	sig, err = remote.SignTx("privatenet", rawTx)
+	require.Nil(err)
+	assert.True(sig.Verify(coinbaseTx.SignBytes("privatenet"), privKey.PublicKey().Address()))
+
+	sendTx := &types.SendTx{
+		Inputs:  []types.TxInput{{Address: privKey.PublicKey().Address()}},
+		Outputs: []types.TxOutput{{Address: common.HexToAddress("a2")}},
+	}
+	rawTx, err = types.TxToBytes(sendTx)
+	require.Nil(err)
+	_, err = remote.SignTx("privatenet", rawTx)
+	assert.NotNil(err)
+
+	// Clients with a certificate from another CA are rejected.
+	otherCA := newTestCA(require, dir, "other-ca")
+	otherCert, otherKey := otherCA.issue(require, "other", 4)
+	otherTLS, err := LoadTLSConfig(otherCert, otherKey, caPath, false)
+	require.Nil(err)
+	_, err = NewRemoteSigner(address, otherTLS, 5*time.Second)
+	assert.NotNil(err)
+}
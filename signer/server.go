@@ -0,0 +1,206 @@
+package signer
+
+import (
+	"crypto/tls"
+	"fmt"
+	"io"
+	"net"
+	"sync"
+
+	"github.com/pandoprojects/pando/common"
+	"github.com/pandoprojects/pando/core"
+	"github.com/pandoprojects/pando/rlp"
+)
+
+// Server serves the signing requests of the nodes connected to it with a LocalSigner. The
+// high-water mark of the signer protects the validator from double-signing, even if several
+// nodes are connected to the server.
+type Server struct {
+	signer    *LocalSigner
+	address   string
+	tlsConfig *tls.Config
+
+	listener net.Listener
+	wg       sync.WaitGroup
+
+	mu      sync.Mutex
+	conns   map[net.Conn]bool
+	stopped bool
+}
+
+// NewServer creates a signer server listening on the given address.
+func NewServer(signer *LocalSigner, address string, tlsConfig *tls.Config) *Server {
+	return &Server{
+		signer:    signer,
+		address:   address,
+		tlsConfig: tlsConfig,
+		conns:     make(map[net.Conn]bool),
+	}
+}
+
+// Start starts accepting connections.
+func (s *Server) Start() error {
+	listener, err := tls.Listen("tcp", s.address, s.tlsConfig)
+	if err != nil {
+		return err
+	}
+	s.listener = listener
+	logger.Infof("Signer listening on %v, address: %v", listener.Addr(), s.signer.PublicKey().Address().Hex())
+
+	s.wg.Add(1)
+	go s.acceptLoop()
+	return nil
+}
+
+// Addr returns the address the server listens on.
+func (s *Server) Addr() net.Addr {
+	return s.listener.Addr()
+}
+
+// Stop closes the listener and the open connections, and waits for them to be released.
+func (s *Server) Stop() {
+	s.mu.Lock()
+	s.stopped = true
+	s.listener.Close()
+	for conn := range s.conns {
+		conn.Close()
+	}
+	s.mu.Unlock()
+
+	s.wg.Wait()
+}
+
+// Wait blocks until the server has been stopped.
+func (s *Server) Wait() {
+	s.wg.Wait()
+}
+
+func (s *Server) acceptLoop() {
+	defer s.wg.Done()
+
+	for {
+		conn, err := s.listener.Accept()
+		if err != nil {
+			s.mu.Lock()
+			stopped := s.stopped
+			s.mu.Unlock()
+			if !stopped {
+				logger.Errorf("Failed to accept signer connection: %v", err)
+			}
+			return
+		}
+
+		s.mu.Lock()
+		if s.stopped {
+			s.mu.Unlock()
+			conn.Close()
+			return
+		}
+		s.conns[conn] = true
+		s.wg.Add(1)
+		s.mu.Unlock()
+
+		go s.handleConn(conn)
+	}
+}
+
+func (s *Server) handleConn(conn net.Conn) {
+	defer s.wg.Done()
+	defer func() {
+		s.mu.Lock()
+		delete(s.conns, conn)
+		s.mu.Unlock()
+		conn.Close()
+	}()
+
+	logger.Infof("Accepted signer connection from %v", conn.RemoteAddr())
+	for {
+		req := &request{}
+		if err := readMessage(conn, req); err != nil {
+			if err != io.EOF {
+				logger.Warnf("Closing signer connection from %v: %v", conn.RemoteAddr(), err)
+			}
+			return
+		}
+
+		res := response{}
+		payload, err := s.handleRequest(req)
+		if err != nil {
+			logger.Warnf("Refused signing request from %v: %v", conn.RemoteAddr(), err)
+			res.Error = err.Error()
+		} else {
+			res.Payload = payload
+		}
+		if err := writeMessage(conn, res); err != nil {
+			logger.Warnf("Closing signer connection from %v: %v", conn.RemoteAddr(), err)
+			return
+		}
+	}
+}
+
+func (s *Server) handleRequest(req *request) (common.Bytes, error) {
+	switch req.Method {
+	case methodPublicKey:
+		return s.signer.PublicKey().ToBytes(), nil
+	case methodBLSPublicKey:
+		return s.signer.BLSPublicKey().ToBytes(), nil
+	case methodBLSPop:
+		pop, sig, err := s.signer.BLSPop()
+		if err != nil {
+			return nil, err
+		}
+		return rlp.EncodeToBytes(blsPopResponse{Pop: pop, Signature: sig})
+	case methodSignVote:
+		vote := core.Vote{}
+		if err := rlp.DecodeBytes(req.Payload, &vote); err != nil {
+			return nil, err
+		}
+		sig, err := s.signer.SignVote(vote)
+		if err != nil {
+			return nil, err
+		}
+		return sig.ToBytes(), nil
+	case methodSignVoteBLS:
+		vote := core.Vote{}
+		if err := rlp.DecodeBytes(req.Payload, &vote); err != nil {
+			return nil, err
+		}
+		sig, err := s.signer.SignVoteBLS(vote)
+		if err != nil {
+			return nil, err
+		}
+		return sig.ToBytes(), nil
+	case methodSignProposal:
+		header := &core.BlockHeader{}
+		if err := rlp.DecodeBytes(req.Payload, header); err != nil {
+			return nil, err
+		}
+		sig, err := s.signer.SignProposal(header)
+		if err != nil {
+			return nil, err
+		}
+		return sig.ToBytes(), nil
+	case methodSignGuardianVote:
+		gv := &guardianVoteRequest{}
+		if err := rlp.DecodeBytes(req.Payload, gv); err != nil {
+			return nil, err
+		}
+		sig, err := s.signer.SignGuardianVote(gv.Height, &core.AggregatedVotes{Block: gv.Block, Gcp: gv.Gcp})
+		if err != nil {
+			return nil, err
+		}
+		return sig.ToBytes(), nil
+	case methodSignTx:
+		tr := &txRequest{}
+		if err := rlp.DecodeBytes(req.Payload, tr); err != nil {
+			return nil, err
+		}
+		sig, err := s.signer.SignTx(tr.ChainID, tr.RawTx)
+		if err != nil {
+			return nil, err
+		}
+		return sig.ToBytes(), nil
+	default:
+		return nil, fmt.Errorf("Unknown method: %v", req.Method)
+	}
+}
@@ -155,7 +155,7 @@ func ExportSnapshotV2(db database.Database, consensus *cns.ConsensusEngine, chai
 					if child.HCC.BlockHash != block.Hash() || grandChild.HCC.BlockHash != child.Hash() {
 						return "", fmt.Errorf("Invalid block HCC link for validator set changes")
 					}
-					if grandChild.HCC.Votes.IsEmpty() {
+					if grandChild.HCC.Votes.IsEmpty() && grandChild.HCC.AggregatedVotes == nil {
 						return "", fmt.Errorf("Missing block HCC votes for validator set changes")
 					}
 					for _, vote := range grandChild.HCC.Votes.Votes() {
@@ -163,6 +163,9 @@ func ExportSnapshotV2(db database.Database, consensus *cns.ConsensusEngine, chai
 							return "", fmt.Errorf("Invalid block HCC votes for validator set changes")
 						}
 					}
+					if grandChild.HCC.AggregatedVotes != nil && grandChild.HCC.AggregatedVotes.Block != child.Hash() {
+						return "", fmt.Errorf("Invalid block HCC aggregated votes for validator set changes")
+					}
 
 					vcpProof, err := proveVCP(block, db)
 					if err != nil {
@@ -371,7 +374,7 @@ func ExportSnapshotV3(db database.Database, consensus *cns.ConsensusEngine, chai
 					if child.HCC.BlockHash != block.Hash() || grandChild.HCC.BlockHash != child.Hash() {
 						return "", fmt.Errorf("Invalid block HCC link for validator set changes")
 					}
-					if grandChild.HCC.Votes.IsEmpty() {
+					if grandChild.HCC.Votes.IsEmpty() && grandChild.HCC.AggregatedVotes == nil {
 						return "", fmt.Errorf("Missing block HCC votes for validator set changes")
 					}
 					for _, vote := range grandChild.HCC.Votes.Votes() {
@@ -379,6 +382,9 @@ func ExportSnapshotV3(db database.Database, consensus *cns.ConsensusEngine, chai
 							return "", fmt.Errorf("Invalid block HCC votes for validator set changes")
 						}
 					}
+					if grandChild.HCC.AggregatedVotes != nil && grandChild.HCC.AggregatedVotes.Block != child.Hash() {
+						return "", fmt.Errorf("Invalid block HCC aggregated votes for validator set changes")
+					}
 
 					vcpProof, err := proveVCP(block, db)
 					if err != nil {
@@ -20,14 +20,13 @@ import (
 	"github.com/pandoprojects/pando/common"
 	"github.com/pandoprojects/pando/consensus"
 	"github.com/pandoprojects/pando/core"
+	"github.com/pandoprojects/pando/lightclient"
 	"github.com/pandoprojects/pando/ledger/state"
 	"github.com/pandoprojects/pando/ledger/types"
-	"github.com/pandoprojects/pando/rlp"
 	"github.com/pandoprojects/pando/store"
 	"github.com/pandoprojects/pando/store/database"
 	"github.com/pandoprojects/pando/store/database/backend"
 	"github.com/pandoprojects/pando/store/kvstore"
-	"github.com/pandoprojects/pando/store/trie"
 )
 
 var logger *log.Entry = log.WithFields(log.Fields{"prefix": "snapshot"})
@@ -513,7 +512,7 @@ func loadChainSegment(filePath string, start, end uint64, prevBlock *core.Extend
 				if proofTrio.First.Header.Height == core.GenesisBlockHeight {
 					provenValSet, err = checkGenesisBlock(proofTrio.Second.Header, db)
 				} else {
-					provenValSet, err = getValidatorSetFromVCPProof(proofTrio.First.Header.StateHash, &proofTrio.First.Proof)
+					provenValSet, err = lightclient.ValidatorSetFromVCPProof(proofTrio.First.Header.StateHash, &proofTrio.First.Proof)
 				}
 				if err != nil {
 					return nil, fmt.Errorf("Failed to retrieve validator set from VCP proof: %v", err)
@@ -522,7 +521,7 @@ func loadChainSegment(filePath string, start, end uint64, prevBlock *core.Extend
 		}
 
 		// check votes
-		if err := validateVotes(provenValSet, block.BlockHeader, backupBlock.Votes); err != nil {
+		if err := lightclient.ValidateVotes(provenValSet, block.BlockHeader, backupBlock.Votes); err != nil {
 			return nil, fmt.Errorf("Failed to validate voteSet, %v", err)
 		}
 
@@ -810,7 +809,7 @@ func checkSnapshotV4(sv *state.StoreView, metadata *core.SnapshotMetadata, db da
 	var err error
 
 	first := tailTrio.First
-	valSet, err = getValidatorSetFromVCPProof(first.Header.StateHash, &first.Proof)
+	valSet, err = lightclient.ValidatorSetFromVCPProof(first.Header.StateHash, &first.Proof)
 	if err != nil {
 		return fmt.Errorf("Failed to retrieve validator set from VCP proof: %v", err)
 	}
@@ -850,11 +849,11 @@ func checkProofTrios(proofTrios []core.SnapshotBlockTrio, db database.Database)
 					second.Header.Hash(), third.Header.HCC.BlockHash)
 			}
 
-			// third.Header.HCC.Votes contains the votes for the second block in the trio
-			if err := validateVotes(provenValSet, second.Header, third.Header.HCC.Votes); err != nil {
+			// third.Header.HCC contains the votes for the second block in the trio
+			if err := lightclient.ValidateCommitCertificate(provenValSet, second.Header, third.Header.HCC); err != nil {
 				return nil, fmt.Errorf("Failed to validate voteSet, %v", err)
 			}
-			provenValSet, err = getValidatorSetFromVCPProof(first.Header.StateHash, &first.Proof)
+			provenValSet, err = lightclient.ValidatorSetFromVCPProof(first.Header.StateHash, &first.Proof)
 			if err != nil {
 				return nil, fmt.Errorf("Failed to retrieve validator set from VCP proof: %v", err)
 			}
@@ -876,7 +875,7 @@ func checkTailTrio(sv *state.StoreView, provenValSet *core.ValidatorSet, tailTri
 			return err
 		}
 	} else {
-		validateVotes(provenValSet, third.Header, third.VoteSet)
+		lightclient.ValidateVotes(provenValSet, third.Header, third.VoteSet)
 		retrievedValSet := getValidatorSetFromSV(sv)
 		if !provenValSet.Equals(retrievedValSet) {
 			return fmt.Errorf("The latest proven and retrieved validator set does not match")
@@ -916,45 +915,11 @@ func checkGenesisBlock(block *core.BlockHeader, db database.Database) (*core.Val
 	return genesisValidatorSet, nil
 }
 
-func getValidatorSetFromVCPProof(stateHash common.Hash, recoverredVp *core.VCPProof) (*core.ValidatorSet, error) {
-	serializedVCP, _, err := trie.VerifyProof(stateHash, state.ValidatorCandidatePoolKey(), recoverredVp)
-	if err != nil {
-		return nil, err
-	}
-
-	vcp := &core.ValidatorCandidatePool{}
-	err = rlp.DecodeBytes(serializedVCP, vcp)
-	if err != nil {
-		return nil, err
-	}
-	return consensus.SelectTopStakeHoldersAsValidators(vcp), nil
-}
-
 func getValidatorSetFromSV(sv *state.StoreView) *core.ValidatorSet {
 	vcp := sv.GetValidatorCandidatePool()
 	return consensus.SelectTopStakeHoldersAsValidators(vcp)
 }
 
-func validateVotes(validatorSet *core.ValidatorSet, block *core.BlockHeader, voteSet *core.VoteSet) error {
-	if !validatorSet.HasMajority(voteSet) {
-		return fmt.Errorf("block doesn't have majority votes")
-	}
-	for _, vote := range voteSet.Votes() {
-		res := vote.Validate()
-		if !res.IsOK() {
-			return fmt.Errorf("vote is not valid, %v", res)
-		}
-		if vote.Block != block.Hash() {
-			return fmt.Errorf("vote is not for corresponding block")
-		}
-		_, err := validatorSet.GetValidator(vote.ID)
-		if err != nil {
-			return fmt.Errorf("can't find validator for vote")
-		}
-	}
-	return nil
-}
-
 func saveTailBlocks(metadata *core.SnapshotMetadata, sv *state.StoreView, kvstore store.Store) *core.BlockHeader {
 	tailBlockTrio := &metadata.TailTrio
 	firstBlock := core.Block{BlockHeader: tailBlockTrio.First.Header}
@@ -85,6 +85,11 @@ func (store *TreeStore) ProveVCP(vcpKey []byte, vp *core.VCPProof) error {
 	return store.Trie.Prove(vcpKey, 0, vp)
 }
 
+// Prove writes the trie nodes on the path to the given key into proofDb.
+func (store *TreeStore) Prove(key []byte, proofDb database.Putter) error {
+	return store.Trie.Prove(key, 0, proofDb)
+}
+
 // Set sets value of given key.
 func (store *TreeStore) Set(key, value common.Bytes) {
 	store.Trie.Update(key, value)